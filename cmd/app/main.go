@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"runtime"
 	"time"
@@ -10,9 +11,12 @@ import (
 	"example.com/api/internal/api/handlers"
 	"example.com/api/internal/api/middlewares"
 	"example.com/api/internal/api/routes"
+	"example.com/api/internal/api/validation"
 	"example.com/api/internal/repository"
 	"example.com/api/internal/services"
 	"example.com/api/internal/services/chat"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/internal/services/webhooks"
 	"example.com/api/pkg/logging"
 	"example.com/api/pkg/metrics"
 	"github.com/gin-gonic/gin"
@@ -23,16 +27,21 @@ func main() {
 	conf := config.GetConfig()
 	logger := logging.NewLogger(conf)
 	db := dbConf.InitDb(conf, logger)
+	validation.SetAttributeSchema(conf.Profile)
 
 	repoManager := repository.NewRepositoryManager(db)
 	serviceManager := services.NewServiceManager(repoManager, logger, *conf)
 
 	userHandler := handlers.NewUserHandler(serviceManager, logger)
 	authHandler := handlers.NewAuthHandler(serviceManager.Auth(), logger)
+	auditHandler := handlers.NewAuditHandler(serviceManager, logger)
+	webhookHandler := handlers.NewWebhookHandler(serviceManager, logger)
+	organizationHandler := handlers.NewOrganizationHandler(serviceManager, logger)
 
 	app := gin.New()
 	app.Use(
 		gin.Recovery(),
+		middlewares.RequestContext(),
 		middlewares.LoggingMiddleware(logger),
 		middlewares.PrometheusMiddleware(),
 		middlewares.CORS(),
@@ -42,17 +51,45 @@ func main() {
 
 	app.SetTrustedProxies([]string{"127.0.0.1"})
 
+	idempotency := middlewares.Idempotency(serviceManager.CacheStorage(), conf.Idempotency, logger)
+	tenant := middlewares.Tenant(serviceManager.Organization(), conf.Tenancy)
+
 	routes.SetupMetricsRoutes(app)
-	routes.SetupAuthRoutes(app, authHandler)
+	routes.SetupAuthRoutes(app, authHandler, tenant, idempotency)
+	routes.SetupEmailChangeRoutes(app, userHandler)
 	protected := app.Group("/api")
 	protected.Use(middlewares.AuthMiddleware(serviceManager.Auth()))
-	routes.SetupUserRoutes(protected, userHandler)
+	routes.SetupUserRoutes(protected, userHandler, tenant, idempotency)
+	routes.SetupOrganizationRoutes(protected, organizationHandler, tenant, middlewares.RequireOrgRole(services.OrgRoleOwner, services.OrgRoleAdmin))
+	routes.SetupAdminRoutes(protected, auditHandler, webhookHandler, userHandler, middlewares.RequireAdmin(conf.Admin.UserIDs))
 
-	hub := chat.NewHub()
+	broker, err := chat.NewBroker(conf.Chat, &conf.Redis)
+	if err != nil {
+		log.Fatal(err)
+	}
+	hub := chat.NewHub(serviceManager.Block(), broker, chat.NewConnLimits(conf.Chat))
 	go hub.Run()
 
 	chatHandler := handlers.NewChatHandler(hub, serviceManager, logger)
-	routes.SetupChatRoutes(protected, chatHandler)
+	routes.SetupChatRoutes(protected, chatHandler, tenant)
+
+	// Webhook subscriptions are fed by the outbox relay, so they only
+	// receive events while the outbox is enabled too.
+	if conf.Outbox.Enabled {
+		sinks, err := outbox.NewSinks(conf.Outbox, &conf.Redis)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if conf.Webhooks.Enabled {
+			sinks = append(sinks, webhooks.NewSubscriptionSink(repoManager.Webhook()))
+		}
+		relay := outbox.NewRelay(repoManager.Outbox(), sinks, logger, conf.Outbox)
+		go relay.Run(context.Background())
+	}
+	if conf.Webhooks.Enabled {
+		dispatcher := webhooks.NewDispatcher(repoManager.Webhook(), nil, logger, conf.Webhooks)
+		go dispatcher.Run(context.Background())
+	}
 
 	monitorSystemMetrics()
 
@@ -10,11 +10,23 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Postgres PostgresConfig
-	Logger   LoggerConfig
-	JWT      JWTConfig
-	Redis    RedisConfig
+	Server      ServerConfig
+	Postgres    PostgresConfig
+	Logger      LoggerConfig
+	JWT         JWTConfig
+	Redis       RedisConfig
+	Profile     ProfileConfig
+	Blob        BlobConfig
+	Avatar      AvatarConfig
+	Mail        MailConfig
+	EmailChange EmailChangeConfig
+	Admin       AdminConfig
+	Outbox      OutboxConfig
+	Webhooks    WebhooksConfig
+	Idempotency IdempotencyConfig
+	Tenancy     TenancyConfig
+	Invitations InvitationConfig
+	Chat        ChatConfig
 }
 
 type ServerConfig struct {
@@ -69,6 +81,158 @@ type RedisConfig struct {
 	PoolTimeout        time.Duration
 }
 
+// ProfileConfig describes the custom attributes users may store on their
+// profile. Attributes not listed are rejected unless AllowUnknownAttributes
+// is set.
+type ProfileConfig struct {
+	AllowUnknownAttributes bool
+	Attributes             []AttributeConfig
+}
+
+type AttributeConfig struct {
+	Name      string
+	Type      string // string, number or boolean
+	Required  bool
+	MaxLength int
+	Enum      []string
+}
+
+type BlobConfig struct {
+	Driver string // local or s3
+	Local  struct {
+		Root string
+	}
+	S3 S3Config
+}
+
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+type AvatarConfig struct {
+	MaxUploadSize int64 // bytes
+	MaxDimension  int   // pixels, applies to both width and height
+	CacheMaxAge   int   // seconds
+}
+
+type MailConfig struct {
+	Driver string // smtp or log
+	From   string
+	SMTP   struct {
+		Host     string
+		Port     int
+		Username string
+		Password string
+	}
+}
+
+// EmailChangeConfig controls the confirmation flow for email changes. The
+// token is appended to ConfirmURL and CancelURL as a "token" query parameter.
+type EmailChangeConfig struct {
+	ConfirmExpireDuration time.Duration // minutes
+	CancelExpireDuration  time.Duration // minutes
+	ConfirmURL            string
+	CancelURL             string
+}
+
+// AdminConfig lists the users allowed to use the /api/admin endpoints.
+type AdminConfig struct {
+	UserIDs []int32
+}
+
+// OutboxConfig controls the relay that delivers domain events from the
+// outbox table. Sinks lists where every event goes: webhook, redis and/or
+// stdout.
+type OutboxConfig struct {
+	Enabled       bool
+	Sinks         []string
+	PollInterval  time.Duration // milliseconds
+	BatchSize     int32
+	LeaseDuration time.Duration // seconds
+	MinBackoff    time.Duration // seconds
+	MaxBackoff    time.Duration // seconds
+	Retention     time.Duration // hours, 0 keeps delivered events
+	Webhook       struct {
+		URL     string
+		Timeout time.Duration // seconds
+	}
+	RedisStream struct {
+		DB     int
+		Stream string
+		MaxLen int64
+	}
+}
+
+// WebhooksConfig controls delivery to webhook subscriptions. A delivery
+// that fails MaxAttempts times is moved to the dead-letter state and is only
+// retried through the redeliver endpoint.
+type WebhooksConfig struct {
+	Enabled       bool
+	PollInterval  time.Duration // milliseconds
+	BatchSize     int32
+	LeaseDuration time.Duration // seconds
+	Timeout       time.Duration // seconds
+	MaxAttempts   int32
+	MinBackoff    time.Duration // seconds
+	MaxBackoff    time.Duration // seconds
+}
+
+// IdempotencyConfig controls how long responses to requests carrying an
+// Idempotency-Key are kept for replay, and how long a key stays locked while
+// its first request is still running.
+type IdempotencyConfig struct {
+	TTL         time.Duration // hours
+	LockTimeout time.Duration // seconds
+}
+
+// TenancyConfig controls how the organization of a request is found: from
+// Header, then from the subdomain of BaseDomain the request was sent to.
+// Requests that name neither fall back to DefaultOrganization; leave it empty
+// to reject them.
+type TenancyConfig struct {
+	Header              string
+	BaseDomain          string
+	DefaultOrganization string
+}
+
+// InvitationConfig controls invitations to join an organization. The token
+// is appended to AcceptURL as a "token" query parameter. With
+// OpenRegistration off, /auth/register is closed and accounts can only be
+// made by accepting an invitation.
+type InvitationConfig struct {
+	ExpireDuration   time.Duration // minutes
+	AcceptURL        string
+	OpenRegistration bool
+}
+
+// ChatConfig selects how the chat hubs of the app instances reach each
+// other. With the memory broker a hub only serves the clients connected to
+// its own instance; with redis every instance publishes to and listens on
+// Channel, so a single-node deployment needs no Redis for chat.
+//
+// Connections are pinged every PingPeriod and dropped when no pong arrives
+// within PongWait or a write takes longer than WriteWait. A client frame
+// larger than MaxMessageSize bytes closes the connection. Users are shown
+// online until PresenceGrace after their last connection closes.
+//
+// Messages can be edited or deleted for EditWindow after they were sent;
+// zero leaves them open for good.
+type ChatConfig struct {
+	Broker         string // memory or redis
+	Channel        string
+	PingPeriod     time.Duration // seconds
+	PongWait       time.Duration // seconds
+	WriteWait      time.Duration // seconds
+	MaxMessageSize int64
+	PresenceGrace  time.Duration // seconds
+	EditWindow     time.Duration // minutes
+	UnreadTTL      time.Duration // minutes
+}
+
 func GetConfig() *Config {
 	cfgPath := getConfigPath(os.Getenv("APP_ENV"))
 	v, err := LoadConfig(cfgPath, "yml")
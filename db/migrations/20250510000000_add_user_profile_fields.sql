@@ -0,0 +1,15 @@
+-- migrate:up
+ALTER TABLE users
+    ADD COLUMN bio TEXT,
+    ADD COLUMN locale VARCHAR(35),
+    ADD COLUMN timezone VARCHAR(64),
+    ADD COLUMN avatar_url VARCHAR(2048),
+    ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- migrate:down
+ALTER TABLE users
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS avatar_url,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS bio;
@@ -0,0 +1,22 @@
+-- migrate:up
+CREATE TABLE email_changes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    old_email VARCHAR(100) NOT NULL,
+    new_email VARCHAR(100) NOT NULL,
+    confirm_token_hash CHAR(64) NOT NULL UNIQUE,
+    cancel_token_hash CHAR(64) NOT NULL UNIQUE,
+    confirm_expires_at TIMESTAMP NOT NULL,
+    cancel_expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    confirmed_at TIMESTAMP,
+    cancelled_at TIMESTAMP
+);
+
+-- At most one change may be pending per user.
+CREATE UNIQUE INDEX email_changes_pending_user_idx
+    ON email_changes (user_id)
+    WHERE confirmed_at IS NULL AND cancelled_at IS NULL;
+
+-- migrate:down
+DROP TABLE IF EXISTS email_changes;
@@ -0,0 +1,62 @@
+-- migrate:up
+
+-- username_skeleton folds case and maps Cyrillic, Greek and dotless-i letters
+-- that render like Latin ones, so "admin" and "аdmin" (Cyrillic а) cannot
+-- both be registered. The application stores usernames NFKC-normalized.
+CREATE FUNCTION username_skeleton(name TEXT) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+        SELECT translate(lower(name), 'асеһіјӏорԛѕԝхуԁοιρυνχκı', 'acehijlopqswxydoipuvxki')
+    $$;
+
+-- Existing rows may already collide under the new rules. Report every group
+-- and stop: the accounts have to be merged or renamed before the unique
+-- indexes can be built.
+DO $$
+DECLARE
+    collision RECORD;
+    found BOOLEAN := false;
+BEGIN
+    FOR collision IN
+        SELECT 'email' AS field, lower(btrim(email)) AS canonical, string_agg(id::TEXT, ', ' ORDER BY id) AS user_ids
+        FROM users
+        GROUP BY lower(btrim(email))
+        HAVING count(*) > 1
+        UNION ALL
+        SELECT 'username', username_skeleton(btrim(username)), string_agg(id::TEXT, ', ' ORDER BY id)
+        FROM users
+        GROUP BY username_skeleton(btrim(username))
+        HAVING count(*) > 1
+    LOOP
+        found := true;
+        RAISE WARNING '% collision on "%": users %', collision.field, collision.canonical, collision.user_ids;
+    END LOOP;
+
+    IF found THEN
+        RAISE EXCEPTION 'users have colliding emails or usernames'
+            USING HINT = 'Resolve the collisions reported above and run the migration again.';
+    END IF;
+END
+$$;
+
+UPDATE users SET email = lower(btrim(email)) WHERE email <> lower(btrim(email));
+UPDATE users SET username = btrim(username) WHERE username <> btrim(username);
+
+ALTER TABLE users
+    DROP CONSTRAINT users_email_key,
+    DROP CONSTRAINT users_username_key;
+
+-- The indexes keep the constraint names so unique violations are reported
+-- the same way as before.
+CREATE UNIQUE INDEX users_email_key ON users (lower(email));
+CREATE UNIQUE INDEX users_username_key ON users (username_skeleton(username));
+
+-- migrate:down
+DROP INDEX IF EXISTS users_username_key;
+DROP INDEX IF EXISTS users_email_key;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_email_key UNIQUE (email),
+    ADD CONSTRAINT users_username_key UNIQUE (username);
+
+DROP FUNCTION IF EXISTS username_skeleton(TEXT);
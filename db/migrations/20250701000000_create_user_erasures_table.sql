@@ -0,0 +1,38 @@
+-- migrate:up
+-- One row per erased user. The table is append-only so the record of an
+-- erasure cannot be altered or removed afterwards; user_id deliberately has
+-- no foreign key so the record outlives any later cleanup of the users row.
+CREATE TABLE user_erasures (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    requested_by INTEGER,
+    messages_anonymized INTEGER NOT NULL,
+    erased_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE FUNCTION reject_user_erasures_change() RETURNS TRIGGER
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    RAISE EXCEPTION 'user_erasures is append-only';
+END
+$$;
+
+CREATE TRIGGER user_erasures_append_only
+    BEFORE UPDATE OR DELETE ON user_erasures
+    FOR EACH ROW EXECUTE FUNCTION reject_user_erasures_change();
+
+CREATE TRIGGER user_erasures_no_truncate
+    BEFORE TRUNCATE ON user_erasures
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_user_erasures_change();
+
+-- Messages of erased users are attributed to this placeholder, so they can
+-- no longer be linked to each other or to the anonymized account. Creating
+-- it here also keeps the name from being registered.
+INSERT INTO users (username, email, full_name, password_hash, deleted_at)
+VALUES ('deleted-user', 'deleted-user@erased.invalid', 'Deleted user', '', CURRENT_TIMESTAMP)
+ON CONFLICT DO NOTHING;
+
+-- migrate:down
+DROP TABLE IF EXISTS user_erasures;
+DROP FUNCTION IF EXISTS reject_user_erasures_change();
@@ -0,0 +1,25 @@
+-- migrate:up
+-- One row per change to a user and per authentication attempt. before and
+-- after hold only the fields that changed; actor_id is NULL when nobody was
+-- signed in, e.g. for registrations and failed logins. The ids are not
+-- foreign keys so events outlive the users they mention.
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id INTEGER,
+    action TEXT NOT NULL,
+    target_type TEXT NOT NULL,
+    target_id INTEGER,
+    before JSONB NOT NULL DEFAULT '{}'::jsonb,
+    after JSONB NOT NULL DEFAULT '{}'::jsonb,
+    metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    ip TEXT NOT NULL DEFAULT '',
+    request_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_events_created_at_idx ON audit_events (created_at);
+CREATE INDEX audit_events_actor_idx ON audit_events (actor_id, created_at);
+CREATE INDEX audit_events_target_idx ON audit_events (target_type, target_id, created_at);
+
+-- migrate:down
+DROP TABLE IF EXISTS audit_events;
@@ -0,0 +1,23 @@
+-- migrate:up
+-- Domain events waiting to be delivered to the configured sinks. Events are
+-- written in the same transaction as the change they describe and picked up
+-- by the outbox relay. next_attempt_at doubles as the claim: a relay pushes
+-- it forward while delivering, so an event claimed by a relay that died is
+-- retried once the claim runs out.
+CREATE TABLE outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (next_attempt_at, id) WHERE delivered_at IS NULL;
+
+-- migrate:down
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,16 @@
+-- migrate:up
+-- A row means blocker_id no longer wants to see blocked_id in chat: live
+-- broadcasts from the blocked user are not delivered to the blocker and
+-- their messages are left out of the blocker's history.
+CREATE TABLE user_blocks (
+    blocker_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX user_blocks_blocked_id_idx ON user_blocks (blocked_id);
+
+-- migrate:down
+DROP TABLE IF EXISTS user_blocks;
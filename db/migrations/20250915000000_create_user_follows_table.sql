@@ -0,0 +1,43 @@
+-- migrate:up
+-- follower_id follows followee_id. The follower and following counts on
+-- users are kept in step by a trigger, so they can be read with the rest of
+-- the profile instead of being counted on every request.
+CREATE TABLE user_follows (
+    follower_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    followee_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX user_follows_followee_id_idx ON user_follows (followee_id, created_at);
+
+ALTER TABLE users
+    ADD COLUMN followers_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN following_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE FUNCTION update_follow_counts() RETURNS TRIGGER
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET followers_count = followers_count + 1 WHERE id = NEW.followee_id;
+        UPDATE users SET following_count = following_count + 1 WHERE id = NEW.follower_id;
+        RETURN NEW;
+    END IF;
+    UPDATE users SET followers_count = followers_count - 1 WHERE id = OLD.followee_id;
+    UPDATE users SET following_count = following_count - 1 WHERE id = OLD.follower_id;
+    RETURN OLD;
+END
+$$;
+
+CREATE TRIGGER user_follows_counts
+    AFTER INSERT OR DELETE ON user_follows
+    FOR EACH ROW EXECUTE FUNCTION update_follow_counts();
+
+-- migrate:down
+DROP TABLE IF EXISTS user_follows;
+DROP FUNCTION IF EXISTS update_follow_counts();
+ALTER TABLE users
+    DROP COLUMN IF EXISTS followers_count,
+    DROP COLUMN IF EXISTS following_count;
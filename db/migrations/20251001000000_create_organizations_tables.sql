@@ -0,0 +1,64 @@
+-- migrate:up
+-- Tenants. Accounts are shared between organizations: a user can belong to
+-- several, with a role in each, and only sees the users and messages of the
+-- organization a request is made in.
+CREATE TABLE organizations (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(63) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE organization_members (
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX organization_members_user_id_idx ON organization_members (user_id);
+
+-- Everything that exists so far belongs to one organization.
+INSERT INTO organizations (slug, name) VALUES ('default', 'Default');
+
+INSERT INTO organization_members (organization_id, user_id)
+SELECT o.id, u.id FROM organizations o CROSS JOIN users u
+WHERE o.slug = 'default' AND u.deleted_at IS NULL;
+
+ALTER TABLE messages ADD COLUMN organization_id INTEGER REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE messages SET organization_id = (SELECT id FROM organizations WHERE slug = 'default');
+ALTER TABLE messages ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX messages_organization_id_created_at_idx ON messages (organization_id, created_at);
+
+-- Row-level security backs up the tenant filters in the queries. Work done
+-- for a tenant runs in a transaction that sets app.tenant_id, and then only
+-- sees that tenant's rows; work that is not done for a tenant (erasure,
+-- exports, background jobs) leaves it unset and sees everything. Superusers
+-- bypass row-level security, so the API must connect as an ordinary role for
+-- this to take effect.
+ALTER TABLE messages ENABLE ROW LEVEL SECURITY;
+ALTER TABLE messages FORCE ROW LEVEL SECURITY;
+CREATE POLICY messages_tenant_isolation ON messages
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+ALTER TABLE organization_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE organization_members FORCE ROW LEVEL SECURITY;
+CREATE POLICY organization_members_tenant_isolation ON organization_members
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+-- migrate:down
+DROP POLICY IF EXISTS messages_tenant_isolation ON messages;
+ALTER TABLE messages DISABLE ROW LEVEL SECURITY;
+ALTER TABLE messages NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE messages DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
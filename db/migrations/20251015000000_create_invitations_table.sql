@@ -0,0 +1,29 @@
+-- migrate:up
+-- Single-use invitations to join an organization. Only a hash of the token
+-- is stored; the token itself is mailed to the invitee.
+CREATE TABLE invitations (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(100) NOT NULL,
+    role TEXT NOT NULL DEFAULT 'member' CHECK (role IN ('owner', 'admin', 'member')),
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    invited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    consumed_at TIMESTAMP,
+    consumed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP
+);
+
+CREATE INDEX invitations_organization_id_created_at_idx ON invitations (organization_id, created_at);
+
+ALTER TABLE invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invitations FORCE ROW LEVEL SECURITY;
+CREATE POLICY invitations_tenant_isolation ON invitations
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+-- migrate:down
+DROP TABLE IF EXISTS invitations;
@@ -0,0 +1,50 @@
+-- migrate:up
+-- Chat rooms. Every message is posted to a room, and only the room's
+-- members may post to it, read its history or receive its messages live.
+CREATE TABLE chat_rooms (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, name)
+);
+
+CREATE TABLE room_members (
+    room_id INTEGER NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    joined_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, user_id)
+);
+
+CREATE INDEX room_members_user_id_idx ON room_members (user_id);
+
+-- The messages sent so far go to a "general" room in their organization,
+-- which every current member of the organization joins.
+INSERT INTO chat_rooms (organization_id, name)
+SELECT id, 'general' FROM organizations;
+
+INSERT INTO room_members (room_id, user_id)
+SELECT r.id, m.user_id FROM chat_rooms r
+JOIN organization_members m ON m.organization_id = r.organization_id;
+
+ALTER TABLE messages ADD COLUMN room_id INTEGER REFERENCES chat_rooms(id) ON DELETE CASCADE;
+UPDATE messages m SET room_id = r.id
+FROM chat_rooms r
+WHERE r.organization_id = m.organization_id AND r.name = 'general';
+ALTER TABLE messages ALTER COLUMN room_id SET NOT NULL;
+
+CREATE INDEX messages_room_id_created_at_idx ON messages (room_id, created_at);
+
+ALTER TABLE chat_rooms ENABLE ROW LEVEL SECURITY;
+ALTER TABLE chat_rooms FORCE ROW LEVEL SECURITY;
+CREATE POLICY chat_rooms_tenant_isolation ON chat_rooms
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+-- migrate:down
+ALTER TABLE messages DROP COLUMN IF EXISTS room_id;
+DROP TABLE IF EXISTS room_members;
+DROP TABLE IF EXISTS chat_rooms;
@@ -0,0 +1,37 @@
+-- migrate:up
+-- A message goes either to a room or, as a direct message, to one user.
+ALTER TABLE messages ALTER COLUMN room_id DROP NOT NULL;
+ALTER TABLE messages ADD COLUMN recipient_id INTEGER REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE messages ADD CONSTRAINT messages_target_check
+    CHECK ((room_id IS NULL) <> (recipient_id IS NULL));
+
+CREATE INDEX messages_recipient_id_idx ON messages (recipient_id, sender_id, id)
+    WHERE recipient_id IS NOT NULL;
+CREATE INDEX messages_direct_sender_id_idx ON messages (sender_id, recipient_id, id)
+    WHERE recipient_id IS NOT NULL;
+
+-- How far each user has read their conversation with each peer. Messages
+-- from the peer with a higher id are unread.
+CREATE TABLE direct_reads (
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    peer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_read_id INTEGER NOT NULL,
+    read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, user_id, peer_id)
+);
+
+ALTER TABLE direct_reads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE direct_reads FORCE ROW LEVEL SECURITY;
+CREATE POLICY direct_reads_tenant_isolation ON direct_reads
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+-- migrate:down
+DROP TABLE IF EXISTS direct_reads;
+DELETE FROM messages WHERE recipient_id IS NOT NULL;
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_target_check;
+ALTER TABLE messages DROP COLUMN IF EXISTS recipient_id;
+ALTER TABLE messages ALTER COLUMN room_id SET NOT NULL;
@@ -0,0 +1,30 @@
+-- migrate:up
+-- Edited messages keep their earlier contents in message_revisions. A
+-- deleted message stays in place with its content cleared, so replies and
+-- read markers still point somewhere.
+ALTER TABLE messages ADD COLUMN edited_at TIMESTAMP;
+ALTER TABLE messages ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE TABLE message_revisions (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    revised_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    revised_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX message_revisions_message_id_idx ON message_revisions (message_id, id);
+
+ALTER TABLE message_revisions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE message_revisions FORCE ROW LEVEL SECURITY;
+CREATE POLICY message_revisions_tenant_isolation ON message_revisions
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+-- migrate:down
+DROP TABLE IF EXISTS message_revisions;
+ALTER TABLE messages DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE messages DROP COLUMN IF EXISTS edited_at;
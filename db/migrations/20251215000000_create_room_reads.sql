@@ -0,0 +1,35 @@
+-- migrate:up
+-- How far each member has read each room, like direct_reads for
+-- conversations. Messages of the room with a higher id are unread.
+CREATE TABLE room_reads (
+    room_id INTEGER NOT NULL REFERENCES chat_rooms(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    last_read_id INTEGER NOT NULL,
+    read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (room_id, user_id)
+);
+
+CREATE INDEX messages_room_id_id_idx ON messages (room_id, id)
+    WHERE room_id IS NOT NULL;
+
+-- Current members start with their rooms read, rather than with the whole
+-- history unread.
+INSERT INTO room_reads (room_id, user_id, organization_id, last_read_id)
+SELECT rm.room_id, rm.user_id, r.organization_id, MAX(m.id)
+FROM room_members rm
+JOIN chat_rooms r ON r.id = rm.room_id
+JOIN messages m ON m.room_id = rm.room_id
+GROUP BY rm.room_id, rm.user_id, r.organization_id;
+
+ALTER TABLE room_reads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE room_reads FORCE ROW LEVEL SECURITY;
+CREATE POLICY room_reads_tenant_isolation ON room_reads
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+-- migrate:down
+DROP TABLE IF EXISTS room_reads;
+DROP INDEX IF EXISTS messages_room_id_id_idx;
@@ -0,0 +1,35 @@
+-- migrate:up
+-- A room message may start a thread: its replies point at it with
+-- parent_id. Threads are one level deep, so a reply's parent is never a
+-- reply itself.
+ALTER TABLE messages ADD COLUMN parent_id INTEGER REFERENCES messages(id) ON DELETE CASCADE;
+ALTER TABLE messages ADD CONSTRAINT messages_parent_check CHECK (parent_id IS NULL OR room_id IS NOT NULL);
+
+CREATE INDEX messages_parent_id_idx ON messages (parent_id, id)
+    WHERE parent_id IS NOT NULL;
+
+-- Each user reacts to a message with an emoji at most once.
+CREATE TABLE message_reactions (
+    message_id INTEGER NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, user_id, emoji)
+);
+
+CREATE INDEX message_reactions_user_id_idx ON message_reactions (user_id);
+
+ALTER TABLE message_reactions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE message_reactions FORCE ROW LEVEL SECURITY;
+CREATE POLICY message_reactions_tenant_isolation ON message_reactions
+    USING (
+        NULLIF(current_setting('app.tenant_id', true), '') IS NULL
+        OR organization_id = NULLIF(current_setting('app.tenant_id', true), '')::integer
+    );
+
+-- migrate:down
+DROP TABLE IF EXISTS message_reactions;
+DROP INDEX IF EXISTS messages_parent_id_idx;
+ALTER TABLE messages DROP CONSTRAINT IF EXISTS messages_parent_check;
+ALTER TABLE messages DROP COLUMN IF EXISTS parent_id;
@@ -8,14 +8,24 @@ RETURNING *;
 SELECT * FROM users
 WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: GetUserByIDWithDeleted :one
+-- Also finds soft-deleted users, which can still be erased.
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: GetUsersByIDs :many
+-- Rows come back in no particular order; callers restore the order they need.
+SELECT * FROM users
+WHERE id = ANY(sqlc.arg(ids)::integer[]) AND deleted_at IS NULL;
+
 -- name: GetUserByUsername :one
 SELECT * FROM users
-WHERE username = $1 AND deleted_at IS NULL;
+WHERE username_skeleton(username) = username_skeleton(sqlc.arg(username)) AND deleted_at IS NULL;
 
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
-WHERE email = $1 AND deleted_at IS NULL;
+WHERE lower(email) = lower(sqlc.arg(email)) AND deleted_at IS NULL;
 
 -- name: UpdateUserFull :one
 UPDATE users
@@ -24,14 +34,29 @@ WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: UpdateUserPartial :one
+-- Nullable profile columns carry an explicit set_* flag so that a NULL
+-- argument clears the column instead of leaving it unchanged.
 UPDATE users
 SET
-    username = COALESCE($2, username),
-    email = COALESCE($3, email),
-    full_name = COALESCE($4, full_name),
-    password_hash = COALESCE($5, password_hash),
+    username = COALESCE(sqlc.narg(username), username),
+    email = COALESCE(sqlc.narg(email), email),
+    full_name = COALESCE(sqlc.narg(full_name), full_name),
+    password_hash = COALESCE(sqlc.narg(password_hash), password_hash),
+    bio = CASE WHEN sqlc.arg(set_bio)::boolean THEN sqlc.narg(bio) ELSE bio END,
+    locale = CASE WHEN sqlc.arg(set_locale)::boolean THEN sqlc.narg(locale) ELSE locale END,
+    timezone = CASE WHEN sqlc.arg(set_timezone)::boolean THEN sqlc.narg(timezone) ELSE timezone END,
+    avatar_url = CASE WHEN sqlc.arg(set_avatar_url)::boolean THEN sqlc.narg(avatar_url) ELSE avatar_url END,
+    attributes = COALESCE(sqlc.narg(attributes)::jsonb, attributes),
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
+WHERE id = sqlc.arg(id) AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SwapUserEmail :one
+-- Replaces the email only if it still matches old_email, so a stale
+-- confirmation or cancellation cannot overwrite a later change.
+UPDATE users
+SET email = sqlc.arg(new_email), updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id) AND email = sqlc.arg(old_email) AND deleted_at IS NULL
 RETURNING *;
 
 -- name: SoftDeleteUser :execrows
@@ -46,13 +71,776 @@ ORDER BY id
 LIMIT $1 OFFSET $2;
 
 -- name: CreateMessage :one
-INSERT INTO messages (sender_id, content)
-VALUES ($1, $2)
+-- Posts a message to a room of the organization, as a reply in the thread
+-- of parent_id unless it is null. Nothing is inserted, and no row returned,
+-- unless the sender is a member of the room.
+INSERT INTO messages (sender_id, content, organization_id, room_id, parent_id)
+SELECT sqlc.arg(sender_id), sqlc.arg(content), r.organization_id, r.id, sqlc.narg(parent_id)
+FROM chat_rooms r
+JOIN room_members rm ON rm.room_id = r.id AND rm.user_id = sqlc.arg(sender_id)
+WHERE r.id = sqlc.arg(room_id) AND r.organization_id = sqlc.arg(organization_id)
 RETURNING *;
 
 -- name: GetMessages :many
-SELECT m.*, u.username as sender_name 
+-- Returns a page of a room's messages, leaving out replies, which are
+-- fetched with their thread. Each message comes with the number of replies
+-- to it and its reactions, grouped by emoji in the order they were first
+-- used.
+SELECT m.*, u.username as sender_name,
+    (SELECT count(*) FROM messages t WHERE t.parent_id = m.id) AS reply_count,
+    COALESCE((
+        SELECT json_agg(json_build_object('emoji', r.emoji, 'count', r.count, 'users', r.users) ORDER BY r.first_at, r.emoji)
+        FROM (
+            SELECT mr.emoji, COUNT(*) AS count, array_agg(mr.user_id ORDER BY mr.created_at, mr.user_id) AS users, MIN(mr.created_at) AS first_at
+            FROM message_reactions mr
+            WHERE mr.message_id = m.id
+            GROUP BY mr.emoji
+        ) r
+    ), '[]')::json AS reactions
 FROM messages m
 JOIN users u ON m.sender_id = u.id
+WHERE m.organization_id = $4 AND m.room_id = $5 AND m.parent_id IS NULL AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = $3 AND b.blocked_id = m.sender_id
+)
 ORDER BY m.created_at DESC
-LIMIT $1 OFFSET $2;
\ No newline at end of file
+LIMIT $1 OFFSET $2;
+
+-- name: CreateEmailChange :one
+INSERT INTO email_changes (
+    user_id, old_email, new_email,
+    confirm_token_hash, cancel_token_hash,
+    confirm_expires_at, cancel_expires_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: CancelPendingEmailChanges :exec
+UPDATE email_changes
+SET cancelled_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL;
+
+-- name: GetEmailChangeByConfirmToken :one
+SELECT * FROM email_changes
+WHERE confirm_token_hash = $1
+FOR UPDATE;
+
+-- name: GetEmailChangeByCancelToken :one
+SELECT * FROM email_changes
+WHERE cancel_token_hash = $1
+FOR UPDATE;
+
+-- name: MarkEmailChangeConfirmed :execrows
+UPDATE email_changes
+SET confirmed_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL;
+
+-- name: MarkEmailChangeCancelled :execrows
+UPDATE email_changes
+SET cancelled_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND cancelled_at IS NULL;
+
+-- name: GetMessagesBySender :many
+SELECT * FROM messages
+WHERE sender_id = $1
+ORDER BY id;
+
+-- name: ReassignMessagesSender :execrows
+UPDATE messages
+SET sender_id = sqlc.arg(to_sender_id)
+WHERE sender_id = sqlc.arg(from_sender_id);
+
+-- name: ReassignMessagesRecipient :execrows
+UPDATE messages
+SET recipient_id = sqlc.arg(to_recipient_id)
+WHERE recipient_id = sqlc.arg(from_recipient_id);
+
+-- name: GetErasedUserPlaceholder :one
+-- The placeholder is created by a migration; inserting it here as well keeps
+-- erasure working on a database where the row was removed.
+WITH created AS (
+    INSERT INTO users (username, email, full_name, password_hash, deleted_at)
+    VALUES ('deleted-user', 'deleted-user@erased.invalid', 'Deleted user', '', CURRENT_TIMESTAMP)
+    ON CONFLICT DO NOTHING
+    RETURNING id
+)
+SELECT id FROM created
+UNION ALL
+SELECT id FROM users WHERE lower(email) = 'deleted-user@erased.invalid'
+LIMIT 1;
+
+-- name: AnonymizeUser :one
+UPDATE users
+SET username = 'erased-' || id,
+    email = 'erased-' || id || '@erased.invalid',
+    full_name = '',
+    password_hash = '',
+    bio = NULL,
+    locale = NULL,
+    timezone = NULL,
+    avatar_url = NULL,
+    attributes = '{}'::jsonb,
+    deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteEmailChangesByUser :exec
+DELETE FROM email_changes
+WHERE user_id = $1;
+
+-- name: CreateUserErasure :one
+INSERT INTO user_erasures (user_id, requested_by, messages_anonymized)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+    actor_id, action, target_type, target_id,
+    before, after, metadata, ip, request_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: ListAuditEvents :many
+-- Every filter is optional; a NULL argument matches all events.
+SELECT * FROM audit_events
+WHERE (sqlc.narg(actor_id)::integer IS NULL OR actor_id = sqlc.narg(actor_id))
+    AND (sqlc.narg(action)::text IS NULL OR action = sqlc.narg(action))
+    AND (sqlc.narg(target_type)::text IS NULL OR target_type = sqlc.narg(target_type))
+    AND (sqlc.narg(target_id)::integer IS NULL OR target_id = sqlc.narg(target_id))
+    AND (sqlc.narg(since)::timestamp IS NULL OR created_at >= sqlc.narg(since))
+    AND (sqlc.narg(until)::timestamp IS NULL OR created_at < sqlc.narg(until))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: RedactAuditEventsForUser :execrows
+UPDATE audit_events
+SET before = '{}'::jsonb, after = '{}'::jsonb, metadata = '{}'::jsonb
+WHERE target_type = 'user' AND target_id = $1;
+
+-- name: RedactFailedLoginsForEmail :execrows
+-- A failed login for an email nobody had at the time has no target, only the
+-- address that was tried.
+UPDATE audit_events
+SET metadata = metadata - 'email'
+WHERE action = 'auth.login_failed' AND lower(metadata->>'email') = lower(sqlc.arg(email)::text);
+
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ClaimOutboxEvents :many
+-- Claims due events by pushing next_attempt_at past the lease, so other
+-- relays skip them while they are delivered.
+UPDATE outbox_events
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg(lease_seconds)::integer),
+    attempts = attempts + 1
+WHERE id IN (
+    SELECT id FROM outbox_events
+    WHERE delivered_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP
+    ORDER BY id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox_events
+SET delivered_at = CURRENT_TIMESTAMP, last_error = ''
+WHERE id = $1;
+
+-- name: RescheduleOutboxEvent :exec
+UPDATE outbox_events
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg(delay_seconds)::double precision),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: CountPendingOutboxEvents :one
+SELECT count(*) FROM outbox_events
+WHERE delivered_at IS NULL;
+
+-- name: DeleteDeliveredOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE delivered_at < $1;
+
+-- name: RedactOutboxEventsForUser :execrows
+UPDATE outbox_events
+SET payload = jsonb_build_object('id', aggregate_id, 'erased', true)
+WHERE aggregate_type = 'user' AND aggregate_id = $1;
+
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, event_types, secret, active)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+ORDER BY id;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET url = $2, event_types = $3, secret = $4, active = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: EnqueueWebhookDeliveries :execrows
+-- Fans an outbox event out to every active subscription listening for its
+-- type. Relaying the same event twice does not duplicate deliveries.
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT id, sqlc.arg(event_id)::bigint, sqlc.arg(event_type)::text, sqlc.arg(payload)::jsonb
+FROM webhook_subscriptions
+WHERE active AND sqlc.arg(event_type)::text = ANY(event_types)
+ON CONFLICT (subscription_id, event_id) DO NOTHING;
+
+-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg(lease_seconds)::integer),
+    attempts = d.attempts + 1
+FROM webhook_subscriptions s
+WHERE s.id = d.subscription_id AND d.id IN (
+    SELECT wd.id FROM webhook_deliveries wd
+    JOIN webhook_subscriptions ws ON ws.id = wd.subscription_id
+    WHERE wd.status = 'pending' AND wd.next_attempt_at <= CURRENT_TIMESTAMP AND ws.active
+    ORDER BY wd.id
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE OF wd SKIP LOCKED
+)
+RETURNING d.*, s.url, s.secret;
+
+-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', last_status_code = $2, last_error = '', delivered_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: RetryWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => sqlc.arg(delay_seconds)::double precision),
+    last_status_code = sqlc.arg(last_status_code),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: DeadLetterWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'dead', last_status_code = $2, last_error = $3
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE subscription_id = sqlc.arg(subscription_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status))
+ORDER BY id DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: RedeliverWebhookDelivery :one
+-- Puts a finished delivery back in the queue with a fresh attempt budget.
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, last_error = '', next_attempt_at = CURRENT_TIMESTAMP, delivered_at = NULL
+WHERE id = $1 AND subscription_id = $2 AND status <> 'pending'
+RETURNING *;
+
+-- name: RedactWebhookDeliveriesForUser :execrows
+-- Deliveries carry a copy of the outbox event, which may have been pruned
+-- already, so they are matched on the event they carry.
+UPDATE webhook_deliveries
+SET payload = jsonb_set(payload, '{data}', jsonb_build_object('id', sqlc.arg(user_id)::integer, 'erased', true))
+WHERE payload->>'aggregateType' = 'user' AND payload->>'aggregateId' = sqlc.arg(user_id)::integer::text;
+
+-- name: CreateUserBlock :execrows
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteUserBlock :execrows
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: ListUserBlocks :many
+SELECT b.blocked_id, u.username, b.created_at
+FROM user_blocks b
+JOIN users u ON u.id = b.blocked_id
+WHERE b.blocker_id = $1
+ORDER BY b.created_at DESC, b.blocked_id;
+
+-- name: ListBlockerIDs :many
+SELECT blocker_id FROM user_blocks
+WHERE blocked_id = $1;
+
+-- name: DeleteUserBlocksByUser :exec
+DELETE FROM user_blocks
+WHERE blocker_id = $1 OR blocked_id = $1;
+
+-- name: CreateFollow :execrows
+INSERT INTO user_follows (follower_id, followee_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteFollow :execrows
+DELETE FROM user_follows
+WHERE follower_id = $1 AND followee_id = $2;
+
+-- name: ListFollowers :many
+-- A NULL organization_id lists users of every organization.
+SELECT u.id, u.username, u.full_name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.follower_id
+WHERE f.followee_id = sqlc.arg(followee_id) AND u.deleted_at IS NULL
+    AND (sqlc.narg(organization_id)::integer IS NULL OR EXISTS (
+        SELECT 1 FROM organization_members m
+        WHERE m.organization_id = sqlc.narg(organization_id) AND m.user_id = u.id))
+ORDER BY f.created_at DESC, u.id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: ListFollowing :many
+-- A NULL organization_id lists users of every organization.
+SELECT u.id, u.username, u.full_name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.followee_id
+WHERE f.follower_id = sqlc.arg(follower_id) AND u.deleted_at IS NULL
+    AND (sqlc.narg(organization_id)::integer IS NULL OR EXISTS (
+        SELECT 1 FROM organization_members m
+        WHERE m.organization_id = sqlc.narg(organization_id) AND m.user_id = u.id))
+ORDER BY f.created_at DESC, u.id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: ListMutualFollows :many
+-- Users that follow follower_id back, newest follow first. A NULL
+-- organization_id lists users of every organization.
+SELECT u.id, u.username, u.full_name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN user_follows back ON back.follower_id = f.followee_id AND back.followee_id = f.follower_id
+JOIN users u ON u.id = f.followee_id
+WHERE f.follower_id = sqlc.arg(follower_id) AND u.deleted_at IS NULL
+    AND (sqlc.narg(organization_id)::integer IS NULL OR EXISTS (
+        SELECT 1 FROM organization_members m
+        WHERE m.organization_id = sqlc.narg(organization_id) AND m.user_id = u.id))
+ORDER BY f.created_at DESC, u.id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: DeleteFollowsByUser :exec
+DELETE FROM user_follows
+WHERE follower_id = $1 OR followee_id = $1;
+
+-- name: CreateOrganization :one
+INSERT INTO organizations (slug, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetOrganizationBySlug :one
+SELECT * FROM organizations
+WHERE slug = $1;
+
+-- name: ListOrganizationsForUser :many
+SELECT o.*, m.role
+FROM organization_members m
+JOIN organizations o ON o.id = m.organization_id
+WHERE m.user_id = $1
+ORDER BY o.name, o.id;
+
+-- name: GetOrganizationMember :one
+SELECT * FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: AddOrganizationMember :execrows
+INSERT INTO organization_members (organization_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UpsertOrganizationMember :one
+INSERT INTO organization_members (organization_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING *;
+
+-- name: DeleteOrganizationMember :execrows
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: CountOrganizationOwners :one
+SELECT count(*) FROM organization_members
+WHERE organization_id = $1 AND role = 'owner';
+
+-- name: ListOrganizationMembers :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, m.role, m.created_at AS joined_at
+FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1 AND u.deleted_at IS NULL
+ORDER BY m.created_at, u.id
+LIMIT $2 OFFSET $3;
+
+-- name: FilterOrganizationMembers :many
+-- Returns the ids that belong to members of the organization.
+SELECT user_id FROM organization_members
+WHERE organization_id = sqlc.arg(organization_id) AND user_id = ANY(sqlc.arg(user_ids)::integer[]);
+
+-- name: ListUsersInOrganization :many
+SELECT u.* FROM users u
+JOIN organization_members m ON m.user_id = u.id
+WHERE m.organization_id = $1 AND u.deleted_at IS NULL
+ORDER BY u.id
+LIMIT $2 OFFSET $3;
+
+-- name: SetTenant :exec
+-- Scopes row-level security to one organization until the transaction ends.
+SELECT set_config('app.tenant_id', sqlc.arg(tenant_id)::text, true);
+
+-- name: CreateInvitation :one
+INSERT INTO invitations (
+    organization_id, email, role, token_hash, invited_by, expires_at
+)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetInvitationByToken :one
+SELECT * FROM invitations
+WHERE token_hash = $1;
+
+-- name: ListInvitations :many
+SELECT * FROM invitations
+WHERE organization_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: RevokeInvitation :execrows
+UPDATE invitations
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND organization_id = $2
+  AND consumed_at IS NULL AND revoked_at IS NULL;
+
+-- name: ConsumeInvitation :execrows
+UPDATE invitations
+SET consumed_at = CURRENT_TIMESTAMP, consumed_by = $2
+WHERE id = $1 AND consumed_at IS NULL AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: RedactInvitationsForEmail :execrows
+-- Invitations to an erased user's address get the address the user was
+-- anonymized to.
+UPDATE invitations
+SET email = 'erased-' || sqlc.arg(user_id)::integer || '@erased.invalid'
+WHERE lower(email) = lower(sqlc.arg(email)::text);
+
+-- name: CreateChatRoom :one
+INSERT INTO chat_rooms (organization_id, name, created_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetChatRoom :one
+SELECT * FROM chat_rooms
+WHERE id = $1 AND organization_id = $2;
+
+-- name: ListChatRooms :many
+SELECT r.*, EXISTS (
+    SELECT 1 FROM room_members rm
+    WHERE rm.room_id = r.id AND rm.user_id = $2
+) AS joined
+FROM chat_rooms r
+WHERE r.organization_id = $1
+ORDER BY r.name;
+
+-- name: AddRoomMember :execrows
+INSERT INTO room_members (room_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteRoomMember :execrows
+DELETE FROM room_members
+WHERE room_id = $1 AND user_id = $2;
+
+-- name: IsRoomMember :one
+SELECT EXISTS (
+    SELECT 1 FROM room_members
+    WHERE room_id = $1 AND user_id = $2
+);
+
+-- name: ListRoomMembers :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, rm.joined_at
+FROM room_members rm
+JOIN users u ON u.id = rm.user_id
+WHERE rm.room_id = $1 AND u.deleted_at IS NULL
+ORDER BY rm.joined_at, u.id;
+
+-- name: ListRoomIDsForUser :many
+SELECT rm.room_id FROM room_members rm
+JOIN chat_rooms r ON r.id = rm.room_id
+WHERE rm.user_id = $1 AND r.organization_id = $2;
+
+-- name: DeleteRoomMembershipsInOrganization :exec
+DELETE FROM room_members rm
+USING chat_rooms r
+WHERE r.id = rm.room_id AND r.organization_id = $1 AND rm.user_id = $2;
+
+-- name: MarkRoomRead :one
+-- Moves user_id's read marker in the room to its newest message, or to the
+-- newest up to up_to_id unless it is 0. The marker never moves back. No row
+-- is returned when there is no such message.
+INSERT INTO room_reads (organization_id, room_id, user_id, last_read_id)
+SELECT sqlc.arg(organization_id), sqlc.arg(room_id), sqlc.arg(user_id), MAX(m.id)
+FROM messages m
+WHERE m.organization_id = sqlc.arg(organization_id) AND m.room_id = sqlc.arg(room_id)
+  AND (sqlc.arg(up_to_id)::integer = 0 OR m.id <= sqlc.arg(up_to_id)::integer)
+HAVING MAX(m.id) IS NOT NULL
+ON CONFLICT (room_id, user_id) DO UPDATE
+SET last_read_id = GREATEST(room_reads.last_read_id, EXCLUDED.last_read_id),
+    read_at = CURRENT_TIMESTAMP
+RETURNING last_read_id;
+
+-- name: CountUnreadRooms :many
+-- Counts, for each room user_id has joined, the messages after their read
+-- marker, leaving out their own and those of users they blocked.
+SELECT rm.room_id, COUNT(m.id) AS unread_count
+FROM room_members rm
+JOIN chat_rooms r ON r.id = rm.room_id
+LEFT JOIN room_reads rr ON rr.room_id = rm.room_id AND rr.user_id = rm.user_id
+LEFT JOIN messages m ON m.room_id = rm.room_id
+    AND m.id > COALESCE(rr.last_read_id, 0)
+    AND m.sender_id <> rm.user_id
+    AND NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = rm.user_id AND b.blocked_id = m.sender_id
+    )
+WHERE rm.user_id = sqlc.arg(user_id) AND r.organization_id = sqlc.arg(organization_id)
+GROUP BY rm.room_id
+ORDER BY rm.room_id;
+
+-- name: ListRoomRecipients :many
+-- Lists the members of a room a message from sender_id is unread for:
+-- everyone but the sender and those who blocked them.
+SELECT rm.user_id FROM room_members rm
+WHERE rm.room_id = sqlc.arg(room_id) AND rm.user_id <> sqlc.arg(sender_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = rm.user_id AND b.blocked_id = sqlc.arg(sender_id)
+  )
+ORDER BY rm.user_id;
+
+-- name: DeleteRoomReadsByUser :exec
+DELETE FROM room_reads
+WHERE user_id = $1;
+
+-- name: CreateDirectMessage :one
+-- Sends a direct message within the organization. Nothing is inserted, and
+-- no row returned, unless the recipient is another member of the
+-- organization who has not blocked the sender.
+INSERT INTO messages (sender_id, content, organization_id, recipient_id)
+SELECT sqlc.arg(sender_id), sqlc.arg(content), om.organization_id, om.user_id
+FROM organization_members om
+WHERE om.organization_id = sqlc.arg(organization_id)
+  AND om.user_id = sqlc.arg(recipient_id)
+  AND om.user_id <> sqlc.arg(sender_id)
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = om.user_id AND b.blocked_id = sqlc.arg(sender_id)
+  )
+RETURNING *;
+
+-- name: GetDirectMessages :many
+-- Returns a page of a conversation with the reactions to each message, as
+-- GetMessages does.
+SELECT m.*, u.username AS sender_name,
+    COALESCE((
+        SELECT json_agg(json_build_object('emoji', r.emoji, 'count', r.count, 'users', r.users) ORDER BY r.first_at, r.emoji)
+        FROM (
+            SELECT mr.emoji, COUNT(*) AS count, array_agg(mr.user_id ORDER BY mr.created_at, mr.user_id) AS users, MIN(mr.created_at) AS first_at
+            FROM message_reactions mr
+            WHERE mr.message_id = m.id
+            GROUP BY mr.emoji
+        ) r
+    ), '[]')::json AS reactions
+FROM messages m
+JOIN users u ON u.id = m.sender_id
+WHERE m.organization_id = sqlc.arg(organization_id)
+  AND ((m.sender_id = sqlc.arg(user_id) AND m.recipient_id = sqlc.arg(peer_id))
+    OR (m.sender_id = sqlc.arg(peer_id) AND m.recipient_id = sqlc.arg(user_id)))
+ORDER BY m.id DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: ListConversations :many
+-- Lists the users user_id has exchanged direct messages with, most recent
+-- conversation first, with the last message of each and how many of the
+-- peer's messages user_id has not read yet.
+WITH direct AS (
+    SELECT m.id, m.sender_id, m.content, m.created_at,
+        CASE WHEN m.sender_id = sqlc.arg(user_id) THEN m.recipient_id ELSE m.sender_id END::integer AS peer_id
+    FROM messages m
+    WHERE m.organization_id = sqlc.arg(organization_id)
+      AND (m.sender_id = sqlc.arg(user_id) OR m.recipient_id = sqlc.arg(user_id))
+      AND m.recipient_id IS NOT NULL
+), latest AS (
+    SELECT DISTINCT ON (peer_id) id, sender_id, content, created_at, peer_id
+    FROM direct
+    ORDER BY peer_id, id DESC
+)
+SELECT l.peer_id, u.username AS peer_username, u.full_name AS peer_full_name, u.avatar_url AS peer_avatar_url,
+    l.id AS last_message_id, l.sender_id AS last_sender_id, l.content AS last_content, l.created_at AS last_created_at,
+    (
+        SELECT COUNT(*) FROM direct d
+        WHERE d.sender_id = l.peer_id AND d.id > COALESCE((
+            SELECT r.last_read_id FROM direct_reads r
+            WHERE r.organization_id = sqlc.arg(organization_id)
+              AND r.user_id = sqlc.arg(user_id) AND r.peer_id = l.peer_id
+        ), 0)
+    ) AS unread_count
+FROM latest l
+JOIN users u ON u.id = l.peer_id
+ORDER BY l.id DESC
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: MarkConversationRead :one
+-- Moves user_id's read marker in their conversation with peer_id to the
+-- newest message peer_id has sent them, or to the newest up to up_to_id
+-- unless it is 0. The marker never moves back. No row is returned when
+-- there is no such message.
+INSERT INTO direct_reads (organization_id, user_id, peer_id, last_read_id)
+SELECT sqlc.arg(organization_id), sqlc.arg(user_id), sqlc.arg(peer_id), MAX(m.id)
+FROM messages m
+WHERE m.organization_id = sqlc.arg(organization_id)
+  AND m.sender_id = sqlc.arg(peer_id) AND m.recipient_id = sqlc.arg(user_id)
+  AND (sqlc.arg(up_to_id)::integer = 0 OR m.id <= sqlc.arg(up_to_id)::integer)
+HAVING MAX(m.id) IS NOT NULL
+ON CONFLICT (organization_id, user_id, peer_id) DO UPDATE
+SET last_read_id = GREATEST(direct_reads.last_read_id, EXCLUDED.last_read_id),
+    read_at = CURRENT_TIMESTAMP
+RETURNING last_read_id;
+
+-- name: CountUnreadConversations :many
+-- Counts, for each peer, the direct messages they sent user_id after
+-- user_id's read marker. Peers with nothing unread are left out.
+SELECT m.sender_id AS peer_id, COUNT(*) AS unread_count
+FROM messages m
+LEFT JOIN direct_reads r ON r.organization_id = m.organization_id
+    AND r.user_id = m.recipient_id AND r.peer_id = m.sender_id
+WHERE m.organization_id = sqlc.arg(organization_id) AND m.recipient_id = sqlc.arg(user_id)::integer
+  AND m.id > COALESCE(r.last_read_id, 0)
+GROUP BY m.sender_id
+ORDER BY m.sender_id;
+
+-- name: DeleteDirectReadsByUser :exec
+DELETE FROM direct_reads
+WHERE user_id = $1 OR peer_id = $1;
+
+-- name: GetMessage :one
+SELECT * FROM messages
+WHERE id = $1 AND organization_id = $2;
+
+-- name: GetMessageForUpdate :one
+SELECT * FROM messages
+WHERE id = $1 AND organization_id = $2
+FOR UPDATE;
+
+-- name: EditMessage :one
+UPDATE messages
+SET content = $2, edited_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteMessage :one
+-- Clears the content of a message and marks it deleted. The row stays, so
+-- the conversation around it keeps its shape.
+UPDATE messages
+SET content = '', deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING *;
+
+-- name: CreateMessageRevision :exec
+INSERT INTO message_revisions (message_id, organization_id, content, revised_by)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListMessagesSince :many
+-- Lists, oldest first, the messages user_id would have received live after
+-- after_id and after_time: the direct messages they sent, and the direct
+-- messages and room messages they got except from users they blocked.
+SELECT m.*, u.username AS sender_name
+FROM messages m
+JOIN users u ON u.id = m.sender_id
+WHERE m.organization_id = sqlc.arg(organization_id)
+  AND m.id > sqlc.arg(after_id)::integer
+  AND m.created_at > sqlc.arg(after_time)::timestamp
+  AND (
+    (m.recipient_id IS NOT NULL AND m.sender_id = sqlc.arg(user_id)::integer)
+    OR (
+      (
+        m.recipient_id = sqlc.arg(user_id)::integer
+        OR m.room_id IN (SELECT rm.room_id FROM room_members rm WHERE rm.user_id = sqlc.arg(user_id)::integer)
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = sqlc.arg(user_id)::integer AND b.blocked_id = m.sender_id
+      )
+    )
+  )
+ORDER BY m.id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetThread :many
+-- Returns the thread of parent_id oldest first: the parent, then its
+-- replies, leaving out the messages of users blocker_id blocked. Messages
+-- come with their reactions as in GetMessages.
+SELECT m.*, u.username AS sender_name,
+    (SELECT count(*) FROM messages t WHERE t.parent_id = m.id) AS reply_count,
+    COALESCE((
+        SELECT json_agg(json_build_object('emoji', r.emoji, 'count', r.count, 'users', r.users) ORDER BY r.first_at, r.emoji)
+        FROM (
+            SELECT mr.emoji, COUNT(*) AS count, array_agg(mr.user_id ORDER BY mr.created_at, mr.user_id) AS users, MIN(mr.created_at) AS first_at
+            FROM message_reactions mr
+            WHERE mr.message_id = m.id
+            GROUP BY mr.emoji
+        ) r
+    ), '[]')::json AS reactions
+FROM messages m
+JOIN users u ON u.id = m.sender_id
+WHERE m.organization_id = sqlc.arg(organization_id)
+  AND (m.id = sqlc.arg(parent_id)::integer OR m.parent_id = sqlc.arg(parent_id)::integer)
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = sqlc.arg(blocker_id) AND b.blocked_id = m.sender_id
+  )
+ORDER BY m.id
+LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: CountReplies :one
+SELECT count(*) FROM messages
+WHERE parent_id = sqlc.arg(parent_id)::integer;
+
+-- name: AddMessageReaction :execrows
+INSERT INTO message_reactions (message_id, user_id, organization_id, emoji)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteMessageReaction :execrows
+DELETE FROM message_reactions
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3;
+
+-- name: CountMessageReactions :one
+SELECT count(*) FROM message_reactions
+WHERE message_id = $1 AND emoji = $2;
+
+-- name: ListMessageReactions :many
+-- Lists who reacted to a message, in the order they reacted.
+SELECT mr.emoji, mr.user_id, u.username, mr.created_at
+FROM message_reactions mr
+JOIN users u ON u.id = mr.user_id
+WHERE mr.message_id = $1
+ORDER BY mr.created_at, mr.user_id;
+
+-- name: DeleteMessageReactionsByUser :exec
+DELETE FROM message_reactions
+WHERE user_id = $1;
+
+-- name: ListRoomIDsWithUserActivity :many
+-- Rooms whose history shows the user: they posted or reacted there.
+SELECT m.room_id::integer AS room_id FROM messages m
+WHERE m.sender_id = $1 AND m.room_id IS NOT NULL
+UNION
+SELECT m.room_id::integer FROM message_reactions mr
+JOIN messages m ON m.id = mr.message_id
+WHERE mr.user_id = $1 AND m.room_id IS NOT NULL;
\ No newline at end of file
@@ -9,6 +9,50 @@ SET xmloption = content;
 SET client_min_messages = warning;
 SET row_security = off;
 
+--
+-- Name: username_skeleton(text); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.username_skeleton(name text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+        SELECT translate(lower(name), 'асеһіјӏорԛѕԝхуԁοιρυνχκı', 'acehijlopqswxydoipuvxki')
+    $$;
+
+
+--
+-- Name: reject_user_erasures_change(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.reject_user_erasures_change() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    RAISE EXCEPTION 'user_erasures is append-only';
+END
+$$;
+
+
+--
+-- Name: update_follow_counts(); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.update_follow_counts() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET followers_count = followers_count + 1 WHERE id = NEW.followee_id;
+        UPDATE users SET following_count = following_count + 1 WHERE id = NEW.follower_id;
+        RETURN NEW;
+    END IF;
+    UPDATE users SET followers_count = followers_count - 1 WHERE id = OLD.followee_id;
+    UPDATE users SET following_count = following_count - 1 WHERE id = OLD.follower_id;
+    RETURN OLD;
+END
+$$;
+
+
 SET default_tablespace = '';
 
 SET default_table_access_method = heap;
@@ -34,7 +78,14 @@ CREATE TABLE public.users (
     password_hash character varying(255) NOT NULL,
     created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
     updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
-    deleted_at timestamp without time zone
+    deleted_at timestamp without time zone,
+    bio text,
+    locale character varying(35),
+    timezone character varying(64),
+    avatar_url character varying(2048),
+    attributes jsonb DEFAULT '{}'::jsonb NOT NULL,
+    followers_count integer DEFAULT 0 NOT NULL,
+    following_count integer DEFAULT 0 NOT NULL
 );
 
 
@@ -74,27 +125,25 @@ ALTER TABLE ONLY public.schema_migrations
 
 
 --
--- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
 
 ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_email_key UNIQUE (email);
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
 
 
 --
--- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: users_email_key; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+CREATE UNIQUE INDEX users_email_key ON public.users USING btree (lower((email)::text));
 
 
 --
--- Name: users users_username_key; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: users_username_key; Type: INDEX; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_username_key UNIQUE (username);
+CREATE UNIQUE INDEX users_username_key ON public.users USING btree (public.username_skeleton((username)::text));
 
 
 --
@@ -108,7 +157,23 @@ ALTER TABLE ONLY public.users
 
 INSERT INTO public.schema_migrations (version) VALUES
     ('20250306055016'),
-    ('20250405000000');
+    ('20250405000000'),
+    ('20250510000000'),
+    ('20250601000000'),
+    ('20250615000000'),
+    ('20250701000000'),
+    ('20250715000000'),
+    ('20250801000000'),
+    ('20250815000000'),
+    ('20250901000000'),
+    ('20250915000000'),
+    ('20251001000000'),
+    ('20251015000000'),
+    ('20251101000000'),
+    ('20251115000000'),
+    ('20251201000000'),
+    ('20251215000000'),
+    ('20260101000000');
 
 
 --
@@ -119,7 +184,15 @@ CREATE TABLE public.messages (
     id integer NOT NULL,
     sender_id integer NOT NULL,
     content text NOT NULL,
-    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    organization_id integer NOT NULL,
+    room_id integer,
+    recipient_id integer,
+    edited_at timestamp without time zone,
+    deleted_at timestamp without time zone,
+    parent_id integer,
+    CONSTRAINT messages_parent_check CHECK (((parent_id IS NULL) OR (room_id IS NOT NULL))),
+    CONSTRAINT messages_target_check CHECK (((room_id IS NULL) <> (recipient_id IS NULL)))
 );
 
 
@@ -163,4 +236,1228 @@ ALTER TABLE ONLY public.messages
 --
 
 ALTER TABLE ONLY public.messages
-    ADD CONSTRAINT messages_sender_id_fkey FOREIGN KEY (sender_id) REFERENCES public.users(id);
\ No newline at end of file
+    ADD CONSTRAINT messages_sender_id_fkey FOREIGN KEY (sender_id) REFERENCES public.users(id);
+
+
+--
+-- Name: email_changes; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.email_changes (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    old_email character varying(100) NOT NULL,
+    new_email character varying(100) NOT NULL,
+    confirm_token_hash character(64) NOT NULL,
+    cancel_token_hash character(64) NOT NULL,
+    confirm_expires_at timestamp without time zone NOT NULL,
+    cancel_expires_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    confirmed_at timestamp without time zone,
+    cancelled_at timestamp without time zone
+);
+
+
+--
+-- Name: email_changes_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.email_changes_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: email_changes_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.email_changes_id_seq OWNED BY public.email_changes.id;
+
+
+--
+-- Name: email_changes id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.email_changes ALTER COLUMN id SET DEFAULT nextval('public.email_changes_id_seq'::regclass);
+
+
+--
+-- Name: email_changes email_changes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.email_changes
+    ADD CONSTRAINT email_changes_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: email_changes email_changes_confirm_token_hash_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.email_changes
+    ADD CONSTRAINT email_changes_confirm_token_hash_key UNIQUE (confirm_token_hash);
+
+
+--
+-- Name: email_changes email_changes_cancel_token_hash_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.email_changes
+    ADD CONSTRAINT email_changes_cancel_token_hash_key UNIQUE (cancel_token_hash);
+
+
+--
+-- Name: email_changes_pending_user_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX email_changes_pending_user_idx ON public.email_changes USING btree (user_id) WHERE ((confirmed_at IS NULL) AND (cancelled_at IS NULL));
+
+
+--
+-- Name: email_changes email_changes_user_id_fkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.email_changes
+    ADD CONSTRAINT email_changes_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: user_erasures; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.user_erasures (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    requested_by integer,
+    messages_anonymized integer NOT NULL,
+    erased_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: user_erasures_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.user_erasures_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: user_erasures_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.user_erasures_id_seq OWNED BY public.user_erasures.id;
+
+
+--
+-- Name: user_erasures id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_erasures ALTER COLUMN id SET DEFAULT nextval('public.user_erasures_id_seq'::regclass);
+
+
+--
+-- Name: user_erasures user_erasures_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_erasures
+    ADD CONSTRAINT user_erasures_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: user_erasures user_erasures_append_only; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER user_erasures_append_only BEFORE DELETE OR UPDATE ON public.user_erasures FOR EACH ROW EXECUTE FUNCTION public.reject_user_erasures_change();
+
+
+--
+-- Name: user_erasures user_erasures_no_truncate; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER user_erasures_no_truncate BEFORE TRUNCATE ON public.user_erasures FOR EACH STATEMENT EXECUTE FUNCTION public.reject_user_erasures_change();
+
+
+--
+-- Name: audit_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.audit_events (
+    id bigint NOT NULL,
+    actor_id integer,
+    action text NOT NULL,
+    target_type text NOT NULL,
+    target_id integer,
+    before jsonb DEFAULT '{}'::jsonb NOT NULL,
+    after jsonb DEFAULT '{}'::jsonb NOT NULL,
+    metadata jsonb DEFAULT '{}'::jsonb NOT NULL,
+    ip text DEFAULT ''::text NOT NULL,
+    request_id text DEFAULT ''::text NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: audit_events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.audit_events_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: audit_events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.audit_events_id_seq OWNED BY public.audit_events.id;
+
+
+--
+-- Name: audit_events id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_events ALTER COLUMN id SET DEFAULT nextval('public.audit_events_id_seq'::regclass);
+
+
+--
+-- Name: audit_events audit_events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_events
+    ADD CONSTRAINT audit_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: audit_events_actor_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_events_actor_idx ON public.audit_events USING btree (actor_id, created_at);
+
+
+--
+-- Name: audit_events_created_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_events_created_at_idx ON public.audit_events USING btree (created_at);
+
+
+--
+-- Name: audit_events_target_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_events_target_idx ON public.audit_events USING btree (target_type, target_id, created_at);
+
+
+--
+-- Name: outbox_events; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.outbox_events (
+    id bigint NOT NULL,
+    event_type text NOT NULL,
+    aggregate_type text NOT NULL,
+    aggregate_id integer NOT NULL,
+    payload jsonb NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_error text DEFAULT ''::text NOT NULL,
+    next_attempt_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    delivered_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: outbox_events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.outbox_events_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: outbox_events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.outbox_events_id_seq OWNED BY public.outbox_events.id;
+
+
+--
+-- Name: outbox_events id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.outbox_events ALTER COLUMN id SET DEFAULT nextval('public.outbox_events_id_seq'::regclass);
+
+
+--
+-- Name: outbox_events outbox_events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.outbox_events
+    ADD CONSTRAINT outbox_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: outbox_events_pending_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX outbox_events_pending_idx ON public.outbox_events USING btree (next_attempt_at, id) WHERE (delivered_at IS NULL);
+
+
+--
+-- Name: webhook_subscriptions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.webhook_subscriptions (
+    id integer NOT NULL,
+    url text NOT NULL,
+    event_types text[] NOT NULL,
+    secret text NOT NULL,
+    active boolean DEFAULT true NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: webhook_subscriptions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.webhook_subscriptions_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhook_subscriptions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.webhook_subscriptions_id_seq OWNED BY public.webhook_subscriptions.id;
+
+
+--
+-- Name: webhook_subscriptions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_subscriptions ALTER COLUMN id SET DEFAULT nextval('public.webhook_subscriptions_id_seq'::regclass);
+
+
+--
+-- Name: webhook_subscriptions webhook_subscriptions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_subscriptions
+    ADD CONSTRAINT webhook_subscriptions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.webhook_deliveries (
+    id bigint NOT NULL,
+    subscription_id integer NOT NULL,
+    event_id bigint NOT NULL,
+    event_type text NOT NULL,
+    payload jsonb NOT NULL,
+    status text DEFAULT 'pending'::text NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_status_code integer DEFAULT 0 NOT NULL,
+    last_error text DEFAULT ''::text NOT NULL,
+    next_attempt_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    delivered_at timestamp without time zone,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.webhook_deliveries_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.webhook_deliveries_id_seq OWNED BY public.webhook_deliveries.id;
+
+
+--
+-- Name: webhook_deliveries id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries ALTER COLUMN id SET DEFAULT nextval('public.webhook_deliveries_id_seq'::regclass);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_subscription_id_event_id_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_subscription_id_event_id_key UNIQUE (subscription_id, event_id);
+
+
+--
+-- Name: webhook_deliveries_pending_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX webhook_deliveries_pending_idx ON public.webhook_deliveries USING btree (next_attempt_at, id) WHERE (status = 'pending'::text);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_subscription_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_subscription_id_fkey FOREIGN KEY (subscription_id) REFERENCES public.webhook_subscriptions(id) ON DELETE CASCADE;
+
+
+--
+-- Name: user_blocks; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.user_blocks (
+    blocker_id integer NOT NULL,
+    blocked_id integer NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT user_blocks_check CHECK ((blocker_id <> blocked_id))
+);
+
+
+--
+-- Name: user_blocks user_blocks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_blocks
+    ADD CONSTRAINT user_blocks_pkey PRIMARY KEY (blocker_id, blocked_id);
+
+
+--
+-- Name: user_blocks_blocked_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX user_blocks_blocked_id_idx ON public.user_blocks USING btree (blocked_id);
+
+
+--
+-- Name: user_blocks user_blocks_blocked_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_blocks
+    ADD CONSTRAINT user_blocks_blocked_id_fkey FOREIGN KEY (blocked_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: user_blocks user_blocks_blocker_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_blocks
+    ADD CONSTRAINT user_blocks_blocker_id_fkey FOREIGN KEY (blocker_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: user_follows; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.user_follows (
+    follower_id integer NOT NULL,
+    followee_id integer NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT user_follows_check CHECK ((follower_id <> followee_id))
+);
+
+
+--
+-- Name: user_follows user_follows_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_follows
+    ADD CONSTRAINT user_follows_pkey PRIMARY KEY (follower_id, followee_id);
+
+
+--
+-- Name: user_follows_followee_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX user_follows_followee_id_idx ON public.user_follows USING btree (followee_id, created_at);
+
+
+--
+-- Name: user_follows user_follows_counts; Type: TRIGGER; Schema: public; Owner: -
+--
+
+CREATE TRIGGER user_follows_counts AFTER INSERT OR DELETE ON public.user_follows FOR EACH ROW EXECUTE FUNCTION public.update_follow_counts();
+
+
+--
+-- Name: user_follows user_follows_followee_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_follows
+    ADD CONSTRAINT user_follows_followee_id_fkey FOREIGN KEY (followee_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: user_follows user_follows_follower_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.user_follows
+    ADD CONSTRAINT user_follows_follower_id_fkey FOREIGN KEY (follower_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: organizations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.organizations (
+    id integer NOT NULL,
+    slug character varying(63) NOT NULL,
+    name character varying(100) NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: organizations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.organizations_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: organizations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.organizations_id_seq OWNED BY public.organizations.id;
+
+
+--
+-- Name: organizations id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organizations ALTER COLUMN id SET DEFAULT nextval('public.organizations_id_seq'::regclass);
+
+
+--
+-- Name: organizations organizations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organizations
+    ADD CONSTRAINT organizations_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: organizations organizations_slug_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organizations
+    ADD CONSTRAINT organizations_slug_key UNIQUE (slug);
+
+
+--
+-- Name: organization_members; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.organization_members (
+    organization_id integer NOT NULL,
+    user_id integer NOT NULL,
+    role text DEFAULT 'member'::text NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT organization_members_role_check CHECK ((role = ANY (ARRAY['owner'::text, 'admin'::text, 'member'::text])))
+);
+
+
+--
+-- Name: organization_members organization_members_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organization_members
+    ADD CONSTRAINT organization_members_pkey PRIMARY KEY (organization_id, user_id);
+
+
+--
+-- Name: organization_members_user_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX organization_members_user_id_idx ON public.organization_members USING btree (user_id);
+
+
+--
+-- Name: messages_organization_id_created_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX messages_organization_id_created_at_idx ON public.messages USING btree (organization_id, created_at);
+
+
+--
+-- Name: organization_members organization_members_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organization_members
+    ADD CONSTRAINT organization_members_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: organization_members organization_members_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.organization_members
+    ADD CONSTRAINT organization_members_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: messages messages_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.messages
+    ADD CONSTRAINT messages_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: messages; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.messages ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.messages FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: messages messages_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY messages_tenant_isolation ON public.messages USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
+
+
+--
+-- Name: organization_members; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.organization_members ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.organization_members FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: organization_members organization_members_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY organization_members_tenant_isolation ON public.organization_members USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
+
+
+--
+-- Name: invitations; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.invitations (
+    id integer NOT NULL,
+    organization_id integer NOT NULL,
+    email character varying(100) NOT NULL,
+    role text DEFAULT 'member'::text NOT NULL,
+    token_hash character(64) NOT NULL,
+    invited_by integer,
+    expires_at timestamp without time zone NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    consumed_at timestamp without time zone,
+    consumed_by integer,
+    revoked_at timestamp without time zone,
+    CONSTRAINT invitations_role_check CHECK ((role = ANY (ARRAY['owner'::text, 'admin'::text, 'member'::text])))
+);
+
+
+--
+-- Name: invitations_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.invitations_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: invitations_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.invitations_id_seq OWNED BY public.invitations.id;
+
+
+--
+-- Name: invitations id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.invitations ALTER COLUMN id SET DEFAULT nextval('public.invitations_id_seq'::regclass);
+
+
+--
+-- Name: invitations invitations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.invitations
+    ADD CONSTRAINT invitations_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: invitations invitations_token_hash_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.invitations
+    ADD CONSTRAINT invitations_token_hash_key UNIQUE (token_hash);
+
+
+--
+-- Name: invitations_organization_id_created_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX invitations_organization_id_created_at_idx ON public.invitations USING btree (organization_id, created_at);
+
+
+--
+-- Name: invitations invitations_consumed_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.invitations
+    ADD CONSTRAINT invitations_consumed_by_fkey FOREIGN KEY (consumed_by) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: invitations invitations_invited_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.invitations
+    ADD CONSTRAINT invitations_invited_by_fkey FOREIGN KEY (invited_by) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: invitations invitations_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.invitations
+    ADD CONSTRAINT invitations_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: invitations; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.invitations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.invitations FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: invitations invitations_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY invitations_tenant_isolation ON public.invitations USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
+
+
+--
+-- Name: chat_rooms; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.chat_rooms (
+    id integer NOT NULL,
+    organization_id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    created_by integer,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: chat_rooms_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.chat_rooms_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: chat_rooms_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.chat_rooms_id_seq OWNED BY public.chat_rooms.id;
+
+
+--
+-- Name: chat_rooms id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.chat_rooms ALTER COLUMN id SET DEFAULT nextval('public.chat_rooms_id_seq'::regclass);
+
+
+--
+-- Name: chat_rooms chat_rooms_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.chat_rooms
+    ADD CONSTRAINT chat_rooms_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: chat_rooms chat_rooms_organization_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.chat_rooms
+    ADD CONSTRAINT chat_rooms_organization_id_name_key UNIQUE (organization_id, name);
+
+
+--
+-- Name: room_members; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.room_members (
+    room_id integer NOT NULL,
+    user_id integer NOT NULL,
+    joined_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: room_members room_members_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.room_members
+    ADD CONSTRAINT room_members_pkey PRIMARY KEY (room_id, user_id);
+
+
+--
+-- Name: room_members_user_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX room_members_user_id_idx ON public.room_members USING btree (user_id);
+
+
+--
+-- Name: messages_room_id_created_at_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX messages_room_id_created_at_idx ON public.messages USING btree (room_id, created_at);
+
+
+--
+-- Name: chat_rooms chat_rooms_created_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.chat_rooms
+    ADD CONSTRAINT chat_rooms_created_by_fkey FOREIGN KEY (created_by) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: chat_rooms chat_rooms_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.chat_rooms
+    ADD CONSTRAINT chat_rooms_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: room_members room_members_room_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.room_members
+    ADD CONSTRAINT room_members_room_id_fkey FOREIGN KEY (room_id) REFERENCES public.chat_rooms(id) ON DELETE CASCADE;
+
+
+--
+-- Name: room_members room_members_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.room_members
+    ADD CONSTRAINT room_members_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: messages messages_room_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.messages
+    ADD CONSTRAINT messages_room_id_fkey FOREIGN KEY (room_id) REFERENCES public.chat_rooms(id) ON DELETE CASCADE;
+
+
+--
+-- Name: chat_rooms; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.chat_rooms ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.chat_rooms FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: chat_rooms chat_rooms_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY chat_rooms_tenant_isolation ON public.chat_rooms USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
+
+
+--
+-- Name: messages_recipient_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX messages_recipient_id_idx ON public.messages USING btree (recipient_id, sender_id, id) WHERE (recipient_id IS NOT NULL);
+
+
+--
+-- Name: messages_direct_sender_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX messages_direct_sender_id_idx ON public.messages USING btree (sender_id, recipient_id, id) WHERE (recipient_id IS NOT NULL);
+
+
+--
+-- Name: messages messages_recipient_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.messages
+    ADD CONSTRAINT messages_recipient_id_fkey FOREIGN KEY (recipient_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: direct_reads; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.direct_reads (
+    organization_id integer NOT NULL,
+    user_id integer NOT NULL,
+    peer_id integer NOT NULL,
+    last_read_id integer NOT NULL,
+    read_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: direct_reads direct_reads_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.direct_reads
+    ADD CONSTRAINT direct_reads_pkey PRIMARY KEY (organization_id, user_id, peer_id);
+
+
+--
+-- Name: direct_reads direct_reads_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.direct_reads
+    ADD CONSTRAINT direct_reads_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: direct_reads direct_reads_peer_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.direct_reads
+    ADD CONSTRAINT direct_reads_peer_id_fkey FOREIGN KEY (peer_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: direct_reads direct_reads_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.direct_reads
+    ADD CONSTRAINT direct_reads_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: direct_reads; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.direct_reads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.direct_reads FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: direct_reads direct_reads_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY direct_reads_tenant_isolation ON public.direct_reads USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
+
+
+--
+-- Name: message_revisions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.message_revisions (
+    id integer NOT NULL,
+    message_id integer NOT NULL,
+    organization_id integer NOT NULL,
+    content text NOT NULL,
+    revised_by integer,
+    revised_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: message_revisions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.message_revisions_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: message_revisions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.message_revisions_id_seq OWNED BY public.message_revisions.id;
+
+
+--
+-- Name: message_revisions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_revisions ALTER COLUMN id SET DEFAULT nextval('public.message_revisions_id_seq'::regclass);
+
+
+--
+-- Name: message_revisions message_revisions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_revisions
+    ADD CONSTRAINT message_revisions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: message_revisions_message_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX message_revisions_message_id_idx ON public.message_revisions USING btree (message_id, id);
+
+
+--
+-- Name: message_revisions message_revisions_message_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_revisions
+    ADD CONSTRAINT message_revisions_message_id_fkey FOREIGN KEY (message_id) REFERENCES public.messages(id) ON DELETE CASCADE;
+
+
+--
+-- Name: message_revisions message_revisions_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_revisions
+    ADD CONSTRAINT message_revisions_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: message_revisions message_revisions_revised_by_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_revisions
+    ADD CONSTRAINT message_revisions_revised_by_fkey FOREIGN KEY (revised_by) REFERENCES public.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: message_revisions; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.message_revisions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.message_revisions FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: message_revisions message_revisions_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY message_revisions_tenant_isolation ON public.message_revisions USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
+
+
+--
+-- Name: room_reads; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.room_reads (
+    room_id integer NOT NULL,
+    user_id integer NOT NULL,
+    organization_id integer NOT NULL,
+    last_read_id integer NOT NULL,
+    read_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: room_reads room_reads_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.room_reads
+    ADD CONSTRAINT room_reads_pkey PRIMARY KEY (room_id, user_id);
+
+
+--
+-- Name: messages_room_id_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX messages_room_id_id_idx ON public.messages USING btree (room_id, id) WHERE (room_id IS NOT NULL);
+
+
+--
+-- Name: room_reads room_reads_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.room_reads
+    ADD CONSTRAINT room_reads_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: room_reads room_reads_room_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.room_reads
+    ADD CONSTRAINT room_reads_room_id_fkey FOREIGN KEY (room_id) REFERENCES public.chat_rooms(id) ON DELETE CASCADE;
+
+
+--
+-- Name: room_reads room_reads_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.room_reads
+    ADD CONSTRAINT room_reads_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: room_reads; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.room_reads ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.room_reads FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: room_reads room_reads_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY room_reads_tenant_isolation ON public.room_reads USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
+
+
+--
+-- Name: message_reactions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.message_reactions (
+    message_id integer NOT NULL,
+    user_id integer NOT NULL,
+    organization_id integer NOT NULL,
+    emoji character varying(32) NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+--
+-- Name: message_reactions message_reactions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_reactions
+    ADD CONSTRAINT message_reactions_pkey PRIMARY KEY (message_id, user_id, emoji);
+
+
+--
+-- Name: message_reactions_user_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX message_reactions_user_id_idx ON public.message_reactions USING btree (user_id);
+
+
+--
+-- Name: messages_parent_id_idx; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX messages_parent_id_idx ON public.messages USING btree (parent_id, id) WHERE (parent_id IS NOT NULL);
+
+
+--
+-- Name: message_reactions message_reactions_message_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_reactions
+    ADD CONSTRAINT message_reactions_message_id_fkey FOREIGN KEY (message_id) REFERENCES public.messages(id) ON DELETE CASCADE;
+
+
+--
+-- Name: message_reactions message_reactions_organization_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_reactions
+    ADD CONSTRAINT message_reactions_organization_id_fkey FOREIGN KEY (organization_id) REFERENCES public.organizations(id) ON DELETE CASCADE;
+
+
+--
+-- Name: message_reactions message_reactions_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.message_reactions
+    ADD CONSTRAINT message_reactions_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: messages messages_parent_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.messages
+    ADD CONSTRAINT messages_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES public.messages(id) ON DELETE CASCADE;
+
+
+--
+-- Name: message_reactions; Type: ROW SECURITY; Schema: public; Owner: -
+--
+
+ALTER TABLE public.message_reactions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ONLY public.message_reactions FORCE ROW LEVEL SECURITY;
+
+
+--
+-- Name: message_reactions message_reactions_tenant_isolation; Type: POLICY; Schema: public; Owner: -
+--
+
+CREATE POLICY message_reactions_tenant_isolation ON public.message_reactions USING (((NULLIF(current_setting('app.tenant_id'::text, true), ''::text) IS NULL) OR (organization_id = (NULLIF(current_setting('app.tenant_id'::text, true), ''::text))::integer)));
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+
+	"example.com/api/internal/api/responses"
+	"example.com/api/internal/api/validation"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services"
+	"example.com/api/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type AuditHandler struct {
+	service services.IServiceManager
+	logger  logging.ILogger
+}
+
+func NewAuditHandler(s services.IServiceManager, l logging.ILogger) *AuditHandler {
+	return &AuditHandler{
+		service: s,
+		logger:  l,
+	}
+}
+
+// List returns audit events, newest first, filtered by the query parameters
+// of dto.AuditEventFilter.
+func (h *AuditHandler) List(c *gin.Context) {
+	var filter dto.AuditEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			responses.BadRequest(c, "Invalid filter", validation.GetValidationErrors(err))
+			return
+		}
+		responses.BadRequest(c, "Invalid filter", err.Error())
+		return
+	}
+
+	events, err := h.service.Audit().List(c.Request.Context(), filter)
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve audit events")
+		return
+	}
+
+	eventResponses := make([]dto.AuditEventResponse, 0, len(events))
+	for _, event := range events {
+		eventResponses = append(eventResponses, dto.NewAuditEventResponse(event))
+	}
+	responses.OK(c, "Audit events retrieved successfully", eventResponses)
+}
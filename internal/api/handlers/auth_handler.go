@@ -7,6 +7,7 @@ import (
 	"example.com/api/internal/api/responses"
 	"example.com/api/internal/api/validation"
 	dto "example.com/api/internal/contracts"
+	contracts "example.com/api/internal/contracts/errors"
 	"example.com/api/internal/services"
 	"example.com/api/pkg/logging"
 	"github.com/gin-gonic/gin"
@@ -82,6 +83,10 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	user, accessToken, refreshToken, err := h.authService.RegisterUser(c.Request.Context(), req)
 	if err != nil {
+		if errors.Is(err, services.ErrRegistrationClosed) {
+			responses.Forbidden(c, "Registration is by invitation only")
+			return
+		}
 		h.logger.Error(logging.Internal, logging.FailedToCreateUser, "Failed to register user", map[logging.ExtraKey]any{
 			logging.ErrorMessage: err.Error(),
 			logging.Path:         c.Request.URL.Path,
@@ -98,6 +103,54 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	})
 }
 
+// AcceptInvite registers an account from an invitation and signs it in.
+func (h *AuthHandler) AcceptInvite(c *gin.Context) {
+	var req dto.AcceptInvitation
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	user, accessToken, refreshToken, err := h.authService.AcceptInvite(c.Request.Context(), req)
+	if err != nil {
+		var (
+			emailErr           *contracts.EmailExistsError
+			usernameErr        *contracts.UsernameExistsError
+			invalidUsernameErr *contracts.InvalidUsernameError
+		)
+		switch {
+		case errors.Is(err, services.ErrInvalidInvitation):
+			responses.BadRequest(c, "Invalid or already used invitation", nil)
+		case errors.Is(err, services.ErrInvitationExpired):
+			responses.BadRequest(c, "Invitation has expired", nil)
+		case errors.As(err, &invalidUsernameErr):
+			responses.BadRequest(c, "Invalid username", gin.H{
+				"info": invalidUsernameErr.Error(),
+			})
+		case errors.As(err, &emailErr):
+			responses.Conflict(c, "An account already exists for this email", nil)
+		case errors.As(err, &usernameErr):
+			responses.Conflict(c, "Username already in use", gin.H{
+				"info": usernameErr.Error(),
+			})
+		default:
+			h.logger.Error(logging.Internal, logging.FailedToCreateUser, "Failed to accept invitation", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				logging.Path:         c.Request.URL.Path,
+				logging.Method:       c.Request.Method,
+			})
+			responses.InternalServerError(c, "Failed to accept invitation")
+		}
+		return
+	}
+
+	responses.Created(c, "User registered successfully", gin.H{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+		"user":          user,
+	})
+}
+
 func (h *AuthHandler) Refresh(c *gin.Context) {
 	var req dto.RefreshRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"github.com/gin-gonic/gin"
+)
+
+// ListConversations lists the authenticated user's direct message
+// conversations in the active organization, most recent first.
+func (h *ChatHandler) ListConversations(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+	if _, ok := activeOrganization(c); !ok {
+		return
+	}
+	var page dto.ChatPageParams
+	if err := c.ShouldBindQuery(&page); err != nil {
+		invalidInput(c, "Invalid query parameters", err)
+		return
+	}
+
+	conversations, err := h.service.Chat().Conversations(c.Request.Context(), int32(userID), page.Limit, page.Offset)
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve conversations")
+		return
+	}
+
+	resp := make([]dto.ConversationResponse, 0, len(conversations))
+	for _, conv := range conversations {
+		resp = append(resp, dto.NewConversationResponse(conv))
+	}
+	responses.OK(c, "Conversations retrieved successfully", resp)
+}
+
+// GetConversation returns a page of the authenticated user's direct
+// messages with the user :id, newest first. Fetching the first page marks
+// the conversation read.
+func (h *ChatHandler) GetConversation(c *gin.Context) {
+	userID, peerID, ok := relationParams(c)
+	if !ok {
+		return
+	}
+	if _, ok := activeOrganization(c); !ok {
+		return
+	}
+	var page dto.ChatPageParams
+	if err := c.ShouldBindQuery(&page); err != nil {
+		invalidInput(c, "Invalid query parameters", err)
+		return
+	}
+
+	messages, err := h.service.Chat().DirectMessages(c.Request.Context(), userID, peerID, page.Limit, page.Offset)
+	if err != nil {
+		responses.InternalServerError(c, "Failed to fetch conversation")
+		return
+	}
+	responses.OK(c, "Conversation retrieved successfully", messages)
+}
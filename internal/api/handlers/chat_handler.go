@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -11,6 +10,7 @@ import (
 	dbCtx "example.com/api/internal/repository/db"
 	"example.com/api/internal/services"
 	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
 	"example.com/api/pkg/logging"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -38,6 +38,10 @@ func NewChatHandler(h *chat.Hub, s services.IServiceManager, l logging.ILogger)
 	}
 }
 
+// HandleWebSocket connects the authenticated user to the chat. A client
+// reconnecting with ?since=<message ID or RFC 3339 time> first gets the
+// messages it missed since then, up to a replayed frame, and only then
+// live frames.
 func (h *ChatHandler) HandleWebSocket(c *gin.Context) {
 	userID, err := strconv.Atoi(c.GetString("user_id"))
 	if err != nil {
@@ -45,31 +49,77 @@ func (h *ChatHandler) HandleWebSocket(c *gin.Context) {
 		return
 	}
 
+	var since *chat.ReplayPoint
+	if raw := c.Query("since"); raw != "" {
+		point, err := chat.ParseReplayPoint(raw)
+		if err != nil {
+			responses.BadRequest(c, "Invalid since, must be a message ID or an RFC 3339 time", nil)
+			return
+		}
+		since = &point
+	}
+
+	orgID, err := tenant.Require(c.Request.Context())
+	if err != nil {
+		responses.BadRequest(c, "Organization is required", nil)
+		return
+	}
+
 	user, err := h.service.User().GetByID(c.Request.Context(), int32(userID))
 	if err != nil {
 		responses.NotFound(c, "User not found")
 		return
 	}
 
+	rooms, err := h.service.Chat().RoomIDs(c.Request.Context(), int32(userID))
+	if err != nil {
+		responses.InternalServerError(c, "Failed to load chat rooms")
+		return
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		h.logger.Error(logging.Internal, logging.Api, "Failed to upgrade connection", nil)
 		return
 	}
 
-	client := chat.NewClient(h.hub, conn, int32(userID), user.Username)
+	client := chat.NewClient(h.hub, conn, int32(userID), user.Username, orgID, rooms)
 
 	h.hub.Register(client)
 
-	go client.SendMessages()
+	if since != nil {
+		go client.Replay(h.service.Chat(), *since)
+	} else {
+		go client.SendMessages()
+	}
 	go client.HandleMessages(h.service.Chat())
 }
 
 func (h *ChatHandler) GetMessageHistory(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+	if _, err := tenant.Require(c.Request.Context()); err != nil {
+		responses.BadRequest(c, "Organization is required", nil)
+		return
+	}
+	roomID, err := strconv.Atoi(c.Query("room"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid room ID, must be an integer", nil)
+		return
+	}
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
 	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
 
-	cacheKey := fmt.Sprintf("messages:limit:%d:offset:%d", limit, offset)
+	generation, err := chat.HistoryGeneration(c.Request.Context(), h.service.CacheStorage(), int32(roomID))
+	if err != nil {
+		slog.Error("cache", "redis", "Failed to get cache")
+		responses.InternalServerError(c, "Failed to fetch message history")
+		return
+	}
+	cacheKey := chat.HistoryCacheKey(int32(roomID), int32(userID), generation, limit, offset)
 
 	var messages []dbCtx.GetMessagesRow
 	found, err := h.service.CacheStorage().Get(c.Request.Context(), cacheKey, &messages)
@@ -84,9 +134,9 @@ func (h *ChatHandler) GetMessageHistory(c *gin.Context) {
 		return
 	}
 
-	messages, err = h.service.Chat().GetMessages(c.Request.Context(), int32(limit), int32(offset))
+	messages, err = h.service.Chat().GetMessages(c.Request.Context(), int32(userID), int32(roomID), int32(limit), int32(offset))
 	if err != nil {
-		responses.InternalServerError(c, "Failed to fetch message history")
+		roomError(c, err, "Failed to fetch message history")
 		return
 	}
 
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services/chat"
+	"github.com/gin-gonic/gin"
+)
+
+// EditMessage replaces the content of the message :id and pushes the change
+// to the connected clients that received it.
+func (h *ChatHandler) EditMessage(c *gin.Context) {
+	userID, messageID, ok := messageParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.EditMessageReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	msg, err := h.service.Chat().EditMessage(c.Request.Context(), userID, messageID, req.Content)
+	if err != nil {
+		messageError(c, err, "Failed to edit message")
+		return
+	}
+	h.hub.Update(msg)
+	responses.OK(c, "Message edited successfully", msg)
+}
+
+// DeleteMessage retracts the message :id and pushes the change to the
+// connected clients that received it.
+func (h *ChatHandler) DeleteMessage(c *gin.Context) {
+	userID, messageID, ok := messageParams(c)
+	if !ok {
+		return
+	}
+
+	msg, err := h.service.Chat().DeleteMessage(c.Request.Context(), userID, messageID)
+	if err != nil {
+		messageError(c, err, "Failed to delete message")
+		return
+	}
+	h.hub.Update(msg)
+	responses.NoContent(c)
+}
+
+// GetThread returns a page of the thread the message :id is in, oldest
+// first, starting with the message the others reply to.
+func (h *ChatHandler) GetThread(c *gin.Context) {
+	userID, messageID, ok := messageParams(c)
+	if !ok {
+		return
+	}
+	var page dto.ChatPageParams
+	if err := c.ShouldBindQuery(&page); err != nil {
+		invalidInput(c, "Invalid query parameters", err)
+		return
+	}
+
+	messages, err := h.service.Chat().Thread(c.Request.Context(), userID, messageID, page.Limit, page.Offset)
+	if err != nil {
+		messageError(c, err, "Failed to fetch thread")
+		return
+	}
+	responses.OK(c, "Thread retrieved successfully", messages)
+}
+
+func messageParams(c *gin.Context) (userID, messageID int32, ok bool) {
+	uid, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return 0, 0, false
+	}
+	mid, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid message ID, must be an integer", nil)
+		return 0, 0, false
+	}
+	if _, ok := activeOrganization(c); !ok {
+		return 0, 0, false
+	}
+	return int32(uid), int32(mid), true
+}
+
+func messageError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, chat.ErrMessageNotFound):
+		responses.NotFound(c, "Message not found")
+	case errors.Is(err, chat.ErrMessageForbidden):
+		responses.Forbidden(c, "Not allowed to change this message")
+	case errors.Is(err, chat.ErrEditWindowClosed):
+		responses.Forbidden(c, "Message can no longer be changed")
+	case errors.Is(err, chat.ErrNotRoomMember):
+		responses.Forbidden(c, "Not a member of this room")
+	case errors.Is(err, chat.ErrInvalidEmoji):
+		responses.BadRequest(c, "Emoji must be 1 to 32 characters without spaces", nil)
+	default:
+		responses.InternalServerError(c, fallback)
+	}
+}
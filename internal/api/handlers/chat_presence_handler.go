@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"github.com/gin-gonic/gin"
+)
+
+// Presence lists the users of the active organization who are online in
+// the chat. Clients keep the list current with the presence frames pushed
+// over the WebSocket.
+func (h *ChatHandler) Presence(c *gin.Context) {
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+	responses.OK(c, "Presence retrieved successfully", dto.PresenceResponse{Online: h.hub.Online(orgID)})
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services/chat"
+	"github.com/gin-gonic/gin"
+)
+
+// React adds the authenticated user's reaction to the message :id and
+// pushes it to the connected clients that received the message.
+func (h *ChatHandler) React(c *gin.Context) {
+	userID, messageID, ok := messageParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReactReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	reaction, err := h.service.Chat().React(c.Request.Context(), userID, messageID, req.Emoji)
+	if err != nil {
+		messageError(c, err, "Failed to add reaction")
+		return
+	}
+	h.hub.Reaction(userID, reaction, true)
+	responses.OK(c, "Reaction added successfully", reactionResponse(reaction))
+}
+
+// Unreact removes the authenticated user's reaction with the emoji :emoji
+// from the message :id and pushes the change to the connected clients that
+// received the message.
+func (h *ChatHandler) Unreact(c *gin.Context) {
+	userID, messageID, ok := messageParams(c)
+	if !ok {
+		return
+	}
+
+	reaction, err := h.service.Chat().Unreact(c.Request.Context(), userID, messageID, c.Param("emoji"))
+	if err != nil {
+		messageError(c, err, "Failed to remove reaction")
+		return
+	}
+	h.hub.Reaction(userID, reaction, false)
+	responses.OK(c, "Reaction removed successfully", reactionResponse(reaction))
+}
+
+// MessageReactions lists who reacted to the message :id, by emoji.
+func (h *ChatHandler) MessageReactions(c *gin.Context) {
+	userID, messageID, ok := messageParams(c)
+	if !ok {
+		return
+	}
+
+	reactions, err := h.service.Chat().Reactions(c.Request.Context(), userID, messageID)
+	if err != nil {
+		messageError(c, err, "Failed to retrieve reactions")
+		return
+	}
+	responses.OK(c, "Reactions retrieved successfully", dto.NewReactionsResponse(reactions))
+}
+
+func reactionResponse(r chat.Reaction) dto.ReactionResponse {
+	return dto.ReactionResponse{MessageID: r.Message.ID, Emoji: r.Emoji, Count: r.Count}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"github.com/gin-gonic/gin"
+)
+
+// MarkRead moves the authenticated user's read marker in a room or a
+// conversation and sends a read receipt to the other participants that are
+// connected.
+func (h *ChatHandler) MarkRead(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+	var req dto.MarkReadReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+	if (req.RoomID == 0) == (req.PeerID == 0) {
+		responses.BadRequest(c, "Exactly one of roomId and peerId is required", nil)
+		return
+	}
+
+	var lastRead int32
+	if req.RoomID != 0 {
+		lastRead, err = h.service.Chat().MarkRoomRead(c.Request.Context(), int32(userID), req.RoomID, req.MessageID)
+	} else {
+		lastRead, err = h.service.Chat().MarkConversationRead(c.Request.Context(), int32(userID), req.PeerID, req.MessageID)
+	}
+	if err != nil {
+		roomError(c, err, "Failed to mark messages read")
+		return
+	}
+	if lastRead != 0 {
+		h.hub.Receipt(orgID, int32(userID), req.RoomID, req.PeerID, lastRead)
+	}
+	responses.OK(c, "Messages marked read", dto.ReadResponse{LastReadID: lastRead})
+}
+
+// Unread returns the authenticated user's unread counts in the active
+// organization.
+func (h *ChatHandler) Unread(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+	if _, ok := activeOrganization(c); !ok {
+		return
+	}
+
+	counts, err := h.service.Chat().UnreadCounts(c.Request.Context(), int32(userID))
+	if err != nil {
+		responses.InternalServerError(c, "Failed to count unread messages")
+		return
+	}
+	responses.OK(c, "Unread counts retrieved successfully", dto.NewUnreadResponse(counts.Rooms, counts.Conversations))
+}
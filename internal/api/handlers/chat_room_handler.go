@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services/chat"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRoom makes a room in the active organization and subscribes the
+// creator's open connections to it.
+func (h *ChatHandler) CreateRoom(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateRoomReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	room, err := h.service.Chat().CreateRoom(c.Request.Context(), int32(userID), req.Name)
+	if err != nil {
+		if errors.Is(err, chat.ErrRoomNameTaken) {
+			responses.Conflict(c, "Room name already taken", nil)
+			return
+		}
+		responses.InternalServerError(c, "Failed to create room")
+		return
+	}
+	h.hub.Join(orgID, int32(userID), room.ID)
+	responses.Created(c, "Room created successfully", dto.NewRoomResponse(room, true))
+}
+
+// ListRooms lists the rooms of the active organization.
+func (h *ChatHandler) ListRooms(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+	if _, ok := activeOrganization(c); !ok {
+		return
+	}
+
+	rooms, err := h.service.Chat().ListRooms(c.Request.Context(), int32(userID))
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve rooms")
+		return
+	}
+
+	resp := make([]dto.RoomResponse, 0, len(rooms))
+	for _, r := range rooms {
+		resp = append(resp, dto.NewRoomListResponse(r))
+	}
+	responses.OK(c, "Rooms retrieved successfully", resp)
+}
+
+// JoinRoom adds the authenticated user to a room and subscribes their open
+// connections to it.
+func (h *ChatHandler) JoinRoom(c *gin.Context) {
+	userID, roomID, orgID, ok := roomParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Chat().JoinRoom(c.Request.Context(), userID, roomID); err != nil {
+		roomError(c, err, "Failed to join room")
+		return
+	}
+	h.hub.Join(orgID, userID, roomID)
+	responses.OK(c, "Joined room successfully", nil)
+}
+
+// LeaveRoom is the opposite of JoinRoom.
+func (h *ChatHandler) LeaveRoom(c *gin.Context) {
+	userID, roomID, orgID, ok := roomParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Chat().LeaveRoom(c.Request.Context(), userID, roomID); err != nil {
+		roomError(c, err, "Failed to leave room")
+		return
+	}
+	h.hub.Leave(orgID, userID, roomID)
+	responses.OK(c, "Left room successfully", nil)
+}
+
+// RoomMembers lists the members of a room.
+func (h *ChatHandler) RoomMembers(c *gin.Context) {
+	_, roomID, _, ok := roomParams(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.service.Chat().RoomMembers(c.Request.Context(), roomID)
+	if err != nil {
+		roomError(c, err, "Failed to retrieve room members")
+		return
+	}
+
+	resp := make([]dto.RoomMemberResponse, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, dto.NewRoomMemberResponse(m))
+	}
+	responses.OK(c, "Room members retrieved successfully", resp)
+}
+
+// roomParams reads the authenticated user, the :id room parameter and the
+// active organization, answering the request itself when one is missing.
+func roomParams(c *gin.Context) (userID, roomID, orgID int32, ok bool) {
+	uid, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return 0, 0, 0, false
+	}
+	rid, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid room ID, must be an integer", nil)
+		return 0, 0, 0, false
+	}
+	orgID, ok = activeOrganization(c)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	return int32(uid), int32(rid), orgID, true
+}
+
+func roomError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, chat.ErrRoomNotFound):
+		responses.NotFound(c, "Room not found")
+	case errors.Is(err, chat.ErrNotRoomMember):
+		responses.Forbidden(c, "Not a member of this room")
+	default:
+		responses.InternalServerError(c, fallback)
+	}
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services"
+	"example.com/api/internal/tenant"
+	"example.com/api/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+type OrganizationHandler struct {
+	service services.IServiceManager
+	logger  logging.ILogger
+}
+
+func NewOrganizationHandler(s services.IServiceManager, l logging.ILogger) *OrganizationHandler {
+	return &OrganizationHandler{
+		service: s,
+		logger:  l,
+	}
+}
+
+// Create makes a new organization owned by the authenticated user.
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	var req dto.CreateOrganizationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	org, err := h.service.Organization().Create(c.Request.Context(), int32(userID), req)
+	if err != nil {
+		if errors.Is(err, services.ErrOrganizationSlugTaken) {
+			responses.Conflict(c, "Organization slug already taken", nil)
+			return
+		}
+		responses.InternalServerError(c, "Failed to create organization")
+		return
+	}
+	responses.Created(c, "Organization created successfully", dto.NewOrganizationResponse(org, services.OrgRoleOwner))
+}
+
+// List returns the organizations the authenticated user belongs to.
+func (h *OrganizationHandler) List(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	orgs, err := h.service.Organization().ListForUser(c.Request.Context(), int32(userID))
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve organizations")
+		return
+	}
+
+	resp := make([]dto.OrganizationResponse, 0, len(orgs))
+	for _, o := range orgs {
+		resp = append(resp, dto.NewMembershipResponse(o))
+	}
+	responses.OK(c, "Organizations retrieved successfully", resp)
+}
+
+// ListMembers lists the members of the active organization.
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+	var page dto.ListMembersParams
+	if err := c.ShouldBindQuery(&page); err != nil {
+		invalidInput(c, "Invalid query parameters", err)
+		return
+	}
+
+	members, err := h.service.Organization().ListMembers(c.Request.Context(), orgID, page)
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve organization members")
+		return
+	}
+
+	resp := make([]dto.OrganizationMemberResponse, 0, len(members))
+	for _, m := range members {
+		resp = append(resp, dto.NewOrganizationMemberResponse(m))
+	}
+	responses.OK(c, "Organization members retrieved successfully", resp)
+}
+
+// SetMemberRole adds the user in the path to the active organization, or
+// changes their role if they already belong to it.
+func (h *OrganizationHandler) SetMemberRole(c *gin.Context) {
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+	_, targetID, ok := relationParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetMemberRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	member, err := h.service.Organization().SetMemberRole(c.Request.Context(), orgID, c.GetString("org_role"), targetID, req.Role)
+	if err != nil {
+		h.memberError(c, err, "Failed to set organization role")
+		return
+	}
+	responses.OK(c, "Organization role updated successfully", member)
+}
+
+// RemoveMember removes the user in the path from the active organization.
+// Members may remove themselves.
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+	userID, targetID, ok := relationParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.Organization().RemoveMember(c.Request.Context(), orgID, c.GetString("org_role"), userID, targetID)
+	if err != nil {
+		h.memberError(c, err, "Failed to remove organization member")
+		return
+	}
+	responses.NoContent(c)
+}
+
+// activeOrganization returns the organization resolved by the tenant
+// middleware.
+func activeOrganization(c *gin.Context) (int32, bool) {
+	orgID, err := tenant.Require(c.Request.Context())
+	if err != nil {
+		responses.BadRequest(c, "Organization is required", nil)
+		return 0, false
+	}
+	return orgID, true
+}
+
+func (h *OrganizationHandler) memberError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, services.ErrOrgRoleForbidden):
+		responses.Forbidden(c, "Insufficient organization role")
+	case errors.Is(err, services.ErrLastOwner):
+		responses.Conflict(c, "Organization must keep at least one owner", nil)
+	case errors.Is(err, services.ErrNotOrganizationMember):
+		responses.NotFound(c, "User is not a member of this organization")
+	case err.Error() == "user not found":
+		responses.NotFound(c, "User not found")
+	default:
+		responses.InternalServerError(c, fallback)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	contracts "example.com/api/internal/contracts/errors"
+	"example.com/api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateInvitation invites an email address to the active organization.
+// The token is only ever mailed to the invitee.
+func (h *OrganizationHandler) CreateInvitation(c *gin.Context) {
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	var req dto.CreateInvitationReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	inv, err := h.service.Invitation().Create(c.Request.Context(), orgID, int32(userID), c.GetString("org_role"), req)
+	if err != nil {
+		var invalidEmailErr *contracts.InvalidEmailError
+		switch {
+		case errors.Is(err, services.ErrOrgRoleForbidden):
+			responses.Forbidden(c, "Only owners may invite owners")
+		case errors.As(err, &invalidEmailErr):
+			responses.BadRequest(c, "Invalid email", invalidEmailErr.Error())
+		default:
+			responses.InternalServerError(c, "Failed to create invitation")
+		}
+		return
+	}
+	responses.Created(c, "Invitation sent successfully", dto.NewInvitationResponse(inv))
+}
+
+// ListInvitations lists the invitations of the active organization, newest
+// first, whatever their status.
+func (h *OrganizationHandler) ListInvitations(c *gin.Context) {
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+
+	var page dto.ListInvitationsParams
+	if err := c.ShouldBindQuery(&page); err != nil {
+		invalidInput(c, "Invalid query parameters", err)
+		return
+	}
+
+	invs, err := h.service.Invitation().List(c.Request.Context(), orgID, page)
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve invitations")
+		return
+	}
+
+	resp := make([]dto.InvitationResponse, 0, len(invs))
+	for _, inv := range invs {
+		resp = append(resp, dto.NewInvitationResponse(inv))
+	}
+	responses.OK(c, "Invitations retrieved successfully", resp)
+}
+
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	orgID, ok := activeOrganization(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid invitation ID, must be an integer", nil)
+		return
+	}
+
+	if err := h.service.Invitation().Revoke(c.Request.Context(), orgID, int32(id)); err != nil {
+		if errors.Is(err, services.ErrInvitationNotFound) {
+			responses.NotFound(c, "No pending invitation with this ID")
+			return
+		}
+		responses.InternalServerError(c, "Failed to revoke invitation")
+		return
+	}
+	responses.NoContent(c)
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime/multipart"
+)
+
+const avatarFormField = "avatar"
+
+// nextFormPart advances reader to the file part named field. Parts are
+// streamed rather than buffered by ParseMultipartForm, so the upload size
+// limit is enforced by the avatar service while reading.
+func nextFormPart(reader *multipart.Reader, field string) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no '" + field + "' file in request")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if part.FormName() == field && part.FileName() != "" {
+			return part, nil
+		}
+		part.Close()
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// BlockUser blocks the user in the path for the authenticated user. Their
+// chat messages stop reaching the caller, live and in history.
+func (h *UserHandler) BlockUser(c *gin.Context) {
+	userID, targetID, ok := relationParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.Block().Block(c.Request.Context(), userID, targetID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCannotBlockSelf):
+			responses.BadRequest(c, "You cannot block yourself", nil)
+		case err.Error() == "user not found":
+			responses.NotFound(c, "User not found")
+		default:
+			responses.InternalServerError(c, "Failed to block user")
+		}
+		return
+	}
+
+	responses.NoContent(c)
+}
+
+func (h *UserHandler) UnblockUser(c *gin.Context) {
+	userID, targetID, ok := relationParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.Block().Unblock(c.Request.Context(), userID, targetID)
+	if err != nil {
+		if errors.Is(err, services.ErrBlockNotFound) {
+			responses.NotFound(c, "User is not blocked")
+			return
+		}
+		responses.InternalServerError(c, "Failed to unblock user")
+		return
+	}
+
+	responses.NoContent(c)
+}
+
+// ListBlocks lists the users the authenticated user has blocked, most
+// recently blocked first.
+func (h *UserHandler) ListBlocks(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	blocks, err := h.service.Block().List(c.Request.Context(), int32(userID))
+	if err != nil {
+		responses.InternalServerError(c, "Failed to fetch blocked users")
+		return
+	}
+
+	resp := make([]dto.BlockedUserResponse, 0, len(blocks))
+	for _, b := range blocks {
+		resp = append(resp, dto.NewBlockedUserResponse(b))
+	}
+	responses.OK(c, "Blocked users retrieved successfully", resp)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	contracts "example.com/api/internal/contracts/errors"
+	"example.com/api/internal/services"
+	"example.com/api/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// updatedMessage tells the client when the email it sent was not applied
+// yet because the new address still has to be confirmed, or because the
+// confirmation mail could not be sent (err is ErrEmailChangeNotSent) and the
+// change has to be requested again.
+func updatedMessage(requested *string, current string, err error) string {
+	if errors.Is(err, services.ErrEmailChangeNotSent) {
+		return "User updated successfully, but the confirmation email could not be sent; request the email change again"
+	}
+	if requested != nil && !sameEmail(*requested, current) {
+		return "User updated successfully, confirm the new email address to complete the change"
+	}
+	return "User updated successfully"
+}
+
+// sameEmail compares addresses the way the service does, so a spelling it
+// stores as the current email is not reported as a pending change.
+func sameEmail(requested, current string) bool {
+	normalized, err := services.NormalizeEmail(requested)
+	if err != nil {
+		return false
+	}
+	return normalized == current
+}
+
+func (h *UserHandler) ConfirmEmailChange(c *gin.Context) {
+	var req dto.EmailChangeTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.BadRequest(c, "Token is required", err)
+		return
+	}
+
+	user, err := h.service.EmailChange().Confirm(c.Request.Context(), req.Token)
+	if err != nil {
+		h.emailChangeError(c, err)
+		return
+	}
+
+	responses.OK(c, "Email changed successfully", dto.NewUserResponse(*user))
+}
+
+func (h *UserHandler) CancelEmailChange(c *gin.Context) {
+	var req dto.EmailChangeTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.BadRequest(c, "Token is required", err)
+		return
+	}
+
+	if err := h.service.EmailChange().Cancel(c.Request.Context(), req.Token); err != nil {
+		h.emailChangeError(c, err)
+		return
+	}
+
+	responses.OK(c, "Email change cancelled", nil)
+}
+
+func (h *UserHandler) emailChangeError(c *gin.Context, err error) {
+	var emailErr *contracts.EmailExistsError
+
+	switch {
+	case errors.Is(err, services.ErrInvalidEmailChangeToken):
+		responses.BadRequest(c, "Invalid or already used token", nil)
+	case errors.Is(err, services.ErrEmailChangeExpired):
+		responses.BadRequest(c, "Token has expired", nil)
+	case errors.As(err, &emailErr):
+		responses.Conflict(c, "Email already in use", gin.H{
+			"info": emailErr.Error(),
+		})
+	default:
+		h.logger.Error(logging.Internal, logging.Update, "Failed to process email change", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			logging.Path:         c.Request.URL.Path,
+			logging.Method:       c.Request.Method,
+		})
+		responses.InternalServerError(c, "Failed to process email change")
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	"example.com/api/internal/api/validation"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Follow makes the authenticated user follow the user in the path.
+func (h *UserHandler) Follow(c *gin.Context) {
+	userID, targetID, ok := relationParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.Relationship().Follow(c.Request.Context(), userID, targetID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrCannotFollowSelf):
+			responses.BadRequest(c, "You cannot follow yourself", nil)
+		case err.Error() == "user not found":
+			responses.NotFound(c, "User not found")
+		default:
+			responses.InternalServerError(c, "Failed to follow user")
+		}
+		return
+	}
+
+	responses.NoContent(c)
+}
+
+func (h *UserHandler) Unfollow(c *gin.Context) {
+	userID, targetID, ok := relationParams(c)
+	if !ok {
+		return
+	}
+
+	err := h.service.Relationship().Unfollow(c.Request.Context(), userID, targetID)
+	if err != nil {
+		if errors.Is(err, services.ErrNotFollowing) {
+			responses.NotFound(c, "User is not followed")
+			return
+		}
+		responses.InternalServerError(c, "Failed to unfollow user")
+		return
+	}
+
+	responses.NoContent(c)
+}
+
+func (h *UserHandler) ListFollowers(c *gin.Context) {
+	id, page, ok := followListParams(c)
+	if !ok {
+		return
+	}
+
+	users, err := h.service.Relationship().Followers(c.Request.Context(), id, page)
+	if err != nil {
+		followListError(c, err, "Failed to fetch followers")
+		return
+	}
+
+	resp := make([]dto.FollowUserResponse, 0, len(users))
+	for _, u := range users {
+		resp = append(resp, dto.NewFollowUserResponse(u.ID, u.Username, u.FullName, u.AvatarUrl, u.FollowedAt))
+	}
+	responses.OK(c, "Followers retrieved successfully", resp)
+}
+
+func (h *UserHandler) ListFollowing(c *gin.Context) {
+	id, page, ok := followListParams(c)
+	if !ok {
+		return
+	}
+
+	users, err := h.service.Relationship().Following(c.Request.Context(), id, page)
+	if err != nil {
+		followListError(c, err, "Failed to fetch followed users")
+		return
+	}
+
+	resp := make([]dto.FollowUserResponse, 0, len(users))
+	for _, u := range users {
+		resp = append(resp, dto.NewFollowUserResponse(u.ID, u.Username, u.FullName, u.AvatarUrl, u.FollowedAt))
+	}
+	responses.OK(c, "Followed users retrieved successfully", resp)
+}
+
+// ListContacts lists the user's mutual follows: the users they follow who
+// follow them back.
+func (h *UserHandler) ListContacts(c *gin.Context) {
+	id, page, ok := followListParams(c)
+	if !ok {
+		return
+	}
+
+	users, err := h.service.Relationship().Mutuals(c.Request.Context(), id, page)
+	if err != nil {
+		followListError(c, err, "Failed to fetch contacts")
+		return
+	}
+
+	resp := make([]dto.FollowUserResponse, 0, len(users))
+	for _, u := range users {
+		resp = append(resp, dto.NewFollowUserResponse(u.ID, u.Username, u.FullName, u.AvatarUrl, u.FollowedAt))
+	}
+	responses.OK(c, "Contacts retrieved successfully", resp)
+}
+
+func relationParams(c *gin.Context) (userID, targetID int32, ok bool) {
+	uid, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return 0, 0, false
+	}
+	tid, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID, must be an integer", nil)
+		return 0, 0, false
+	}
+	return int32(uid), int32(tid), true
+}
+
+func followListParams(c *gin.Context) (int32, dto.FollowListParams, bool) {
+	var page dto.FollowListParams
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID, must be an integer", nil)
+		return 0, page, false
+	}
+	if err := c.ShouldBindQuery(&page); err != nil {
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			responses.BadRequest(c, "Invalid query parameters", validation.GetValidationErrors(err))
+			return 0, page, false
+		}
+		responses.BadRequest(c, "Invalid query parameters", nil)
+		return 0, page, false
+	}
+	return int32(id), page, true
+}
+
+func followListError(c *gin.Context, err error, msg string) {
+	if err.Error() == "user not found" {
+		responses.NotFound(c, "User not found")
+		return
+	}
+	responses.InternalServerError(c, msg)
+}
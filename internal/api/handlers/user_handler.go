@@ -2,8 +2,12 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"strconv"
 
+	"example.com/api/internal/api/patch"
 	"example.com/api/internal/api/responses"
 	"example.com/api/internal/api/validation"
 	dto "example.com/api/internal/contracts"
@@ -49,11 +53,21 @@ func (h *UserHandler) Create(c *gin.Context) {
 	user, err := h.service.User().Create(c.Request.Context(), req)
 	if err != nil {
 		var (
-			usernameErr *contracts.UsernameExistsError
-			emailErr    *contracts.EmailExistsError
+			usernameErr        *contracts.UsernameExistsError
+			emailErr           *contracts.EmailExistsError
+			invalidUsernameErr *contracts.InvalidUsernameError
+			invalidEmailErr    *contracts.InvalidEmailError
 		)
 
 		switch {
+		case errors.As(err, &invalidUsernameErr):
+			responses.BadRequest(c, "Invalid username", gin.H{
+				"info": invalidUsernameErr.Error(),
+			})
+		case errors.As(err, &invalidEmailErr):
+			responses.BadRequest(c, "Invalid email", gin.H{
+				"info": invalidEmailErr.Error(),
+			})
 		case errors.As(err, &usernameErr):
 			responses.Conflict(c, "Username already in use", gin.H{
 				"info": usernameErr.Error(),
@@ -92,7 +106,38 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	responses.OK(c, "User retrieved successfully", user)
+	responses.OK(c, "User retrieved successfully", dto.NewUserResponse(*user))
+}
+
+// BatchGet resolves up to dto.MaxBatchGetUsers IDs in one request. Users
+// come back in the requested order and unknown IDs are listed as missing
+// rather than failing the request.
+func (h *UserHandler) BatchGet(c *gin.Context) {
+	var req dto.BatchGetUsersReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var ve validator.ValidationErrors
+		if errors.As(err, &ve) {
+			responses.BadRequest(c, "Invalid request body", validation.GetValidationErrors(err))
+			return
+		}
+		responses.BadRequest(c, "Invalid request body", err)
+		return
+	}
+
+	users, missing, err := h.service.User().GetByIDs(c.Request.Context(), req.IDs)
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve users")
+		return
+	}
+
+	userResponses := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, dto.NewUserResponse(user))
+	}
+	responses.OK(c, "Users retrieved successfully", dto.BatchGetUsersResponse{
+		Users:   userResponses,
+		Missing: missing,
+	})
 }
 
 func (h *UserHandler) GetAll(c *gin.Context) {
@@ -117,7 +162,12 @@ func (h *UserHandler) GetAll(c *gin.Context) {
 		return
 	}
 
-	responses.OK(c, "Users retrieved successfully", users)
+	userResponses := make([]dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		userResponses = append(userResponses, dto.NewUserResponse(user))
+	}
+
+	responses.OK(c, "Users retrieved successfully", userResponses)
 }
 
 func (h *UserHandler) UpdateFull(c *gin.Context) {
@@ -135,13 +185,23 @@ func (h *UserHandler) UpdateFull(c *gin.Context) {
 
 	req.ID = int32(id)
 	user, err := h.service.User().UpdateFull(c.Request.Context(), req)
-	if err != nil {
+	if err != nil && !errors.Is(err, services.ErrEmailChangeNotSent) {
 		var (
-			usernameErr *contracts.UsernameExistsError
-			emailErr    *contracts.EmailExistsError
+			usernameErr        *contracts.UsernameExistsError
+			emailErr           *contracts.EmailExistsError
+			invalidUsernameErr *contracts.InvalidUsernameError
+			invalidEmailErr    *contracts.InvalidEmailError
 		)
 
 		switch {
+		case errors.As(err, &invalidUsernameErr):
+			responses.BadRequest(c, "Invalid username", gin.H{
+				"info": invalidUsernameErr.Error(),
+			})
+		case errors.As(err, &invalidEmailErr):
+			responses.BadRequest(c, "Invalid email", gin.H{
+				"info": invalidEmailErr.Error(),
+			})
 		case errors.As(err, &usernameErr):
 			responses.Conflict(c, "Username already in use", gin.H{
 				"info": usernameErr.Error(),
@@ -163,7 +223,7 @@ func (h *UserHandler) UpdateFull(c *gin.Context) {
 		return
 	}
 
-	responses.OK(c, "User updated successfully", user)
+	responses.OK(c, updatedMessage(&req.Email, user.Email, err), dto.NewUserResponse(*user))
 }
 
 func (h *UserHandler) UpdatePartial(c *gin.Context) {
@@ -173,21 +233,71 @@ func (h *UserHandler) UpdatePartial(c *gin.Context) {
 		return
 	}
 
-	var req dto.UpdateUserPartialReq
-	if err := c.ShouldBindJSON(&req); err != nil {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
 		responses.BadRequest(c, "Invalid request body", err)
 		return
 	}
 
-	req.ID = int32(id)
-	user, err := h.service.User().UpdatePartial(c.Request.Context(), req)
+	current, err := h.service.User().GetByID(c.Request.Context(), int32(id))
+	if err != nil {
+		if err.Error() == "user not found" {
+			responses.NotFound(c, "User not found")
+			return
+		}
+		h.logger.Error(logging.Internal, logging.Update, "Failed to update user", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			logging.Path:         c.Request.URL.Path,
+			logging.Method:       c.Request.Method,
+		})
+		responses.InternalServerError(c, "Failed to update user")
+		return
+	}
+
+	doc, err := applyUserPatch(c.ContentType(), *current, body)
 	if err != nil {
 		var (
-			usernameErr *contracts.UsernameExistsError
-			emailErr    *contracts.EmailExistsError
+			readOnlyErr *contracts.ReadOnlyFieldError
+			ve          validator.ValidationErrors
 		)
 
 		switch {
+		case errors.Is(err, patch.ErrUnsupportedMediaType):
+			c.Header("Accept-Patch", acceptPatch)
+			responses.UnsupportedMediaType(c, "Unsupported patch media type")
+		case errors.As(err, &readOnlyErr):
+			responses.UnprocessableEntity(c, "Patch modifies a read-only field", gin.H{
+				"info": readOnlyErr.Error(),
+			})
+		case errors.Is(err, patch.ErrTestFailed):
+			responses.Conflict(c, "Patch test operation failed", nil)
+		case errors.As(err, &ve):
+			responses.BadRequest(c, "Invalid patched document", validation.GetValidationErrors(err))
+		default:
+			responses.BadRequest(c, "Invalid patch document", err)
+		}
+		return
+	}
+
+	req := userPatchChanges(*current, doc)
+	user, err := h.service.User().UpdatePartial(c.Request.Context(), req)
+	if err != nil && !errors.Is(err, services.ErrEmailChangeNotSent) {
+		var (
+			usernameErr        *contracts.UsernameExistsError
+			emailErr           *contracts.EmailExistsError
+			invalidUsernameErr *contracts.InvalidUsernameError
+			invalidEmailErr    *contracts.InvalidEmailError
+		)
+
+		switch {
+		case errors.As(err, &invalidUsernameErr):
+			responses.BadRequest(c, "Invalid username", gin.H{
+				"info": invalidUsernameErr.Error(),
+			})
+		case errors.As(err, &invalidEmailErr):
+			responses.BadRequest(c, "Invalid email", gin.H{
+				"info": invalidEmailErr.Error(),
+			})
 		case errors.As(err, &usernameErr):
 			responses.Conflict(c, "Username already in use", gin.H{
 				"info": usernameErr.Error(),
@@ -209,7 +319,7 @@ func (h *UserHandler) UpdatePartial(c *gin.Context) {
 		return
 	}
 
-	responses.OK(c, "User updated successfully", user)
+	responses.OK(c, updatedMessage(req.Email, user.Email, err), dto.NewUserResponse(*user))
 }
 
 func (h *UserHandler) DeleteUser(c *gin.Context) {
@@ -231,3 +341,94 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 
 	responses.NoContent(c)
 }
+
+func (h *UserHandler) UploadAvatar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID, must be an integer", nil)
+		return
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		responses.BadRequest(c, "Expected a multipart/form-data upload", err)
+		return
+	}
+	part, err := nextFormPart(reader, avatarFormField)
+	if err != nil {
+		responses.BadRequest(c, "Missing avatar file", err)
+		return
+	}
+	defer part.Close()
+
+	user, err := h.service.Avatar().Upload(c.Request.Context(), int32(id), part)
+	if err != nil {
+		var (
+			tooLargeErr    *contracts.ImageTooLargeError
+			unsupportedErr *contracts.UnsupportedImageError
+			dimensionsErr  *contracts.ImageDimensionsError
+			invalidErr     *contracts.InvalidImageError
+		)
+
+		switch {
+		case errors.As(err, &tooLargeErr):
+			responses.PayloadTooLarge(c, tooLargeErr.Error())
+		case errors.As(err, &unsupportedErr):
+			responses.UnsupportedMediaType(c, unsupportedErr.Error())
+		case errors.As(err, &dimensionsErr):
+			responses.UnprocessableEntity(c, "Image dimensions are too large", gin.H{
+				"info": dimensionsErr.Error(),
+			})
+		case errors.As(err, &invalidErr):
+			responses.BadRequest(c, "Invalid image", invalidErr)
+		case err.Error() == "user not found":
+			responses.NotFound(c, "User not found")
+		default:
+			h.logger.Error(logging.IO, logging.WriteFile, "Failed to upload avatar", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				logging.Path:         c.Request.URL.Path,
+				logging.Method:       c.Request.Method,
+			})
+			responses.InternalServerError(c, "Failed to upload avatar")
+		}
+		return
+	}
+
+	responses.OK(c, "Avatar uploaded successfully", dto.NewUserResponse(*user))
+}
+
+func (h *UserHandler) GetAvatar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID, must be an integer", nil)
+		return
+	}
+
+	avatar, err := h.service.Avatar().Open(c.Request.Context(), int32(id), c.Query("size"))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidAvatarSize):
+			responses.BadRequest(c, "Invalid avatar size", nil)
+		case errors.Is(err, services.ErrAvatarNotFound):
+			responses.NotFound(c, "Avatar not found")
+		case err.Error() == "user not found":
+			responses.NotFound(c, "User not found")
+		default:
+			responses.InternalServerError(c, "Failed to load avatar")
+		}
+		return
+	}
+	defer avatar.Body.Close()
+
+	c.Header("ETag", avatar.ETag)
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", avatar.MaxAge))
+	if !avatar.LastModified.IsZero() {
+		c.Header("Last-Modified", avatar.LastModified.UTC().Format(http.TimeFormat))
+	}
+	if c.GetHeader("If-None-Match") == avatar.ETag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, avatar.Size, avatar.ContentType, avatar.Body, nil)
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"example.com/api/internal/api/patch"
+	dto "example.com/api/internal/contracts"
+	dbCtx "example.com/api/internal/repository/db"
+	"github.com/gin-gonic/gin/binding"
+)
+
+var acceptPatch = strings.Join([]string{
+	patch.MediaTypeMerge,
+	patch.MediaTypeJSONPatch,
+	patch.MediaTypeJSON,
+}, ", ")
+
+// applyUserPatch renders user as a UserPatchDocument, applies the patch body
+// to it and validates the result. A request without a content type is
+// treated as plain JSON.
+func applyUserPatch(contentType string, user dbCtx.User, body []byte) (dto.UserPatchDocument, error) {
+	if contentType == "" {
+		contentType = patch.MediaTypeJSON
+	}
+
+	original, err := json.Marshal(newUserPatchDocument(user))
+	if err != nil {
+		return dto.UserPatchDocument{}, err
+	}
+
+	patched, err := patch.Apply(contentType, original, body, dto.UserPatchReadOnlyFields)
+	if err != nil {
+		return dto.UserPatchDocument{}, err
+	}
+
+	var doc dto.UserPatchDocument
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return dto.UserPatchDocument{}, fmt.Errorf("%w: %s", patch.ErrInvalidPatch, err.Error())
+	}
+
+	if err := binding.Validator.ValidateStruct(&doc); err != nil {
+		return dto.UserPatchDocument{}, err
+	}
+	return doc, nil
+}
+
+func newUserPatchDocument(user dbCtx.User) dto.UserPatchDocument {
+	doc := dto.UserPatchDocument{
+		ID:       user.ID,
+		Username: &user.Username,
+		Email:    &user.Email,
+		FullName: &user.FullName,
+	}
+	if user.Bio.Valid {
+		doc.Bio = &user.Bio.String
+	}
+	if user.Locale.Valid {
+		doc.Locale = &user.Locale.String
+	}
+	if user.Timezone.Valid {
+		doc.Timezone = &user.Timezone.String
+	}
+	if user.AvatarUrl.Valid {
+		doc.AvatarURL = &user.AvatarUrl.String
+	}
+	doc.Attributes = userAttributes(user)
+	if user.CreatedAt.Valid {
+		doc.CreatedAt = &user.CreatedAt.Time
+	}
+	if user.UpdatedAt.Valid {
+		doc.UpdatedAt = &user.UpdatedAt.Time
+	}
+	return doc
+}
+
+// userPatchChanges turns a patched document into an UpdateUserPartialReq that
+// only carries the fields that differ from user. full_name is NOT NULL, so a
+// null fullName clears it to an empty string; a null attributes object
+// clears every attribute.
+func userPatchChanges(user dbCtx.User, doc dto.UserPatchDocument) dto.UpdateUserPartialReq {
+	req := dto.UpdateUserPartialReq{ID: user.ID}
+
+	if *doc.Username != user.Username {
+		req.Username = doc.Username
+	}
+	if *doc.Email != user.Email {
+		req.Email = doc.Email
+	}
+
+	fullName := ""
+	if doc.FullName != nil {
+		fullName = *doc.FullName
+	}
+	if fullName != user.FullName {
+		req.FullName = &fullName
+	}
+
+	req.Password = doc.Password
+	req.Bio = nullableChange(user.Bio, doc.Bio)
+	req.Locale = nullableChange(user.Locale, doc.Locale)
+	req.Timezone = nullableChange(user.Timezone, doc.Timezone)
+	req.AvatarURL = nullableChange(user.AvatarUrl, doc.AvatarURL)
+
+	attributes := doc.Attributes
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	if !reflect.DeepEqual(attributes, userAttributes(user)) {
+		req.Attributes = attributes
+	}
+	return req
+}
+
+func nullableChange(current sql.NullString, next *string) dto.Nullable[string] {
+	if next == nil && !current.Valid {
+		return dto.Nullable[string]{}
+	}
+	if next != nil && current.Valid && *next == current.String {
+		return dto.Nullable[string]{}
+	}
+	return dto.Nullable[string]{Set: true, Value: next}
+}
+
+func userAttributes(user dbCtx.User) map[string]any {
+	attributes := map[string]any{}
+	if len(user.Attributes) > 0 {
+		_ = json.Unmarshal(user.Attributes, &attributes)
+	}
+	return attributes
+}
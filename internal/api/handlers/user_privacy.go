@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// ExportMe sends the authenticated user a zip archive of their data.
+func (h *UserHandler) ExportMe(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	export, err := h.service.Privacy().Export(c.Request.Context(), int32(userID))
+	if err != nil {
+		if err.Error() == "user not found" {
+			responses.NotFound(c, "User not found")
+			return
+		}
+		responses.InternalServerError(c, "Failed to export user data")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := export.WriteArchive(&buf); err != nil {
+		h.logger.Error(logging.IO, logging.WriteFile, "Failed to build export archive", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		responses.InternalServerError(c, "Failed to export user data")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d-export.zip"`, userID))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+// EraseMe erases the authenticated user's account. The password is asked for
+// again because the erasure cannot be undone.
+func (h *UserHandler) EraseMe(c *gin.Context) {
+	userID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	var req dto.EraseAccountReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.BadRequest(c, "Password is required", err)
+		return
+	}
+
+	user, err := h.service.User().GetByID(c.Request.Context(), int32(userID))
+	if err != nil {
+		responses.NotFound(c, "User not found")
+		return
+	}
+	if err := h.service.Hash().Compare(user.PasswordHash, req.Password); err != nil {
+		responses.Unauthorized(c, "Invalid password")
+		return
+	}
+
+	h.erase(c, user.ID, int32(userID))
+}
+
+// EraseUser erases the account :id for an administrator. Unlike EraseMe it
+// also erases accounts that were soft-deleted already.
+func (h *UserHandler) EraseUser(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID, must be an integer", nil)
+		return
+	}
+	adminID, err := strconv.Atoi(c.GetString("user_id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid user ID", nil)
+		return
+	}
+
+	h.erase(c, int32(id), int32(adminID))
+}
+
+func (h *UserHandler) erase(c *gin.Context, userID, requestedBy int32) {
+	if err := h.service.Privacy().Erase(c.Request.Context(), userID, requestedBy); err != nil {
+		if err.Error() == "user not found" {
+			responses.NotFound(c, "User not found")
+			return
+		}
+		responses.InternalServerError(c, "Failed to erase user")
+		return
+	}
+
+	responses.NoContent(c)
+}
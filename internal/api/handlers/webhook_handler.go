@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	"example.com/api/internal/api/validation"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/services"
+	"example.com/api/pkg/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type WebhookHandler struct {
+	service services.IServiceManager
+	logger  logging.ILogger
+}
+
+func NewWebhookHandler(s services.IServiceManager, l logging.ILogger) *WebhookHandler {
+	return &WebhookHandler{
+		service: s,
+		logger:  l,
+	}
+}
+
+// Create subscribes an endpoint. The response is the only place the secret
+// is shown, unless it is rotated later.
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req dto.CreateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	sub, err := h.service.Webhook().Create(c.Request.Context(), req)
+	if err != nil {
+		responses.InternalServerError(c, "Failed to create webhook")
+		return
+	}
+
+	resp := dto.NewWebhookResponse(sub)
+	resp.Secret = sub.Secret
+	responses.Created(c, "Webhook created successfully", resp)
+}
+
+func (h *WebhookHandler) List(c *gin.Context) {
+	subs, err := h.service.Webhook().List(c.Request.Context())
+	if err != nil {
+		responses.InternalServerError(c, "Failed to retrieve webhooks")
+		return
+	}
+
+	subResponses := make([]dto.WebhookResponse, 0, len(subs))
+	for _, sub := range subs {
+		subResponses = append(subResponses, dto.NewWebhookResponse(sub))
+	}
+	responses.OK(c, "Webhooks retrieved successfully", subResponses)
+}
+
+func (h *WebhookHandler) Get(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.service.Webhook().Get(c.Request.Context(), id)
+	if err != nil {
+		h.webhookError(c, err, "Failed to retrieve webhook")
+		return
+	}
+	responses.OK(c, "Webhook retrieved successfully", dto.NewWebhookResponse(sub))
+}
+
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateWebhookReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidInput(c, "Invalid request body", err)
+		return
+	}
+
+	sub, secretChanged, err := h.service.Webhook().Update(c.Request.Context(), id, req)
+	if err != nil {
+		h.webhookError(c, err, "Failed to update webhook")
+		return
+	}
+
+	resp := dto.NewWebhookResponse(sub)
+	if secretChanged {
+		resp.Secret = sub.Secret
+	}
+	responses.OK(c, "Webhook updated successfully", resp)
+}
+
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Webhook().Delete(c.Request.Context(), id); err != nil {
+		h.webhookError(c, err, "Failed to delete webhook")
+		return
+	}
+	responses.NoContent(c)
+}
+
+// ListDeliveries returns the delivery history of a webhook, newest first.
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+
+	var filter dto.WebhookDeliveryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		invalidInput(c, "Invalid filter", err)
+		return
+	}
+
+	deliveries, err := h.service.Webhook().ListDeliveries(c.Request.Context(), id, filter)
+	if err != nil {
+		h.webhookError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	deliveryResponses := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, dto.NewWebhookDeliveryResponse(delivery))
+	}
+	responses.OK(c, "Webhook deliveries retrieved successfully", deliveryResponses)
+}
+
+// Redeliver queues a delivered or dead-lettered delivery again with a fresh
+// attempt budget.
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	id, ok := webhookID(c)
+	if !ok {
+		return
+	}
+	deliveryID, err := strconv.ParseInt(c.Param("deliveryId"), 10, 64)
+	if err != nil {
+		responses.BadRequest(c, "Invalid delivery ID, must be an integer", nil)
+		return
+	}
+
+	delivery, err := h.service.Webhook().Redeliver(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		h.webhookError(c, err, "Failed to redeliver webhook delivery")
+		return
+	}
+	responses.OK(c, "Webhook delivery queued", dto.NewWebhookDeliveryResponse(delivery))
+}
+
+func (h *WebhookHandler) webhookError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrWebhookNotFound):
+		responses.NotFound(c, "Webhook not found")
+	case errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		responses.NotFound(c, "Delivery not found or still pending")
+	default:
+		responses.InternalServerError(c, message)
+	}
+}
+
+func webhookID(c *gin.Context) (int32, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		responses.BadRequest(c, "Invalid webhook ID, must be an integer", nil)
+		return 0, false
+	}
+	return int32(id), true
+}
+
+func invalidInput(c *gin.Context, message string, err error) {
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		responses.BadRequest(c, message, validation.GetValidationErrors(err))
+		return
+	}
+	responses.BadRequest(c, message, err.Error())
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"slices"
+	"strconv"
+
+	"example.com/api/internal/api/responses"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAdmin lets through only the listed users. It must run after
+// AuthMiddleware.
+func RequireAdmin(adminIDs []int32) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := strconv.Atoi(c.GetString("user_id"))
+		if err != nil || !slices.Contains(adminIDs, int32(userID)) {
+			responses.Forbidden(c, "Admin access required")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
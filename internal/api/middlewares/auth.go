@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"strconv"
 	"strings"
 
 	"example.com/api/internal/api/responses"
@@ -32,6 +33,9 @@ func AuthMiddleware(authService services.IAuthService) gin.HandlerFunc {
 		}
 
 		c.Set("user_id", userID)
+		if id, err := strconv.Atoi(userID); err == nil {
+			c.Request = c.Request.WithContext(services.WithActor(c.Request.Context(), int32(id)))
+		}
 		c.Next()
 	}
 }
@@ -0,0 +1,191 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"example.com/api/config"
+	"example.com/api/internal/api/responses"
+	"example.com/api/internal/storage/cache"
+	"example.com/api/internal/tenant"
+	"example.com/api/pkg/logging"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	idempotencyCachePrefix    = "idempotency:"
+	idempotencyLockKeySuffix  = ":lock"
+	anonymousIdempotencyScope = "anonymous"
+)
+
+// idempotentResponse is what is kept for a key: the fingerprint of the
+// request that used it and the response that request got.
+type idempotentResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType"`
+	Body        []byte `json:"body"`
+}
+
+// Idempotency makes a route safe to retry. A request carrying an
+// Idempotency-Key has its response stored; a later request with the same key
+// gets that response replayed instead of running again. Reusing a key for a
+// different request is rejected with 422, and a key whose first request is
+// still running with 409. Keys are scoped to the organization and to the
+// authenticated user, or to the client address of an anonymous request, so
+// the middleware must run after AuthMiddleware and Tenant.
+//
+// Server errors are not stored, so a retry after a 5xx runs again. When the
+// cache is unavailable requests go through unprotected rather than failing.
+func Idempotency(store cache.ICacheService, cfg config.IdempotencyConfig, logger logging.ILogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			responses.BadRequest(c, "Idempotency-Key must be at most 255 characters", nil)
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			responses.BadRequest(c, "Failed to read request body", nil)
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, body)
+		cacheKey := idempotencyCacheKey(idempotencyScope(c), key)
+		lockKey := cacheKey + idempotencyLockKeySuffix
+
+		var stored idempotentResponse
+		found, err := store.Get(ctx, cacheKey, &stored)
+		if err != nil {
+			idempotencyCacheError(logger, c, err)
+			c.Next()
+			return
+		}
+		if found {
+			if stored.Fingerprint != fingerprint {
+				keyReused(c)
+				return
+			}
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(stored.Status, stored.ContentType, stored.Body)
+			c.Abort()
+			return
+		}
+
+		locked, err := store.SetNX(ctx, lockKey, fingerprint, cfg.LockTimeout*time.Second)
+		if err != nil {
+			idempotencyCacheError(logger, c, err)
+			c.Next()
+			return
+		}
+		if !locked {
+			var lockedFingerprint string
+			if found, _ := store.Get(ctx, lockKey, &lockedFingerprint); found && lockedFingerprint != fingerprint {
+				keyReused(c)
+				return
+			}
+			responses.Conflict(c, "A request with this Idempotency-Key is still being processed", nil)
+			c.Abort()
+			return
+		}
+		// The response is stored even if the client has gone away meanwhile,
+		// which is exactly when it will retry.
+		storeCtx := context.WithoutCancel(ctx)
+		defer func() {
+			if err := store.Delete(storeCtx, lockKey); err != nil {
+				idempotencyCacheError(logger, c, err)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		err = store.Set(storeCtx, cacheKey, idempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}, cfg.TTL*time.Hour)
+		if err != nil {
+			idempotencyCacheError(logger, c, err)
+		}
+	}
+}
+
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyScope names whose keys a request uses: the caller's in the
+// organization it acts in. Anonymous callers are told apart by address, so
+// two of them picking the same key do not share its response.
+func idempotencyScope(c *gin.Context) string {
+	principal := c.GetString("user_id")
+	if principal == "" {
+		principal = anonymousIdempotencyScope + ":" + c.ClientIP()
+	}
+	orgID, _ := tenant.FromContext(c.Request.Context())
+	return fmt.Sprintf("%d:%s", orgID, principal)
+}
+
+// idempotencyCacheKey hashes the scope and key so that client-chosen keys
+// cannot reach into other parts of the cache keyspace.
+func idempotencyCacheKey(scope, key string) string {
+	sum := sha256.Sum256([]byte(scope + "\x00" + key))
+	return idempotencyCachePrefix + hex.EncodeToString(sum[:])
+}
+
+func keyReused(c *gin.Context) {
+	responses.UnprocessableEntity(c, "Idempotency-Key was already used for a different request", nil)
+	c.Abort()
+}
+
+func idempotencyCacheError(logger logging.ILogger, c *gin.Context, err error) {
+	logger.Warn(logging.Redis, logging.Api, "Idempotency cache unavailable", map[logging.ExtraKey]any{
+		logging.ErrorMessage: err.Error(),
+		logging.Path:         c.Request.URL.Path,
+		logging.RequestId:    c.GetString("request_id"),
+	})
+}
+
+// responseRecorder keeps a copy of everything written to the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -23,6 +23,7 @@ func LoggingMiddleware(logger logging.ILogger) gin.HandlerFunc {
 			logging.ClientIp:   c.ClientIP(),
 			logging.StatusCode: c.Writer.Status(),
 			logging.Latency:    duration.String(),
+			logging.RequestId:  c.GetString("request_id"),
 		})
 	}
 }
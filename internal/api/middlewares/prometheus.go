@@ -15,6 +15,11 @@ func init() {
 	prometheus.MustRegister(metrics.DbCall)
 	prometheus.MustRegister(metrics.TotalReq)
 	prometheus.MustRegister(metrics.NodeUsage)
+	prometheus.MustRegister(metrics.OutboxDeliveries)
+	prometheus.MustRegister(metrics.OutboxPending)
+	prometheus.MustRegister(metrics.OutboxDeliveryLag)
+	prometheus.MustRegister(metrics.WebhookDeliveries)
+	prometheus.MustRegister(metrics.ChatPresenceDropped)
 }
 
 func PrometheusMiddleware() gin.HandlerFunc {
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"regexp"
+
+	"example.com/api/internal/services"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestContext gives every request an ID, echoed in the X-Request-ID
+// header, and attaches the ID and client IP to the request context for the
+// services. An ID sent by the client or a proxy is kept if it looks sane.
+func RequestContext() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Header(RequestIDHeader, requestID)
+		c.Set("request_id", requestID)
+
+		ctx := services.WithRequestMeta(c.Request.Context(), services.RequestMeta{
+			IP:        c.ClientIP(),
+			RequestID: requestID,
+		})
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"example.com/api/config"
+	"example.com/api/internal/api/responses"
+	"example.com/api/internal/services"
+	"example.com/api/internal/tenant"
+	"github.com/gin-gonic/gin"
+)
+
+// Tenant resolves the organization a request acts in and scopes the
+// request context to it. The slug is taken from, in order, the configured
+// header, the tenant query parameter on the chat socket, the subdomain of
+// cfg.BaseDomain and finally cfg.DefaultOrganization. Authenticated callers must be members of the
+// organization; anonymous requests such as registration are only ever
+// placed in the default one.
+func Tenant(orgs services.IOrganizationService, cfg config.TenancyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var userID int32
+		slug := cfg.DefaultOrganization
+		if id, err := strconv.Atoi(c.GetString("user_id")); err == nil {
+			userID = int32(id)
+			if requested := requestedTenant(c, cfg); requested != "" {
+				slug = requested
+			}
+		}
+		if slug == "" {
+			responses.BadRequest(c, "Organization is required", nil)
+			c.Abort()
+			return
+		}
+
+		org, role, err := orgs.Resolve(c.Request.Context(), slug, userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrOrganizationNotFound):
+				responses.NotFound(c, "Organization not found")
+			case errors.Is(err, services.ErrNotOrganizationMember):
+				responses.Forbidden(c, "Not a member of this organization")
+			default:
+				responses.InternalServerError(c, "Failed to resolve organization")
+			}
+			c.Abort()
+			return
+		}
+
+		c.Set("org_id", org.ID)
+		c.Set("org_role", role)
+		c.Request = c.Request.WithContext(tenant.WithID(c.Request.Context(), org.ID))
+		c.Next()
+	}
+}
+
+// RequireOrgRole lets through only members holding one of roles in the
+// active organization. It must run after Tenant.
+func RequireOrgRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !slices.Contains(roles, c.GetString("org_role")) {
+			responses.Forbidden(c, "Insufficient organization role")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func requestedTenant(c *gin.Context, cfg config.TenancyConfig) string {
+	if cfg.Header != "" {
+		if slug := c.GetHeader(cfg.Header); slug != "" {
+			return slug
+		}
+	}
+	if c.Request.URL.Path == "/api/chat/ws" {
+		if slug := c.Query("tenant"); slug != "" {
+			return slug
+		}
+	}
+	if cfg.BaseDomain != "" {
+		host := c.Request.Host
+		if i := strings.LastIndexByte(host, ':'); i >= 0 {
+			host = host[:i]
+		}
+		if sub, ok := strings.CutSuffix(host, "."+cfg.BaseDomain); ok && sub != "" && !strings.Contains(sub, ".") {
+			return sub
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	contracts "example.com/api/internal/contracts/errors"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+const (
+	MediaTypeJSON      = "application/json"
+	MediaTypeMerge     = "application/merge-patch+json"
+	MediaTypeJSONPatch = "application/json-patch+json"
+)
+
+var (
+	ErrUnsupportedMediaType = errors.New("unsupported patch media type")
+	ErrInvalidPatch         = errors.New("invalid patch document")
+	ErrTestFailed           = errors.New("patch test operation failed")
+)
+
+// Apply applies body to the JSON document original according to the patch
+// format identified by contentType. Plain application/json is treated as a
+// merge patch, so an explicit null clears a field while an absent key leaves
+// it untouched. After the patch is applied, every field listed in readOnly is
+// compared with its original value and a ReadOnlyFieldError is returned if
+// the patch changed it.
+func Apply(contentType string, original, body []byte, readOnly []string) ([]byte, error) {
+	var (
+		patched []byte
+		err     error
+	)
+
+	switch contentType {
+	case MediaTypeJSON, MediaTypeMerge:
+		if !isJSONObject(body) {
+			return nil, fmt.Errorf("%w: merge patch must be a JSON object", ErrInvalidPatch)
+		}
+		patched, err = jsonpatch.MergePatch(original, body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err.Error())
+		}
+
+	case MediaTypeJSONPatch:
+		ops, err := jsonpatch.DecodePatch(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err.Error())
+		}
+		patched, err = ops.Apply(original)
+		if err != nil {
+			if errors.Is(err, jsonpatch.ErrTestFailed) {
+				return nil, ErrTestFailed
+			}
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPatch, err.Error())
+		}
+
+	default:
+		return nil, ErrUnsupportedMediaType
+	}
+
+	if err := checkReadOnly(original, patched, readOnly); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func checkReadOnly(original, patched []byte, readOnly []string) error {
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(original, &before); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPatch, err.Error())
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return fmt.Errorf("%w: patched document must be a JSON object", ErrInvalidPatch)
+	}
+
+	for _, field := range readOnly {
+		if !equalJSON(before[field], after[field]) {
+			return &contracts.ReadOnlyFieldError{Field: field}
+		}
+	}
+	return nil
+}
+
+// equalJSON reports whether two raw JSON values are semantically equal. A
+// missing value is treated as null.
+func equalJSON(a, b json.RawMessage) bool {
+	var av, bv any
+	if len(a) > 0 && json.Unmarshal(a, &av) != nil {
+		return false
+	}
+	if len(b) > 0 && json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+func isJSONObject(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed)
+}
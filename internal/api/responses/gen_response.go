@@ -82,3 +82,25 @@ func Conflict(c *gin.Context, message string, err any) {
 func NoContent(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
+
+func UnprocessableEntity(c *gin.Context, message string, err any) {
+	c.JSON(http.StatusUnprocessableEntity, BaseResponse{
+		Status:  "fail",
+		Message: message,
+		Errors:  err,
+	})
+}
+
+func UnsupportedMediaType(c *gin.Context, message string) {
+	c.JSON(http.StatusUnsupportedMediaType, BaseResponse{
+		Status:  "fail",
+		Message: message,
+	})
+}
+
+func PayloadTooLarge(c *gin.Context, message string) {
+	c.JSON(http.StatusRequestEntityTooLarge, BaseResponse{
+		Status:  "fail",
+		Message: message,
+	})
+}
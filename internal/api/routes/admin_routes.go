@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"example.com/api/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func SetupAdminRoutes(router *gin.RouterGroup, audit *handlers.AuditHandler, webhooks *handlers.WebhookHandler, users *handlers.UserHandler, requireAdmin gin.HandlerFunc) {
+	admin := router.Group("/admin")
+	admin.Use(requireAdmin)
+	{
+		admin.GET("/audit-events", audit.List)
+
+		admin.POST("/users/:id/erase", users.EraseUser)
+
+		admin.POST("/webhooks", webhooks.Create)
+		admin.GET("/webhooks", webhooks.List)
+		admin.GET("/webhooks/:id", webhooks.Get)
+		admin.PATCH("/webhooks/:id", webhooks.Update)
+		admin.DELETE("/webhooks/:id", webhooks.Delete)
+		admin.GET("/webhooks/:id/deliveries", webhooks.ListDeliveries)
+		admin.POST("/webhooks/:id/deliveries/:deliveryId/redeliver", webhooks.Redeliver)
+	}
+}
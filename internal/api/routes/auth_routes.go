@@ -5,11 +5,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupAuthRoutes(router *gin.Engine, handler *handlers.AuthHandler) {
+// SetupAuthRoutes registers the public auth endpoints. tenant places new
+// accounts in the default organization.
+func SetupAuthRoutes(router *gin.Engine, handler *handlers.AuthHandler, tenant, idempotency gin.HandlerFunc) {
 	auth := router.Group("/auth")
 	{
 		auth.POST("/login", handler.Login)
-		auth.POST("/register", handler.Register)
+		auth.POST("/register", tenant, idempotency, handler.Register)
+		auth.POST("/accept-invite", handler.AcceptInvite)
 		auth.POST("/refresh", handler.Refresh)
 	}
 }
@@ -5,10 +5,29 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SetupChatRoutes(router *gin.RouterGroup, handler *handlers.ChatHandler) {
+func SetupChatRoutes(router *gin.RouterGroup, handler *handlers.ChatHandler, tenant gin.HandlerFunc) {
 	chat := router.Group("/chat")
+	chat.Use(tenant)
 	{
 		chat.GET("/ws", handler.HandleWebSocket)
 		chat.GET("/messages", handler.GetMessageHistory)
+		chat.PATCH("/messages/:id", handler.EditMessage)
+		chat.DELETE("/messages/:id", handler.DeleteMessage)
+		chat.GET("/messages/:id/thread", handler.GetThread)
+		chat.GET("/messages/:id/reactions", handler.MessageReactions)
+		chat.POST("/messages/:id/reactions", handler.React)
+		chat.DELETE("/messages/:id/reactions/:emoji", handler.Unreact)
+		chat.GET("/presence", handler.Presence)
+		chat.POST("/read", handler.MarkRead)
+		chat.GET("/unread", handler.Unread)
+
+		chat.POST("/rooms", handler.CreateRoom)
+		chat.GET("/rooms", handler.ListRooms)
+		chat.POST("/rooms/:id/join", handler.JoinRoom)
+		chat.POST("/rooms/:id/leave", handler.LeaveRoom)
+		chat.GET("/rooms/:id/members", handler.RoomMembers)
+
+		chat.GET("/conversations", handler.ListConversations)
+		chat.GET("/conversations/:id/messages", handler.GetConversation)
 	}
 }
@@ -0,0 +1,33 @@
+package routes
+
+import (
+	"example.com/api/internal/api/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+// SetupOrganizationRoutes registers organization management. The
+// /organizations endpoints span every organization the caller belongs to;
+// /organization and /invitations act on the one resolved by tenant.
+func SetupOrganizationRoutes(router *gin.RouterGroup, h *handlers.OrganizationHandler, tenant, requireManager gin.HandlerFunc) {
+	orgs := router.Group("/organizations")
+	{
+		orgs.POST("", h.Create)
+		orgs.GET("", h.List)
+	}
+
+	current := router.Group("/organization")
+	current.Use(tenant)
+	{
+		current.GET("/members", h.ListMembers)
+		current.PUT("/members/:id", requireManager, h.SetMemberRole)
+		current.DELETE("/members/:id", h.RemoveMember)
+	}
+
+	invitations := router.Group("/invitations")
+	invitations.Use(tenant, requireManager)
+	{
+		invitations.POST("", h.CreateInvitation)
+		invitations.GET("", h.ListInvitations)
+		invitations.DELETE("/:id", h.RevokeInvitation)
+	}
+}
@@ -1,25 +1,42 @@
 package routes
 
 import (
-	"time"
-
 	"example.com/api/internal/api/handlers"
-	"github.com/gin-contrib/cache"
-	"github.com/gin-contrib/cache/persistence"
 	"github.com/gin-gonic/gin"
 )
 
-var store = persistence.NewInMemoryStore(time.Second)
-
-func SetupUserRoutes(router *gin.RouterGroup, h *handlers.UserHandler) {
+func SetupUserRoutes(router *gin.RouterGroup, h *handlers.UserHandler, tenant, idempotency gin.HandlerFunc) {
 	users := router.Group("/users")
+	users.Use(tenant)
 	{
 		users.GET("", h.GetAll)
-		users.GET("/cached", cache.CachePage(store, time.Minute, h.GetAll))
+		users.POST("/batch-get", h.BatchGet)
+		users.GET("/me/export", h.ExportMe)
+		users.POST("/me/erase", h.EraseMe)
+		users.GET("/me/blocks", h.ListBlocks)
 		users.GET("/:id", h.GetByID)
-		users.POST("", h.Create)
+		users.POST("", idempotency, h.Create)
 		users.PUT("/:id", h.UpdateFull)
 		users.PATCH("/:id", h.UpdatePartial)
 		users.DELETE("/:id", h.DeleteUser)
+		users.PUT("/:id/avatar", h.UploadAvatar)
+		users.GET("/:id/avatar", h.GetAvatar)
+		users.PUT("/:id/block", h.BlockUser)
+		users.DELETE("/:id/block", h.UnblockUser)
+		users.PUT("/:id/follow", h.Follow)
+		users.DELETE("/:id/follow", h.Unfollow)
+		users.GET("/:id/followers", h.ListFollowers)
+		users.GET("/:id/following", h.ListFollowing)
+		users.GET("/:id/contacts", h.ListContacts)
+	}
+}
+
+// SetupEmailChangeRoutes registers the endpoints behind the links mailed for
+// an email change. They are public: the token is the credential.
+func SetupEmailChangeRoutes(router *gin.Engine, h *handlers.UserHandler) {
+	email := router.Group("/auth/email")
+	{
+		email.POST("/confirm", h.ConfirmEmailChange)
+		email.POST("/cancel", h.CancelEmailChange)
 	}
 }
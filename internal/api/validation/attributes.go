@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"example.com/api/config"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const userAttributesTag = "user_attributes"
+
+var (
+	schemaMu        sync.RWMutex
+	attributeSchema config.ProfileConfig
+)
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		_ = v.RegisterValidation(userAttributesTag, validateUserAttributes)
+	}
+}
+
+// SetAttributeSchema replaces the schema that fields tagged with
+// user_attributes are validated against.
+func SetAttributeSchema(cfg config.ProfileConfig) {
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+	attributeSchema = cfg
+}
+
+func validateUserAttributes(fl validator.FieldLevel) bool {
+	attrs, _ := fl.Field().Interface().(map[string]any)
+	return CheckAttributes(attrs) == nil
+}
+
+// CheckAttributes validates attrs against the configured schema and returns
+// the first violation found.
+func CheckAttributes(attrs map[string]any) error {
+	schemaMu.RLock()
+	schema := attributeSchema
+	schemaMu.RUnlock()
+
+	known := make(map[string]config.AttributeConfig, len(schema.Attributes))
+	for _, attr := range schema.Attributes {
+		known[attr.Name] = attr
+		if _, ok := attrs[attr.Name]; !ok && attr.Required {
+			return fmt.Errorf("attribute '%s' is required", attr.Name)
+		}
+	}
+
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attr, ok := known[name]
+		if !ok {
+			if schema.AllowUnknownAttributes {
+				continue
+			}
+			return fmt.Errorf("attribute '%s' is not allowed", name)
+		}
+		if err := checkAttribute(attr, attrs[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkAttribute(attr config.AttributeConfig, value any) error {
+	if value == nil {
+		if attr.Required {
+			return fmt.Errorf("attribute '%s' is required", attr.Name)
+		}
+		return nil
+	}
+
+	switch attr.Type {
+	case "string", "":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("attribute '%s' must be a string", attr.Name)
+		}
+		if attr.MaxLength > 0 && len([]rune(s)) > attr.MaxLength {
+			return fmt.Errorf("attribute '%s' must be at most %d characters", attr.Name, attr.MaxLength)
+		}
+		if len(attr.Enum) > 0 && !slices.Contains(attr.Enum, s) {
+			return fmt.Errorf("attribute '%s' must be one of %v", attr.Name, attr.Enum)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("attribute '%s' must be a number", attr.Name)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("attribute '%s' must be a boolean", attr.Name)
+		}
+	default:
+		return fmt.Errorf("attribute '%s' has unsupported type '%s'", attr.Name, attr.Type)
+	}
+	return nil
+}
@@ -22,6 +22,12 @@ func GetValidationErrors(err error) *[]ValidationError {
 			el.Property = err.Field()
 			el.Tag = err.Tag()
 			el.Value = err.Param()
+			if err.Tag() == userAttributesTag {
+				attrs, _ := err.Value().(map[string]any)
+				if attrErr := CheckAttributes(attrs); attrErr != nil {
+					el.Message = attrErr.Error()
+				}
+			}
 			validationErrors = append(validationErrors, el)
 		}
 		return &validationErrors
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// AuditEventFilter selects audit events. Every field is optional; times are
+// RFC 3339 and Until is exclusive.
+type AuditEventFilter struct {
+	ActorID    *int32     `form:"actorId"`
+	Action     string     `form:"action"`
+	TargetType string     `form:"targetType"`
+	TargetID   *int32     `form:"targetId"`
+	Since      *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until      *time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit      int32      `form:"limit,default=50" binding:"min=1,max=100"`
+	Offset     int32      `form:"offset" binding:"min=0"`
+}
+
+type AuditEventResponse struct {
+	ID         int64           `json:"id"`
+	ActorID    *int32          `json:"actorId"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   *int32          `json:"targetId"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	Metadata   json.RawMessage `json:"metadata"`
+	IP         string          `json:"ip"`
+	RequestID  string          `json:"requestId"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+func NewAuditEventResponse(event dbCtx.AuditEvent) AuditEventResponse {
+	return AuditEventResponse{
+		ID:         event.ID,
+		ActorID:    nullInt32(event.ActorID.Int32, event.ActorID.Valid),
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   nullInt32(event.TargetID.Int32, event.TargetID.Valid),
+		Before:     event.Before,
+		After:      event.After,
+		Metadata:   event.Metadata,
+		IP:         event.Ip,
+		RequestID:  event.RequestID,
+		CreatedAt:  event.CreatedAt,
+	}
+}
+
+func nullInt32(i int32, valid bool) *int32 {
+	if !valid {
+		return nil
+	}
+	return &i
+}
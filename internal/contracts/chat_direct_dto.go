@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type ChatPageParams struct {
+	Limit  int32 `form:"limit,default=50" binding:"min=1,max=100"`
+	Offset int32 `form:"offset" binding:"min=0"`
+}
+
+type ConversationPeer struct {
+	ID        int32   `json:"id"`
+	Username  string  `json:"username"`
+	FullName  string  `json:"fullName"`
+	AvatarURL *string `json:"avatarUrl"`
+}
+
+type ConversationMessage struct {
+	ID        int32     `json:"id"`
+	SenderID  int32     `json:"senderId"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// ConversationResponse is one direct message conversation as seen by one of
+// its two users, the other being Peer.
+type ConversationResponse struct {
+	Peer        ConversationPeer    `json:"peer"`
+	LastMessage ConversationMessage `json:"lastMessage"`
+	UnreadCount int64               `json:"unreadCount"`
+}
+
+func NewConversationResponse(row dbCtx.ListConversationsRow) ConversationResponse {
+	return ConversationResponse{
+		Peer: ConversationPeer{
+			ID:        row.PeerID,
+			Username:  row.PeerUsername,
+			FullName:  row.PeerFullName,
+			AvatarURL: nullString(row.PeerAvatarUrl.String, row.PeerAvatarUrl.Valid),
+		},
+		LastMessage: ConversationMessage{
+			ID:        row.LastMessageID,
+			SenderID:  row.LastSenderID,
+			Content:   row.LastContent,
+			CreatedAt: row.LastCreatedAt.Time,
+		},
+		UnreadCount: row.UnreadCount,
+	}
+}
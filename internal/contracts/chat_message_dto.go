@@ -0,0 +1,5 @@
+package dto
+
+type EditMessageReq struct {
+	Content string `json:"content" binding:"required,min=1,max=4000"`
+}
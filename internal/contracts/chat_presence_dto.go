@@ -0,0 +1,7 @@
+package dto
+
+// PresenceResponse lists the users of the active organization who are
+// connected to the chat.
+type PresenceResponse struct {
+	Online []int32 `json:"online"`
+}
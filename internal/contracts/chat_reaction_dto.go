@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type ReactReq struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+// ReactionResponse is how many users react to MessageID with Emoji after a
+// reaction was added or removed.
+type ReactionResponse struct {
+	MessageID int32  `json:"messageId"`
+	Emoji     string `json:"emoji"`
+	Count     int64  `json:"count"`
+}
+
+type Reactor struct {
+	ID        int32     `json:"id"`
+	Username  string    `json:"username"`
+	ReactedAt time.Time `json:"reactedAt"`
+}
+
+// MessageReactions is who reacted to a message with Emoji, in the order
+// they reacted.
+type MessageReactions struct {
+	Emoji string    `json:"emoji"`
+	Count int       `json:"count"`
+	Users []Reactor `json:"users"`
+}
+
+// NewReactionsResponse groups reactions by emoji, in the order each emoji
+// was first used.
+func NewReactionsResponse(rows []dbCtx.ListMessageReactionsRow) []MessageReactions {
+	resp := make([]MessageReactions, 0)
+	index := make(map[string]int)
+	for _, row := range rows {
+		i, ok := index[row.Emoji]
+		if !ok {
+			i = len(resp)
+			index[row.Emoji] = i
+			resp = append(resp, MessageReactions{Emoji: row.Emoji})
+		}
+		resp[i].Users = append(resp[i].Users, Reactor{
+			ID:        row.UserID,
+			Username:  row.Username,
+			ReactedAt: row.CreatedAt,
+		})
+		resp[i].Count++
+	}
+	return resp
+}
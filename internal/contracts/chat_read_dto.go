@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"maps"
+	"slices"
+)
+
+// MarkReadReq marks the room RoomID, or the conversation with the user
+// PeerID, read up to MessageID, or up to the newest message when MessageID
+// is 0.
+type MarkReadReq struct {
+	RoomID    int32 `json:"roomId" binding:"min=0"`
+	PeerID    int32 `json:"peerId" binding:"min=0"`
+	MessageID int32 `json:"messageId" binding:"min=0"`
+}
+
+// ReadResponse is the message the read marker points at after a read, 0
+// when there was nothing to read.
+type ReadResponse struct {
+	LastReadID int32 `json:"lastReadId"`
+}
+
+type RoomUnread struct {
+	RoomID      int32 `json:"roomId"`
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+type ConversationUnread struct {
+	PeerID      int32 `json:"peerId"`
+	UnreadCount int64 `json:"unreadCount"`
+}
+
+// UnreadResponse lists the authenticated user's unread counts, by room and
+// by conversation, with their sum.
+type UnreadResponse struct {
+	Rooms         []RoomUnread         `json:"rooms"`
+	Conversations []ConversationUnread `json:"conversations"`
+	Total         int64                `json:"total"`
+}
+
+// NewUnreadResponse takes counts by room and by peer, and lists them by
+// id.
+func NewUnreadResponse(rooms, conversations map[int32]int64) UnreadResponse {
+	resp := UnreadResponse{
+		Rooms:         make([]RoomUnread, 0, len(rooms)),
+		Conversations: make([]ConversationUnread, 0, len(conversations)),
+	}
+	for _, id := range slices.Sorted(maps.Keys(rooms)) {
+		resp.Rooms = append(resp.Rooms, RoomUnread{RoomID: id, UnreadCount: rooms[id]})
+		resp.Total += rooms[id]
+	}
+	for _, id := range slices.Sorted(maps.Keys(conversations)) {
+		resp.Conversations = append(resp.Conversations, ConversationUnread{PeerID: id, UnreadCount: conversations[id]})
+		resp.Total += conversations[id]
+	}
+	return resp
+}
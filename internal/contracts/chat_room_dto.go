@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type CreateRoomReq struct {
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type RoomResponse struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy *int32    `json:"createdBy"`
+	Joined    bool      `json:"joined"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewRoomResponse(room dbCtx.ChatRoom, joined bool) RoomResponse {
+	return RoomResponse{
+		ID:        room.ID,
+		Name:      room.Name,
+		CreatedBy: nullInt32(room.CreatedBy.Int32, room.CreatedBy.Valid),
+		Joined:    joined,
+		CreatedAt: room.CreatedAt,
+	}
+}
+
+// NewRoomListResponse describes a room as the user who listed the rooms
+// sees it.
+func NewRoomListResponse(row dbCtx.ListChatRoomsRow) RoomResponse {
+	return RoomResponse{
+		ID:        row.ID,
+		Name:      row.Name,
+		CreatedBy: nullInt32(row.CreatedBy.Int32, row.CreatedBy.Valid),
+		Joined:    row.Joined,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+type RoomMemberResponse struct {
+	UserID    int32     `json:"userId"`
+	Username  string    `json:"username"`
+	FullName  string    `json:"fullName"`
+	AvatarURL *string   `json:"avatarUrl"`
+	JoinedAt  time.Time `json:"joinedAt"`
+}
+
+func NewRoomMemberResponse(row dbCtx.ListRoomMembersRow) RoomMemberResponse {
+	return RoomMemberResponse{
+		UserID:    row.ID,
+		Username:  row.Username,
+		FullName:  row.FullName,
+		AvatarURL: nullString(row.AvatarUrl.String, row.AvatarUrl.Valid),
+		JoinedAt:  row.JoinedAt,
+	}
+}
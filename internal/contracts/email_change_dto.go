@@ -0,0 +1,5 @@
+package dto
+
+type EmailChangeTokenReq struct {
+	Token string `json:"token" binding:"required"`
+}
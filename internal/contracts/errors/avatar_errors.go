@@ -0,0 +1,37 @@
+package contracts
+
+import "fmt"
+
+type ImageTooLargeError struct {
+	Limit int64
+}
+
+func (e *ImageTooLargeError) Error() string {
+	return fmt.Sprintf("image exceeds the maximum upload size of %d bytes", e.Limit)
+}
+
+type UnsupportedImageError struct {
+	ContentType string
+}
+
+func (e *UnsupportedImageError) Error() string {
+	return fmt.Sprintf("unsupported image type '%s'", e.ContentType)
+}
+
+type ImageDimensionsError struct {
+	Width  int
+	Height int
+	Limit  int
+}
+
+func (e *ImageDimensionsError) Error() string {
+	return fmt.Sprintf("image is %dx%d, the maximum is %dx%d", e.Width, e.Height, e.Limit, e.Limit)
+}
+
+type InvalidImageError struct {
+	Reason string
+}
+
+func (e *InvalidImageError) Error() string {
+	return fmt.Sprintf("invalid image: %s", e.Reason)
+}
@@ -0,0 +1,21 @@
+package contracts
+
+import "fmt"
+
+type InvalidEmailError struct {
+	Email  string
+	Reason string
+}
+
+func (e *InvalidEmailError) Error() string {
+	return fmt.Sprintf("invalid email '%s': %s", e.Email, e.Reason)
+}
+
+type InvalidUsernameError struct {
+	Username string
+	Reason   string
+}
+
+func (e *InvalidUsernameError) Error() string {
+	return fmt.Sprintf("invalid username '%s': %s", e.Username, e.Reason)
+}
@@ -0,0 +1,11 @@
+package contracts
+
+import "fmt"
+
+type ReadOnlyFieldError struct {
+	Field string
+}
+
+func (e *ReadOnlyFieldError) Error() string {
+	return fmt.Sprintf("field '%s' is read-only", e.Field)
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type CreateInvitationReq struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"omitempty,oneof=owner admin member"`
+}
+
+type ListInvitationsParams struct {
+	Limit  int32 `form:"limit,default=50" binding:"min=1,max=100"`
+	Offset int32 `form:"offset" binding:"min=0"`
+}
+
+// AcceptInvitation registers an account for the address an invitation was
+// sent to, so it carries no email of its own.
+type AcceptInvitation struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" binding:"required,min=3,max=50"`
+	Password string `json:"password" binding:"required,min=6,max=20"`
+}
+
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+type InvitationResponse struct {
+	ID         int32      `json:"id"`
+	Email      string     `json:"email"`
+	Role       string     `json:"role"`
+	Status     string     `json:"status"`
+	InvitedBy  *int32     `json:"invitedBy"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty"`
+}
+
+func NewInvitationResponse(inv dbCtx.Invitation) InvitationResponse {
+	resp := InvitationResponse{
+		ID:        inv.ID,
+		Email:     inv.Email,
+		Role:      inv.Role,
+		Status:    InvitationPending,
+		ExpiresAt: inv.ExpiresAt,
+		CreatedAt: inv.CreatedAt,
+	}
+	if inv.InvitedBy.Valid {
+		resp.InvitedBy = &inv.InvitedBy.Int32
+	}
+	switch {
+	case inv.ConsumedAt.Valid:
+		resp.Status = InvitationAccepted
+		resp.AcceptedAt = &inv.ConsumedAt.Time
+	case inv.RevokedAt.Valid:
+		resp.Status = InvitationRevoked
+	case time.Now().UTC().After(inv.ExpiresAt):
+		resp.Status = InvitationExpired
+	}
+	return resp
+}
@@ -0,0 +1,9 @@
+package dto
+
+// Nullable carries an optional update to a nullable column. Set reports
+// whether the column should be written at all; when it is, a nil Value
+// clears it.
+type Nullable[T any] struct {
+	Set   bool
+	Value *T
+}
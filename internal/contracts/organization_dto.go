@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// CreateOrganizationReq creates an organization. The slug names it in the
+// tenant header and subdomain, so it is restricted to what a DNS label
+// allows.
+type CreateOrganizationReq struct {
+	Slug string `json:"slug" binding:"required,min=2,max=63,hostname_rfc1123,lowercase,excludes=."`
+	Name string `json:"name" binding:"required,min=1,max=100"`
+}
+
+type SetMemberRoleReq struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member"`
+}
+
+type ListMembersParams struct {
+	Limit  int32 `form:"limit,default=50" binding:"min=1,max=100"`
+	Offset int32 `form:"offset" binding:"min=0"`
+}
+
+type OrganizationResponse struct {
+	ID        int32     `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewOrganizationResponse(org dbCtx.Organization, role string) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        org.ID,
+		Slug:      org.Slug,
+		Name:      org.Name,
+		Role:      role,
+		CreatedAt: org.CreatedAt,
+	}
+}
+
+// NewMembershipResponse describes an organization from the point of view
+// of one of its members.
+func NewMembershipResponse(row dbCtx.ListOrganizationsForUserRow) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        row.ID,
+		Slug:      row.Slug,
+		Name:      row.Name,
+		Role:      row.Role,
+		CreatedAt: row.CreatedAt,
+	}
+}
+
+type OrganizationMemberResponse struct {
+	UserID    int32     `json:"userId"`
+	Username  string    `json:"username"`
+	FullName  string    `json:"fullName"`
+	AvatarURL *string   `json:"avatarUrl"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joinedAt"`
+}
+
+func NewOrganizationMemberResponse(row dbCtx.ListOrganizationMembersRow) OrganizationMemberResponse {
+	return OrganizationMemberResponse{
+		UserID:    row.ID,
+		Username:  row.Username,
+		FullName:  row.FullName,
+		AvatarURL: nullString(row.AvatarUrl.String, row.AvatarUrl.Valid),
+		Role:      row.Role,
+		JoinedAt:  row.JoinedAt,
+	}
+}
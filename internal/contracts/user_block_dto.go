@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type BlockedUserResponse struct {
+	UserID    int32     `json:"userId"`
+	Username  string    `json:"username"`
+	BlockedAt time.Time `json:"blockedAt"`
+}
+
+func NewBlockedUserResponse(row dbCtx.ListUserBlocksRow) BlockedUserResponse {
+	return BlockedUserResponse{
+		UserID:    row.BlockedID,
+		Username:  row.Username,
+		BlockedAt: row.CreatedAt,
+	}
+}
@@ -1,13 +1,59 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
 
 type UserResponse struct {
-	ID        int32      `json:"id"`
-	Username  string     `json:"username"`
-	Email     string     `json:"email"`
-	FullName  string     `json:"fullName"`
-	CreatedAt *time.Time `json:"createdAt,omitempty"`
-	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
-	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	ID             int32          `json:"id"`
+	Username       string         `json:"username"`
+	Email          string         `json:"email"`
+	FullName       string         `json:"fullName"`
+	Bio            *string        `json:"bio"`
+	Locale         *string        `json:"locale"`
+	Timezone       *string        `json:"timezone"`
+	AvatarURL      *string        `json:"avatarUrl"`
+	Attributes     map[string]any `json:"attributes"`
+	FollowersCount int32          `json:"followersCount"`
+	FollowingCount int32          `json:"followingCount"`
+	CreatedAt      *time.Time     `json:"createdAt,omitempty"`
+	UpdatedAt      *time.Time     `json:"updatedAt,omitempty"`
+	DeletedAt      *time.Time     `json:"deletedAt,omitempty"`
+}
+
+func NewUserResponse(user dbCtx.User) UserResponse {
+	var createdAt *time.Time
+	if user.CreatedAt.Valid {
+		createdAt = &user.CreatedAt.Time
+	}
+
+	attributes := map[string]any{}
+	if len(user.Attributes) > 0 {
+		_ = json.Unmarshal(user.Attributes, &attributes)
+	}
+
+	return UserResponse{
+		ID:             user.ID,
+		Username:       user.Username,
+		Email:          user.Email,
+		FullName:       user.FullName,
+		Bio:            nullString(user.Bio.String, user.Bio.Valid),
+		Locale:         nullString(user.Locale.String, user.Locale.Valid),
+		Timezone:       nullString(user.Timezone.String, user.Timezone.Valid),
+		AvatarURL:      nullString(user.AvatarUrl.String, user.AvatarUrl.Valid),
+		Attributes:     attributes,
+		FollowersCount: user.FollowersCount,
+		FollowingCount: user.FollowingCount,
+		CreatedAt:      createdAt,
+	}
+}
+
+func nullString(s string, valid bool) *string {
+	if !valid {
+		return nil
+	}
+	return &s
 }
@@ -0,0 +1,5 @@
+package dto
+
+type EraseAccountReq struct {
+	Password string `json:"password" binding:"required"`
+}
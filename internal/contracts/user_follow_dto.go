@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FollowUserResponse is a user in a followers, following or contacts list.
+// FollowedAt is when the follow that put them on the list was made.
+type FollowUserResponse struct {
+	ID         int32     `json:"id"`
+	Username   string    `json:"username"`
+	FullName   string    `json:"fullName"`
+	AvatarURL  *string   `json:"avatarUrl"`
+	FollowedAt time.Time `json:"followedAt"`
+}
+
+func NewFollowUserResponse(id int32, username, fullName string, avatarURL sql.NullString, followedAt time.Time) FollowUserResponse {
+	return FollowUserResponse{
+		ID:         id,
+		Username:   username,
+		FullName:   fullName,
+		AvatarURL:  nullString(avatarURL.String, avatarURL.Valid),
+		FollowedAt: followedAt,
+	}
+}
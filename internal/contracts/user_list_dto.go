@@ -4,3 +4,22 @@ type ListUsersParams struct {
 	Limit  int32 `binding:"required,min=1,max=100"`
 	Offset int32 `binding:"min=0"`
 }
+
+// MaxBatchGetUsers caps how many users one batch lookup may ask for.
+const MaxBatchGetUsers = 100
+
+type BatchGetUsersReq struct {
+	IDs []int32 `json:"ids" binding:"required,min=1,max=100,dive,min=1"`
+}
+
+// BatchGetUsersResponse lists the users in the requested order. Missing
+// holds the requested IDs that matched no user, including deleted ones.
+type BatchGetUsersResponse struct {
+	Users   []UserResponse `json:"users"`
+	Missing []int32        `json:"missing"`
+}
+
+type FollowListParams struct {
+	Limit  int32 `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int32 `form:"offset" binding:"min=0"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// UserPatchDocument is the JSON representation of a user that PATCH requests
+// are applied to. Pointer fields let a patch set them to null; password is
+// write-only and is always rendered as null.
+type UserPatchDocument struct {
+	ID         int32          `json:"id"`
+	Username   *string        `json:"username" binding:"required,min=3,max=50"`
+	Email      *string        `json:"email" binding:"required,email"`
+	FullName   *string        `json:"fullName" binding:"omitempty,max=100"`
+	Password   *string        `json:"password" binding:"omitempty,min=6"`
+	Bio        *string        `json:"bio" binding:"omitempty,max=500"`
+	Locale     *string        `json:"locale" binding:"omitempty,bcp47_language_tag"`
+	Timezone   *string        `json:"timezone" binding:"omitempty,timezone"`
+	AvatarURL  *string        `json:"avatarUrl" binding:"omitempty,url,max=2048"`
+	Attributes map[string]any `json:"attributes" binding:"user_attributes"`
+	CreatedAt  *time.Time     `json:"createdAt"`
+	UpdatedAt  *time.Time     `json:"updatedAt"`
+}
+
+// UserPatchReadOnlyFields lists the document fields a patch may not change.
+var UserPatchReadOnlyFields = []string{"id", "createdAt", "updatedAt"}
@@ -14,4 +14,10 @@ type UpdateUserPartialReq struct {
 	Email    *string `json:"email,omitempty" binding:"omitempty,email"`
 	FullName *string `json:"fullName,omitempty" binding:"omitempty,max=100"`
 	Password *string `json:"password,omitempty" binding:"omitempty,min=6"`
+
+	Bio        Nullable[string] `json:"-"`
+	Locale     Nullable[string] `json:"-"`
+	Timezone   Nullable[string] `json:"-"`
+	AvatarURL  Nullable[string] `json:"-"`
+	Attributes map[string]any   `json:"-"`
 }
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// CreateWebhookReq subscribes url to the given event types. A secret is
+// generated when none is given; it is only ever returned on creation and
+// rotation.
+type CreateWebhookReq struct {
+	URL        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1,dive,oneof=user.created user.updated user.deleted message.sent"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16,max=256"`
+	Active     *bool    `json:"active"`
+}
+
+// UpdateWebhookReq changes the fields that are set. RotateSecret replaces
+// the secret with a generated one.
+type UpdateWebhookReq struct {
+	URL          *string  `json:"url" binding:"omitempty,url"`
+	EventTypes   []string `json:"eventTypes" binding:"omitempty,min=1,dive,oneof=user.created user.updated user.deleted message.sent"`
+	Secret       *string  `json:"secret" binding:"omitempty,min=16,max=256"`
+	RotateSecret bool     `json:"rotateSecret"`
+	Active       *bool    `json:"active"`
+}
+
+type WebhookResponse struct {
+	ID         int32     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"eventTypes"`
+	Active     bool      `json:"active"`
+	Secret     string    `json:"secret,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// NewWebhookResponse leaves the secret out; callers that just set it add it
+// themselves.
+func NewWebhookResponse(sub dbCtx.WebhookSubscription) WebhookResponse {
+	return WebhookResponse{
+		ID:         sub.ID,
+		URL:        sub.Url,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
+
+type WebhookDeliveryFilter struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending delivered dead"`
+	Limit  int32  `form:"limit,default=50" binding:"min=1,max=100"`
+	Offset int32  `form:"offset" binding:"min=0"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EventID        int64           `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Status         string          `json:"status"`
+	Attempts       int32           `json:"attempts"`
+	LastStatusCode int32           `json:"lastStatusCode"`
+	LastError      string          `json:"lastError"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+func NewWebhookDeliveryResponse(d dbCtx.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt,
+		Payload:        d.Payload,
+	}
+	if d.Status == "pending" {
+		resp.NextAttemptAt = &d.NextAttemptAt
+	}
+	if d.DeliveredAt.Valid {
+		resp.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return resp
+}
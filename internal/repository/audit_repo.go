@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"database/sql"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IAuditRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateAuditEventParams) (dbCtx.AuditEvent, error)
+	List(ctx Ctx, arg dbCtx.ListAuditEventsParams) ([]dbCtx.AuditEvent, error)
+	RedactForUser(ctx Ctx, userID sql.NullInt32) (int64, error)
+	RedactFailedLogins(ctx Ctx, email string) (int64, error)
+}
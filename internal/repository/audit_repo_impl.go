@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"database/sql"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type AuditRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewAuditRepo(db dbCtx.DBTX) IAuditRepo {
+	return &AuditRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *AuditRepo) Create(ctx Ctx, arg dbCtx.CreateAuditEventParams) (dbCtx.AuditEvent, error) {
+	return r.q.CreateAuditEvent(ctx, arg)
+}
+
+func (r *AuditRepo) List(ctx Ctx, arg dbCtx.ListAuditEventsParams) ([]dbCtx.AuditEvent, error) {
+	return r.q.ListAuditEvents(ctx, arg)
+}
+
+func (r *AuditRepo) RedactForUser(ctx Ctx, userID sql.NullInt32) (int64, error) {
+	return r.q.RedactAuditEventsForUser(ctx, userID)
+}
+
+func (r *AuditRepo) RedactFailedLogins(ctx Ctx, email string) (int64, error) {
+	return r.q.RedactFailedLoginsForEmail(ctx, email)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IBlockRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateUserBlockParams) (int64, error)
+	Delete(ctx Ctx, arg dbCtx.DeleteUserBlockParams) (int64, error)
+	List(ctx Ctx, blockerID int32) ([]dbCtx.ListUserBlocksRow, error)
+	// ListBlockerIDs returns the users who have blocked blockedID.
+	ListBlockerIDs(ctx Ctx, blockedID int32) ([]int32, error)
+	DeleteByUser(ctx Ctx, userID int32) error
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type BlockRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewBlockRepo(db dbCtx.DBTX) IBlockRepo {
+	return &BlockRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *BlockRepo) Create(ctx Ctx, arg dbCtx.CreateUserBlockParams) (int64, error) {
+	return r.q.CreateUserBlock(ctx, arg)
+}
+
+func (r *BlockRepo) Delete(ctx Ctx, arg dbCtx.DeleteUserBlockParams) (int64, error) {
+	return r.q.DeleteUserBlock(ctx, arg)
+}
+
+func (r *BlockRepo) List(ctx Ctx, blockerID int32) ([]dbCtx.ListUserBlocksRow, error) {
+	return r.q.ListUserBlocks(ctx, blockerID)
+}
+
+func (r *BlockRepo) ListBlockerIDs(ctx Ctx, blockedID int32) ([]int32, error) {
+	return r.q.ListBlockerIDs(ctx, blockedID)
+}
+
+func (r *BlockRepo) DeleteByUser(ctx Ctx, userID int32) error {
+	return r.q.DeleteUserBlocksByUser(ctx, userID)
+}
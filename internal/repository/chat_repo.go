@@ -9,4 +9,57 @@ import (
 type IChatRepo interface {
 	CreateMessage(ctx context.Context, params dbCtx.CreateMessageParams) (dbCtx.Message, error)
 	GetMessages(ctx context.Context, params dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error)
+	GetMessagesBySender(ctx context.Context, senderID int32) ([]dbCtx.Message, error)
+	ListMessagesSince(ctx context.Context, params dbCtx.ListMessagesSinceParams) ([]dbCtx.ListMessagesSinceRow, error)
+	ReassignSender(ctx context.Context, params dbCtx.ReassignMessagesSenderParams) (int64, error)
+	ReassignRecipient(ctx context.Context, params dbCtx.ReassignMessagesRecipientParams) (int64, error)
+	// GetMessageForUpdate locks the message until the transaction ends.
+	GetMessageForUpdate(ctx context.Context, params dbCtx.GetMessageForUpdateParams) (dbCtx.Message, error)
+	EditMessage(ctx context.Context, params dbCtx.EditMessageParams) (dbCtx.Message, error)
+	DeleteMessage(ctx context.Context, id int32) (dbCtx.Message, error)
+	CreateRevision(ctx context.Context, params dbCtx.CreateMessageRevisionParams) error
+	GetMessage(ctx context.Context, params dbCtx.GetMessageParams) (dbCtx.Message, error)
+
+	GetThread(ctx context.Context, params dbCtx.GetThreadParams) ([]dbCtx.GetThreadRow, error)
+	CountReplies(ctx context.Context, parentID int32) (int64, error)
+
+	// AddReaction is a no-op, returning 0, when the user already reacted to
+	// the message with the emoji.
+	AddReaction(ctx context.Context, params dbCtx.AddMessageReactionParams) (int64, error)
+	DeleteReaction(ctx context.Context, params dbCtx.DeleteMessageReactionParams) (int64, error)
+	CountReactions(ctx context.Context, params dbCtx.CountMessageReactionsParams) (int64, error)
+	ListReactions(ctx context.Context, messageID int32) ([]dbCtx.ListMessageReactionsRow, error)
+	DeleteReactionsByUser(ctx context.Context, userID int32) error
+	// ListRoomIDsWithUserActivity lists the rooms the user posted or reacted
+	// in.
+	ListRoomIDsWithUserActivity(ctx context.Context, userID int32) ([]int32, error)
+
+	CreateRoom(ctx context.Context, params dbCtx.CreateChatRoomParams) (dbCtx.ChatRoom, error)
+	GetRoom(ctx context.Context, params dbCtx.GetChatRoomParams) (dbCtx.ChatRoom, error)
+	ListRooms(ctx context.Context, params dbCtx.ListChatRoomsParams) ([]dbCtx.ListChatRoomsRow, error)
+	AddRoomMember(ctx context.Context, params dbCtx.AddRoomMemberParams) (int64, error)
+	DeleteRoomMember(ctx context.Context, params dbCtx.DeleteRoomMemberParams) (int64, error)
+	IsRoomMember(ctx context.Context, params dbCtx.IsRoomMemberParams) (bool, error)
+	ListRoomMembers(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error)
+	ListRoomIDsForUser(ctx context.Context, params dbCtx.ListRoomIDsForUserParams) ([]int32, error)
+	// DeleteMembershipsInOrganization takes userID out of every room of the
+	// organization, for when they leave it.
+	DeleteMembershipsInOrganization(ctx context.Context, params dbCtx.DeleteRoomMembershipsInOrganizationParams) error
+	// ListRoomRecipients returns the members a message from the sender is
+	// unread for.
+	ListRoomRecipients(ctx context.Context, params dbCtx.ListRoomRecipientsParams) ([]int32, error)
+	// MarkRoomRead returns the id the read marker points at, or
+	// sql.ErrNoRows when there is nothing to read.
+	MarkRoomRead(ctx context.Context, params dbCtx.MarkRoomReadParams) (int32, error)
+	CountUnreadRooms(ctx context.Context, params dbCtx.CountUnreadRoomsParams) ([]dbCtx.CountUnreadRoomsRow, error)
+	DeleteRoomReadsByUser(ctx context.Context, userID int32) error
+
+	CreateDirectMessage(ctx context.Context, params dbCtx.CreateDirectMessageParams) (dbCtx.Message, error)
+	GetDirectMessages(ctx context.Context, params dbCtx.GetDirectMessagesParams) ([]dbCtx.GetDirectMessagesRow, error)
+	ListConversations(ctx context.Context, params dbCtx.ListConversationsParams) ([]dbCtx.ListConversationsRow, error)
+	// MarkConversationRead returns the id the read marker points at, or
+	// sql.ErrNoRows when there is nothing to read.
+	MarkConversationRead(ctx context.Context, params dbCtx.MarkConversationReadParams) (int32, error)
+	CountUnreadConversations(ctx context.Context, params dbCtx.CountUnreadConversationsParams) ([]dbCtx.CountUnreadConversationsRow, error)
+	DeleteDirectReadsByUser(ctx context.Context, userID int32) error
 }
@@ -21,3 +21,147 @@ func (r *ChatRepository) CreateMessage(ctx context.Context, params dbCtx.CreateM
 func (r *ChatRepository) GetMessages(ctx context.Context, params dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error) {
 	return r.q.GetMessages(ctx, params)
 }
+
+func (r *ChatRepository) GetMessagesBySender(ctx context.Context, senderID int32) ([]dbCtx.Message, error) {
+	return r.q.GetMessagesBySender(ctx, senderID)
+}
+
+func (r *ChatRepository) ListMessagesSince(ctx context.Context, params dbCtx.ListMessagesSinceParams) ([]dbCtx.ListMessagesSinceRow, error) {
+	return r.q.ListMessagesSince(ctx, params)
+}
+
+func (r *ChatRepository) ReassignSender(ctx context.Context, params dbCtx.ReassignMessagesSenderParams) (int64, error) {
+	return r.q.ReassignMessagesSender(ctx, params)
+}
+
+func (r *ChatRepository) ReassignRecipient(ctx context.Context, params dbCtx.ReassignMessagesRecipientParams) (int64, error) {
+	return r.q.ReassignMessagesRecipient(ctx, params)
+}
+
+func (r *ChatRepository) GetMessageForUpdate(ctx context.Context, params dbCtx.GetMessageForUpdateParams) (dbCtx.Message, error) {
+	return r.q.GetMessageForUpdate(ctx, params)
+}
+
+func (r *ChatRepository) EditMessage(ctx context.Context, params dbCtx.EditMessageParams) (dbCtx.Message, error) {
+	return r.q.EditMessage(ctx, params)
+}
+
+func (r *ChatRepository) DeleteMessage(ctx context.Context, id int32) (dbCtx.Message, error) {
+	return r.q.DeleteMessage(ctx, id)
+}
+
+func (r *ChatRepository) CreateRevision(ctx context.Context, params dbCtx.CreateMessageRevisionParams) error {
+	return r.q.CreateMessageRevision(ctx, params)
+}
+
+func (r *ChatRepository) GetMessage(ctx context.Context, params dbCtx.GetMessageParams) (dbCtx.Message, error) {
+	return r.q.GetMessage(ctx, params)
+}
+
+func (r *ChatRepository) GetThread(ctx context.Context, params dbCtx.GetThreadParams) ([]dbCtx.GetThreadRow, error) {
+	return r.q.GetThread(ctx, params)
+}
+
+func (r *ChatRepository) CountReplies(ctx context.Context, parentID int32) (int64, error) {
+	return r.q.CountReplies(ctx, parentID)
+}
+
+func (r *ChatRepository) AddReaction(ctx context.Context, params dbCtx.AddMessageReactionParams) (int64, error) {
+	return r.q.AddMessageReaction(ctx, params)
+}
+
+func (r *ChatRepository) DeleteReaction(ctx context.Context, params dbCtx.DeleteMessageReactionParams) (int64, error) {
+	return r.q.DeleteMessageReaction(ctx, params)
+}
+
+func (r *ChatRepository) CountReactions(ctx context.Context, params dbCtx.CountMessageReactionsParams) (int64, error) {
+	return r.q.CountMessageReactions(ctx, params)
+}
+
+func (r *ChatRepository) ListReactions(ctx context.Context, messageID int32) ([]dbCtx.ListMessageReactionsRow, error) {
+	return r.q.ListMessageReactions(ctx, messageID)
+}
+
+func (r *ChatRepository) DeleteReactionsByUser(ctx context.Context, userID int32) error {
+	return r.q.DeleteMessageReactionsByUser(ctx, userID)
+}
+
+func (r *ChatRepository) ListRoomIDsWithUserActivity(ctx context.Context, userID int32) ([]int32, error) {
+	return r.q.ListRoomIDsWithUserActivity(ctx, userID)
+}
+
+func (r *ChatRepository) CreateRoom(ctx context.Context, params dbCtx.CreateChatRoomParams) (dbCtx.ChatRoom, error) {
+	return r.q.CreateChatRoom(ctx, params)
+}
+
+func (r *ChatRepository) GetRoom(ctx context.Context, params dbCtx.GetChatRoomParams) (dbCtx.ChatRoom, error) {
+	return r.q.GetChatRoom(ctx, params)
+}
+
+func (r *ChatRepository) ListRooms(ctx context.Context, params dbCtx.ListChatRoomsParams) ([]dbCtx.ListChatRoomsRow, error) {
+	return r.q.ListChatRooms(ctx, params)
+}
+
+func (r *ChatRepository) AddRoomMember(ctx context.Context, params dbCtx.AddRoomMemberParams) (int64, error) {
+	return r.q.AddRoomMember(ctx, params)
+}
+
+func (r *ChatRepository) DeleteRoomMember(ctx context.Context, params dbCtx.DeleteRoomMemberParams) (int64, error) {
+	return r.q.DeleteRoomMember(ctx, params)
+}
+
+func (r *ChatRepository) IsRoomMember(ctx context.Context, params dbCtx.IsRoomMemberParams) (bool, error) {
+	return r.q.IsRoomMember(ctx, params)
+}
+
+func (r *ChatRepository) ListRoomMembers(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error) {
+	return r.q.ListRoomMembers(ctx, roomID)
+}
+
+func (r *ChatRepository) ListRoomIDsForUser(ctx context.Context, params dbCtx.ListRoomIDsForUserParams) ([]int32, error) {
+	return r.q.ListRoomIDsForUser(ctx, params)
+}
+
+func (r *ChatRepository) DeleteMembershipsInOrganization(ctx context.Context, params dbCtx.DeleteRoomMembershipsInOrganizationParams) error {
+	return r.q.DeleteRoomMembershipsInOrganization(ctx, params)
+}
+
+func (r *ChatRepository) ListRoomRecipients(ctx context.Context, params dbCtx.ListRoomRecipientsParams) ([]int32, error) {
+	return r.q.ListRoomRecipients(ctx, params)
+}
+
+func (r *ChatRepository) MarkRoomRead(ctx context.Context, params dbCtx.MarkRoomReadParams) (int32, error) {
+	return r.q.MarkRoomRead(ctx, params)
+}
+
+func (r *ChatRepository) CountUnreadRooms(ctx context.Context, params dbCtx.CountUnreadRoomsParams) ([]dbCtx.CountUnreadRoomsRow, error) {
+	return r.q.CountUnreadRooms(ctx, params)
+}
+
+func (r *ChatRepository) DeleteRoomReadsByUser(ctx context.Context, userID int32) error {
+	return r.q.DeleteRoomReadsByUser(ctx, userID)
+}
+
+func (r *ChatRepository) CreateDirectMessage(ctx context.Context, params dbCtx.CreateDirectMessageParams) (dbCtx.Message, error) {
+	return r.q.CreateDirectMessage(ctx, params)
+}
+
+func (r *ChatRepository) GetDirectMessages(ctx context.Context, params dbCtx.GetDirectMessagesParams) ([]dbCtx.GetDirectMessagesRow, error) {
+	return r.q.GetDirectMessages(ctx, params)
+}
+
+func (r *ChatRepository) ListConversations(ctx context.Context, params dbCtx.ListConversationsParams) ([]dbCtx.ListConversationsRow, error) {
+	return r.q.ListConversations(ctx, params)
+}
+
+func (r *ChatRepository) MarkConversationRead(ctx context.Context, params dbCtx.MarkConversationReadParams) (int32, error) {
+	return r.q.MarkConversationRead(ctx, params)
+}
+
+func (r *ChatRepository) CountUnreadConversations(ctx context.Context, params dbCtx.CountUnreadConversationsParams) ([]dbCtx.CountUnreadConversationsRow, error) {
+	return r.q.CountUnreadConversations(ctx, params)
+}
+
+func (r *ChatRepository) DeleteDirectReadsByUser(ctx context.Context, userID int32) error {
+	return r.q.DeleteDirectReadsByUser(ctx, userID)
+}
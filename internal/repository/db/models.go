@@ -6,13 +6,138 @@ package dbCtx
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
+type AuditEvent struct {
+	ID         int64           `db:"id" json:"id"`
+	ActorID    sql.NullInt32   `db:"actor_id" json:"actorId"`
+	Action     string          `db:"action" json:"action"`
+	TargetType string          `db:"target_type" json:"targetType"`
+	TargetID   sql.NullInt32   `db:"target_id" json:"targetId"`
+	Before     json.RawMessage `db:"before" json:"before"`
+	After      json.RawMessage `db:"after" json:"after"`
+	Metadata   json.RawMessage `db:"metadata" json:"metadata"`
+	Ip         string          `db:"ip" json:"ip"`
+	RequestID  string          `db:"request_id" json:"requestId"`
+	CreatedAt  time.Time       `db:"created_at" json:"createdAt"`
+}
+
+type ChatRoom struct {
+	ID             int32         `db:"id" json:"id"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	Name           string        `db:"name" json:"name"`
+	CreatedBy      sql.NullInt32 `db:"created_by" json:"createdBy"`
+	CreatedAt      time.Time     `db:"created_at" json:"createdAt"`
+}
+
+type DirectRead struct {
+	OrganizationID int32     `db:"organization_id" json:"organizationId"`
+	UserID         int32     `db:"user_id" json:"userId"`
+	PeerID         int32     `db:"peer_id" json:"peerId"`
+	LastReadID     int32     `db:"last_read_id" json:"lastReadId"`
+	ReadAt         time.Time `db:"read_at" json:"readAt"`
+}
+
+type EmailChange struct {
+	ID               int32        `db:"id" json:"id"`
+	UserID           int32        `db:"user_id" json:"userId"`
+	OldEmail         string       `db:"old_email" json:"oldEmail"`
+	NewEmail         string       `db:"new_email" json:"newEmail"`
+	ConfirmTokenHash string       `db:"confirm_token_hash" json:"confirmTokenHash"`
+	CancelTokenHash  string       `db:"cancel_token_hash" json:"cancelTokenHash"`
+	ConfirmExpiresAt time.Time    `db:"confirm_expires_at" json:"confirmExpiresAt"`
+	CancelExpiresAt  time.Time    `db:"cancel_expires_at" json:"cancelExpiresAt"`
+	CreatedAt        sql.NullTime `db:"created_at" json:"createdAt"`
+	ConfirmedAt      sql.NullTime `db:"confirmed_at" json:"confirmedAt"`
+	CancelledAt      sql.NullTime `db:"cancelled_at" json:"cancelledAt"`
+}
+
+type Invitation struct {
+	ID             int32         `db:"id" json:"id"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	Email          string        `db:"email" json:"email"`
+	Role           string        `db:"role" json:"role"`
+	TokenHash      string        `db:"token_hash" json:"tokenHash"`
+	InvitedBy      sql.NullInt32 `db:"invited_by" json:"invitedBy"`
+	ExpiresAt      time.Time     `db:"expires_at" json:"expiresAt"`
+	CreatedAt      time.Time     `db:"created_at" json:"createdAt"`
+	ConsumedAt     sql.NullTime  `db:"consumed_at" json:"consumedAt"`
+	ConsumedBy     sql.NullInt32 `db:"consumed_by" json:"consumedBy"`
+	RevokedAt      sql.NullTime  `db:"revoked_at" json:"revokedAt"`
+}
+
 type Message struct {
-	ID        int32        `db:"id" json:"id"`
-	SenderID  int32        `db:"sender_id" json:"senderId"`
-	Content   string       `db:"content" json:"content"`
-	CreatedAt sql.NullTime `db:"created_at" json:"createdAt"`
+	ID             int32         `db:"id" json:"id"`
+	SenderID       int32         `db:"sender_id" json:"senderId"`
+	Content        string        `db:"content" json:"content"`
+	CreatedAt      sql.NullTime  `db:"created_at" json:"createdAt"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	RoomID         sql.NullInt32 `db:"room_id" json:"roomId"`
+	RecipientID    sql.NullInt32 `db:"recipient_id" json:"recipientId"`
+	EditedAt       sql.NullTime  `db:"edited_at" json:"editedAt"`
+	DeletedAt      sql.NullTime  `db:"deleted_at" json:"deletedAt"`
+	ParentID       sql.NullInt32 `db:"parent_id" json:"parentId"`
+}
+
+type MessageReaction struct {
+	MessageID      int32     `db:"message_id" json:"messageId"`
+	UserID         int32     `db:"user_id" json:"userId"`
+	OrganizationID int32     `db:"organization_id" json:"organizationId"`
+	Emoji          string    `db:"emoji" json:"emoji"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+}
+
+type MessageRevision struct {
+	ID             int32         `db:"id" json:"id"`
+	MessageID      int32         `db:"message_id" json:"messageId"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	Content        string        `db:"content" json:"content"`
+	RevisedBy      sql.NullInt32 `db:"revised_by" json:"revisedBy"`
+	RevisedAt      time.Time     `db:"revised_at" json:"revisedAt"`
+}
+
+type Organization struct {
+	ID        int32     `db:"id" json:"id"`
+	Slug      string    `db:"slug" json:"slug"`
+	Name      string    `db:"name" json:"name"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+type OrganizationMember struct {
+	OrganizationID int32     `db:"organization_id" json:"organizationId"`
+	UserID         int32     `db:"user_id" json:"userId"`
+	Role           string    `db:"role" json:"role"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+}
+
+type OutboxEvent struct {
+	ID            int64           `db:"id" json:"id"`
+	EventType     string          `db:"event_type" json:"eventType"`
+	AggregateType string          `db:"aggregate_type" json:"aggregateType"`
+	AggregateID   int32           `db:"aggregate_id" json:"aggregateId"`
+	Payload       json.RawMessage `db:"payload" json:"payload"`
+	Attempts      int32           `db:"attempts" json:"attempts"`
+	LastError     string          `db:"last_error" json:"lastError"`
+	NextAttemptAt time.Time       `db:"next_attempt_at" json:"nextAttemptAt"`
+	DeliveredAt   sql.NullTime    `db:"delivered_at" json:"deliveredAt"`
+	CreatedAt     time.Time       `db:"created_at" json:"createdAt"`
+}
+
+type RoomMember struct {
+	RoomID   int32     `db:"room_id" json:"roomId"`
+	UserID   int32     `db:"user_id" json:"userId"`
+	JoinedAt time.Time `db:"joined_at" json:"joinedAt"`
+}
+
+type RoomRead struct {
+	RoomID         int32     `db:"room_id" json:"roomId"`
+	UserID         int32     `db:"user_id" json:"userId"`
+	OrganizationID int32     `db:"organization_id" json:"organizationId"`
+	LastReadID     int32     `db:"last_read_id" json:"lastReadId"`
+	ReadAt         time.Time `db:"read_at" json:"readAt"`
 }
 
 type SchemaMigration struct {
@@ -20,12 +145,64 @@ type SchemaMigration struct {
 }
 
 type User struct {
-	ID           int32        `db:"id" json:"id"`
-	Username     string       `db:"username" json:"username"`
-	Email        string       `db:"email" json:"email"`
-	FullName     string       `db:"full_name" json:"fullName"`
-	PasswordHash string       `db:"password_hash" json:"passwordHash"`
-	CreatedAt    sql.NullTime `db:"created_at" json:"createdAt"`
-	UpdatedAt    sql.NullTime `db:"updated_at" json:"updatedAt"`
-	DeletedAt    sql.NullTime `db:"deleted_at" json:"deletedAt"`
+	ID             int32           `db:"id" json:"id"`
+	Username       string          `db:"username" json:"username"`
+	Email          string          `db:"email" json:"email"`
+	FullName       string          `db:"full_name" json:"fullName"`
+	PasswordHash   string          `db:"password_hash" json:"passwordHash"`
+	CreatedAt      sql.NullTime    `db:"created_at" json:"createdAt"`
+	UpdatedAt      sql.NullTime    `db:"updated_at" json:"updatedAt"`
+	DeletedAt      sql.NullTime    `db:"deleted_at" json:"deletedAt"`
+	Bio            sql.NullString  `db:"bio" json:"bio"`
+	Locale         sql.NullString  `db:"locale" json:"locale"`
+	Timezone       sql.NullString  `db:"timezone" json:"timezone"`
+	AvatarUrl      sql.NullString  `db:"avatar_url" json:"avatarUrl"`
+	Attributes     json.RawMessage `db:"attributes" json:"attributes"`
+	FollowersCount int32           `db:"followers_count" json:"followersCount"`
+	FollowingCount int32           `db:"following_count" json:"followingCount"`
+}
+
+type UserBlock struct {
+	BlockerID int32     `db:"blocker_id" json:"blockerId"`
+	BlockedID int32     `db:"blocked_id" json:"blockedId"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+type UserErasure struct {
+	ID                 int32         `db:"id" json:"id"`
+	UserID             int32         `db:"user_id" json:"userId"`
+	RequestedBy        sql.NullInt32 `db:"requested_by" json:"requestedBy"`
+	MessagesAnonymized int32         `db:"messages_anonymized" json:"messagesAnonymized"`
+	ErasedAt           time.Time     `db:"erased_at" json:"erasedAt"`
+}
+
+type UserFollow struct {
+	FollowerID int32     `db:"follower_id" json:"followerId"`
+	FolloweeID int32     `db:"followee_id" json:"followeeId"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `db:"id" json:"id"`
+	SubscriptionID int32           `db:"subscription_id" json:"subscriptionId"`
+	EventID        int64           `db:"event_id" json:"eventId"`
+	EventType      string          `db:"event_type" json:"eventType"`
+	Payload        json.RawMessage `db:"payload" json:"payload"`
+	Status         string          `db:"status" json:"status"`
+	Attempts       int32           `db:"attempts" json:"attempts"`
+	LastStatusCode int32           `db:"last_status_code" json:"lastStatusCode"`
+	LastError      string          `db:"last_error" json:"lastError"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at" json:"nextAttemptAt"`
+	DeliveredAt    sql.NullTime    `db:"delivered_at" json:"deliveredAt"`
+	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
+}
+
+type WebhookSubscription struct {
+	ID         int32     `db:"id" json:"id"`
+	Url        string    `db:"url" json:"url"`
+	EventTypes []string  `db:"event_types" json:"eventTypes"`
+	Secret     string    `db:"secret" json:"secret"`
+	Active     bool      `db:"active" json:"active"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updatedAt"`
 }
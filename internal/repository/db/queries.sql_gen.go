@@ -8,221 +8,3404 @@ package dbCtx
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const addMessageReaction = `-- name: AddMessageReaction :execrows
+INSERT INTO message_reactions (message_id, user_id, organization_id, emoji)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING
+`
+
+type AddMessageReactionParams struct {
+	MessageID      int32  `db:"message_id" json:"messageId"`
+	UserID         int32  `db:"user_id" json:"userId"`
+	OrganizationID int32  `db:"organization_id" json:"organizationId"`
+	Emoji          string `db:"emoji" json:"emoji"`
+}
+
+func (q *Queries) AddMessageReaction(ctx context.Context, arg AddMessageReactionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addMessageReaction,
+		arg.MessageID,
+		arg.UserID,
+		arg.OrganizationID,
+		arg.Emoji,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const addOrganizationMember = `-- name: AddOrganizationMember :execrows
+INSERT INTO organization_members (organization_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddOrganizationMemberParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+}
+
+func (q *Queries) AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addOrganizationMember, arg.OrganizationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const addRoomMember = `-- name: AddRoomMember :execrows
+INSERT INTO room_members (room_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddRoomMemberParams struct {
+	RoomID int32 `db:"room_id" json:"roomId"`
+	UserID int32 `db:"user_id" json:"userId"`
+}
+
+func (q *Queries) AddRoomMember(ctx context.Context, arg AddRoomMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, addRoomMember, arg.RoomID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const anonymizeUser = `-- name: AnonymizeUser :one
+UPDATE users
+SET username = 'erased-' || id,
+    email = 'erased-' || id || '@erased.invalid',
+    full_name = '',
+    password_hash = '',
+    bio = NULL,
+    locale = NULL,
+    timezone = NULL,
+    avatar_url = NULL,
+    attributes = '{}'::jsonb,
+    deleted_at = COALESCE(deleted_at, CURRENT_TIMESTAMP),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count
+`
+
+func (q *Queries) AnonymizeUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, anonymizeUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
+const cancelPendingEmailChanges = `-- name: CancelPendingEmailChanges :exec
+UPDATE email_changes
+SET cancelled_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL
+`
+
+func (q *Queries) CancelPendingEmailChanges(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, cancelPendingEmailChanges, userID)
+	return err
+}
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+UPDATE outbox_events
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $1::integer),
+    attempts = attempts + 1
+WHERE id IN (
+    SELECT id FROM outbox_events
+    WHERE delivered_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP
+    ORDER BY id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, event_type, aggregate_type, aggregate_id, payload, attempts, last_error, next_attempt_at, delivered_at, created_at
+`
+
+type ClaimOutboxEventsParams struct {
+	LeaseSeconds int32 `db:"lease_seconds" json:"leaseSeconds"`
+	BatchSize    int32 `db:"batch_size" json:"batchSize"`
+}
+
+// Claims due events by pushing next_attempt_at past the lease, so other
+// relays skip them while they are delivered.
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, arg ClaimOutboxEventsParams) ([]OutboxEvent, error) {
+	rows, err := q.db.QueryContext(ctx, claimOutboxEvents, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OutboxEvent
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventType,
+			&i.AggregateType,
+			&i.AggregateID,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimWebhookDeliveries = `-- name: ClaimWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $1::integer),
+    attempts = d.attempts + 1
+FROM webhook_subscriptions s
+WHERE s.id = d.subscription_id AND d.id IN (
+    SELECT wd.id FROM webhook_deliveries wd
+    JOIN webhook_subscriptions ws ON ws.id = wd.subscription_id
+    WHERE wd.status = 'pending' AND wd.next_attempt_at <= CURRENT_TIMESTAMP AND ws.active
+    ORDER BY wd.id
+    LIMIT $2
+    FOR UPDATE OF wd SKIP LOCKED
+)
+RETURNING d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.last_status_code, d.last_error, d.next_attempt_at, d.delivered_at, d.created_at, s.url, s.secret
+`
+
+type ClaimWebhookDeliveriesParams struct {
+	LeaseSeconds int32 `db:"lease_seconds" json:"leaseSeconds"`
+	BatchSize    int32 `db:"batch_size" json:"batchSize"`
+}
+
+type ClaimWebhookDeliveriesRow struct {
+	ID             int64           `db:"id" json:"id"`
+	SubscriptionID int32           `db:"subscription_id" json:"subscriptionId"`
+	EventID        int64           `db:"event_id" json:"eventId"`
+	EventType      string          `db:"event_type" json:"eventType"`
+	Payload        json.RawMessage `db:"payload" json:"payload"`
+	Status         string          `db:"status" json:"status"`
+	Attempts       int32           `db:"attempts" json:"attempts"`
+	LastStatusCode int32           `db:"last_status_code" json:"lastStatusCode"`
+	LastError      string          `db:"last_error" json:"lastError"`
+	NextAttemptAt  time.Time       `db:"next_attempt_at" json:"nextAttemptAt"`
+	DeliveredAt    sql.NullTime    `db:"delivered_at" json:"deliveredAt"`
+	CreatedAt      time.Time       `db:"created_at" json:"createdAt"`
+	Url            string          `db:"url" json:"url"`
+	Secret         string          `db:"secret" json:"secret"`
+}
+
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, claimWebhookDeliveries, arg.LeaseSeconds, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const consumeInvitation = `-- name: ConsumeInvitation :execrows
+UPDATE invitations
+SET consumed_at = CURRENT_TIMESTAMP, consumed_by = $2
+WHERE id = $1 AND consumed_at IS NULL AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+`
+
+type ConsumeInvitationParams struct {
+	ID         int32         `db:"id" json:"id"`
+	ConsumedBy sql.NullInt32 `db:"consumed_by" json:"consumedBy"`
+}
+
+func (q *Queries) ConsumeInvitation(ctx context.Context, arg ConsumeInvitationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, consumeInvitation, arg.ID, arg.ConsumedBy)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countMessageReactions = `-- name: CountMessageReactions :one
+SELECT count(*) FROM message_reactions
+WHERE message_id = $1 AND emoji = $2
+`
+
+type CountMessageReactionsParams struct {
+	MessageID int32  `db:"message_id" json:"messageId"`
+	Emoji     string `db:"emoji" json:"emoji"`
+}
+
+func (q *Queries) CountMessageReactions(ctx context.Context, arg CountMessageReactionsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMessageReactions, arg.MessageID, arg.Emoji)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countOrganizationOwners = `-- name: CountOrganizationOwners :one
+SELECT count(*) FROM organization_members
+WHERE organization_id = $1 AND role = 'owner'
+`
+
+func (q *Queries) CountOrganizationOwners(ctx context.Context, organizationID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOrganizationOwners, organizationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPendingOutboxEvents = `-- name: CountPendingOutboxEvents :one
+SELECT count(*) FROM outbox_events
+WHERE delivered_at IS NULL
+`
+
+func (q *Queries) CountPendingOutboxEvents(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPendingOutboxEvents)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countReplies = `-- name: CountReplies :one
+SELECT count(*) FROM messages
+WHERE parent_id = $1::integer
+`
+
+func (q *Queries) CountReplies(ctx context.Context, parentID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countReplies, parentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUnreadConversations = `-- name: CountUnreadConversations :many
+SELECT m.sender_id AS peer_id, COUNT(*) AS unread_count
+FROM messages m
+LEFT JOIN direct_reads r ON r.organization_id = m.organization_id
+    AND r.user_id = m.recipient_id AND r.peer_id = m.sender_id
+WHERE m.organization_id = $1 AND m.recipient_id = $2
+  AND m.id > COALESCE(r.last_read_id, 0)
+GROUP BY m.sender_id
+ORDER BY m.sender_id
+`
+
+type CountUnreadConversationsParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+}
+
+type CountUnreadConversationsRow struct {
+	PeerID      int32 `db:"peer_id" json:"peerId"`
+	UnreadCount int64 `db:"unread_count" json:"unreadCount"`
+}
+
+// Counts, for each peer, the direct messages they sent user_id after
+// user_id's read marker. Peers with nothing unread are left out.
+func (q *Queries) CountUnreadConversations(ctx context.Context, arg CountUnreadConversationsParams) ([]CountUnreadConversationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, countUnreadConversations, arg.OrganizationID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountUnreadConversationsRow
+	for rows.Next() {
+		var i CountUnreadConversationsRow
+		if err := rows.Scan(&i.PeerID, &i.UnreadCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countUnreadRooms = `-- name: CountUnreadRooms :many
+SELECT rm.room_id, COUNT(m.id) AS unread_count
+FROM room_members rm
+JOIN chat_rooms r ON r.id = rm.room_id
+LEFT JOIN room_reads rr ON rr.room_id = rm.room_id AND rr.user_id = rm.user_id
+LEFT JOIN messages m ON m.room_id = rm.room_id
+    AND m.id > COALESCE(rr.last_read_id, 0)
+    AND m.sender_id <> rm.user_id
+    AND NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = rm.user_id AND b.blocked_id = m.sender_id
+    )
+WHERE rm.user_id = $1 AND r.organization_id = $2
+GROUP BY rm.room_id
+ORDER BY rm.room_id
+`
+
+type CountUnreadRoomsParams struct {
+	UserID         int32 `db:"user_id" json:"userId"`
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+}
+
+type CountUnreadRoomsRow struct {
+	RoomID      int32 `db:"room_id" json:"roomId"`
+	UnreadCount int64 `db:"unread_count" json:"unreadCount"`
+}
+
+// Counts, for each room user_id has joined, the messages after their read
+// marker, leaving out their own and those of users they blocked.
+func (q *Queries) CountUnreadRooms(ctx context.Context, arg CountUnreadRoomsParams) ([]CountUnreadRoomsRow, error) {
+	rows, err := q.db.QueryContext(ctx, countUnreadRooms, arg.UserID, arg.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountUnreadRoomsRow
+	for rows.Next() {
+		var i CountUnreadRoomsRow
+		if err := rows.Scan(&i.RoomID, &i.UnreadCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createAuditEvent = `-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+    actor_id, action, target_type, target_id,
+    before, after, metadata, ip, request_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, actor_id, action, target_type, target_id, before, after, metadata, ip, request_id, created_at
+`
+
+type CreateAuditEventParams struct {
+	ActorID    sql.NullInt32   `db:"actor_id" json:"actorId"`
+	Action     string          `db:"action" json:"action"`
+	TargetType string          `db:"target_type" json:"targetType"`
+	TargetID   sql.NullInt32   `db:"target_id" json:"targetId"`
+	Before     json.RawMessage `db:"before" json:"before"`
+	After      json.RawMessage `db:"after" json:"after"`
+	Metadata   json.RawMessage `db:"metadata" json:"metadata"`
+	Ip         string          `db:"ip" json:"ip"`
+	RequestID  string          `db:"request_id" json:"requestId"`
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRowContext(ctx, createAuditEvent,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Before,
+		arg.After,
+		arg.Metadata,
+		arg.Ip,
+		arg.RequestID,
+	)
+	var i AuditEvent
+	err := row.Scan(
+		&i.ID,
+		&i.ActorID,
+		&i.Action,
+		&i.TargetType,
+		&i.TargetID,
+		&i.Before,
+		&i.After,
+		&i.Metadata,
+		&i.Ip,
+		&i.RequestID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createChatRoom = `-- name: CreateChatRoom :one
+INSERT INTO chat_rooms (organization_id, name, created_by)
+VALUES ($1, $2, $3)
+RETURNING id, organization_id, name, created_by, created_at
+`
+
+type CreateChatRoomParams struct {
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	Name           string        `db:"name" json:"name"`
+	CreatedBy      sql.NullInt32 `db:"created_by" json:"createdBy"`
+}
+
+func (q *Queries) CreateChatRoom(ctx context.Context, arg CreateChatRoomParams) (ChatRoom, error) {
+	row := q.db.QueryRowContext(ctx, createChatRoom, arg.OrganizationID, arg.Name, arg.CreatedBy)
+	var i ChatRoom
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Name,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createDirectMessage = `-- name: CreateDirectMessage :one
+INSERT INTO messages (sender_id, content, organization_id, recipient_id)
+SELECT $1, $2, om.organization_id, om.user_id
+FROM organization_members om
+WHERE om.organization_id = $3
+  AND om.user_id = $4
+  AND om.user_id <> $1
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = om.user_id AND b.blocked_id = $1
+  )
+RETURNING id, sender_id, content, created_at, organization_id, room_id, recipient_id, edited_at, deleted_at, parent_id
+`
+
+type CreateDirectMessageParams struct {
+	SenderID       int32  `db:"sender_id" json:"senderId"`
+	Content        string `db:"content" json:"content"`
+	OrganizationID int32  `db:"organization_id" json:"organizationId"`
+	RecipientID    int32  `db:"recipient_id" json:"recipientId"`
+}
+
+// Sends a direct message within the organization. Nothing is inserted, and
+// no row returned, unless the recipient is another member of the
+// organization who has not blocked the sender.
+func (q *Queries) CreateDirectMessage(ctx context.Context, arg CreateDirectMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, createDirectMessage,
+		arg.SenderID,
+		arg.Content,
+		arg.OrganizationID,
+		arg.RecipientID,
+	)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.SenderID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.OrganizationID,
+		&i.RoomID,
+		&i.RecipientID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const createEmailChange = `-- name: CreateEmailChange :one
+INSERT INTO email_changes (
+    user_id, old_email, new_email,
+    confirm_token_hash, cancel_token_hash,
+    confirm_expires_at, cancel_expires_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, old_email, new_email, confirm_token_hash, cancel_token_hash, confirm_expires_at, cancel_expires_at, created_at, confirmed_at, cancelled_at
+`
+
+type CreateEmailChangeParams struct {
+	UserID           int32     `db:"user_id" json:"userId"`
+	OldEmail         string    `db:"old_email" json:"oldEmail"`
+	NewEmail         string    `db:"new_email" json:"newEmail"`
+	ConfirmTokenHash string    `db:"confirm_token_hash" json:"confirmTokenHash"`
+	CancelTokenHash  string    `db:"cancel_token_hash" json:"cancelTokenHash"`
+	ConfirmExpiresAt time.Time `db:"confirm_expires_at" json:"confirmExpiresAt"`
+	CancelExpiresAt  time.Time `db:"cancel_expires_at" json:"cancelExpiresAt"`
+}
+
+func (q *Queries) CreateEmailChange(ctx context.Context, arg CreateEmailChangeParams) (EmailChange, error) {
+	row := q.db.QueryRowContext(ctx, createEmailChange,
+		arg.UserID,
+		arg.OldEmail,
+		arg.NewEmail,
+		arg.ConfirmTokenHash,
+		arg.CancelTokenHash,
+		arg.ConfirmExpiresAt,
+		arg.CancelExpiresAt,
+	)
+	var i EmailChange
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OldEmail,
+		&i.NewEmail,
+		&i.ConfirmTokenHash,
+		&i.CancelTokenHash,
+		&i.ConfirmExpiresAt,
+		&i.CancelExpiresAt,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const createFollow = `-- name: CreateFollow :execrows
+INSERT INTO user_follows (follower_id, followee_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type CreateFollowParams struct {
+	FollowerID int32 `db:"follower_id" json:"followerId"`
+	FolloweeID int32 `db:"followee_id" json:"followeeId"`
+}
+
+func (q *Queries) CreateFollow(ctx context.Context, arg CreateFollowParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createFollow, arg.FollowerID, arg.FolloweeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createInvitation = `-- name: CreateInvitation :one
+INSERT INTO invitations (
+    organization_id, email, role, token_hash, invited_by, expires_at
 )
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, organization_id, email, role, token_hash, invited_by, expires_at, created_at, consumed_at, consumed_by, revoked_at
+`
+
+type CreateInvitationParams struct {
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	Email          string        `db:"email" json:"email"`
+	Role           string        `db:"role" json:"role"`
+	TokenHash      string        `db:"token_hash" json:"tokenHash"`
+	InvitedBy      sql.NullInt32 `db:"invited_by" json:"invitedBy"`
+	ExpiresAt      time.Time     `db:"expires_at" json:"expiresAt"`
+}
+
+func (q *Queries) CreateInvitation(ctx context.Context, arg CreateInvitationParams) (Invitation, error) {
+	row := q.db.QueryRowContext(ctx, createInvitation,
+		arg.OrganizationID,
+		arg.Email,
+		arg.Role,
+		arg.TokenHash,
+		arg.InvitedBy,
+		arg.ExpiresAt,
+	)
+	var i Invitation
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Email,
+		&i.Role,
+		&i.TokenHash,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.ConsumedAt,
+		&i.ConsumedBy,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const createMessage = `-- name: CreateMessage :one
+INSERT INTO messages (sender_id, content, organization_id, room_id, parent_id)
+SELECT $1, $2, r.organization_id, r.id, $3
+FROM chat_rooms r
+JOIN room_members rm ON rm.room_id = r.id AND rm.user_id = $1
+WHERE r.id = $4 AND r.organization_id = $5
+RETURNING id, sender_id, content, created_at, organization_id, room_id, recipient_id, edited_at, deleted_at, parent_id
+`
+
+type CreateMessageParams struct {
+	SenderID       int32         `db:"sender_id" json:"senderId"`
+	Content        string        `db:"content" json:"content"`
+	ParentID       sql.NullInt32 `db:"parent_id" json:"parentId"`
+	RoomID         int32         `db:"room_id" json:"roomId"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+}
+
+// Posts a message to a room of the organization, as a reply in the thread
+// of parent_id unless it is null. Nothing is inserted, and no row returned,
+// unless the sender is a member of the room.
+func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, createMessage,
+		arg.SenderID,
+		arg.Content,
+		arg.ParentID,
+		arg.RoomID,
+		arg.OrganizationID,
+	)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.SenderID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.OrganizationID,
+		&i.RoomID,
+		&i.RecipientID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const createMessageRevision = `-- name: CreateMessageRevision :exec
+INSERT INTO message_revisions (message_id, organization_id, content, revised_by)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateMessageRevisionParams struct {
+	MessageID      int32         `db:"message_id" json:"messageId"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	Content        string        `db:"content" json:"content"`
+	RevisedBy      sql.NullInt32 `db:"revised_by" json:"revisedBy"`
+}
+
+func (q *Queries) CreateMessageRevision(ctx context.Context, arg CreateMessageRevisionParams) error {
+	_, err := q.db.ExecContext(ctx, createMessageRevision,
+		arg.MessageID,
+		arg.OrganizationID,
+		arg.Content,
+		arg.RevisedBy,
+	)
+	return err
+}
+
+const createOrganization = `-- name: CreateOrganization :one
+INSERT INTO organizations (slug, name)
+VALUES ($1, $2)
+RETURNING id, slug, name, created_at, updated_at
+`
+
+type CreateOrganizationParams struct {
+	Slug string `db:"slug" json:"slug"`
+	Name string `db:"name" json:"name"`
+}
+
+func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, createOrganization, arg.Slug, arg.Name)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createOutboxEvent = `-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING id, event_type, aggregate_type, aggregate_id, payload, attempts, last_error, next_attempt_at, delivered_at, created_at
+`
+
+type CreateOutboxEventParams struct {
+	EventType     string          `db:"event_type" json:"eventType"`
+	AggregateType string          `db:"aggregate_type" json:"aggregateType"`
+	AggregateID   int32           `db:"aggregate_id" json:"aggregateId"`
+	Payload       json.RawMessage `db:"payload" json:"payload"`
+}
+
+func (q *Queries) CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error) {
+	row := q.db.QueryRowContext(ctx, createOutboxEvent,
+		arg.EventType,
+		arg.AggregateType,
+		arg.AggregateID,
+		arg.Payload,
+	)
+	var i OutboxEvent
+	err := row.Scan(
+		&i.ID,
+		&i.EventType,
+		&i.AggregateType,
+		&i.AggregateID,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (username, email, full_name, password_hash)
+VALUES ($1, $2, $3, $4)
+RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count
+`
+
+type CreateUserParams struct {
+	Username     string `db:"username" json:"username"`
+	Email        string `db:"email" json:"email"`
+	FullName     string `db:"full_name" json:"fullName"`
+	PasswordHash string `db:"password_hash" json:"passwordHash"`
+}
+
+// queries.sql
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, createUser,
+		arg.Username,
+		arg.Email,
+		arg.FullName,
+		arg.PasswordHash,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
+const createUserBlock = `-- name: CreateUserBlock :execrows
+INSERT INTO user_blocks (blocker_id, blocked_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type CreateUserBlockParams struct {
+	BlockerID int32 `db:"blocker_id" json:"blockerId"`
+	BlockedID int32 `db:"blocked_id" json:"blockedId"`
+}
+
+func (q *Queries) CreateUserBlock(ctx context.Context, arg CreateUserBlockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createUserBlock, arg.BlockerID, arg.BlockedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createUserErasure = `-- name: CreateUserErasure :one
+INSERT INTO user_erasures (user_id, requested_by, messages_anonymized)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, requested_by, messages_anonymized, erased_at
+`
+
+type CreateUserErasureParams struct {
+	UserID             int32         `db:"user_id" json:"userId"`
+	RequestedBy        sql.NullInt32 `db:"requested_by" json:"requestedBy"`
+	MessagesAnonymized int32         `db:"messages_anonymized" json:"messagesAnonymized"`
+}
+
+func (q *Queries) CreateUserErasure(ctx context.Context, arg CreateUserErasureParams) (UserErasure, error) {
+	row := q.db.QueryRowContext(ctx, createUserErasure, arg.UserID, arg.RequestedBy, arg.MessagesAnonymized)
+	var i UserErasure
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.RequestedBy,
+		&i.MessagesAnonymized,
+		&i.ErasedAt,
+	)
+	return i, err
+}
+
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, event_types, secret, active)
+VALUES ($1, $2, $3, $4)
+RETURNING id, url, event_types, secret, active, created_at, updated_at
+`
+
+type CreateWebhookSubscriptionParams struct {
+	Url        string   `db:"url" json:"url"`
+	EventTypes []string `db:"event_types" json:"eventTypes"`
+	Secret     string   `db:"secret" json:"secret"`
+	Active     bool     `db:"active" json:"active"`
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookSubscription,
+		arg.Url,
+		pq.Array(arg.EventTypes),
+		arg.Secret,
+		arg.Active,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		pq.Array(&i.EventTypes),
+		&i.Secret,
+		&i.Active,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deadLetterWebhookDelivery = `-- name: DeadLetterWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET status = 'dead', last_status_code = $2, last_error = $3
+WHERE id = $1
+`
+
+type DeadLetterWebhookDeliveryParams struct {
+	ID             int64  `db:"id" json:"id"`
+	LastStatusCode int32  `db:"last_status_code" json:"lastStatusCode"`
+	LastError      string `db:"last_error" json:"lastError"`
+}
+
+func (q *Queries) DeadLetterWebhookDelivery(ctx context.Context, arg DeadLetterWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, deadLetterWebhookDelivery, arg.ID, arg.LastStatusCode, arg.LastError)
+	return err
+}
+
+const deleteDeliveredOutboxEvents = `-- name: DeleteDeliveredOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE delivered_at < $1
+`
+
+func (q *Queries) DeleteDeliveredOutboxEvents(ctx context.Context, deliveredAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteDeliveredOutboxEvents, deliveredAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteDirectReadsByUser = `-- name: DeleteDirectReadsByUser :exec
+DELETE FROM direct_reads
+WHERE user_id = $1 OR peer_id = $1
+`
+
+func (q *Queries) DeleteDirectReadsByUser(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteDirectReadsByUser, userID)
+	return err
+}
+
+const deleteEmailChangesByUser = `-- name: DeleteEmailChangesByUser :exec
+DELETE FROM email_changes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteEmailChangesByUser(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteEmailChangesByUser, userID)
+	return err
+}
+
+const deleteFollow = `-- name: DeleteFollow :execrows
+DELETE FROM user_follows
+WHERE follower_id = $1 AND followee_id = $2
+`
+
+type DeleteFollowParams struct {
+	FollowerID int32 `db:"follower_id" json:"followerId"`
+	FolloweeID int32 `db:"followee_id" json:"followeeId"`
+}
+
+func (q *Queries) DeleteFollow(ctx context.Context, arg DeleteFollowParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFollow, arg.FollowerID, arg.FolloweeID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteFollowsByUser = `-- name: DeleteFollowsByUser :exec
+DELETE FROM user_follows
+WHERE follower_id = $1 OR followee_id = $1
+`
+
+func (q *Queries) DeleteFollowsByUser(ctx context.Context, followerID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteFollowsByUser, followerID)
+	return err
+}
+
+const deleteMessage = `-- name: DeleteMessage :one
+UPDATE messages
+SET content = '', deleted_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, sender_id, content, created_at, organization_id, room_id, recipient_id, edited_at, deleted_at, parent_id
+`
+
+// Clears the content of a message and marks it deleted. The row stays, so
+// the conversation around it keeps its shape.
+func (q *Queries) DeleteMessage(ctx context.Context, id int32) (Message, error) {
+	row := q.db.QueryRowContext(ctx, deleteMessage, id)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.SenderID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.OrganizationID,
+		&i.RoomID,
+		&i.RecipientID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const deleteMessageReaction = `-- name: DeleteMessageReaction :execrows
+DELETE FROM message_reactions
+WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+`
+
+type DeleteMessageReactionParams struct {
+	MessageID int32  `db:"message_id" json:"messageId"`
+	UserID    int32  `db:"user_id" json:"userId"`
+	Emoji     string `db:"emoji" json:"emoji"`
+}
+
+func (q *Queries) DeleteMessageReaction(ctx context.Context, arg DeleteMessageReactionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteMessageReaction, arg.MessageID, arg.UserID, arg.Emoji)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteMessageReactionsByUser = `-- name: DeleteMessageReactionsByUser :exec
+DELETE FROM message_reactions
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteMessageReactionsByUser(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteMessageReactionsByUser, userID)
+	return err
+}
+
+const deleteOrganizationMember = `-- name: DeleteOrganizationMember :execrows
+DELETE FROM organization_members
+WHERE organization_id = $1 AND user_id = $2
+`
+
+type DeleteOrganizationMemberParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+}
+
+func (q *Queries) DeleteOrganizationMember(ctx context.Context, arg DeleteOrganizationMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteOrganizationMember, arg.OrganizationID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRoomMember = `-- name: DeleteRoomMember :execrows
+DELETE FROM room_members
+WHERE room_id = $1 AND user_id = $2
+`
+
+type DeleteRoomMemberParams struct {
+	RoomID int32 `db:"room_id" json:"roomId"`
+	UserID int32 `db:"user_id" json:"userId"`
+}
+
+func (q *Queries) DeleteRoomMember(ctx context.Context, arg DeleteRoomMemberParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRoomMember, arg.RoomID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteRoomMembershipsInOrganization = `-- name: DeleteRoomMembershipsInOrganization :exec
+DELETE FROM room_members rm
+USING chat_rooms r
+WHERE r.id = rm.room_id AND r.organization_id = $1 AND rm.user_id = $2
+`
+
+type DeleteRoomMembershipsInOrganizationParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+}
+
+func (q *Queries) DeleteRoomMembershipsInOrganization(ctx context.Context, arg DeleteRoomMembershipsInOrganizationParams) error {
+	_, err := q.db.ExecContext(ctx, deleteRoomMembershipsInOrganization, arg.OrganizationID, arg.UserID)
+	return err
+}
+
+const deleteRoomReadsByUser = `-- name: DeleteRoomReadsByUser :exec
+DELETE FROM room_reads
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteRoomReadsByUser(ctx context.Context, userID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteRoomReadsByUser, userID)
+	return err
+}
+
+const deleteUserBlock = `-- name: DeleteUserBlock :execrows
+DELETE FROM user_blocks
+WHERE blocker_id = $1 AND blocked_id = $2
+`
+
+type DeleteUserBlockParams struct {
+	BlockerID int32 `db:"blocker_id" json:"blockerId"`
+	BlockedID int32 `db:"blocked_id" json:"blockedId"`
+}
+
+func (q *Queries) DeleteUserBlock(ctx context.Context, arg DeleteUserBlockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUserBlock, arg.BlockerID, arg.BlockedID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteUserBlocksByUser = `-- name: DeleteUserBlocksByUser :exec
+DELETE FROM user_blocks
+WHERE blocker_id = $1 OR blocked_id = $1
+`
+
+func (q *Queries) DeleteUserBlocksByUser(ctx context.Context, blockerID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteUserBlocksByUser, blockerID)
+	return err
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWebhookSubscription, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const editMessage = `-- name: EditMessage :one
+UPDATE messages
+SET content = $2, edited_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, sender_id, content, created_at, organization_id, room_id, recipient_id, edited_at, deleted_at, parent_id
+`
+
+type EditMessageParams struct {
+	ID      int32  `db:"id" json:"id"`
+	Content string `db:"content" json:"content"`
+}
+
+func (q *Queries) EditMessage(ctx context.Context, arg EditMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, editMessage, arg.ID, arg.Content)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.SenderID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.OrganizationID,
+		&i.RoomID,
+		&i.RecipientID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const enqueueWebhookDeliveries = `-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT id, $1::bigint, $2::text, $3::jsonb
+FROM webhook_subscriptions
+WHERE active AND $2::text = ANY(event_types)
+ON CONFLICT (subscription_id, event_id) DO NOTHING
+`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID   int64           `db:"event_id" json:"eventId"`
+	EventType string          `db:"event_type" json:"eventType"`
+	Payload   json.RawMessage `db:"payload" json:"payload"`
+}
+
+// Fans an outbox event out to every active subscription listening for its
+// type. Relaying the same event twice does not duplicate deliveries.
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, enqueueWebhookDeliveries, arg.EventID, arg.EventType, arg.Payload)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const filterOrganizationMembers = `-- name: FilterOrganizationMembers :many
+SELECT user_id FROM organization_members
+WHERE organization_id = $1 AND user_id = ANY($2::integer[])
+`
+
+type FilterOrganizationMembersParams struct {
+	OrganizationID int32   `db:"organization_id" json:"organizationId"`
+	UserIds        []int32 `db:"user_ids" json:"userIds"`
+}
+
+// Returns the ids that belong to members of the organization.
+func (q *Queries) FilterOrganizationMembers(ctx context.Context, arg FilterOrganizationMembersParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, filterOrganizationMembers, arg.OrganizationID, pq.Array(arg.UserIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getChatRoom = `-- name: GetChatRoom :one
+SELECT id, organization_id, name, created_by, created_at FROM chat_rooms
+WHERE id = $1 AND organization_id = $2
+`
+
+type GetChatRoomParams struct {
+	ID             int32 `db:"id" json:"id"`
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+}
+
+func (q *Queries) GetChatRoom(ctx context.Context, arg GetChatRoomParams) (ChatRoom, error) {
+	row := q.db.QueryRowContext(ctx, getChatRoom, arg.ID, arg.OrganizationID)
+	var i ChatRoom
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Name,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDirectMessages = `-- name: GetDirectMessages :many
+SELECT m.id, m.sender_id, m.content, m.created_at, m.organization_id, m.room_id, m.recipient_id, m.edited_at, m.deleted_at, m.parent_id, u.username AS sender_name,
+    COALESCE((
+        SELECT json_agg(json_build_object('emoji', r.emoji, 'count', r.count, 'users', r.users) ORDER BY r.first_at, r.emoji)
+        FROM (
+            SELECT mr.emoji, COUNT(*) AS count, array_agg(mr.user_id ORDER BY mr.created_at, mr.user_id) AS users, MIN(mr.created_at) AS first_at
+            FROM message_reactions mr
+            WHERE mr.message_id = m.id
+            GROUP BY mr.emoji
+        ) r
+    ), '[]')::json AS reactions
+FROM messages m
+JOIN users u ON u.id = m.sender_id
+WHERE m.organization_id = $1
+  AND ((m.sender_id = $2 AND m.recipient_id = $3)
+    OR (m.sender_id = $3 AND m.recipient_id = $2))
+ORDER BY m.id DESC
+LIMIT $4 OFFSET $5
+`
+
+type GetDirectMessagesParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+	PeerID         int32 `db:"peer_id" json:"peerId"`
+	LimitCount     int32 `db:"limit_count" json:"limitCount"`
+	OffsetCount    int32 `db:"offset_count" json:"offsetCount"`
+}
+
+type GetDirectMessagesRow struct {
+	ID             int32           `db:"id" json:"id"`
+	SenderID       int32           `db:"sender_id" json:"senderId"`
+	Content        string          `db:"content" json:"content"`
+	CreatedAt      sql.NullTime    `db:"created_at" json:"createdAt"`
+	OrganizationID int32           `db:"organization_id" json:"organizationId"`
+	RoomID         sql.NullInt32   `db:"room_id" json:"roomId"`
+	RecipientID    sql.NullInt32   `db:"recipient_id" json:"recipientId"`
+	EditedAt       sql.NullTime    `db:"edited_at" json:"editedAt"`
+	DeletedAt      sql.NullTime    `db:"deleted_at" json:"deletedAt"`
+	ParentID       sql.NullInt32   `db:"parent_id" json:"parentId"`
+	SenderName     string          `db:"sender_name" json:"senderName"`
+	Reactions      json.RawMessage `db:"reactions" json:"reactions"`
+}
+
+// Returns a page of a conversation with the reactions to each message, as
+// GetMessages does.
+func (q *Queries) GetDirectMessages(ctx context.Context, arg GetDirectMessagesParams) ([]GetDirectMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDirectMessages,
+		arg.OrganizationID,
+		arg.UserID,
+		arg.PeerID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDirectMessagesRow
+	for rows.Next() {
+		var i GetDirectMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SenderID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.OrganizationID,
+			&i.RoomID,
+			&i.RecipientID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ParentID,
+			&i.SenderName,
+			&i.Reactions,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEmailChangeByCancelToken = `-- name: GetEmailChangeByCancelToken :one
+SELECT id, user_id, old_email, new_email, confirm_token_hash, cancel_token_hash, confirm_expires_at, cancel_expires_at, created_at, confirmed_at, cancelled_at FROM email_changes
+WHERE cancel_token_hash = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetEmailChangeByCancelToken(ctx context.Context, cancelTokenHash string) (EmailChange, error) {
+	row := q.db.QueryRowContext(ctx, getEmailChangeByCancelToken, cancelTokenHash)
+	var i EmailChange
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OldEmail,
+		&i.NewEmail,
+		&i.ConfirmTokenHash,
+		&i.CancelTokenHash,
+		&i.ConfirmExpiresAt,
+		&i.CancelExpiresAt,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const getEmailChangeByConfirmToken = `-- name: GetEmailChangeByConfirmToken :one
+SELECT id, user_id, old_email, new_email, confirm_token_hash, cancel_token_hash, confirm_expires_at, cancel_expires_at, created_at, confirmed_at, cancelled_at FROM email_changes
+WHERE confirm_token_hash = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetEmailChangeByConfirmToken(ctx context.Context, confirmTokenHash string) (EmailChange, error) {
+	row := q.db.QueryRowContext(ctx, getEmailChangeByConfirmToken, confirmTokenHash)
+	var i EmailChange
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OldEmail,
+		&i.NewEmail,
+		&i.ConfirmTokenHash,
+		&i.CancelTokenHash,
+		&i.ConfirmExpiresAt,
+		&i.CancelExpiresAt,
+		&i.CreatedAt,
+		&i.ConfirmedAt,
+		&i.CancelledAt,
+	)
+	return i, err
+}
+
+const getErasedUserPlaceholder = `-- name: GetErasedUserPlaceholder :one
+WITH created AS (
+    INSERT INTO users (username, email, full_name, password_hash, deleted_at)
+    VALUES ('deleted-user', 'deleted-user@erased.invalid', 'Deleted user', '', CURRENT_TIMESTAMP)
+    ON CONFLICT DO NOTHING
+    RETURNING id
+)
+SELECT id FROM created
+UNION ALL
+SELECT id FROM users WHERE lower(email) = 'deleted-user@erased.invalid'
+LIMIT 1
+`
+
+// The placeholder is created by a migration; inserting it here as well keeps
+// erasure working on a database where the row was removed.
+func (q *Queries) GetErasedUserPlaceholder(ctx context.Context) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getErasedUserPlaceholder)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getInvitationByToken = `-- name: GetInvitationByToken :one
+SELECT id, organization_id, email, role, token_hash, invited_by, expires_at, created_at, consumed_at, consumed_by, revoked_at FROM invitations
+WHERE token_hash = $1
+`
+
+func (q *Queries) GetInvitationByToken(ctx context.Context, tokenHash string) (Invitation, error) {
+	row := q.db.QueryRowContext(ctx, getInvitationByToken, tokenHash)
+	var i Invitation
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Email,
+		&i.Role,
+		&i.TokenHash,
+		&i.InvitedBy,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.ConsumedAt,
+		&i.ConsumedBy,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getMessage = `-- name: GetMessage :one
+SELECT id, sender_id, content, created_at, organization_id, room_id, recipient_id, edited_at, deleted_at, parent_id FROM messages
+WHERE id = $1 AND organization_id = $2
+`
+
+type GetMessageParams struct {
+	ID             int32 `db:"id" json:"id"`
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+}
+
+func (q *Queries) GetMessage(ctx context.Context, arg GetMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, getMessage, arg.ID, arg.OrganizationID)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.SenderID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.OrganizationID,
+		&i.RoomID,
+		&i.RecipientID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getMessageForUpdate = `-- name: GetMessageForUpdate :one
+SELECT id, sender_id, content, created_at, organization_id, room_id, recipient_id, edited_at, deleted_at, parent_id FROM messages
+WHERE id = $1 AND organization_id = $2
+FOR UPDATE
+`
+
+type GetMessageForUpdateParams struct {
+	ID             int32 `db:"id" json:"id"`
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+}
+
+func (q *Queries) GetMessageForUpdate(ctx context.Context, arg GetMessageForUpdateParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, getMessageForUpdate, arg.ID, arg.OrganizationID)
+	var i Message
+	err := row.Scan(
+		&i.ID,
+		&i.SenderID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.OrganizationID,
+		&i.RoomID,
+		&i.RecipientID,
+		&i.EditedAt,
+		&i.DeletedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getMessages = `-- name: GetMessages :many
+SELECT m.id, m.sender_id, m.content, m.created_at, m.organization_id, m.room_id, m.recipient_id, m.edited_at, m.deleted_at, m.parent_id, u.username as sender_name,
+    (SELECT count(*) FROM messages t WHERE t.parent_id = m.id) AS reply_count,
+    COALESCE((
+        SELECT json_agg(json_build_object('emoji', r.emoji, 'count', r.count, 'users', r.users) ORDER BY r.first_at, r.emoji)
+        FROM (
+            SELECT mr.emoji, COUNT(*) AS count, array_agg(mr.user_id ORDER BY mr.created_at, mr.user_id) AS users, MIN(mr.created_at) AS first_at
+            FROM message_reactions mr
+            WHERE mr.message_id = m.id
+            GROUP BY mr.emoji
+        ) r
+    ), '[]')::json AS reactions
+FROM messages m
+JOIN users u ON m.sender_id = u.id
+WHERE m.organization_id = $4 AND m.room_id = $5 AND m.parent_id IS NULL AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = $3 AND b.blocked_id = m.sender_id
+)
+ORDER BY m.created_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetMessagesParams struct {
+	Limit          int32         `db:"limit" json:"limit"`
+	Offset         int32         `db:"offset" json:"offset"`
+	BlockerID      int32         `db:"blocker_id" json:"blockerId"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	RoomID         sql.NullInt32 `db:"room_id" json:"roomId"`
+}
+
+type GetMessagesRow struct {
+	ID             int32           `db:"id" json:"id"`
+	SenderID       int32           `db:"sender_id" json:"senderId"`
+	Content        string          `db:"content" json:"content"`
+	CreatedAt      sql.NullTime    `db:"created_at" json:"createdAt"`
+	OrganizationID int32           `db:"organization_id" json:"organizationId"`
+	RoomID         sql.NullInt32   `db:"room_id" json:"roomId"`
+	RecipientID    sql.NullInt32   `db:"recipient_id" json:"recipientId"`
+	EditedAt       sql.NullTime    `db:"edited_at" json:"editedAt"`
+	DeletedAt      sql.NullTime    `db:"deleted_at" json:"deletedAt"`
+	ParentID       sql.NullInt32   `db:"parent_id" json:"parentId"`
+	SenderName     string          `db:"sender_name" json:"senderName"`
+	ReplyCount     int64           `db:"reply_count" json:"replyCount"`
+	Reactions      json.RawMessage `db:"reactions" json:"reactions"`
+}
+
+// Returns a page of a room's messages, leaving out replies, which are
+// fetched with their thread. Each message comes with the number of replies
+// to it and its reactions, grouped by emoji in the order they were first
+// used.
+func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]GetMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMessages,
+		arg.Limit,
+		arg.Offset,
+		arg.BlockerID,
+		arg.OrganizationID,
+		arg.RoomID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMessagesRow
+	for rows.Next() {
+		var i GetMessagesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SenderID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.OrganizationID,
+			&i.RoomID,
+			&i.RecipientID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ParentID,
+			&i.SenderName,
+			&i.ReplyCount,
+			&i.Reactions,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMessagesBySender = `-- name: GetMessagesBySender :many
+SELECT id, sender_id, content, created_at, organization_id, room_id, recipient_id, edited_at, deleted_at, parent_id FROM messages
+WHERE sender_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetMessagesBySender(ctx context.Context, senderID int32) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, getMessagesBySender, senderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Message
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.ID,
+			&i.SenderID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.OrganizationID,
+			&i.RoomID,
+			&i.RecipientID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationBySlug = `-- name: GetOrganizationBySlug :one
+SELECT id, slug, name, created_at, updated_at FROM organizations
+WHERE slug = $1
+`
+
+func (q *Queries) GetOrganizationBySlug(ctx context.Context, slug string) (Organization, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationBySlug, slug)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Name,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOrganizationMember = `-- name: GetOrganizationMember :one
+SELECT organization_id, user_id, role, created_at FROM organization_members
+WHERE organization_id = $1 AND user_id = $2
+`
+
+type GetOrganizationMemberParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+}
+
+func (q *Queries) GetOrganizationMember(ctx context.Context, arg GetOrganizationMemberParams) (OrganizationMember, error) {
+	row := q.db.QueryRowContext(ctx, getOrganizationMember, arg.OrganizationID, arg.UserID)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getThread = `-- name: GetThread :many
+SELECT m.id, m.sender_id, m.content, m.created_at, m.organization_id, m.room_id, m.recipient_id, m.edited_at, m.deleted_at, m.parent_id, u.username AS sender_name,
+    (SELECT count(*) FROM messages t WHERE t.parent_id = m.id) AS reply_count,
+    COALESCE((
+        SELECT json_agg(json_build_object('emoji', r.emoji, 'count', r.count, 'users', r.users) ORDER BY r.first_at, r.emoji)
+        FROM (
+            SELECT mr.emoji, COUNT(*) AS count, array_agg(mr.user_id ORDER BY mr.created_at, mr.user_id) AS users, MIN(mr.created_at) AS first_at
+            FROM message_reactions mr
+            WHERE mr.message_id = m.id
+            GROUP BY mr.emoji
+        ) r
+    ), '[]')::json AS reactions
+FROM messages m
+JOIN users u ON u.id = m.sender_id
+WHERE m.organization_id = $1
+  AND (m.id = $2::integer OR m.parent_id = $2::integer)
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = $3 AND b.blocked_id = m.sender_id
+  )
+ORDER BY m.id
+LIMIT $4 OFFSET $5
+`
+
+type GetThreadParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	ParentID       int32 `db:"parent_id" json:"parentId"`
+	BlockerID      int32 `db:"blocker_id" json:"blockerId"`
+	LimitCount     int32 `db:"limit_count" json:"limitCount"`
+	OffsetCount    int32 `db:"offset_count" json:"offsetCount"`
+}
+
+type GetThreadRow struct {
+	ID             int32           `db:"id" json:"id"`
+	SenderID       int32           `db:"sender_id" json:"senderId"`
+	Content        string          `db:"content" json:"content"`
+	CreatedAt      sql.NullTime    `db:"created_at" json:"createdAt"`
+	OrganizationID int32           `db:"organization_id" json:"organizationId"`
+	RoomID         sql.NullInt32   `db:"room_id" json:"roomId"`
+	RecipientID    sql.NullInt32   `db:"recipient_id" json:"recipientId"`
+	EditedAt       sql.NullTime    `db:"edited_at" json:"editedAt"`
+	DeletedAt      sql.NullTime    `db:"deleted_at" json:"deletedAt"`
+	ParentID       sql.NullInt32   `db:"parent_id" json:"parentId"`
+	SenderName     string          `db:"sender_name" json:"senderName"`
+	ReplyCount     int64           `db:"reply_count" json:"replyCount"`
+	Reactions      json.RawMessage `db:"reactions" json:"reactions"`
+}
+
+// Returns the thread of parent_id oldest first: the parent, then its
+// replies, leaving out the messages of users blocker_id blocked. Messages
+// come with their reactions as in GetMessages.
+func (q *Queries) GetThread(ctx context.Context, arg GetThreadParams) ([]GetThreadRow, error) {
+	rows, err := q.db.QueryContext(ctx, getThread,
+		arg.OrganizationID,
+		arg.ParentID,
+		arg.BlockerID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetThreadRow
+	for rows.Next() {
+		var i GetThreadRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SenderID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.OrganizationID,
+			&i.RoomID,
+			&i.RecipientID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ParentID,
+			&i.SenderName,
+			&i.ReplyCount,
+			&i.Reactions,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count FROM users
+WHERE lower(email) = lower($1) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count FROM users
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
+const getUserByIDWithDeleted = `-- name: GetUserByIDWithDeleted :one
+SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count FROM users
+WHERE id = $1
+`
+
+// Also finds soft-deleted users, which can still be erased.
+func (q *Queries) GetUserByIDWithDeleted(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByIDWithDeleted, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
+const getUserByUsername = `-- name: GetUserByUsername :one
+SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count FROM users
+WHERE username_skeleton(username) = username_skeleton($1) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByUsername, username)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count FROM users
+WHERE id = ANY($1::integer[]) AND deleted_at IS NULL
+`
+
+// Rows come back in no particular order; callers restore the order they need.
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []int32) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getUsersByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.FullName,
+			&i.PasswordHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Bio,
+			&i.Locale,
+			&i.Timezone,
+			&i.AvatarUrl,
+			&i.Attributes,
+			&i.FollowersCount,
+			&i.FollowingCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions
+WHERE id = $1
+`
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, id int32) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookSubscription, id)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		pq.Array(&i.EventTypes),
+		&i.Secret,
+		&i.Active,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const isRoomMember = `-- name: IsRoomMember :one
+SELECT EXISTS (
+    SELECT 1 FROM room_members
+    WHERE room_id = $1 AND user_id = $2
+)
+`
+
+type IsRoomMemberParams struct {
+	RoomID int32 `db:"room_id" json:"roomId"`
+	UserID int32 `db:"user_id" json:"userId"`
+}
+
+func (q *Queries) IsRoomMember(ctx context.Context, arg IsRoomMemberParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isRoomMember, arg.RoomID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT id, actor_id, action, target_type, target_id, before, after, metadata, ip, request_id, created_at FROM audit_events
+WHERE ($1::integer IS NULL OR actor_id = $1)
+    AND ($2::text IS NULL OR action = $2)
+    AND ($3::text IS NULL OR target_type = $3)
+    AND ($4::integer IS NULL OR target_id = $4)
+    AND ($5::timestamp IS NULL OR created_at >= $5)
+    AND ($6::timestamp IS NULL OR created_at < $6)
+ORDER BY created_at DESC, id DESC
+LIMIT $7 OFFSET $8
+`
+
+type ListAuditEventsParams struct {
+	ActorID     sql.NullInt32  `db:"actor_id" json:"actorId"`
+	Action      sql.NullString `db:"action" json:"action"`
+	TargetType  sql.NullString `db:"target_type" json:"targetType"`
+	TargetID    sql.NullInt32  `db:"target_id" json:"targetId"`
+	Since       sql.NullTime   `db:"since" json:"since"`
+	Until       sql.NullTime   `db:"until" json:"until"`
+	LimitCount  int32          `db:"limit_count" json:"limitCount"`
+	OffsetCount int32          `db:"offset_count" json:"offsetCount"`
+}
+
+// Every filter is optional; a NULL argument matches all events.
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEvents,
+		arg.ActorID,
+		arg.Action,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Since,
+		arg.Until,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ActorID,
+			&i.Action,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Before,
+			&i.After,
+			&i.Metadata,
+			&i.Ip,
+			&i.RequestID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlockerIDs = `-- name: ListBlockerIDs :many
+SELECT blocker_id FROM user_blocks
+WHERE blocked_id = $1
+`
+
+func (q *Queries) ListBlockerIDs(ctx context.Context, blockedID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listBlockerIDs, blockedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var blocker_id int32
+		if err := rows.Scan(&blocker_id); err != nil {
+			return nil, err
+		}
+		items = append(items, blocker_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChatRooms = `-- name: ListChatRooms :many
+SELECT r.id, r.organization_id, r.name, r.created_by, r.created_at, EXISTS (
+    SELECT 1 FROM room_members rm
+    WHERE rm.room_id = r.id AND rm.user_id = $2
+) AS joined
+FROM chat_rooms r
+WHERE r.organization_id = $1
+ORDER BY r.name
+`
+
+type ListChatRoomsParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+}
+
+type ListChatRoomsRow struct {
+	ID             int32         `db:"id" json:"id"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	Name           string        `db:"name" json:"name"`
+	CreatedBy      sql.NullInt32 `db:"created_by" json:"createdBy"`
+	CreatedAt      time.Time     `db:"created_at" json:"createdAt"`
+	Joined         bool          `db:"joined" json:"joined"`
+}
+
+func (q *Queries) ListChatRooms(ctx context.Context, arg ListChatRoomsParams) ([]ListChatRoomsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChatRooms, arg.OrganizationID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListChatRoomsRow
+	for rows.Next() {
+		var i ListChatRoomsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Name,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.Joined,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listConversations = `-- name: ListConversations :many
+WITH direct AS (
+    SELECT m.id, m.sender_id, m.content, m.created_at,
+        CASE WHEN m.sender_id = $1 THEN m.recipient_id ELSE m.sender_id END::integer AS peer_id
+    FROM messages m
+    WHERE m.organization_id = $2
+      AND (m.sender_id = $1 OR m.recipient_id = $1)
+      AND m.recipient_id IS NOT NULL
+), latest AS (
+    SELECT DISTINCT ON (peer_id) id, sender_id, content, created_at, peer_id
+    FROM direct
+    ORDER BY peer_id, id DESC
+)
+SELECT l.peer_id, u.username AS peer_username, u.full_name AS peer_full_name, u.avatar_url AS peer_avatar_url,
+    l.id AS last_message_id, l.sender_id AS last_sender_id, l.content AS last_content, l.created_at AS last_created_at,
+    (
+        SELECT COUNT(*) FROM direct d
+        WHERE d.sender_id = l.peer_id AND d.id > COALESCE((
+            SELECT r.last_read_id FROM direct_reads r
+            WHERE r.organization_id = $2
+              AND r.user_id = $1 AND r.peer_id = l.peer_id
+        ), 0)
+    ) AS unread_count
+FROM latest l
+JOIN users u ON u.id = l.peer_id
+ORDER BY l.id DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListConversationsParams struct {
+	UserID         int32 `db:"user_id" json:"userId"`
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	LimitCount     int32 `db:"limit_count" json:"limitCount"`
+	OffsetCount    int32 `db:"offset_count" json:"offsetCount"`
+}
+
+type ListConversationsRow struct {
+	PeerID        int32          `db:"peer_id" json:"peerId"`
+	PeerUsername  string         `db:"peer_username" json:"peerUsername"`
+	PeerFullName  string         `db:"peer_full_name" json:"peerFullName"`
+	PeerAvatarUrl sql.NullString `db:"peer_avatar_url" json:"peerAvatarUrl"`
+	LastMessageID int32          `db:"last_message_id" json:"lastMessageId"`
+	LastSenderID  int32          `db:"last_sender_id" json:"lastSenderId"`
+	LastContent   string         `db:"last_content" json:"lastContent"`
+	LastCreatedAt sql.NullTime   `db:"last_created_at" json:"lastCreatedAt"`
+	UnreadCount   int64          `db:"unread_count" json:"unreadCount"`
+}
+
+// Lists the users user_id has exchanged direct messages with, most recent
+// conversation first, with the last message of each and how many of the
+// peer's messages user_id has not read yet.
+func (q *Queries) ListConversations(ctx context.Context, arg ListConversationsParams) ([]ListConversationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listConversations,
+		arg.UserID,
+		arg.OrganizationID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListConversationsRow
+	for rows.Next() {
+		var i ListConversationsRow
+		if err := rows.Scan(
+			&i.PeerID,
+			&i.PeerUsername,
+			&i.PeerFullName,
+			&i.PeerAvatarUrl,
+			&i.LastMessageID,
+			&i.LastSenderID,
+			&i.LastContent,
+			&i.LastCreatedAt,
+			&i.UnreadCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFollowers = `-- name: ListFollowers :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.follower_id
+WHERE f.followee_id = $1 AND u.deleted_at IS NULL
+    AND ($2::integer IS NULL OR EXISTS (
+        SELECT 1 FROM organization_members m
+        WHERE m.organization_id = $2 AND m.user_id = u.id))
+ORDER BY f.created_at DESC, u.id
+LIMIT $3 OFFSET $4
+`
+
+type ListFollowersParams struct {
+	FolloweeID     int32         `db:"followee_id" json:"followeeId"`
+	OrganizationID sql.NullInt32 `db:"organization_id" json:"organizationId"`
+	LimitCount     int32         `db:"limit_count" json:"limitCount"`
+	OffsetCount    int32         `db:"offset_count" json:"offsetCount"`
+}
+
+type ListFollowersRow struct {
+	ID         int32          `db:"id" json:"id"`
+	Username   string         `db:"username" json:"username"`
+	FullName   string         `db:"full_name" json:"fullName"`
+	AvatarUrl  sql.NullString `db:"avatar_url" json:"avatarUrl"`
+	FollowedAt time.Time      `db:"followed_at" json:"followedAt"`
+}
+
+// A NULL organization_id lists users of every organization.
+// A NULL organization_id lists users of every organization.
+func (q *Queries) ListFollowers(ctx context.Context, arg ListFollowersParams) ([]ListFollowersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFollowers,
+		arg.FolloweeID,
+		arg.OrganizationID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFollowersRow
+	for rows.Next() {
+		var i ListFollowersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.AvatarUrl,
+			&i.FollowedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFollowing = `-- name: ListFollowing :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN users u ON u.id = f.followee_id
+WHERE f.follower_id = $1 AND u.deleted_at IS NULL
+    AND ($2::integer IS NULL OR EXISTS (
+        SELECT 1 FROM organization_members m
+        WHERE m.organization_id = $2 AND m.user_id = u.id))
+ORDER BY f.created_at DESC, u.id
+LIMIT $3 OFFSET $4
+`
+
+type ListFollowingParams struct {
+	FollowerID     int32         `db:"follower_id" json:"followerId"`
+	OrganizationID sql.NullInt32 `db:"organization_id" json:"organizationId"`
+	LimitCount     int32         `db:"limit_count" json:"limitCount"`
+	OffsetCount    int32         `db:"offset_count" json:"offsetCount"`
+}
+
+type ListFollowingRow struct {
+	ID         int32          `db:"id" json:"id"`
+	Username   string         `db:"username" json:"username"`
+	FullName   string         `db:"full_name" json:"fullName"`
+	AvatarUrl  sql.NullString `db:"avatar_url" json:"avatarUrl"`
+	FollowedAt time.Time      `db:"followed_at" json:"followedAt"`
+}
+
+// A NULL organization_id lists users of every organization.
+// A NULL organization_id lists users of every organization.
+func (q *Queries) ListFollowing(ctx context.Context, arg ListFollowingParams) ([]ListFollowingRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFollowing,
+		arg.FollowerID,
+		arg.OrganizationID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFollowingRow
+	for rows.Next() {
+		var i ListFollowingRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.AvatarUrl,
+			&i.FollowedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInvitations = `-- name: ListInvitations :many
+SELECT id, organization_id, email, role, token_hash, invited_by, expires_at, created_at, consumed_at, consumed_by, revoked_at FROM invitations
+WHERE organization_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListInvitationsParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	Limit          int32 `db:"limit" json:"limit"`
+	Offset         int32 `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListInvitations(ctx context.Context, arg ListInvitationsParams) ([]Invitation, error) {
+	rows, err := q.db.QueryContext(ctx, listInvitations, arg.OrganizationID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invitation
+	for rows.Next() {
+		var i Invitation
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Email,
+			&i.Role,
+			&i.TokenHash,
+			&i.InvitedBy,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.ConsumedAt,
+			&i.ConsumedBy,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMessageReactions = `-- name: ListMessageReactions :many
+SELECT mr.emoji, mr.user_id, u.username, mr.created_at
+FROM message_reactions mr
+JOIN users u ON u.id = mr.user_id
+WHERE mr.message_id = $1
+ORDER BY mr.created_at, mr.user_id
+`
+
+type ListMessageReactionsRow struct {
+	Emoji     string    `db:"emoji" json:"emoji"`
+	UserID    int32     `db:"user_id" json:"userId"`
+	Username  string    `db:"username" json:"username"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Lists who reacted to a message, in the order they reacted.
+func (q *Queries) ListMessageReactions(ctx context.Context, messageID int32) ([]ListMessageReactionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMessageReactions, messageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMessageReactionsRow
+	for rows.Next() {
+		var i ListMessageReactionsRow
+		if err := rows.Scan(
+			&i.Emoji,
+			&i.UserID,
+			&i.Username,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMessagesSince = `-- name: ListMessagesSince :many
+SELECT m.id, m.sender_id, m.content, m.created_at, m.organization_id, m.room_id, m.recipient_id, m.edited_at, m.deleted_at, m.parent_id, u.username AS sender_name
+FROM messages m
+JOIN users u ON u.id = m.sender_id
+WHERE m.organization_id = $1
+  AND m.id > $2::integer
+  AND m.created_at > $3::timestamp
+  AND (
+    (m.recipient_id IS NOT NULL AND m.sender_id = $4::integer)
+    OR (
+      (
+        m.recipient_id = $4::integer
+        OR m.room_id IN (SELECT rm.room_id FROM room_members rm WHERE rm.user_id = $4::integer)
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM user_blocks b
+        WHERE b.blocker_id = $4::integer AND b.blocked_id = m.sender_id
+      )
+    )
+  )
+ORDER BY m.id
+LIMIT $5
+`
+
+type ListMessagesSinceParams struct {
+	OrganizationID int32     `db:"organization_id" json:"organizationId"`
+	AfterID        int32     `db:"after_id" json:"afterId"`
+	AfterTime      time.Time `db:"after_time" json:"afterTime"`
+	UserID         int32     `db:"user_id" json:"userId"`
+	LimitCount     int32     `db:"limit_count" json:"limitCount"`
+}
+
+type ListMessagesSinceRow struct {
+	ID             int32         `db:"id" json:"id"`
+	SenderID       int32         `db:"sender_id" json:"senderId"`
+	Content        string        `db:"content" json:"content"`
+	CreatedAt      sql.NullTime  `db:"created_at" json:"createdAt"`
+	OrganizationID int32         `db:"organization_id" json:"organizationId"`
+	RoomID         sql.NullInt32 `db:"room_id" json:"roomId"`
+	RecipientID    sql.NullInt32 `db:"recipient_id" json:"recipientId"`
+	EditedAt       sql.NullTime  `db:"edited_at" json:"editedAt"`
+	DeletedAt      sql.NullTime  `db:"deleted_at" json:"deletedAt"`
+	ParentID       sql.NullInt32 `db:"parent_id" json:"parentId"`
+	SenderName     string        `db:"sender_name" json:"senderName"`
+}
+
+// Lists, oldest first, the messages user_id would have received live after
+// after_id and after_time: the direct messages they sent, and the direct
+// messages and room messages they got except from users they blocked.
+func (q *Queries) ListMessagesSince(ctx context.Context, arg ListMessagesSinceParams) ([]ListMessagesSinceRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMessagesSince,
+		arg.OrganizationID,
+		arg.AfterID,
+		arg.AfterTime,
+		arg.UserID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMessagesSinceRow
+	for rows.Next() {
+		var i ListMessagesSinceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SenderID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.OrganizationID,
+			&i.RoomID,
+			&i.RecipientID,
+			&i.EditedAt,
+			&i.DeletedAt,
+			&i.ParentID,
+			&i.SenderName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMutualFollows = `-- name: ListMutualFollows :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, f.created_at AS followed_at
+FROM user_follows f
+JOIN user_follows back ON back.follower_id = f.followee_id AND back.followee_id = f.follower_id
+JOIN users u ON u.id = f.followee_id
+WHERE f.follower_id = $1 AND u.deleted_at IS NULL
+    AND ($2::integer IS NULL OR EXISTS (
+        SELECT 1 FROM organization_members m
+        WHERE m.organization_id = $2 AND m.user_id = u.id))
+ORDER BY f.created_at DESC, u.id
+LIMIT $3 OFFSET $4
+`
+
+type ListMutualFollowsParams struct {
+	FollowerID     int32         `db:"follower_id" json:"followerId"`
+	OrganizationID sql.NullInt32 `db:"organization_id" json:"organizationId"`
+	LimitCount     int32         `db:"limit_count" json:"limitCount"`
+	OffsetCount    int32         `db:"offset_count" json:"offsetCount"`
+}
+
+type ListMutualFollowsRow struct {
+	ID         int32          `db:"id" json:"id"`
+	Username   string         `db:"username" json:"username"`
+	FullName   string         `db:"full_name" json:"fullName"`
+	AvatarUrl  sql.NullString `db:"avatar_url" json:"avatarUrl"`
+	FollowedAt time.Time      `db:"followed_at" json:"followedAt"`
+}
+
+// Users that follow follower_id back, newest follow first. A NULL
+// organization_id lists users of every organization.
+func (q *Queries) ListMutualFollows(ctx context.Context, arg ListMutualFollowsParams) ([]ListMutualFollowsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMutualFollows,
+		arg.FollowerID,
+		arg.OrganizationID,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMutualFollowsRow
+	for rows.Next() {
+		var i ListMutualFollowsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.AvatarUrl,
+			&i.FollowedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrganizationMembers = `-- name: ListOrganizationMembers :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, m.role, m.created_at AS joined_at
+FROM organization_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1 AND u.deleted_at IS NULL
+ORDER BY m.created_at, u.id
+LIMIT $2 OFFSET $3
+`
+
+type ListOrganizationMembersParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	Limit          int32 `db:"limit" json:"limit"`
+	Offset         int32 `db:"offset" json:"offset"`
+}
+
+type ListOrganizationMembersRow struct {
+	ID        int32          `db:"id" json:"id"`
+	Username  string         `db:"username" json:"username"`
+	FullName  string         `db:"full_name" json:"fullName"`
+	AvatarUrl sql.NullString `db:"avatar_url" json:"avatarUrl"`
+	Role      string         `db:"role" json:"role"`
+	JoinedAt  time.Time      `db:"joined_at" json:"joinedAt"`
+}
+
+func (q *Queries) ListOrganizationMembers(ctx context.Context, arg ListOrganizationMembersParams) ([]ListOrganizationMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOrganizationMembers, arg.OrganizationID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrganizationMembersRow
+	for rows.Next() {
+		var i ListOrganizationMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.AvatarUrl,
+			&i.Role,
+			&i.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrganizationsForUser = `-- name: ListOrganizationsForUser :many
+SELECT o.id, o.slug, o.name, o.created_at, o.updated_at, m.role
+FROM organization_members m
+JOIN organizations o ON o.id = m.organization_id
+WHERE m.user_id = $1
+ORDER BY o.name, o.id
+`
+
+type ListOrganizationsForUserRow struct {
+	ID        int32     `db:"id" json:"id"`
+	Slug      string    `db:"slug" json:"slug"`
+	Name      string    `db:"name" json:"name"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt time.Time `db:"updated_at" json:"updatedAt"`
+	Role      string    `db:"role" json:"role"`
+}
+
+func (q *Queries) ListOrganizationsForUser(ctx context.Context, userID int32) ([]ListOrganizationsForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOrganizationsForUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrganizationsForUserRow
+	for rows.Next() {
+		var i ListOrganizationsForUserRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomIDsForUser = `-- name: ListRoomIDsForUser :many
+SELECT rm.room_id FROM room_members rm
+JOIN chat_rooms r ON r.id = rm.room_id
+WHERE rm.user_id = $1 AND r.organization_id = $2
+`
+
+type ListRoomIDsForUserParams struct {
+	UserID         int32 `db:"user_id" json:"userId"`
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+}
+
+func (q *Queries) ListRoomIDsForUser(ctx context.Context, arg ListRoomIDsForUserParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listRoomIDsForUser, arg.UserID, arg.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var room_id int32
+		if err := rows.Scan(&room_id); err != nil {
+			return nil, err
+		}
+		items = append(items, room_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomIDsWithUserActivity = `-- name: ListRoomIDsWithUserActivity :many
+SELECT m.room_id::integer AS room_id FROM messages m
+WHERE m.sender_id = $1 AND m.room_id IS NOT NULL
+UNION
+SELECT m.room_id::integer FROM message_reactions mr
+JOIN messages m ON m.id = mr.message_id
+WHERE mr.user_id = $1 AND m.room_id IS NOT NULL
+`
+
+// Rooms whose history shows the user: they posted or reacted there.
+func (q *Queries) ListRoomIDsWithUserActivity(ctx context.Context, senderID int32) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listRoomIDsWithUserActivity, senderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var room_id int32
+		if err := rows.Scan(&room_id); err != nil {
+			return nil, err
+		}
+		items = append(items, room_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomMembers = `-- name: ListRoomMembers :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, rm.joined_at
+FROM room_members rm
+JOIN users u ON u.id = rm.user_id
+WHERE rm.room_id = $1 AND u.deleted_at IS NULL
+ORDER BY rm.joined_at, u.id
+`
+
+type ListRoomMembersRow struct {
+	ID        int32          `db:"id" json:"id"`
+	Username  string         `db:"username" json:"username"`
+	FullName  string         `db:"full_name" json:"fullName"`
+	AvatarUrl sql.NullString `db:"avatar_url" json:"avatarUrl"`
+	JoinedAt  time.Time      `db:"joined_at" json:"joinedAt"`
+}
+
+func (q *Queries) ListRoomMembers(ctx context.Context, roomID int32) ([]ListRoomMembersRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRoomMembers, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRoomMembersRow
+	for rows.Next() {
+		var i ListRoomMembersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.AvatarUrl,
+			&i.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRoomRecipients = `-- name: ListRoomRecipients :many
+SELECT rm.user_id FROM room_members rm
+WHERE rm.room_id = $1 AND rm.user_id <> $2
+  AND NOT EXISTS (
+    SELECT 1 FROM user_blocks b
+    WHERE b.blocker_id = rm.user_id AND b.blocked_id = $2
+  )
+ORDER BY rm.user_id
+`
+
+type ListRoomRecipientsParams struct {
+	RoomID   int32 `db:"room_id" json:"roomId"`
+	SenderID int32 `db:"sender_id" json:"senderId"`
+}
+
+// Lists the members of a room a message from sender_id is unread for:
+// everyone but the sender and those who blocked them.
+func (q *Queries) ListRoomRecipients(ctx context.Context, arg ListRoomRecipientsParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listRoomRecipients, arg.RoomID, arg.SenderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var user_id int32
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserBlocks = `-- name: ListUserBlocks :many
+SELECT b.blocked_id, u.username, b.created_at
+FROM user_blocks b
+JOIN users u ON u.id = b.blocked_id
+WHERE b.blocker_id = $1
+ORDER BY b.created_at DESC, b.blocked_id
+`
+
+type ListUserBlocksRow struct {
+	BlockedID int32     `db:"blocked_id" json:"blockedId"`
+	Username  string    `db:"username" json:"username"`
+	CreatedAt time.Time `db:"created_at" json:"createdAt"`
+}
+
+func (q *Queries) ListUserBlocks(ctx context.Context, blockerID int32) ([]ListUserBlocksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserBlocks, blockerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserBlocksRow
+	for rows.Next() {
+		var i ListUserBlocksRow
+		if err := rows.Scan(&i.BlockedID, &i.Username, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count FROM users
+WHERE deleted_at IS NULL
+ORDER BY id
+LIMIT $1 OFFSET $2
+`
+
+type ListUsersParams struct {
+	Limit  int32 `db:"limit" json:"limit"`
+	Offset int32 `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.FullName,
+			&i.PasswordHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Bio,
+			&i.Locale,
+			&i.Timezone,
+			&i.AvatarUrl,
+			&i.Attributes,
+			&i.FollowersCount,
+			&i.FollowingCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersInOrganization = `-- name: ListUsersInOrganization :many
+SELECT u.id, u.username, u.email, u.full_name, u.password_hash, u.created_at, u.updated_at, u.deleted_at, u.bio, u.locale, u.timezone, u.avatar_url, u.attributes, u.followers_count, u.following_count FROM users u
+JOIN organization_members m ON m.user_id = u.id
+WHERE m.organization_id = $1 AND u.deleted_at IS NULL
+ORDER BY u.id
+LIMIT $2 OFFSET $3
+`
+
+type ListUsersInOrganizationParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	Limit          int32 `db:"limit" json:"limit"`
+	Offset         int32 `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListUsersInOrganization(ctx context.Context, arg ListUsersInOrganizationParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersInOrganization, arg.OrganizationID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.FullName,
+			&i.PasswordHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Bio,
+			&i.Locale,
+			&i.Timezone,
+			&i.AvatarUrl,
+			&i.Attributes,
+			&i.FollowersCount,
+			&i.FollowingCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, next_attempt_at, delivered_at, created_at FROM webhook_deliveries
+WHERE subscription_id = $1
+  AND ($2::text IS NULL OR status = $2)
+ORDER BY id DESC
+LIMIT $3 OFFSET $4
+`
+
+type ListWebhookDeliveriesParams struct {
+	SubscriptionID int32          `db:"subscription_id" json:"subscriptionId"`
+	Status         sql.NullString `db:"status" json:"status"`
+	LimitCount     int32          `db:"limit_count" json:"limitCount"`
+	OffsetCount    int32          `db:"offset_count" json:"offsetCount"`
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries,
+		arg.SubscriptionID,
+		arg.Status,
+		arg.LimitCount,
+		arg.OffsetCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.Attempts,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+SELECT id, url, event_types, secret, active, created_at, updated_at FROM webhook_subscriptions
+ORDER BY id
+`
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookSubscription
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			pq.Array(&i.EventTypes),
+			&i.Secret,
+			&i.Active,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markConversationRead = `-- name: MarkConversationRead :one
+INSERT INTO direct_reads (organization_id, user_id, peer_id, last_read_id)
+SELECT $1, $2, $3, MAX(m.id)
+FROM messages m
+WHERE m.organization_id = $1
+  AND m.sender_id = $3 AND m.recipient_id = $2::integer
+  AND ($4::integer = 0 OR m.id <= $4::integer)
+HAVING MAX(m.id) IS NOT NULL
+ON CONFLICT (organization_id, user_id, peer_id) DO UPDATE
+SET last_read_id = GREATEST(direct_reads.last_read_id, EXCLUDED.last_read_id),
+    read_at = CURRENT_TIMESTAMP
+RETURNING last_read_id
+`
+
+type MarkConversationReadParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+	PeerID         int32 `db:"peer_id" json:"peerId"`
+	UpToID         int32 `db:"up_to_id" json:"upToId"`
+}
+
+// Moves user_id's read marker in their conversation with peer_id to the
+// newest message peer_id has sent them, or to the newest up to up_to_id
+// unless it is 0. The marker never moves back. No row is returned when
+// there is no such message.
+func (q *Queries) MarkConversationRead(ctx context.Context, arg MarkConversationReadParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, markConversationRead,
+		arg.OrganizationID,
+		arg.UserID,
+		arg.PeerID,
+		arg.UpToID,
+	)
+	var last_read_id int32
+	err := row.Scan(&last_read_id)
+	return last_read_id, err
+}
+
+const markEmailChangeCancelled = `-- name: MarkEmailChangeCancelled :execrows
+UPDATE email_changes
+SET cancelled_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND cancelled_at IS NULL
+`
+
+func (q *Queries) MarkEmailChangeCancelled(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markEmailChangeCancelled, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markEmailChangeConfirmed = `-- name: MarkEmailChangeConfirmed :execrows
+UPDATE email_changes
+SET confirmed_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL
+`
+
+func (q *Queries) MarkEmailChangeConfirmed(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markEmailChangeConfirmed, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markOutboxEventDelivered = `-- name: MarkOutboxEventDelivered :exec
+UPDATE outbox_events
+SET delivered_at = CURRENT_TIMESTAMP, last_error = ''
+WHERE id = $1
+`
 
-const createMessage = `-- name: CreateMessage :one
-INSERT INTO messages (sender_id, content)
-VALUES ($1, $2)
-RETURNING id, sender_id, content, created_at
+func (q *Queries) MarkOutboxEventDelivered(ctx context.Context, id int64) error {
+	_, err := q.db.ExecContext(ctx, markOutboxEventDelivered, id)
+	return err
+}
+
+const markRoomRead = `-- name: MarkRoomRead :one
+INSERT INTO room_reads (organization_id, room_id, user_id, last_read_id)
+SELECT $1, $2, $3, MAX(m.id)
+FROM messages m
+WHERE m.organization_id = $1 AND m.room_id = $2
+  AND ($4::integer = 0 OR m.id <= $4::integer)
+HAVING MAX(m.id) IS NOT NULL
+ON CONFLICT (room_id, user_id) DO UPDATE
+SET last_read_id = GREATEST(room_reads.last_read_id, EXCLUDED.last_read_id),
+    read_at = CURRENT_TIMESTAMP
+RETURNING last_read_id
 `
 
-type CreateMessageParams struct {
-	SenderID int32  `db:"sender_id" json:"senderId"`
-	Content  string `db:"content" json:"content"`
+type MarkRoomReadParams struct {
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
+	RoomID         int32 `db:"room_id" json:"roomId"`
+	UserID         int32 `db:"user_id" json:"userId"`
+	UpToID         int32 `db:"up_to_id" json:"upToId"`
 }
 
-func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error) {
-	row := q.db.QueryRowContext(ctx, createMessage, arg.SenderID, arg.Content)
-	var i Message
-	err := row.Scan(
-		&i.ID,
-		&i.SenderID,
-		&i.Content,
-		&i.CreatedAt,
+// Moves user_id's read marker in the room to its newest message, or to the
+// newest up to up_to_id unless it is 0. The marker never moves back. No row
+// is returned when there is no such message.
+func (q *Queries) MarkRoomRead(ctx context.Context, arg MarkRoomReadParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, markRoomRead,
+		arg.OrganizationID,
+		arg.RoomID,
+		arg.UserID,
+		arg.UpToID,
 	)
-	return i, err
+	var last_read_id int32
+	err := row.Scan(&last_read_id)
+	return last_read_id, err
 }
 
-const createUser = `-- name: CreateUser :one
-INSERT INTO users (username, email, full_name, password_hash)
-VALUES ($1, $2, $3, $4)
-RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at
+const markWebhookDeliveryDelivered = `-- name: MarkWebhookDeliveryDelivered :exec
+UPDATE webhook_deliveries
+SET status = 'delivered', last_status_code = $2, last_error = '', delivered_at = CURRENT_TIMESTAMP
+WHERE id = $1
 `
 
-type CreateUserParams struct {
-	Username     string `db:"username" json:"username"`
-	Email        string `db:"email" json:"email"`
-	FullName     string `db:"full_name" json:"fullName"`
-	PasswordHash string `db:"password_hash" json:"passwordHash"`
+type MarkWebhookDeliveryDeliveredParams struct {
+	ID             int64 `db:"id" json:"id"`
+	LastStatusCode int32 `db:"last_status_code" json:"lastStatusCode"`
 }
 
-// queries.sql
-func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
-	row := q.db.QueryRowContext(ctx, createUser,
-		arg.Username,
-		arg.Email,
-		arg.FullName,
-		arg.PasswordHash,
-	)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.FullName,
-		&i.PasswordHash,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
+func (q *Queries) MarkWebhookDeliveryDelivered(ctx context.Context, arg MarkWebhookDeliveryDeliveredParams) error {
+	_, err := q.db.ExecContext(ctx, markWebhookDeliveryDelivered, arg.ID, arg.LastStatusCode)
+	return err
 }
 
-const getMessages = `-- name: GetMessages :many
-SELECT m.id, m.sender_id, m.content, m.created_at, u.username as sender_name 
-FROM messages m
-JOIN users u ON m.sender_id = u.id
-ORDER BY m.created_at DESC
-LIMIT $1 OFFSET $2
+const reassignMessagesRecipient = `-- name: ReassignMessagesRecipient :execrows
+UPDATE messages
+SET recipient_id = $1
+WHERE recipient_id = $2
 `
 
-type GetMessagesParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+type ReassignMessagesRecipientParams struct {
+	ToRecipientID   sql.NullInt32 `db:"to_recipient_id" json:"toRecipientId"`
+	FromRecipientID sql.NullInt32 `db:"from_recipient_id" json:"fromRecipientId"`
 }
 
-type GetMessagesRow struct {
-	ID         int32        `db:"id" json:"id"`
-	SenderID   int32        `db:"sender_id" json:"senderId"`
-	Content    string       `db:"content" json:"content"`
-	CreatedAt  sql.NullTime `db:"created_at" json:"createdAt"`
-	SenderName string       `db:"sender_name" json:"senderName"`
+func (q *Queries) ReassignMessagesRecipient(ctx context.Context, arg ReassignMessagesRecipientParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignMessagesRecipient, arg.ToRecipientID, arg.FromRecipientID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-func (q *Queries) GetMessages(ctx context.Context, arg GetMessagesParams) ([]GetMessagesRow, error) {
-	rows, err := q.db.QueryContext(ctx, getMessages, arg.Limit, arg.Offset)
+const reassignMessagesSender = `-- name: ReassignMessagesSender :execrows
+UPDATE messages
+SET sender_id = $1
+WHERE sender_id = $2
+`
+
+type ReassignMessagesSenderParams struct {
+	ToSenderID   int32 `db:"to_sender_id" json:"toSenderId"`
+	FromSenderID int32 `db:"from_sender_id" json:"fromSenderId"`
+}
+
+func (q *Queries) ReassignMessagesSender(ctx context.Context, arg ReassignMessagesSenderParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reassignMessagesSender, arg.ToSenderID, arg.FromSenderID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []GetMessagesRow
-	for rows.Next() {
-		var i GetMessagesRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.SenderID,
-			&i.Content,
-			&i.CreatedAt,
-			&i.SenderName,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+	return result.RowsAffected()
+}
+
+const redactAuditEventsForUser = `-- name: RedactAuditEventsForUser :execrows
+UPDATE audit_events
+SET before = '{}'::jsonb, after = '{}'::jsonb, metadata = '{}'::jsonb
+WHERE target_type = 'user' AND target_id = $1
+`
+
+func (q *Queries) RedactAuditEventsForUser(ctx context.Context, targetID sql.NullInt32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, redactAuditEventsForUser, targetID)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Close(); err != nil {
-		return nil, err
+	return result.RowsAffected()
+}
+
+const redactFailedLoginsForEmail = `-- name: RedactFailedLoginsForEmail :execrows
+UPDATE audit_events
+SET metadata = metadata - 'email'
+WHERE action = 'auth.login_failed' AND lower(metadata->>'email') = lower($1::text)
+`
+
+// A failed login for an email nobody had at the time has no target, only the
+// address that was tried.
+func (q *Queries) RedactFailedLoginsForEmail(ctx context.Context, email string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, redactFailedLoginsForEmail, email)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected()
+}
+
+const redactInvitationsForEmail = `-- name: RedactInvitationsForEmail :execrows
+UPDATE invitations
+SET email = 'erased-' || $1::integer || '@erased.invalid'
+WHERE lower(email) = lower($2::text)
+`
+
+type RedactInvitationsForEmailParams struct {
+	UserID int32  `db:"user_id" json:"userId"`
+	Email  string `db:"email" json:"email"`
+}
+
+// Invitations to an erased user's address get the address the user was
+// anonymized to.
+func (q *Queries) RedactInvitationsForEmail(ctx context.Context, arg RedactInvitationsForEmailParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, redactInvitationsForEmail, arg.UserID, arg.Email)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
 }
 
-const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at FROM users
-WHERE email = $1 AND deleted_at IS NULL
+const redactOutboxEventsForUser = `-- name: RedactOutboxEventsForUser :execrows
+UPDATE outbox_events
+SET payload = jsonb_build_object('id', aggregate_id, 'erased', true)
+WHERE aggregate_type = 'user' AND aggregate_id = $1
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.FullName,
-		&i.PasswordHash,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
-	)
-	return i, err
+func (q *Queries) RedactOutboxEventsForUser(ctx context.Context, aggregateID int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, redactOutboxEventsForUser, aggregateID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at FROM users
-WHERE id = $1 AND deleted_at IS NULL
+const redactWebhookDeliveriesForUser = `-- name: RedactWebhookDeliveriesForUser :execrows
+UPDATE webhook_deliveries
+SET payload = jsonb_set(payload, '{data}', jsonb_build_object('id', $1::integer, 'erased', true))
+WHERE payload->>'aggregateType' = 'user' AND payload->>'aggregateId' = $1::integer::text
 `
 
-func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByID, id)
-	var i User
+// Deliveries carry a copy of the outbox event, which may have been pruned
+// already, so they are matched on the event they carry.
+func (q *Queries) RedactWebhookDeliveriesForUser(ctx context.Context, userID int32) (int64, error) {
+	result, err := q.db.ExecContext(ctx, redactWebhookDeliveriesForUser, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const redeliverWebhookDelivery = `-- name: RedeliverWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, last_error = '', next_attempt_at = CURRENT_TIMESTAMP, delivered_at = NULL
+WHERE id = $1 AND subscription_id = $2 AND status <> 'pending'
+RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, last_status_code, last_error, next_attempt_at, delivered_at, created_at
+`
+
+type RedeliverWebhookDeliveryParams struct {
+	ID             int64 `db:"id" json:"id"`
+	SubscriptionID int32 `db:"subscription_id" json:"subscriptionId"`
+}
+
+// Puts a finished delivery back in the queue with a fresh attempt budget.
+func (q *Queries) RedeliverWebhookDelivery(ctx context.Context, arg RedeliverWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, redeliverWebhookDelivery, arg.ID, arg.SubscriptionID)
+	var i WebhookDelivery
 	err := row.Scan(
 		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.FullName,
-		&i.PasswordHash,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.EventType,
+		&i.Payload,
+		&i.Status,
+		&i.Attempts,
+		&i.LastStatusCode,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.DeliveredAt,
 		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at FROM users
-WHERE username = $1 AND deleted_at IS NULL
+const rescheduleOutboxEvent = `-- name: RescheduleOutboxEvent :exec
+UPDATE outbox_events
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $1::double precision),
+    last_error = $2
+WHERE id = $3
 `
 
-func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User, error) {
-	row := q.db.QueryRowContext(ctx, getUserByUsername, username)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Username,
-		&i.Email,
-		&i.FullName,
-		&i.PasswordHash,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-		&i.DeletedAt,
+type RescheduleOutboxEventParams struct {
+	DelaySeconds float64 `db:"delay_seconds" json:"delaySeconds"`
+	LastError    string  `db:"last_error" json:"lastError"`
+	ID           int64   `db:"id" json:"id"`
+}
+
+func (q *Queries) RescheduleOutboxEvent(ctx context.Context, arg RescheduleOutboxEventParams) error {
+	_, err := q.db.ExecContext(ctx, rescheduleOutboxEvent, arg.DelaySeconds, arg.LastError, arg.ID)
+	return err
+}
+
+const retryWebhookDelivery = `-- name: RetryWebhookDelivery :exec
+UPDATE webhook_deliveries
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => $1::double precision),
+    last_status_code = $2,
+    last_error = $3
+WHERE id = $4
+`
+
+type RetryWebhookDeliveryParams struct {
+	DelaySeconds   float64 `db:"delay_seconds" json:"delaySeconds"`
+	LastStatusCode int32   `db:"last_status_code" json:"lastStatusCode"`
+	LastError      string  `db:"last_error" json:"lastError"`
+	ID             int64   `db:"id" json:"id"`
+}
+
+func (q *Queries) RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) error {
+	_, err := q.db.ExecContext(ctx, retryWebhookDelivery,
+		arg.DelaySeconds,
+		arg.LastStatusCode,
+		arg.LastError,
+		arg.ID,
 	)
-	return i, err
+	return err
 }
 
-const listUsers = `-- name: ListUsers :many
-SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at FROM users
-WHERE deleted_at IS NULL
-ORDER BY id
-LIMIT $1 OFFSET $2
+const revokeInvitation = `-- name: RevokeInvitation :execrows
+UPDATE invitations
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND organization_id = $2
+  AND consumed_at IS NULL AND revoked_at IS NULL
 `
 
-type ListUsersParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+type RevokeInvitationParams struct {
+	ID             int32 `db:"id" json:"id"`
+	OrganizationID int32 `db:"organization_id" json:"organizationId"`
 }
 
-func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+func (q *Queries) RevokeInvitation(ctx context.Context, arg RevokeInvitationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeInvitation, arg.ID, arg.OrganizationID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []User
-	for rows.Next() {
-		var i User
-		if err := rows.Scan(
-			&i.ID,
-			&i.Username,
-			&i.Email,
-			&i.FullName,
-			&i.PasswordHash,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
+}
+
+const setTenant = `-- name: SetTenant :exec
+SELECT set_config('app.tenant_id', $1::text, true)
+`
+
+// Scopes row-level security to one organization until the transaction ends.
+func (q *Queries) SetTenant(ctx context.Context, tenantID string) error {
+	_, err := q.db.ExecContext(ctx, setTenant, tenantID)
+	return err
 }
 
 const softDeleteUser = `-- name: SoftDeleteUser :execrows
@@ -239,11 +3422,49 @@ func (q *Queries) SoftDeleteUser(ctx context.Context, id int32) (int64, error) {
 	return result.RowsAffected()
 }
 
+const swapUserEmail = `-- name: SwapUserEmail :one
+UPDATE users
+SET email = $1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND email = $3 AND deleted_at IS NULL
+RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count
+`
+
+type SwapUserEmailParams struct {
+	NewEmail string `db:"new_email" json:"newEmail"`
+	ID       int32  `db:"id" json:"id"`
+	OldEmail string `db:"old_email" json:"oldEmail"`
+}
+
+// Replaces the email only if it still matches old_email, so a stale
+// confirmation or cancellation cannot overwrite a later change.
+func (q *Queries) SwapUserEmail(ctx context.Context, arg SwapUserEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, swapUserEmail, arg.NewEmail, arg.ID, arg.OldEmail)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.FullName,
+		&i.PasswordHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
 const updateUserFull = `-- name: UpdateUserFull :one
 UPDATE users
 SET username = $2, email = $3, full_name = $4, password_hash = $5, updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at
+RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count
 `
 
 type UpdateUserFullParams struct {
@@ -272,6 +3493,13 @@ func (q *Queries) UpdateUserFull(ctx context.Context, arg UpdateUserFullParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
 	)
 	return i, err
 }
@@ -279,30 +3507,55 @@ func (q *Queries) UpdateUserFull(ctx context.Context, arg UpdateUserFullParams)
 const updateUserPartial = `-- name: UpdateUserPartial :one
 UPDATE users
 SET
-    username = COALESCE($2, username),
-    email = COALESCE($3, email),
-    full_name = COALESCE($4, full_name),
-    password_hash = COALESCE($5, password_hash),
+    username = COALESCE($1, username),
+    email = COALESCE($2, email),
+    full_name = COALESCE($3, full_name),
+    password_hash = COALESCE($4, password_hash),
+    bio = CASE WHEN $5::boolean THEN $6 ELSE bio END,
+    locale = CASE WHEN $7::boolean THEN $8 ELSE locale END,
+    timezone = CASE WHEN $9::boolean THEN $10 ELSE timezone END,
+    avatar_url = CASE WHEN $11::boolean THEN $12 ELSE avatar_url END,
+    attributes = COALESCE($13::jsonb, attributes),
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at
+WHERE id = $14 AND deleted_at IS NULL
+RETURNING id, username, email, full_name, password_hash, created_at, updated_at, deleted_at, bio, locale, timezone, avatar_url, attributes, followers_count, following_count
 `
 
 type UpdateUserPartialParams struct {
-	ID           int32  `db:"id" json:"id"`
-	Username     *string `db:"username" json:"username"`
-	Email        *string `db:"email" json:"email"`
-	FullName     *string `db:"full_name" json:"fullName"`
-	PasswordHash *string `db:"password_hash" json:"passwordHash"`
+	Username     *string         `db:"username" json:"username"`
+	Email        *string         `db:"email" json:"email"`
+	FullName     *string         `db:"full_name" json:"fullName"`
+	PasswordHash *string         `db:"password_hash" json:"passwordHash"`
+	SetBio       bool            `db:"set_bio" json:"setBio"`
+	Bio          sql.NullString  `db:"bio" json:"bio"`
+	SetLocale    bool            `db:"set_locale" json:"setLocale"`
+	Locale       sql.NullString  `db:"locale" json:"locale"`
+	SetTimezone  bool            `db:"set_timezone" json:"setTimezone"`
+	Timezone     sql.NullString  `db:"timezone" json:"timezone"`
+	SetAvatarUrl bool            `db:"set_avatar_url" json:"setAvatarUrl"`
+	AvatarUrl    sql.NullString  `db:"avatar_url" json:"avatarUrl"`
+	Attributes   json.RawMessage `db:"attributes" json:"attributes"`
+	ID           int32           `db:"id" json:"id"`
 }
 
+// Nullable profile columns carry an explicit set_* flag so that a NULL
+// argument clears the column instead of leaving it unchanged.
 func (q *Queries) UpdateUserPartial(ctx context.Context, arg UpdateUserPartialParams) (User, error) {
 	row := q.db.QueryRowContext(ctx, updateUserPartial,
-		arg.ID,
 		arg.Username,
 		arg.Email,
 		arg.FullName,
 		arg.PasswordHash,
+		arg.SetBio,
+		arg.Bio,
+		arg.SetLocale,
+		arg.Locale,
+		arg.SetTimezone,
+		arg.Timezone,
+		arg.SetAvatarUrl,
+		arg.AvatarUrl,
+		arg.Attributes,
+		arg.ID,
 	)
 	var i User
 	err := row.Scan(
@@ -314,6 +3567,74 @@ func (q *Queries) UpdateUserPartial(ctx context.Context, arg UpdateUserPartialPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Bio,
+		&i.Locale,
+		&i.Timezone,
+		&i.AvatarUrl,
+		&i.Attributes,
+		&i.FollowersCount,
+		&i.FollowingCount,
+	)
+	return i, err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET url = $2, event_types = $3, secret = $4, active = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, url, event_types, secret, active, created_at, updated_at
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	ID         int32    `db:"id" json:"id"`
+	Url        string   `db:"url" json:"url"`
+	EventTypes []string `db:"event_types" json:"eventTypes"`
+	Secret     string   `db:"secret" json:"secret"`
+	Active     bool     `db:"active" json:"active"`
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, updateWebhookSubscription,
+		arg.ID,
+		arg.Url,
+		pq.Array(arg.EventTypes),
+		arg.Secret,
+		arg.Active,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		pq.Array(&i.EventTypes),
+		&i.Secret,
+		&i.Active,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertOrganizationMember = `-- name: UpsertOrganizationMember :one
+INSERT INTO organization_members (organization_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, user_id) DO UPDATE SET role = EXCLUDED.role
+RETURNING organization_id, user_id, role, created_at
+`
+
+type UpsertOrganizationMemberParams struct {
+	OrganizationID int32  `db:"organization_id" json:"organizationId"`
+	UserID         int32  `db:"user_id" json:"userId"`
+	Role           string `db:"role" json:"role"`
+}
+
+func (q *Queries) UpsertOrganizationMember(ctx context.Context, arg UpsertOrganizationMemberParams) (OrganizationMember, error) {
+	row := q.db.QueryRowContext(ctx, upsertOrganizationMember, arg.OrganizationID, arg.UserID, arg.Role)
+	var i OrganizationMember
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.UserID,
+		&i.Role,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IEmailChangeRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateEmailChangeParams) (dbCtx.EmailChange, error)
+
+	CancelPending(ctx Ctx, userID int32) error
+
+	GetByConfirmToken(ctx Ctx, tokenHash string) (dbCtx.EmailChange, error)
+
+	GetByCancelToken(ctx Ctx, tokenHash string) (dbCtx.EmailChange, error)
+
+	MarkConfirmed(ctx Ctx, id int32) (int64, error)
+
+	MarkCancelled(ctx Ctx, id int32) (int64, error)
+
+	DeleteByUser(ctx Ctx, userID int32) error
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type EmailChangeRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewEmailChangeRepo(db dbCtx.DBTX) IEmailChangeRepo {
+	return &EmailChangeRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *EmailChangeRepo) Create(ctx Ctx, arg dbCtx.CreateEmailChangeParams) (dbCtx.EmailChange, error) {
+	return r.q.CreateEmailChange(ctx, arg)
+}
+
+func (r *EmailChangeRepo) CancelPending(ctx Ctx, userID int32) error {
+	return r.q.CancelPendingEmailChanges(ctx, userID)
+}
+
+func (r *EmailChangeRepo) GetByConfirmToken(ctx Ctx, tokenHash string) (dbCtx.EmailChange, error) {
+	return r.q.GetEmailChangeByConfirmToken(ctx, tokenHash)
+}
+
+func (r *EmailChangeRepo) GetByCancelToken(ctx Ctx, tokenHash string) (dbCtx.EmailChange, error) {
+	return r.q.GetEmailChangeByCancelToken(ctx, tokenHash)
+}
+
+func (r *EmailChangeRepo) MarkConfirmed(ctx Ctx, id int32) (int64, error) {
+	return r.q.MarkEmailChangeConfirmed(ctx, id)
+}
+
+func (r *EmailChangeRepo) MarkCancelled(ctx Ctx, id int32) (int64, error) {
+	return r.q.MarkEmailChangeCancelled(ctx, id)
+}
+
+func (r *EmailChangeRepo) DeleteByUser(ctx Ctx, userID int32) error {
+	return r.q.DeleteEmailChangesByUser(ctx, userID)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IFollowRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateFollowParams) (int64, error)
+	Delete(ctx Ctx, arg dbCtx.DeleteFollowParams) (int64, error)
+	ListFollowers(ctx Ctx, arg dbCtx.ListFollowersParams) ([]dbCtx.ListFollowersRow, error)
+	ListFollowing(ctx Ctx, arg dbCtx.ListFollowingParams) ([]dbCtx.ListFollowingRow, error)
+	ListMutual(ctx Ctx, arg dbCtx.ListMutualFollowsParams) ([]dbCtx.ListMutualFollowsRow, error)
+	DeleteByUser(ctx Ctx, userID int32) error
+}
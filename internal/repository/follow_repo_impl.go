@@ -0,0 +1,39 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type FollowRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewFollowRepo(db dbCtx.DBTX) IFollowRepo {
+	return &FollowRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *FollowRepo) Create(ctx Ctx, arg dbCtx.CreateFollowParams) (int64, error) {
+	return r.q.CreateFollow(ctx, arg)
+}
+
+func (r *FollowRepo) Delete(ctx Ctx, arg dbCtx.DeleteFollowParams) (int64, error) {
+	return r.q.DeleteFollow(ctx, arg)
+}
+
+func (r *FollowRepo) ListFollowers(ctx Ctx, arg dbCtx.ListFollowersParams) ([]dbCtx.ListFollowersRow, error) {
+	return r.q.ListFollowers(ctx, arg)
+}
+
+func (r *FollowRepo) ListFollowing(ctx Ctx, arg dbCtx.ListFollowingParams) ([]dbCtx.ListFollowingRow, error) {
+	return r.q.ListFollowing(ctx, arg)
+}
+
+func (r *FollowRepo) ListMutual(ctx Ctx, arg dbCtx.ListMutualFollowsParams) ([]dbCtx.ListMutualFollowsRow, error) {
+	return r.q.ListMutualFollows(ctx, arg)
+}
+
+func (r *FollowRepo) DeleteByUser(ctx Ctx, userID int32) error {
+	return r.q.DeleteFollowsByUser(ctx, userID)
+}
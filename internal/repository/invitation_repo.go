@@ -0,0 +1,16 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IInvitationRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateInvitationParams) (dbCtx.Invitation, error)
+	GetByToken(ctx Ctx, tokenHash string) (dbCtx.Invitation, error)
+	List(ctx Ctx, arg dbCtx.ListInvitationsParams) ([]dbCtx.Invitation, error)
+	// Revoke and Consume only touch pending invitations; they report zero
+	// rows for one that was already used or revoked.
+	Revoke(ctx Ctx, arg dbCtx.RevokeInvitationParams) (int64, error)
+	Consume(ctx Ctx, arg dbCtx.ConsumeInvitationParams) (int64, error)
+	RedactEmail(ctx Ctx, arg dbCtx.RedactInvitationsForEmailParams) (int64, error)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type InvitationRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewInvitationRepo(db dbCtx.DBTX) IInvitationRepo {
+	return &InvitationRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *InvitationRepo) Create(ctx Ctx, arg dbCtx.CreateInvitationParams) (dbCtx.Invitation, error) {
+	return r.q.CreateInvitation(ctx, arg)
+}
+
+func (r *InvitationRepo) GetByToken(ctx Ctx, tokenHash string) (dbCtx.Invitation, error) {
+	return r.q.GetInvitationByToken(ctx, tokenHash)
+}
+
+func (r *InvitationRepo) List(ctx Ctx, arg dbCtx.ListInvitationsParams) ([]dbCtx.Invitation, error) {
+	return r.q.ListInvitations(ctx, arg)
+}
+
+func (r *InvitationRepo) Revoke(ctx Ctx, arg dbCtx.RevokeInvitationParams) (int64, error) {
+	return r.q.RevokeInvitation(ctx, arg)
+}
+
+func (r *InvitationRepo) Consume(ctx Ctx, arg dbCtx.ConsumeInvitationParams) (int64, error) {
+	return r.q.ConsumeInvitation(ctx, arg)
+}
+
+func (r *InvitationRepo) RedactEmail(ctx Ctx, arg dbCtx.RedactInvitationsForEmailParams) (int64, error) {
+	return r.q.RedactInvitationsForEmail(ctx, arg)
+}
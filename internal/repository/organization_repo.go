@@ -0,0 +1,23 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IOrganizationRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateOrganizationParams) (dbCtx.Organization, error)
+	GetBySlug(ctx Ctx, slug string) (dbCtx.Organization, error)
+	ListForUser(ctx Ctx, userID int32) ([]dbCtx.ListOrganizationsForUserRow, error)
+	GetMember(ctx Ctx, arg dbCtx.GetOrganizationMemberParams) (dbCtx.OrganizationMember, error)
+	AddMember(ctx Ctx, arg dbCtx.AddOrganizationMemberParams) (int64, error)
+	UpsertMember(ctx Ctx, arg dbCtx.UpsertOrganizationMemberParams) (dbCtx.OrganizationMember, error)
+	DeleteMember(ctx Ctx, arg dbCtx.DeleteOrganizationMemberParams) (int64, error)
+	CountOwners(ctx Ctx, organizationID int32) (int64, error)
+	ListMembers(ctx Ctx, arg dbCtx.ListOrganizationMembersParams) ([]dbCtx.ListOrganizationMembersRow, error)
+	// FilterMembers returns the userIDs that are members of the organization.
+	FilterMembers(ctx Ctx, arg dbCtx.FilterOrganizationMembersParams) ([]int32, error)
+	ListUsers(ctx Ctx, arg dbCtx.ListUsersInOrganizationParams) ([]dbCtx.User, error)
+	// SetTenant scopes row-level security to organizationID for the rest of
+	// the transaction. It has no lasting effect outside one.
+	SetTenant(ctx Ctx, organizationID int32) error
+}
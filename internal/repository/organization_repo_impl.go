@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"strconv"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type OrganizationRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewOrganizationRepo(db dbCtx.DBTX) IOrganizationRepo {
+	return &OrganizationRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *OrganizationRepo) Create(ctx Ctx, arg dbCtx.CreateOrganizationParams) (dbCtx.Organization, error) {
+	return r.q.CreateOrganization(ctx, arg)
+}
+
+func (r *OrganizationRepo) GetBySlug(ctx Ctx, slug string) (dbCtx.Organization, error) {
+	return r.q.GetOrganizationBySlug(ctx, slug)
+}
+
+func (r *OrganizationRepo) ListForUser(ctx Ctx, userID int32) ([]dbCtx.ListOrganizationsForUserRow, error) {
+	return r.q.ListOrganizationsForUser(ctx, userID)
+}
+
+func (r *OrganizationRepo) GetMember(ctx Ctx, arg dbCtx.GetOrganizationMemberParams) (dbCtx.OrganizationMember, error) {
+	return r.q.GetOrganizationMember(ctx, arg)
+}
+
+func (r *OrganizationRepo) AddMember(ctx Ctx, arg dbCtx.AddOrganizationMemberParams) (int64, error) {
+	return r.q.AddOrganizationMember(ctx, arg)
+}
+
+func (r *OrganizationRepo) UpsertMember(ctx Ctx, arg dbCtx.UpsertOrganizationMemberParams) (dbCtx.OrganizationMember, error) {
+	return r.q.UpsertOrganizationMember(ctx, arg)
+}
+
+func (r *OrganizationRepo) DeleteMember(ctx Ctx, arg dbCtx.DeleteOrganizationMemberParams) (int64, error) {
+	return r.q.DeleteOrganizationMember(ctx, arg)
+}
+
+func (r *OrganizationRepo) CountOwners(ctx Ctx, organizationID int32) (int64, error) {
+	return r.q.CountOrganizationOwners(ctx, organizationID)
+}
+
+func (r *OrganizationRepo) ListMembers(ctx Ctx, arg dbCtx.ListOrganizationMembersParams) ([]dbCtx.ListOrganizationMembersRow, error) {
+	return r.q.ListOrganizationMembers(ctx, arg)
+}
+
+func (r *OrganizationRepo) FilterMembers(ctx Ctx, arg dbCtx.FilterOrganizationMembersParams) ([]int32, error) {
+	return r.q.FilterOrganizationMembers(ctx, arg)
+}
+
+func (r *OrganizationRepo) ListUsers(ctx Ctx, arg dbCtx.ListUsersInOrganizationParams) ([]dbCtx.User, error) {
+	return r.q.ListUsersInOrganization(ctx, arg)
+}
+
+func (r *OrganizationRepo) SetTenant(ctx Ctx, organizationID int32) error {
+	return r.q.SetTenant(ctx, strconv.Itoa(int(organizationID)))
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"database/sql"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IOutboxRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateOutboxEventParams) (dbCtx.OutboxEvent, error)
+	Claim(ctx Ctx, arg dbCtx.ClaimOutboxEventsParams) ([]dbCtx.OutboxEvent, error)
+	MarkDelivered(ctx Ctx, id int64) error
+	Reschedule(ctx Ctx, arg dbCtx.RescheduleOutboxEventParams) error
+	CountPending(ctx Ctx) (int64, error)
+	DeleteDelivered(ctx Ctx, before sql.NullTime) (int64, error)
+	RedactForUser(ctx Ctx, userID int32) (int64, error)
+}
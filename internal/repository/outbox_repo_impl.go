@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"database/sql"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type OutboxRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewOutboxRepo(db dbCtx.DBTX) IOutboxRepo {
+	return &OutboxRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *OutboxRepo) Create(ctx Ctx, arg dbCtx.CreateOutboxEventParams) (dbCtx.OutboxEvent, error) {
+	return r.q.CreateOutboxEvent(ctx, arg)
+}
+
+func (r *OutboxRepo) Claim(ctx Ctx, arg dbCtx.ClaimOutboxEventsParams) ([]dbCtx.OutboxEvent, error) {
+	return r.q.ClaimOutboxEvents(ctx, arg)
+}
+
+func (r *OutboxRepo) MarkDelivered(ctx Ctx, id int64) error {
+	return r.q.MarkOutboxEventDelivered(ctx, id)
+}
+
+func (r *OutboxRepo) Reschedule(ctx Ctx, arg dbCtx.RescheduleOutboxEventParams) error {
+	return r.q.RescheduleOutboxEvent(ctx, arg)
+}
+
+func (r *OutboxRepo) CountPending(ctx Ctx) (int64, error) {
+	return r.q.CountPendingOutboxEvents(ctx)
+}
+
+func (r *OutboxRepo) DeleteDelivered(ctx Ctx, before sql.NullTime) (int64, error) {
+	return r.q.DeleteDeliveredOutboxEvents(ctx, before)
+}
+
+func (r *OutboxRepo) RedactForUser(ctx Ctx, userID int32) (int64, error) {
+	return r.q.RedactOutboxEventsForUser(ctx, userID)
+}
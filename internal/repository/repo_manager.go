@@ -7,5 +7,14 @@ import (
 type IRepositoryManager interface {
 	User() IUserRepo
 	Chat() IChatRepo
+	EmailChange() IEmailChangeRepo
+	Erasure() IUserErasureRepo
+	Audit() IAuditRepo
+	Outbox() IOutboxRepo
+	Webhook() IWebhookRepo
+	Block() IBlockRepo
+	Follow() IFollowRepo
+	Organization() IOrganizationRepo
+	Invitation() IInvitationRepo
 	WithTx(context.Context, func(IRepositoryManager) error) error
 }
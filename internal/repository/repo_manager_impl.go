@@ -9,9 +9,18 @@ import (
 )
 
 type RepositoryManager struct {
-	db       dbCtx.DBTX
-	userRepo IUserRepo
-	chatRepo IChatRepo
+	db              dbCtx.DBTX
+	userRepo        IUserRepo
+	chatRepo        IChatRepo
+	emailChangeRepo IEmailChangeRepo
+	erasureRepo     IUserErasureRepo
+	auditRepo       IAuditRepo
+	outboxRepo      IOutboxRepo
+	webhookRepo     IWebhookRepo
+	blockRepo       IBlockRepo
+	followRepo      IFollowRepo
+	orgRepo         IOrganizationRepo
+	invitationRepo  IInvitationRepo
 }
 
 func NewRepositoryManager(db dbCtx.DBTX) IRepositoryManager {
@@ -41,7 +50,7 @@ func (rm *RepositoryManager) WithTx(ctx context.Context, fn func(IRepositoryMana
 		}
 	}()
 
-	if err := fn(txRepoMgr); err != nil {
+	if err = fn(txRepoMgr); err != nil {
 		return err
 	}
 	return tx.Commit()
@@ -60,3 +69,66 @@ func (r *RepositoryManager) Chat() IChatRepo {
 	}
 	return r.chatRepo
 }
+
+func (r *RepositoryManager) EmailChange() IEmailChangeRepo {
+	if r.emailChangeRepo == nil {
+		r.emailChangeRepo = NewEmailChangeRepo(r.db)
+	}
+	return r.emailChangeRepo
+}
+
+func (r *RepositoryManager) Erasure() IUserErasureRepo {
+	if r.erasureRepo == nil {
+		r.erasureRepo = NewUserErasureRepo(r.db)
+	}
+	return r.erasureRepo
+}
+
+func (r *RepositoryManager) Audit() IAuditRepo {
+	if r.auditRepo == nil {
+		r.auditRepo = NewAuditRepo(r.db)
+	}
+	return r.auditRepo
+}
+
+func (r *RepositoryManager) Outbox() IOutboxRepo {
+	if r.outboxRepo == nil {
+		r.outboxRepo = NewOutboxRepo(r.db)
+	}
+	return r.outboxRepo
+}
+
+func (r *RepositoryManager) Webhook() IWebhookRepo {
+	if r.webhookRepo == nil {
+		r.webhookRepo = NewWebhookRepo(r.db)
+	}
+	return r.webhookRepo
+}
+
+func (r *RepositoryManager) Block() IBlockRepo {
+	if r.blockRepo == nil {
+		r.blockRepo = NewBlockRepo(r.db)
+	}
+	return r.blockRepo
+}
+
+func (r *RepositoryManager) Follow() IFollowRepo {
+	if r.followRepo == nil {
+		r.followRepo = NewFollowRepo(r.db)
+	}
+	return r.followRepo
+}
+
+func (r *RepositoryManager) Organization() IOrganizationRepo {
+	if r.orgRepo == nil {
+		r.orgRepo = NewOrganizationRepo(r.db)
+	}
+	return r.orgRepo
+}
+
+func (r *RepositoryManager) Invitation() IInvitationRepo {
+	if r.invitationRepo == nil {
+		r.invitationRepo = NewInvitationRepo(r.db)
+	}
+	return r.invitationRepo
+}
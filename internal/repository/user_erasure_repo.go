@@ -0,0 +1,9 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IUserErasureRepo interface {
+	Create(ctx Ctx, arg dbCtx.CreateUserErasureParams) (dbCtx.UserErasure, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type UserErasureRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewUserErasureRepo(db dbCtx.DBTX) IUserErasureRepo {
+	return &UserErasureRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *UserErasureRepo) Create(ctx Ctx, arg dbCtx.CreateUserErasureParams) (dbCtx.UserErasure, error) {
+	return r.q.CreateUserErasure(ctx, arg)
+}
@@ -7,6 +7,11 @@ import (
 type IUserRepo interface {
 	GetByID(ctx Ctx, id int32) (User, error)
 
+	// GetByIDWithDeleted also finds soft-deleted users.
+	GetByIDWithDeleted(ctx Ctx, id int32) (User, error)
+
+	GetByIDs(ctx Ctx, ids []int32) ([]User, error)
+
 	GetByUsername(ctx Ctx, username string) (User, error)
 
 	GetByEmail(ctx Ctx, email string) (User, error)
@@ -19,5 +24,11 @@ type IUserRepo interface {
 
 	UpdatePartial(ctx Ctx, arg dbCtx.UpdateUserPartialParams) (User, error)
 
+	SwapEmail(ctx Ctx, arg dbCtx.SwapUserEmailParams) (User, error)
+
+	Anonymize(ctx Ctx, id int32) (User, error)
+
+	GetErasedPlaceholderID(ctx Ctx) (int32, error)
+
 	SoftDelete(ctx Ctx, id int32) (int64, error) 
 }
\ No newline at end of file
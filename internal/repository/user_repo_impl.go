@@ -25,6 +25,14 @@ func (u *UserRepo) GetByID(ctx Ctx, id int32) (User, error) {
 	return u.q.GetUserByID(ctx, id)
 }
 
+func (u *UserRepo) GetByIDWithDeleted(ctx Ctx, id int32) (User, error) {
+	return u.q.GetUserByIDWithDeleted(ctx, id)
+}
+
+func (u *UserRepo) GetByIDs(ctx Ctx, ids []int32) ([]User, error) {
+	return u.q.GetUsersByIDs(ctx, ids)
+}
+
 func (u *UserRepo) GetByUsername(ctx Ctx, username string) (User, error) {
 	return u.q.GetUserByUsername(ctx, username)
 }
@@ -44,3 +52,15 @@ func (u *UserRepo) UpdateFull(ctx Ctx, arg dbCtx.UpdateUserFullParams) (User, er
 func (u *UserRepo) UpdatePartial(ctx Ctx, arg dbCtx.UpdateUserPartialParams) (User, error) {
 	return u.q.UpdateUserPartial(ctx, arg)
 }
+
+func (u *UserRepo) SwapEmail(ctx Ctx, arg dbCtx.SwapUserEmailParams) (User, error) {
+	return u.q.SwapUserEmail(ctx, arg)
+}
+
+func (u *UserRepo) Anonymize(ctx Ctx, id int32) (User, error) {
+	return u.q.AnonymizeUser(ctx, id)
+}
+
+func (u *UserRepo) GetErasedPlaceholderID(ctx Ctx) (int32, error) {
+	return u.q.GetErasedUserPlaceholder(ctx)
+}
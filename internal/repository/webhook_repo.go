@@ -0,0 +1,22 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IWebhookRepo interface {
+	CreateSubscription(ctx Ctx, arg dbCtx.CreateWebhookSubscriptionParams) (dbCtx.WebhookSubscription, error)
+	GetSubscription(ctx Ctx, id int32) (dbCtx.WebhookSubscription, error)
+	ListSubscriptions(ctx Ctx) ([]dbCtx.WebhookSubscription, error)
+	UpdateSubscription(ctx Ctx, arg dbCtx.UpdateWebhookSubscriptionParams) (dbCtx.WebhookSubscription, error)
+	DeleteSubscription(ctx Ctx, id int32) (int64, error)
+
+	EnqueueDeliveries(ctx Ctx, arg dbCtx.EnqueueWebhookDeliveriesParams) (int64, error)
+	ClaimDeliveries(ctx Ctx, arg dbCtx.ClaimWebhookDeliveriesParams) ([]dbCtx.ClaimWebhookDeliveriesRow, error)
+	MarkDelivered(ctx Ctx, arg dbCtx.MarkWebhookDeliveryDeliveredParams) error
+	RetryDelivery(ctx Ctx, arg dbCtx.RetryWebhookDeliveryParams) error
+	DeadLetter(ctx Ctx, arg dbCtx.DeadLetterWebhookDeliveryParams) error
+	ListDeliveries(ctx Ctx, arg dbCtx.ListWebhookDeliveriesParams) ([]dbCtx.WebhookDelivery, error)
+	Redeliver(ctx Ctx, arg dbCtx.RedeliverWebhookDeliveryParams) (dbCtx.WebhookDelivery, error)
+	RedactDeliveriesForUser(ctx Ctx, userID int32) (int64, error)
+}
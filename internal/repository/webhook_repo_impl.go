@@ -0,0 +1,67 @@
+package repository
+
+import (
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type WebhookRepo struct {
+	q *dbCtx.Queries
+}
+
+func NewWebhookRepo(db dbCtx.DBTX) IWebhookRepo {
+	return &WebhookRepo{
+		q: dbCtx.New(db),
+	}
+}
+
+func (r *WebhookRepo) CreateSubscription(ctx Ctx, arg dbCtx.CreateWebhookSubscriptionParams) (dbCtx.WebhookSubscription, error) {
+	return r.q.CreateWebhookSubscription(ctx, arg)
+}
+
+func (r *WebhookRepo) GetSubscription(ctx Ctx, id int32) (dbCtx.WebhookSubscription, error) {
+	return r.q.GetWebhookSubscription(ctx, id)
+}
+
+func (r *WebhookRepo) ListSubscriptions(ctx Ctx) ([]dbCtx.WebhookSubscription, error) {
+	return r.q.ListWebhookSubscriptions(ctx)
+}
+
+func (r *WebhookRepo) UpdateSubscription(ctx Ctx, arg dbCtx.UpdateWebhookSubscriptionParams) (dbCtx.WebhookSubscription, error) {
+	return r.q.UpdateWebhookSubscription(ctx, arg)
+}
+
+func (r *WebhookRepo) DeleteSubscription(ctx Ctx, id int32) (int64, error) {
+	return r.q.DeleteWebhookSubscription(ctx, id)
+}
+
+func (r *WebhookRepo) EnqueueDeliveries(ctx Ctx, arg dbCtx.EnqueueWebhookDeliveriesParams) (int64, error) {
+	return r.q.EnqueueWebhookDeliveries(ctx, arg)
+}
+
+func (r *WebhookRepo) ClaimDeliveries(ctx Ctx, arg dbCtx.ClaimWebhookDeliveriesParams) ([]dbCtx.ClaimWebhookDeliveriesRow, error) {
+	return r.q.ClaimWebhookDeliveries(ctx, arg)
+}
+
+func (r *WebhookRepo) MarkDelivered(ctx Ctx, arg dbCtx.MarkWebhookDeliveryDeliveredParams) error {
+	return r.q.MarkWebhookDeliveryDelivered(ctx, arg)
+}
+
+func (r *WebhookRepo) RetryDelivery(ctx Ctx, arg dbCtx.RetryWebhookDeliveryParams) error {
+	return r.q.RetryWebhookDelivery(ctx, arg)
+}
+
+func (r *WebhookRepo) DeadLetter(ctx Ctx, arg dbCtx.DeadLetterWebhookDeliveryParams) error {
+	return r.q.DeadLetterWebhookDelivery(ctx, arg)
+}
+
+func (r *WebhookRepo) ListDeliveries(ctx Ctx, arg dbCtx.ListWebhookDeliveriesParams) ([]dbCtx.WebhookDelivery, error) {
+	return r.q.ListWebhookDeliveries(ctx, arg)
+}
+
+func (r *WebhookRepo) Redeliver(ctx Ctx, arg dbCtx.RedeliverWebhookDeliveryParams) (dbCtx.WebhookDelivery, error) {
+	return r.q.RedeliverWebhookDelivery(ctx, arg)
+}
+
+func (r *WebhookRepo) RedactDeliveriesForUser(ctx Ctx, userID int32) (int64, error) {
+	return r.q.RedactWebhookDeliveriesForUser(ctx, userID)
+}
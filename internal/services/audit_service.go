@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// Audit actions recorded by the services.
+const (
+	AuditUserCreated   = "user.created"
+	AuditUserUpdated   = "user.updated"
+	AuditUserDeleted   = "user.deleted"
+	AuditUserErased    = "user.erased"
+	AuditLogin         = "auth.login"
+	AuditLoginFailed   = "auth.login_failed"
+	AuditRefresh       = "auth.refresh"
+	AuditRefreshFailed = "auth.refresh_failed"
+)
+
+const AuditTargetUser = "user"
+
+// AuditEntry is an event about to be recorded. Before and After are encoded
+// as JSON and should only hold what changed. The actor, IP and request ID
+// come from the RequestMeta of the context unless ActorID is set.
+type AuditEntry struct {
+	Action     string
+	TargetType string
+	TargetID   int32 // 0 when there is no target
+	ActorID    int32
+	Before     map[string]any
+	After      map[string]any
+	Metadata   map[string]any
+}
+
+type IAuditService interface {
+	// Record writes an event outside of any transaction.
+	Record(ctx context.Context, entry AuditEntry) error
+
+	// RecordTx writes an event through tx, so it is committed or rolled back
+	// together with the change it describes.
+	RecordTx(ctx context.Context, tx repository.IRepositoryManager, entry AuditEntry) error
+
+	List(ctx context.Context, filter dto.AuditEventFilter) ([]dbCtx.AuditEvent, error)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/pkg/logging"
+	"example.com/api/pkg/metrics"
+)
+
+type AuditService struct {
+	repo   repository.IRepositoryManager
+	logger logging.ILogger
+}
+
+func NewAuditService(r repository.IRepositoryManager, l logging.ILogger) *AuditService {
+	return &AuditService{
+		repo:   r,
+		logger: l,
+	}
+}
+
+func (s *AuditService) Record(ctx context.Context, entry AuditEntry) error {
+	return s.RecordTx(ctx, s.repo, entry)
+}
+
+func (s *AuditService) RecordTx(ctx context.Context, tx repository.IRepositoryManager, entry AuditEntry) error {
+	meta := RequestMetaFrom(ctx)
+	actorID := entry.ActorID
+	if actorID == 0 {
+		actorID = meta.ActorID
+	}
+
+	before, err := encodeAuditFields(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := encodeAuditFields(entry.After)
+	if err != nil {
+		return err
+	}
+	metadata, err := encodeAuditFields(entry.Metadata)
+	if err != nil {
+		return err
+	}
+
+	metrics.DbCall.WithLabelValues("Audit", "Create", "started").Inc()
+	_, err = tx.Audit().Create(ctx, dbCtx.CreateAuditEventParams{
+		ActorID:    sql.NullInt32{Int32: actorID, Valid: actorID != 0},
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   sql.NullInt32{Int32: entry.TargetID, Valid: entry.TargetID != 0},
+		Before:     before,
+		After:      after,
+		Metadata:   metadata,
+		Ip:         meta.IP,
+		RequestID:  meta.RequestID,
+	})
+	if err != nil {
+		metrics.DbCall.WithLabelValues("Audit", "Create", "error").Inc()
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+	metrics.DbCall.WithLabelValues("Audit", "Create", "success").Inc()
+	return nil
+}
+
+func (s *AuditService) List(ctx context.Context, filter dto.AuditEventFilter) ([]dbCtx.AuditEvent, error) {
+	params := dbCtx.ListAuditEventsParams{
+		Action:      sql.NullString{String: filter.Action, Valid: filter.Action != ""},
+		TargetType:  sql.NullString{String: filter.TargetType, Valid: filter.TargetType != ""},
+		LimitCount:  filter.Limit,
+		OffsetCount: filter.Offset,
+	}
+	if filter.ActorID != nil {
+		params.ActorID = sql.NullInt32{Int32: *filter.ActorID, Valid: true}
+	}
+	if filter.TargetID != nil {
+		params.TargetID = sql.NullInt32{Int32: *filter.TargetID, Valid: true}
+	}
+	if filter.Since != nil {
+		params.Since = sql.NullTime{Time: filter.Since.UTC(), Valid: true}
+	}
+	if filter.Until != nil {
+		params.Until = sql.NullTime{Time: filter.Until.UTC(), Valid: true}
+	}
+
+	events, err := s.repo.Audit().List(ctx, params)
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to fetch audit events", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+		})
+		return nil, errors.New("failed to fetch audit events")
+	}
+	return events, nil
+}
+
+func encodeAuditFields(fields map[string]any) (json.RawMessage, error) {
+	if len(fields) == 0 {
+		return json.RawMessage(`{}`), nil
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	return data, nil
+}
+
+// auditSnapshot is the audited view of a user: the public profile without
+// timestamps. The password hash is left out; diffUsers reports a password
+// change without the hashes.
+func auditSnapshot(user dbCtx.User) map[string]any {
+	snapshot := map[string]any{}
+	data, _ := json.Marshal(dto.NewUserResponse(user))
+	_ = json.Unmarshal(data, &snapshot)
+	delete(snapshot, "createdAt")
+	delete(snapshot, "updatedAt")
+	delete(snapshot, "deletedAt")
+	return snapshot
+}
+
+// diffUsers returns the fields that differ between two versions of a user,
+// as they were before and as they are after.
+func diffUsers(before, after dbCtx.User) (map[string]any, map[string]any) {
+	old, updated := auditSnapshot(before), auditSnapshot(after)
+	changedBefore, changedAfter := map[string]any{}, map[string]any{}
+	for key, value := range updated {
+		if !reflect.DeepEqual(old[key], value) {
+			changedBefore[key] = old[key]
+			changedAfter[key] = value
+		}
+	}
+	if before.PasswordHash != after.PasswordHash {
+		changedBefore["password"] = "[redacted]"
+		changedAfter["password"] = "[redacted]"
+	}
+	return changedBefore, changedAfter
+}
@@ -23,5 +23,9 @@ type IAuthService interface {
 
 	Authenticate(ctx context.Context, email, password string) (*dbCtx.User, error)
 
+	// RegisterUser creates an account through open registration. It fails
+	// with ErrRegistrationClosed when registration is invite-only.
 	RegisterUser(ctx context.Context, args dto.Register) (*dto.UserResponse, string, string, error)
+
+	AcceptInvite(ctx context.Context, args dto.AcceptInvitation) (*dto.UserResponse, string, string, error)
 }
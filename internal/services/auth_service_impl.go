@@ -4,24 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"example.com/api/config"
 	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
 	dbCtx "example.com/api/internal/repository/db"
 	"example.com/api/internal/services/hashing"
 	"example.com/api/internal/storage"
+	"example.com/api/internal/tenant"
 	"example.com/api/pkg/logging"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
 
 type AuthService struct {
+	repo         repository.IRepositoryManager
 	logger       logging.ILogger
 	jwtConf      config.JWTConfig
 	hashService  hashing.IHashService
 	userService  IUserService
 	tokenStorage storage.ITokenStorage
+	audit        IAuditService
+	invitations  IInvitationService
+	invConf      config.InvitationConfig
 }
 
 const (
@@ -29,17 +36,27 @@ const (
 	tokenTypeRefresh = "refresh"
 )
 
+var ErrRegistrationClosed = errors.New("registration is by invitation only")
+
 func NewAuthService(
+	repo repository.IRepositoryManager,
 	jwtConf config.JWTConfig, hasher hashing.IHashService,
 	userSvc IUserService, logger logging.ILogger,
 	storage storage.ITokenStorage,
+	audit IAuditService,
+	invitations IInvitationService,
+	invConf config.InvitationConfig,
 ) *AuthService {
 	return &AuthService{
+		repo:         repo,
 		logger:       logger,
 		jwtConf:      jwtConf,
 		hashService:  hasher,
 		userService:  userSvc,
 		tokenStorage: storage,
+		audit:        audit,
+		invitations:  invitations,
+		invConf:      invConf,
 	}
 }
 
@@ -77,49 +94,121 @@ func (s *AuthService) GenerateRefreshToken(userID string) (string, error) {
 }
 
 func (s *AuthService) Authenticate(ctx context.Context, email, password string) (*dbCtx.User, error) {
-	// email = strings.ToLower(email) //
 	user, err := s.userService.GetByEmail(ctx, email)
 	if err != nil {
 		// s.logger.Error("Failed to fetch user by email: %v", err) //
+		s.recordAudit(ctx, AuditEntry{
+			Action:     AuditLoginFailed,
+			TargetType: AuditTargetUser,
+			Metadata:   map[string]any{"email": email, "reason": "unknown email"},
+		})
 		return nil, errors.New("invalid credentials, fetch")
 	}
 
 	if err := s.hashService.Compare(user.PasswordHash, password); err != nil {
 		// s.logger.Error("Password comparison failed: %v", err)
+		s.recordAudit(ctx, AuditEntry{
+			Action:     AuditLoginFailed,
+			TargetType: AuditTargetUser,
+			TargetID:   user.ID,
+			Metadata:   map[string]any{"reason": "wrong password"},
+		})
 		return nil, errors.New(err.Error())
 	}
 
+	s.recordAudit(ctx, AuditEntry{
+		Action:     AuditLogin,
+		TargetType: AuditTargetUser,
+		TargetID:   user.ID,
+		ActorID:    user.ID,
+	})
 	return user, nil
 }
 
 func (s *AuthService) RegisterUser(ctx context.Context, args dto.Register) (*dto.UserResponse, string, string, error) {
-	createParams := dto.CreateUserReq{
+	if !s.invConf.OpenRegistration {
+		return nil, "", "", ErrRegistrationClosed
+	}
+	return s.register(ctx, dto.CreateUserReq{
 		Username: args.Name,
 		FullName: "",
 		Email:    args.Email,
 		Password: args.Password,
+	})
+}
+
+// AcceptInvite registers an account for the invited address in the
+// organization the invitation came from. The account is created and the
+// invitation consumed in one transaction, so a failure leaves neither and
+// the invitation can be accepted again; of two accepts that race, only one
+// consumes the token.
+func (s *AuthService) AcceptInvite(ctx context.Context, args dto.AcceptInvitation) (*dto.UserResponse, string, string, error) {
+	inv, err := s.invitations.Lookup(ctx, args.Token)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	ctx = tenant.WithID(ctx, inv.OrganizationID)
+	var user *dto.UserResponse
+	err = s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		var err error
+		user, err = s.userService.CreateTx(ctx, tx, dto.CreateUserReq{
+			Username: args.Name,
+			FullName: "",
+			Email:    inv.Email,
+			Password: args.Password,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+		return s.invitations.ConsumeTx(ctx, tx, inv, user.ID)
+	})
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	accessToken, refreshToken, err := s.issueTokens(user.ID)
+	if err != nil {
+		return nil, "", "", err
 	}
+	return user, accessToken, refreshToken, nil
+}
+
+func (s *AuthService) register(ctx context.Context, createParams dto.CreateUserReq) (*dto.UserResponse, string, string, error) {
 	user, err := s.userService.Create(ctx, createParams)
 	if err != nil {
 		return nil, "", "", fmt.Errorf("failed to create user: %w", err)
 	}
 
-	accessToken, err := s.GenerateAccessToken(fmt.Sprintf("%d", user.ID))
+	accessToken, refreshToken, err := s.issueTokens(user.ID)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("failed to generate access token: %w", err)
+		return nil, "", "", err
 	}
+	return user, accessToken, refreshToken, nil
+}
 
-	refreshToken, err := s.GenerateRefreshToken(fmt.Sprintf("%d", user.ID))
+func (s *AuthService) issueTokens(userID int32) (string, string, error) {
+	accessToken, err := s.GenerateAccessToken(fmt.Sprintf("%d", userID))
 	if err != nil {
-		return nil, "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+		return "", "", fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	return user, accessToken, refreshToken, nil
+	refreshToken, err := s.GenerateRefreshToken(fmt.Sprintf("%d", userID))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return accessToken, refreshToken, nil
 }
 
 func (s *AuthService) RotateTokens(ctx context.Context, refreshToken string) (string, string, error) {
 	claims, err := s.ValidateRefreshToken(ctx, refreshToken)
 	if err != nil {
+		s.recordAudit(ctx, AuditEntry{
+			Action:     AuditRefreshFailed,
+			TargetType: AuditTargetUser,
+			Metadata:   map[string]any{"reason": err.Error()},
+		})
 		return "", "", err
 	}
 
@@ -139,6 +228,13 @@ func (s *AuthService) RotateTokens(ctx context.Context, refreshToken string) (st
 		return "", "", err
 	}
 
+	id, _ := strconv.Atoi(userID)
+	s.recordAudit(ctx, AuditEntry{
+		Action:     AuditRefresh,
+		TargetType: AuditTargetUser,
+		TargetID:   int32(id),
+		ActorID:    int32(id),
+	})
 	return accessToken, refreshToken, nil
 }
 
@@ -210,3 +306,15 @@ func (s *AuthService) ValidateRefreshToken(ctx context.Context, tokenString stri
 func (s *AuthService) InvalidateRefreshToken(ctx context.Context, userID string) error {
 	return s.tokenStorage.Invalidate(ctx, userID)
 }
+
+// recordAudit records an authentication event. Logins change nothing in the
+// database, so there is no transaction to share and a failed write is only
+// logged rather than failing the login.
+func (s *AuthService) recordAudit(ctx context.Context, entry AuditEntry) {
+	if err := s.audit.Record(ctx, entry); err != nil {
+		s.logger.Error(logging.Postgres, logging.Insert, "Failed to record audit event", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"action":             entry.Action,
+		})
+	}
+}
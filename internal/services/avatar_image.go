@@ -0,0 +1,218 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	contracts "example.com/api/internal/contracts/errors"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+type avatarVariant struct {
+	name string
+	size int // edge of the square thumbnail, 0 keeps the original dimensions
+}
+
+var avatarVariants = []avatarVariant{
+	{name: "original"},
+	{name: "medium", size: 256},
+	{name: "small", size: 64},
+}
+
+var allowedAvatarTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+type encodedImage struct {
+	data        []byte
+	contentType string
+}
+
+// processAvatar validates an uploaded image and renders every avatar variant.
+// The content type is sniffed from the data rather than trusted from the
+// client, and the dimensions are checked before the pixels are decoded.
+// Re-encoding drops EXIF and any other metadata; the JPEG orientation tag is
+// applied to the pixels first so photos keep their intended rotation.
+func processAvatar(data []byte, maxDimension int) (map[string]encodedImage, error) {
+	contentType := http.DetectContentType(data)
+	if !allowedAvatarTypes[contentType] {
+		return nil, &contracts.UnsupportedImageError{ContentType: contentType}
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, &contracts.InvalidImageError{Reason: err.Error()}
+	}
+	if maxDimension > 0 && (cfg.Width > maxDimension || cfg.Height > maxDimension) {
+		return nil, &contracts.ImageDimensionsError{Width: cfg.Width, Height: cfg.Height, Limit: maxDimension}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, &contracts.InvalidImageError{Reason: err.Error()}
+	}
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	variants := make(map[string]encodedImage, len(avatarVariants))
+	for _, variant := range avatarVariants {
+		out := img
+		if variant.size > 0 {
+			out = squareThumbnail(img, variant.size)
+		}
+
+		encoded, err := encodeAvatar(out, contentType)
+		if err != nil {
+			return nil, err
+		}
+		variants[variant.name] = encoded
+	}
+	return variants, nil
+}
+
+// encodeAvatar keeps JPEG uploads as JPEG and stores everything else as PNG
+// so transparency survives.
+func encodeAvatar(img image.Image, sourceType string) (encodedImage, error) {
+	var buf bytes.Buffer
+	if sourceType == "image/jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return encodedImage{}, err
+		}
+		return encodedImage{data: buf.Bytes(), contentType: "image/jpeg"}, nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return encodedImage{}, err
+	}
+	return encodedImage{data: buf.Bytes(), contentType: "image/png"}, nil
+}
+
+// squareThumbnail center-crops img to a square and scales it down to size.
+// Images smaller than size are cropped but never upscaled.
+func squareThumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		b.Min.X+(b.Dx()-side)/2,
+		b.Min.Y+(b.Dy()-side)/2,
+	))
+
+	size = min(size, side)
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	return dst
+}
+
+// jpegOrientation returns the EXIF orientation (1-8) of a JPEG, or 1 when the
+// image carries no orientation tag.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// applyOrientation transforms img so that it displays upright for the given
+// EXIF orientation.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirror horizontal
+				dx, dy = w-1-x, y
+			case 3: // rotate 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirror vertical
+				dx, dy = x, h-1-y
+			case 5: // transpose
+				dx, dy = y, x
+			case 6: // rotate 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // transverse
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotate 270 clockwise
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+	"io"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// Avatar is a stored avatar variant ready to be streamed to a client. The
+// caller must close Body.
+type Avatar struct {
+	Body         io.ReadCloser
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+	ETag         string
+	MaxAge       int // seconds clients may cache the response
+}
+
+type IAvatarService interface {
+	Upload(ctx context.Context, userID int32, r io.Reader) (*dbCtx.User, error)
+
+	Open(ctx context.Context, userID int32, size string) (*Avatar, error)
+}
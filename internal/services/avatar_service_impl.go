@@ -0,0 +1,201 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"example.com/api/config"
+	contracts "example.com/api/internal/contracts/errors"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/storage/blob"
+	"example.com/api/pkg/logging"
+	"example.com/api/pkg/metrics"
+)
+
+var (
+	ErrAvatarNotFound    = errors.New("avatar not found")
+	ErrInvalidAvatarSize = errors.New("invalid avatar size")
+)
+
+type AvatarService struct {
+	repo    repository.IRepositoryManager
+	logger  logging.ILogger
+	storage blob.IBlobStorage
+	cfg     config.AvatarConfig
+}
+
+func NewAvatarService(r repository.IRepositoryManager, l logging.ILogger, storage blob.IBlobStorage, cfg config.AvatarConfig) *AvatarService {
+	return &AvatarService{
+		repo:    r,
+		logger:  l,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// Upload stores a new avatar for the user and points avatar_url at it. Every
+// upload gets a fresh version so cached copies of the previous avatar are
+// never served under the new URL; the previous version is removed once the
+// user row has been updated.
+func (s *AvatarService) Upload(ctx context.Context, userID int32, r io.Reader) (*dbCtx.User, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if err := checkTenant(ctx, s.repo, userID); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.cfg.MaxUploadSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if int64(len(data)) > s.cfg.MaxUploadSize {
+		return nil, &contracts.ImageTooLargeError{Limit: s.cfg.MaxUploadSize}
+	}
+
+	variants, err := processAvatar(data, s.cfg.MaxDimension)
+	if err != nil {
+		return nil, err
+	}
+
+	version := strconv.FormatInt(time.Now().UnixNano(), 36)
+	for _, variant := range avatarVariants {
+		img := variants[variant.name]
+		key := avatarKey(userID, version, variant.name)
+		if err := s.storage.Put(ctx, key, bytes.NewReader(img.data), int64(len(img.data)), img.contentType); err != nil {
+			s.logger.Error(logging.IO, logging.WriteFile, "Failed to store avatar", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				"userID":             userID,
+				"key":                key,
+			})
+			s.removeVersion(ctx, userID, version)
+			return nil, fmt.Errorf("failed to store avatar: %w", err)
+		}
+	}
+
+	metrics.DbCall.WithLabelValues("User", "UpdateAvatar", "started").Inc()
+	updated, err := s.repo.User().UpdatePartial(ctx, dbCtx.UpdateUserPartialParams{
+		ID:           userID,
+		SetAvatarUrl: true,
+		AvatarUrl:    sql.NullString{String: avatarURL(userID, version), Valid: true},
+	})
+	if err != nil {
+		metrics.DbCall.WithLabelValues("User", "UpdateAvatar", "error").Inc()
+		s.logger.Error(logging.Postgres, logging.Update, "Failed to update avatar url", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		s.removeVersion(ctx, userID, version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+	metrics.DbCall.WithLabelValues("User", "UpdateAvatar", "success").Inc()
+
+	if previous := avatarVersion(userID, user.AvatarUrl); previous != "" {
+		s.removeVersion(ctx, userID, previous)
+	}
+	return &updated, nil
+}
+
+func (s *AvatarService) Open(ctx context.Context, userID int32, size string) (*Avatar, error) {
+	if size == "" {
+		size = avatarVariants[0].name
+	}
+	if !isAvatarVariant(size) {
+		return nil, ErrInvalidAvatarSize
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+	if err := checkTenant(ctx, s.repo, userID); err != nil {
+		return nil, err
+	}
+
+	version := avatarVersion(userID, user.AvatarUrl)
+	if version == "" {
+		return nil, ErrAvatarNotFound
+	}
+
+	body, info, err := s.storage.Get(ctx, avatarKey(userID, version, size))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, ErrAvatarNotFound
+	}
+	if err != nil {
+		s.logger.Error(logging.IO, logging.ExternalService, "Failed to read avatar", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+
+	return &Avatar{
+		Body:         body,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+		ETag:         fmt.Sprintf(`"%s-%s"`, version, size),
+		MaxAge:       s.cfg.CacheMaxAge,
+	}, nil
+}
+
+func (s *AvatarService) removeVersion(ctx context.Context, userID int32, version string) {
+	removeAvatarVersion(ctx, s.storage, s.logger, userID, version)
+}
+
+// removeAvatarVersion deletes every variant of an avatar version. Failures
+// are only logged: a leftover file wastes space but does not affect the user.
+func removeAvatarVersion(ctx context.Context, storage blob.IBlobStorage, logger logging.ILogger, userID int32, version string) {
+	for _, variant := range avatarVariants {
+		key := avatarKey(userID, version, variant.name)
+		if err := storage.Delete(ctx, key); err != nil {
+			logger.Warn(logging.IO, logging.RemoveFile, "Failed to remove avatar", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				"userID":             userID,
+				"key":                key,
+			})
+		}
+	}
+}
+
+func avatarKey(userID int32, version, variant string) string {
+	return fmt.Sprintf("avatars/%d/%s/%s", userID, version, variant)
+}
+
+func avatarURL(userID int32, version string) string {
+	return fmt.Sprintf("/api/users/%d/avatar?v=%s", userID, version)
+}
+
+// avatarVersion extracts the version from an avatar_url produced by Upload.
+// It returns an empty string for external URLs set through the profile API.
+func avatarVersion(userID int32, avatarUrl sql.NullString) string {
+	if !avatarUrl.Valid {
+		return ""
+	}
+	u, err := url.Parse(avatarUrl.String)
+	if err != nil || u.Host != "" || u.Path != fmt.Sprintf("/api/users/%d/avatar", userID) {
+		return ""
+	}
+	return u.Query().Get("v")
+}
+
+func isAvatarVariant(name string) bool {
+	for _, variant := range avatarVariants {
+		if variant.name == name {
+			return true
+		}
+	}
+	return false
+}
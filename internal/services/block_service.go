@@ -0,0 +1,18 @@
+package services
+
+import (
+	"context"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IBlockService interface {
+	// Block stops blockedID's chat messages from reaching blockerID. Blocking
+	// a user twice is not an error.
+	Block(ctx context.Context, blockerID, blockedID int32) error
+	Unblock(ctx context.Context, blockerID, blockedID int32) error
+	List(ctx context.Context, blockerID int32) ([]dbCtx.ListUserBlocksRow, error)
+	// BlockedBy returns the users who have blocked userID. The chat hub asks
+	// for it on every broadcast, so the answer is served from the cache.
+	BlockedBy(ctx context.Context, userID int32) ([]int32, error)
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/storage/cache"
+	"example.com/api/internal/tenant"
+	"example.com/api/pkg/logging"
+)
+
+// BlockedByCachePrefix prefixes the cache keys holding who has blocked a
+// user. The entries of a user are versioned: a change to a block naming the
+// user bumps the version after it is saved, so an entry filled from a read
+// that raced the change is never looked up again.
+const BlockedByCachePrefix = "blocked-by:"
+
+const blockedByCacheTTL = time.Hour
+
+var (
+	ErrCannotBlockSelf = errors.New("cannot block yourself")
+	ErrBlockNotFound   = errors.New("block not found")
+)
+
+type BlockService struct {
+	repo   repository.IRepositoryManager
+	logger logging.ILogger
+	cache  cache.ICacheService
+}
+
+func NewBlockService(r repository.IRepositoryManager, l logging.ILogger, c cache.ICacheService) *BlockService {
+	return &BlockService{
+		repo:   r,
+		logger: l,
+		cache:  c,
+	}
+}
+
+func (s *BlockService) Block(ctx context.Context, blockerID, blockedID int32) error {
+	if blockerID == blockedID {
+		return ErrCannotBlockSelf
+	}
+	if _, err := s.repo.User().GetByID(ctx, blockedID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("user not found")
+		}
+		return err
+	}
+	if err := checkTenant(ctx, s.repo, blockedID); err != nil {
+		return err
+	}
+
+	created, err := s.repo.Block().Create(ctx, dbCtx.CreateUserBlockParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Insert, "Failed to block user", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"blockerID":          blockerID,
+			"blockedID":          blockedID,
+		})
+		return errors.New("failed to block user")
+	}
+	if created > 0 {
+		s.evict(ctx, blockerID, blockedID)
+	}
+	return nil
+}
+
+func (s *BlockService) Unblock(ctx context.Context, blockerID, blockedID int32) error {
+	deleted, err := s.repo.Block().Delete(ctx, dbCtx.DeleteUserBlockParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Delete, "Failed to unblock user", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"blockerID":          blockerID,
+			"blockedID":          blockedID,
+		})
+		return errors.New("failed to unblock user")
+	}
+	if deleted == 0 {
+		return ErrBlockNotFound
+	}
+	s.evict(ctx, blockerID, blockedID)
+	return nil
+}
+
+func (s *BlockService) List(ctx context.Context, blockerID int32) ([]dbCtx.ListUserBlocksRow, error) {
+	blocks, err := s.repo.Block().List(ctx, blockerID)
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to fetch blocked users", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"blockerID":          blockerID,
+		})
+		return nil, errors.New("failed to fetch blocked users")
+	}
+	blocks, err = s.filterTenant(ctx, blocks)
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to filter blocked users", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"blockerID":          blockerID,
+		})
+		return nil, errors.New("failed to fetch blocked users")
+	}
+	return blocks, nil
+}
+
+// filterTenant drops the blocked users outside the organization ctx is
+// scoped to.
+func (s *BlockService) filterTenant(ctx context.Context, blocks []dbCtx.ListUserBlocksRow) ([]dbCtx.ListUserBlocksRow, error) {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok || len(blocks) == 0 {
+		return blocks, nil
+	}
+	ids := make([]int32, len(blocks))
+	for i, block := range blocks {
+		ids[i] = block.BlockedID
+	}
+	memberIDs, err := s.repo.Organization().FilterMembers(ctx, dbCtx.FilterOrganizationMembersParams{
+		OrganizationID: orgID,
+		UserIds:        ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[int32]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		members[id] = true
+	}
+	kept := blocks[:0]
+	for _, block := range blocks {
+		if members[block.BlockedID] {
+			kept = append(kept, block)
+		}
+	}
+	return kept, nil
+}
+
+func (s *BlockService) BlockedBy(ctx context.Context, userID int32) ([]int32, error) {
+	// The version is read before the blocks, so that a change saved in
+	// between leaves what is cached below under a version nobody reads.
+	var version int64
+	_, err := s.cache.Get(ctx, blockedByVersionKey(userID), &version)
+	if err != nil {
+		s.logger.Warn(logging.Redis, logging.Select, "Failed to read blocks from cache", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		return s.listBlockerIDs(ctx, userID)
+	}
+	key := blockedByKey(userID, version)
+
+	var ids []int32
+	found, err := s.cache.Get(ctx, key, &ids)
+	if err != nil {
+		s.logger.Warn(logging.Redis, logging.Select, "Failed to read blocks from cache", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+	}
+	if found {
+		return ids, nil
+	}
+
+	ids, err = s.listBlockerIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.Set(ctx, key, ids, blockedByCacheTTL); err != nil {
+		s.logger.Warn(logging.Redis, logging.Insert, "Failed to cache blocks", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+	}
+	return ids, nil
+}
+
+func (s *BlockService) listBlockerIDs(ctx context.Context, userID int32) ([]int32, error) {
+	ids, err := s.repo.Block().ListBlockerIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blocks: %w", err)
+	}
+	if ids == nil {
+		// Cache the empty answer too: most senders are blocked by nobody.
+		ids = []int32{}
+	}
+	return ids, nil
+}
+
+// evict drops the cached data a changed block makes stale once the change is
+// saved: who has blocked blockedID, by bumping its version, and the message
+// history pages filtered for blockerID.
+func (s *BlockService) evict(ctx context.Context, blockerID, blockedID int32) {
+	if _, err := s.cache.Incr(ctx, blockedByVersionKey(blockedID)); err != nil {
+		s.logger.Error(logging.Redis, logging.Delete, "Failed to evict cached blocks", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             blockedID,
+		})
+	}
+	if err := s.cache.DeletePrefix(ctx, chat.HistoryViewerPrefix(blockerID)); err != nil {
+		s.logger.Error(logging.Redis, logging.Delete, "Failed to evict message history", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             blockerID,
+		})
+	}
+}
+
+func blockedByKey(userID int32, version int64) string {
+	return fmt.Sprintf("%s%d:v%d", BlockedByCachePrefix, userID, version)
+}
+
+func blockedByVersionKey(userID int32) string {
+	return fmt.Sprintf("%s%d:version", BlockedByCachePrefix, userID)
+}
@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+
+	"example.com/api/config"
+	"example.com/api/internal/storage"
+)
+
+// Broker carries hub events between the app instances. Every event a hub
+// publishes comes back from Messages on every hub sharing the broker,
+// including the one that published it, and each hub delivers it to its own
+// clients.
+type Broker interface {
+	Publish(ctx context.Context, data []byte) error
+	Messages() <-chan []byte
+}
+
+// NewBroker returns the broker cfg selects.
+func NewBroker(cfg config.ChatConfig, redisCfg *config.RedisConfig) (Broker, error) {
+	switch cfg.Broker {
+	case "", "memory":
+		return NewMemoryBroker(), nil
+	case "redis":
+		if cfg.Channel == "" {
+			return nil, fmt.Errorf("redis chat broker needs a channel")
+		}
+		return NewRedisBroker(context.Background(), storage.NewRedisClient(redisCfg, 0), cfg.Channel)
+	default:
+		return nil, fmt.Errorf("unknown chat broker %q", cfg.Broker)
+	}
+}
+
+const memoryBrokerBuffer = 256
+
+// MemoryBroker hands events straight back to the one hub that uses it.
+type MemoryBroker struct {
+	messages chan []byte
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{messages: make(chan []byte, memoryBrokerBuffer)}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, data []byte) error {
+	select {
+	case b.messages <- data:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *MemoryBroker) Messages() <-chan []byte {
+	return b.messages
+}
@@ -2,11 +2,146 @@ package chat
 
 import (
 	"context"
+	"fmt"
 
 	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/storage/cache"
 )
 
+// HistoryCachePrefix prefixes the cache keys of message history pages. The
+// pages carry sender names, so they are evicted when a user is erased.
+const HistoryCachePrefix = "messages:"
+
+// HistoryViewerPrefix prefixes the cached history pages of one viewer. Pages
+// are cached per viewer because they leave out the users the viewer blocked.
+func HistoryViewerPrefix(viewerID int32) string {
+	return fmt.Sprintf("%sviewer:%d:", HistoryCachePrefix, viewerID)
+}
+
+// HistoryRoomPrefix prefixes the cached pages of room roomID as viewerID
+// sees them. They are evicted when viewerID leaves the room.
+func HistoryRoomPrefix(viewerID, roomID int32) string {
+	return fmt.Sprintf("%sroom:%d:", HistoryViewerPrefix(viewerID), roomID)
+}
+
+// HistoryGenerationKey holds the generation of room roomID's cached pages.
+// It is bumped when a message of the room changes, which leaves the pages of
+// every viewer cached under older generations to expire unread.
+func HistoryGenerationKey(roomID int32) string {
+	return fmt.Sprintf("%sroom:%d:generation", HistoryCachePrefix, roomID)
+}
+
+// HistoryGeneration returns the current generation of room roomID's cached
+// pages. Read it before loading a page to cache, so that a change saved in
+// between leaves the page under a generation nobody reads.
+func HistoryGeneration(ctx context.Context, c cache.ICacheService, roomID int32) (int64, error) {
+	var generation int64
+	_, err := c.Get(ctx, HistoryGenerationKey(roomID), &generation)
+	return generation, err
+}
+
+// HistoryCacheKey is the cache key of a page of room roomID's message
+// history as viewerID sees it in generation.
+func HistoryCacheKey(roomID, viewerID int32, generation int64, limit, offset int) string {
+	return fmt.Sprintf("%sgen:%d:limit:%d:offset:%d", HistoryRoomPrefix(viewerID, roomID), generation, limit, offset)
+}
+
+// UnreadCounts holds a user's unread message counts by room and by the
+// peer of each direct conversation. Rooms the user has joined are all
+// there; conversations only when something in them is unread.
+type UnreadCounts struct {
+	Rooms         map[int32]int64
+	Conversations map[int32]int64
+}
+
+// Reaction is where a message's reactions with Emoji stand after a user
+// added or removed theirs: Count users react to Message with it.
+type Reaction struct {
+	Message dbCtx.Message
+	Emoji   string
+	Count   int64
+}
+
+// IChatService works within the organization the context is scoped to, see
+// package tenant, and fails with tenant.ErrNoTenant when there is none.
 type IChatService interface {
-	SaveMessage(ctx context.Context, senderID int32, content string) error
-	GetMessages(ctx context.Context, limit, offset int32) ([]dbCtx.GetMessagesRow, error)
+	// SaveMessage posts a message to roomID. It fails with ErrNotRoomMember
+	// unless senderID has joined the room.
+	SaveMessage(ctx context.Context, senderID, roomID int32, content string) (dbCtx.Message, error)
+	// GetMessages returns a page of roomID's history as viewerID sees it,
+	// without the messages of users viewerID has blocked. Only members of
+	// the room may read it.
+	GetMessages(ctx context.Context, viewerID, roomID, limit, offset int32) ([]dbCtx.GetMessagesRow, error)
+	// Reply posts a reply to parentID in its thread, or in the thread
+	// parentID is itself a reply in, and returns it with how many replies
+	// the thread now has. It fails with ErrMessageNotFound unless the
+	// parent is a room message that was not deleted, and with
+	// ErrNotRoomMember unless senderID has joined its room.
+	Reply(ctx context.Context, senderID, parentID int32, content string) (dbCtx.Message, int64, error)
+	// Thread returns a page of the thread messageID is in, oldest first,
+	// starting with the message the others reply to. It leaves out the
+	// messages of users viewerID has blocked. Only members of the room may
+	// read it.
+	Thread(ctx context.Context, viewerID, messageID, limit, offset int32) ([]dbCtx.GetThreadRow, error)
+	// MessagesSince returns, oldest first, up to limit of the messages
+	// userID would have received live after since: their direct messages
+	// and those of their rooms, without the ones of users they blocked.
+	MessagesSince(ctx context.Context, userID int32, since ReplayPoint, limit int32) ([]dbCtx.ListMessagesSinceRow, error)
+
+	// CreateRoom makes a room named name, which creatorID joins right away.
+	CreateRoom(ctx context.Context, creatorID int32, name string) (dbCtx.ChatRoom, error)
+	// ListRooms returns every room of the organization, marking the ones
+	// userID has joined.
+	ListRooms(ctx context.Context, userID int32) ([]dbCtx.ListChatRoomsRow, error)
+	// JoinRoom is a no-op when userID is already a member of roomID.
+	JoinRoom(ctx context.Context, userID, roomID int32) error
+	LeaveRoom(ctx context.Context, userID, roomID int32) error
+	RoomMembers(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error)
+	// RoomIDs returns the rooms userID has joined.
+	RoomIDs(ctx context.Context, userID int32) ([]int32, error)
+
+	// SendDirect sends a direct message from senderID to recipientID. It
+	// fails with ErrRecipientNotFound unless the recipient is another member
+	// of the organization who has not blocked the sender.
+	SendDirect(ctx context.Context, senderID, recipientID int32, content string) (dbCtx.Message, error)
+	// Conversations lists the users userID has exchanged direct messages
+	// with, with the last message and the unread count of each.
+	Conversations(ctx context.Context, userID, limit, offset int32) ([]dbCtx.ListConversationsRow, error)
+	// DirectMessages returns a page of the conversation between userID and
+	// peerID, newest first. Reading the first page marks the conversation
+	// read.
+	DirectMessages(ctx context.Context, userID, peerID, limit, offset int32) ([]dbCtx.GetDirectMessagesRow, error)
+
+	// MarkRoomRead marks roomID read by userID up to messageID, or up to its
+	// newest message when messageID is 0. It returns the id read up to,
+	// which is 0 when the room has no messages. Only members of the room
+	// may mark it read.
+	MarkRoomRead(ctx context.Context, userID, roomID, messageID int32) (int32, error)
+	// MarkConversationRead is MarkRoomRead for the conversation between
+	// userID and peerID.
+	MarkConversationRead(ctx context.Context, userID, peerID, messageID int32) (int32, error)
+	// UnreadCounts returns how many messages userID has not read in each of
+	// their rooms and conversations.
+	UnreadCounts(ctx context.Context, userID int32) (UnreadCounts, error)
+
+	// EditMessage replaces the content of messageID. The author may edit
+	// their messages, and the organization's owners and admins any room
+	// message, within the configured edit window. It fails with
+	// ErrMessageNotFound, ErrMessageForbidden or ErrEditWindowClosed.
+	EditMessage(ctx context.Context, actorID, messageID int32, content string) (dbCtx.Message, error)
+	// DeleteMessage clears the content of messageID and marks it deleted,
+	// under the same rules as EditMessage.
+	DeleteMessage(ctx context.Context, actorID, messageID int32) (dbCtx.Message, error)
+
+	// React adds userID's reaction with emoji to messageID, and is a no-op
+	// when they already reacted with it. Users may react to the messages of
+	// the rooms they joined and to the direct messages they sent or
+	// received, unless deleted; it fails with ErrMessageNotFound,
+	// ErrNotRoomMember or ErrInvalidEmoji otherwise.
+	React(ctx context.Context, userID, messageID int32, emoji string) (Reaction, error)
+	// Unreact is the opposite of React.
+	Unreact(ctx context.Context, userID, messageID int32, emoji string) (Reaction, error)
+	// Reactions lists who reacted to messageID, and with what, under the
+	// same rules as React.
+	Reactions(ctx context.Context, userID, messageID int32) ([]dbCtx.ListMessageReactionsRow, error)
 }
@@ -2,35 +2,851 @@ package chat
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"example.com/api/config"
 	"example.com/api/internal/repository"
 	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/internal/storage/cache"
+	"example.com/api/internal/tenant"
 	"example.com/api/pkg/logging"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrRoomNotFound  = errors.New("room not found")
+	ErrNotRoomMember = errors.New("not a member of this room")
+	ErrRoomNameTaken = errors.New("room name already taken")
+
+	ErrRecipientNotFound = errors.New("recipient not found")
+
+	ErrMessageNotFound  = errors.New("message not found")
+	ErrMessageForbidden = errors.New("not allowed to change this message")
+	ErrEditWindowClosed = errors.New("message can no longer be changed")
+
+	ErrInvalidEmoji = errors.New("emoji must be 1 to 32 characters without spaces")
+)
+
+// maxEmojiLength is the most characters a reaction's emoji may have, as
+// message_reactions.emoji allows.
+const maxEmojiLength = 32
+
+// The organization roles that may moderate room messages. They mirror
+// services.OrgRoleOwner and services.OrgRoleAdmin, which this package
+// cannot import.
+const (
+	orgRoleOwner = "owner"
+	orgRoleAdmin = "admin"
 )
 
 type ChatService struct {
-	repo   repository.IRepositoryManager
-	logger logging.ILogger
+	repo       repository.IRepositoryManager
+	logger     logging.ILogger
+	cache      cache.ICacheService
+	unread     UnreadCounter
+	editWindow time.Duration
 }
 
-func NewChatService(r repository.IRepositoryManager, l logging.ILogger) *ChatService {
+// NewChatService counts unread messages with unread, or straight from
+// Postgres when it is nil.
+func NewChatService(
+	r repository.IRepositoryManager,
+	l logging.ILogger,
+	c cache.ICacheService,
+	unread UnreadCounter,
+	cfg config.ChatConfig,
+) *ChatService {
 	return &ChatService{
-		repo:   r,
-		logger: l,
+		repo:       r,
+		logger:     l,
+		cache:      c,
+		unread:     unread,
+		editWindow: cfg.EditWindow * time.Minute,
+	}
+}
+
+// inTenant runs fn in a transaction scoped to the organization of ctx.
+func (s *ChatService) inTenant(ctx context.Context, fn func(tx repository.IRepositoryManager, orgID int32) error) error {
+	orgID, err := tenant.Require(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		if err := tx.Organization().SetTenant(ctx, orgID); err != nil {
+			return err
+		}
+		return fn(tx, orgID)
+	})
+}
+
+// SaveMessage stores a message in roomID of the organization ctx is scoped
+// to, counts it as unread for the other members and drops the room's cached
+// history pages.
+func (s *ChatService) SaveMessage(ctx context.Context, senderID, roomID int32, content string) (dbCtx.Message, error) {
+	msg, _, err := s.post(ctx, senderID, roomID, 0, content)
+	return msg, err
+}
+
+// Reply stores a reply as SaveMessage stores a message, in the room of its
+// parent.
+func (s *ChatService) Reply(ctx context.Context, senderID, parentID int32, content string) (dbCtx.Message, int64, error) {
+	return s.post(ctx, senderID, 0, parentID, content)
+}
+
+// post stores a message in roomID, or a reply to parentID in the parent's
+// room when parentID is not 0, counts it as unread for the other members
+// and drops the room's cached history pages. For a reply, it also returns
+// how many replies its thread has.
+func (s *ChatService) post(ctx context.Context, senderID, roomID, parentID int32, content string) (dbCtx.Message, int64, error) {
+	var (
+		msg        dbCtx.Message
+		replies    int64
+		recipients []int32
+	)
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var thread sql.NullInt32
+		if parentID != 0 {
+			parent, err := visibleMessage(ctx, tx, orgID, senderID, parentID)
+			if err != nil {
+				return err
+			}
+			if !parent.RoomID.Valid || parent.DeletedAt.Valid {
+				return ErrMessageNotFound
+			}
+			roomID = parent.RoomID.Int32
+			thread = sql.NullInt32{Int32: parent.ID, Valid: true}
+			if parent.ParentID.Valid {
+				thread = parent.ParentID
+			}
+		}
+
+		var err error
+		msg, err = tx.Chat().CreateMessage(ctx, dbCtx.CreateMessageParams{
+			SenderID:       senderID,
+			Content:        content,
+			ParentID:       thread,
+			RoomID:         roomID,
+			OrganizationID: orgID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotRoomMember
+			}
+			return err
+		}
+		if thread.Valid {
+			replies, err = tx.Chat().CountReplies(ctx, thread.Int32)
+			if err != nil {
+				return err
+			}
+		}
+		if s.unread != nil {
+			recipients, err = tx.Chat().ListRoomRecipients(ctx, dbCtx.ListRoomRecipientsParams{
+				RoomID:   roomID,
+				SenderID: senderID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return outbox.Enqueue(ctx, tx, outbox.MessageSent, outbox.AggregateMessage, msg.ID, map[string]any{
+			"id":             msg.ID,
+			"senderId":       msg.SenderID,
+			"organizationId": msg.OrganizationID,
+			"roomId":         msg.RoomID,
+			"parentId":       msg.ParentID,
+			"content":        msg.Content,
+			"createdAt":      msg.CreatedAt.Time,
+		})
+	})
+	if err != nil {
+		return dbCtx.Message{}, 0, err
+	}
+
+	s.evictHistory(ctx, roomID)
+	s.countUnread(ctx, msg.OrganizationID, recipients, roomField(roomID))
+	return msg, replies, nil
+}
+
+func (s *ChatService) GetMessages(ctx context.Context, viewerID, roomID, limit, offset int32) ([]dbCtx.GetMessagesRow, error) {
+	var messages []dbCtx.GetMessagesRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		if err := requireMember(ctx, tx, orgID, roomID, viewerID); err != nil {
+			return err
+		}
+		var err error
+		messages, err = tx.Chat().GetMessages(ctx, dbCtx.GetMessagesParams{
+			Limit:          limit,
+			Offset:         offset,
+			BlockerID:      viewerID,
+			OrganizationID: orgID,
+			RoomID:         sql.NullInt32{Int32: roomID, Valid: true},
+		})
+		return err
+	})
+	return messages, err
+}
+
+// Thread finds the message the thread of messageID starts with, which is
+// messageID itself unless it is a reply.
+func (s *ChatService) Thread(ctx context.Context, viewerID, messageID, limit, offset int32) ([]dbCtx.GetThreadRow, error) {
+	var messages []dbCtx.GetThreadRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		msg, err := visibleMessage(ctx, tx, orgID, viewerID, messageID)
+		if err != nil {
+			return err
+		}
+		if !msg.RoomID.Valid {
+			return ErrMessageNotFound
+		}
+		parentID := msg.ID
+		if msg.ParentID.Valid {
+			parentID = msg.ParentID.Int32
+		}
+		messages, err = tx.Chat().GetThread(ctx, dbCtx.GetThreadParams{
+			OrganizationID: orgID,
+			ParentID:       parentID,
+			BlockerID:      viewerID,
+			LimitCount:     limit,
+			OffsetCount:    offset,
+		})
+		return err
+	})
+	return messages, err
+}
+
+func (s *ChatService) MessagesSince(ctx context.Context, userID int32, since ReplayPoint, limit int32) ([]dbCtx.ListMessagesSinceRow, error) {
+	var messages []dbCtx.ListMessagesSinceRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		messages, err = tx.Chat().ListMessagesSince(ctx, dbCtx.ListMessagesSinceParams{
+			OrganizationID: orgID,
+			AfterID:        since.AfterID,
+			AfterTime:      since.AfterTime.UTC(),
+			UserID:         userID,
+			LimitCount:     limit,
+		})
+		return err
+	})
+	return messages, err
+}
+
+func (s *ChatService) CreateRoom(ctx context.Context, creatorID int32, name string) (dbCtx.ChatRoom, error) {
+	var room dbCtx.ChatRoom
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		room, err = tx.Chat().CreateRoom(ctx, dbCtx.CreateChatRoomParams{
+			OrganizationID: orgID,
+			Name:           name,
+			CreatedBy:      sql.NullInt32{Int32: creatorID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.Chat().AddRoomMember(ctx, dbCtx.AddRoomMemberParams{
+			RoomID: room.ID,
+			UserID: creatorID,
+		})
+		return err
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return dbCtx.ChatRoom{}, ErrRoomNameTaken
+		}
+		return dbCtx.ChatRoom{}, err
+	}
+	return room, nil
+}
+
+func (s *ChatService) ListRooms(ctx context.Context, userID int32) ([]dbCtx.ListChatRoomsRow, error) {
+	var rooms []dbCtx.ListChatRoomsRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		rooms, err = tx.Chat().ListRooms(ctx, dbCtx.ListChatRoomsParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+		})
+		return err
+	})
+	return rooms, err
+}
+
+// JoinRoom starts userID with the history of roomID read, so that only
+// what is posted after they join counts as unread, and adds the room to
+// their unread counts.
+func (s *ChatService) JoinRoom(ctx context.Context, userID, roomID int32) error {
+	joined := false
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		if err := requireRoom(ctx, tx, orgID, roomID); err != nil {
+			return err
+		}
+		n, err := tx.Chat().AddRoomMember(ctx, dbCtx.AddRoomMemberParams{
+			RoomID: roomID,
+			UserID: userID,
+		})
+		if err != nil || n == 0 {
+			return err
+		}
+		joined = true
+		_, err = tx.Chat().MarkRoomRead(ctx, dbCtx.MarkRoomReadParams{
+			OrganizationID: orgID,
+			RoomID:         roomID,
+			UserID:         userID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err == nil && joined {
+		s.recountUnread(ctx, userID)
+	}
+	return err
+}
+
+// LeaveRoom takes userID out of roomID and drops the history pages cached
+// for them, which they may no longer read, and the room's unread count.
+func (s *ChatService) LeaveRoom(ctx context.Context, userID, roomID int32) error {
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		if err := requireRoom(ctx, tx, orgID, roomID); err != nil {
+			return err
+		}
+		n, err := tx.Chat().DeleteRoomMember(ctx, dbCtx.DeleteRoomMemberParams{
+			RoomID: roomID,
+			UserID: userID,
+		})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return ErrNotRoomMember
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.cache.DeletePrefix(ctx, HistoryRoomPrefix(userID, roomID)); err != nil {
+		s.logger.Error(logging.Redis, logging.Delete, "Failed to evict message history", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+			"roomID":             roomID,
+		})
+	}
+	s.recountUnread(ctx, userID)
+	return nil
+}
+
+func (s *ChatService) RoomMembers(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error) {
+	var members []dbCtx.ListRoomMembersRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		if err := requireRoom(ctx, tx, orgID, roomID); err != nil {
+			return err
+		}
+		var err error
+		members, err = tx.Chat().ListRoomMembers(ctx, roomID)
+		return err
+	})
+	return members, err
+}
+
+func (s *ChatService) RoomIDs(ctx context.Context, userID int32) ([]int32, error) {
+	var ids []int32
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		ids, err = tx.Chat().ListRoomIDsForUser(ctx, dbCtx.ListRoomIDsForUserParams{
+			UserID:         userID,
+			OrganizationID: orgID,
+		})
+		return err
+	})
+	return ids, err
+}
+
+// SendDirect stores a direct message and counts it as unread for the
+// recipient. Unlike room messages, direct messages are not published to the
+// outbox, since its consumers see the whole organization's traffic.
+func (s *ChatService) SendDirect(ctx context.Context, senderID, recipientID int32, content string) (dbCtx.Message, error) {
+	var msg dbCtx.Message
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		msg, err = tx.Chat().CreateDirectMessage(ctx, dbCtx.CreateDirectMessageParams{
+			SenderID:       senderID,
+			Content:        content,
+			OrganizationID: orgID,
+			RecipientID:    recipientID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRecipientNotFound
+		}
+		return err
+	})
+	if err != nil {
+		return dbCtx.Message{}, err
+	}
+
+	s.countUnread(ctx, msg.OrganizationID, []int32{recipientID}, conversationField(senderID))
+	return msg, nil
+}
+
+func (s *ChatService) Conversations(ctx context.Context, userID, limit, offset int32) ([]dbCtx.ListConversationsRow, error) {
+	var conversations []dbCtx.ListConversationsRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		conversations, err = tx.Chat().ListConversations(ctx, dbCtx.ListConversationsParams{
+			UserID:         userID,
+			OrganizationID: orgID,
+			LimitCount:     limit,
+			OffsetCount:    offset,
+		})
+		return err
+	})
+	return conversations, err
+}
+
+func (s *ChatService) DirectMessages(ctx context.Context, userID, peerID, limit, offset int32) ([]dbCtx.GetDirectMessagesRow, error) {
+	var messages []dbCtx.GetDirectMessagesRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		messages, err = tx.Chat().GetDirectMessages(ctx, dbCtx.GetDirectMessagesParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+			PeerID:         peerID,
+			LimitCount:     limit,
+			OffsetCount:    offset,
+		})
+		if err != nil || offset > 0 {
+			return err
+		}
+		_, err = tx.Chat().MarkConversationRead(ctx, dbCtx.MarkConversationReadParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+			PeerID:         peerID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err == nil && offset == 0 {
+		s.recountUnread(ctx, userID)
+	}
+	return messages, err
+}
+
+func (s *ChatService) MarkRoomRead(ctx context.Context, userID, roomID, messageID int32) (int32, error) {
+	var lastRead int32
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		if err := requireMember(ctx, tx, orgID, roomID, userID); err != nil {
+			return err
+		}
+		var err error
+		lastRead, err = tx.Chat().MarkRoomRead(ctx, dbCtx.MarkRoomReadParams{
+			OrganizationID: orgID,
+			RoomID:         roomID,
+			UserID:         userID,
+			UpToID:         messageID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.recountUnread(ctx, userID)
+	return lastRead, nil
+}
+
+func (s *ChatService) MarkConversationRead(ctx context.Context, userID, peerID, messageID int32) (int32, error) {
+	var lastRead int32
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		lastRead, err = tx.Chat().MarkConversationRead(ctx, dbCtx.MarkConversationReadParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+			PeerID:         peerID,
+			UpToID:         messageID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.recountUnread(ctx, userID)
+	return lastRead, nil
+}
+
+// UnreadCounts answers from the unread counter, and rebuilds the counts
+// from Postgres when it has none for userID.
+func (s *ChatService) UnreadCounts(ctx context.Context, userID int32) (UnreadCounts, error) {
+	orgID, err := tenant.Require(ctx)
+	if err != nil {
+		return UnreadCounts{}, err
+	}
+	if s.unread != nil {
+		fields, ok, err := s.unread.Load(ctx, orgID, userID)
+		if err == nil && ok {
+			return unreadFromFields(fields), nil
+		}
+		if err != nil {
+			s.logger.Error(logging.Redis, logging.Select, "Failed to load unread counts", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				"userID":             userID,
+			})
+		}
+	}
+	return s.rebuildUnread(ctx, orgID, userID)
+}
+
+// rebuildUnread counts userID's unread messages in Postgres and hands the
+// counts to the unread counter.
+func (s *ChatService) rebuildUnread(ctx context.Context, orgID, userID int32) (UnreadCounts, error) {
+	counts := UnreadCounts{Rooms: map[int32]int64{}, Conversations: map[int32]int64{}}
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		rooms, err := tx.Chat().CountUnreadRooms(ctx, dbCtx.CountUnreadRoomsParams{
+			UserID:         userID,
+			OrganizationID: orgID,
+		})
+		if err != nil {
+			return err
+		}
+		for _, r := range rooms {
+			counts.Rooms[r.RoomID] = r.UnreadCount
+		}
+		conversations, err := tx.Chat().CountUnreadConversations(ctx, dbCtx.CountUnreadConversationsParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+		})
+		if err != nil {
+			return err
+		}
+		for _, c := range conversations {
+			counts.Conversations[c.PeerID] = c.UnreadCount
+		}
+		return nil
+	})
+	if err != nil || s.unread == nil {
+		return counts, err
+	}
+
+	if err := s.unread.Store(ctx, orgID, userID, counts.fields()); err != nil {
+		s.logger.Error(logging.Redis, logging.Insert, "Failed to store unread counts", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+	}
+	return counts, nil
+}
+
+// recountUnread rebuilds userID's counts after their read markers moved or
+// they joined or left a room. The change itself is already stored, so a failure is
+// only logged; the counts are rebuilt again when they expire.
+func (s *ChatService) recountUnread(ctx context.Context, userID int32) {
+	if s.unread == nil {
+		return
+	}
+	orgID, err := tenant.Require(ctx)
+	if err != nil {
+		return
+	}
+	if _, err := s.rebuildUnread(ctx, orgID, userID); err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to count unread messages", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+	}
+}
+
+// countUnread adds a message to field of the counts of userIDs.
+func (s *ChatService) countUnread(ctx context.Context, orgID int32, userIDs []int32, field string) {
+	if s.unread == nil {
+		return
+	}
+	if err := s.unread.Increment(ctx, orgID, userIDs, field); err != nil {
+		s.logger.Error(logging.Redis, logging.Update, "Failed to count unread message", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"field":              field,
+			"users":              len(userIDs),
+		})
+	}
+}
+
+// EditMessage replaces the content of messageID, keeping the old content as
+// a revision.
+func (s *ChatService) EditMessage(ctx context.Context, actorID, messageID int32, content string) (dbCtx.Message, error) {
+	return s.revise(ctx, actorID, messageID, outbox.MessageEdited, func(tx repository.IRepositoryManager) (dbCtx.Message, error) {
+		return tx.Chat().EditMessage(ctx, dbCtx.EditMessageParams{
+			ID:      messageID,
+			Content: content,
+		})
+	})
+}
+
+// DeleteMessage clears the content of messageID, keeping it as a revision.
+func (s *ChatService) DeleteMessage(ctx context.Context, actorID, messageID int32) (dbCtx.Message, error) {
+	return s.revise(ctx, actorID, messageID, outbox.MessageDeleted, func(tx repository.IRepositoryManager) (dbCtx.Message, error) {
+		return tx.Chat().DeleteMessage(ctx, messageID)
+	})
+}
+
+// revise applies change to messageID on behalf of actorID once canRevise
+// allows it, after recording the content the message had. Room messages
+// are published to the outbox as event, and the history pages cached for
+// their room are evicted.
+func (s *ChatService) revise(
+	ctx context.Context,
+	actorID, messageID int32,
+	event string,
+	change func(tx repository.IRepositoryManager) (dbCtx.Message, error),
+) (dbCtx.Message, error) {
+	var msg dbCtx.Message
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		current, err := tx.Chat().GetMessageForUpdate(ctx, dbCtx.GetMessageForUpdateParams{
+			ID:             messageID,
+			OrganizationID: orgID,
+		})
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && current.DeletedAt.Valid) {
+			return ErrMessageNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.canRevise(ctx, tx, orgID, actorID, current); err != nil {
+			return err
+		}
+
+		err = tx.Chat().CreateRevision(ctx, dbCtx.CreateMessageRevisionParams{
+			MessageID:      current.ID,
+			OrganizationID: orgID,
+			Content:        current.Content,
+			RevisedBy:      sql.NullInt32{Int32: actorID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		msg, err = change(tx)
+		if err != nil || !msg.RoomID.Valid {
+			return err
+		}
+		return outbox.Enqueue(ctx, tx, event, outbox.AggregateMessage, msg.ID, map[string]any{
+			"id":             msg.ID,
+			"organizationId": msg.OrganizationID,
+			"roomId":         msg.RoomID,
+			"content":        msg.Content,
+			"revisedBy":      actorID,
+		})
+	})
+	if err != nil {
+		return dbCtx.Message{}, err
+	}
+
+	if msg.RoomID.Valid {
+		s.evictHistory(ctx, msg.RoomID.Int32)
+	}
+	return msg, nil
+}
+
+// evictHistory drops the history pages cached for roomID, for every
+// viewer, after one of its messages changed, by bumping its generation.
+func (s *ChatService) evictHistory(ctx context.Context, roomID int32) {
+	if _, err := s.cache.Incr(ctx, HistoryGenerationKey(roomID)); err != nil {
+		s.logger.Error(logging.Redis, logging.Delete, "Failed to evict message history", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"roomID":             roomID,
+		})
+	}
+}
+
+// canRevise lets the author change a message, and the organization's
+// owners and admins change room messages too, until the edit window
+// closes. Direct messages of other users are not found at all, unless
+// actorID received them.
+func (s *ChatService) canRevise(ctx context.Context, tx repository.IRepositoryManager, orgID, actorID int32, msg dbCtx.Message) error {
+	if msg.SenderID != actorID {
+		if msg.RecipientID.Valid {
+			if msg.RecipientID.Int32 == actorID {
+				return ErrMessageForbidden
+			}
+			return ErrMessageNotFound
+		}
+		member, err := tx.Organization().GetMember(ctx, dbCtx.GetOrganizationMemberParams{
+			OrganizationID: orgID,
+			UserID:         actorID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMessageForbidden
+		}
+		if err != nil {
+			return err
+		}
+		if member.Role != orgRoleOwner && member.Role != orgRoleAdmin {
+			return ErrMessageForbidden
+		}
+	}
+	if s.editWindow > 0 && time.Now().UTC().Sub(msg.CreatedAt.Time) > s.editWindow {
+		return ErrEditWindowClosed
+	}
+	return nil
+}
+
+// React stores the reaction and counts the message's reactions with emoji.
+func (s *ChatService) React(ctx context.Context, userID, messageID int32, emoji string) (Reaction, error) {
+	return s.react(ctx, userID, messageID, emoji, func(tx repository.IRepositoryManager, msg dbCtx.Message) error {
+		if msg.DeletedAt.Valid {
+			return ErrMessageNotFound
+		}
+		_, err := tx.Chat().AddReaction(ctx, dbCtx.AddMessageReactionParams{
+			MessageID:      msg.ID,
+			UserID:         userID,
+			OrganizationID: msg.OrganizationID,
+			Emoji:          emoji,
+		})
+		return err
+	})
+}
+
+// Unreact takes the reaction back, also from a message deleted since.
+func (s *ChatService) Unreact(ctx context.Context, userID, messageID int32, emoji string) (Reaction, error) {
+	return s.react(ctx, userID, messageID, emoji, func(tx repository.IRepositoryManager, msg dbCtx.Message) error {
+		_, err := tx.Chat().DeleteReaction(ctx, dbCtx.DeleteMessageReactionParams{
+			MessageID: msg.ID,
+			UserID:    userID,
+			Emoji:     emoji,
+		})
+		return err
+	})
+}
+
+// react applies change to messageID once userID is found to see it, and
+// counts the message's reactions with emoji afterwards. The history pages
+// cached for the message's room are evicted.
+func (s *ChatService) react(
+	ctx context.Context,
+	userID, messageID int32,
+	emoji string,
+	change func(tx repository.IRepositoryManager, msg dbCtx.Message) error,
+) (Reaction, error) {
+	if !validEmoji(emoji) {
+		return Reaction{}, ErrInvalidEmoji
+	}
+	reaction := Reaction{Emoji: emoji}
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		var err error
+		reaction.Message, err = visibleMessage(ctx, tx, orgID, userID, messageID)
+		if err != nil {
+			return err
+		}
+		if err := change(tx, reaction.Message); err != nil {
+			return err
+		}
+		reaction.Count, err = tx.Chat().CountReactions(ctx, dbCtx.CountMessageReactionsParams{
+			MessageID: messageID,
+			Emoji:     emoji,
+		})
+		return err
+	})
+	if err != nil {
+		return Reaction{}, err
+	}
+
+	if reaction.Message.RoomID.Valid {
+		s.evictHistory(ctx, reaction.Message.RoomID.Int32)
+	}
+	return reaction, nil
+}
+
+func (s *ChatService) Reactions(ctx context.Context, userID, messageID int32) ([]dbCtx.ListMessageReactionsRow, error) {
+	var reactions []dbCtx.ListMessageReactionsRow
+	err := s.inTenant(ctx, func(tx repository.IRepositoryManager, orgID int32) error {
+		if _, err := visibleMessage(ctx, tx, orgID, userID, messageID); err != nil {
+			return err
+		}
+		var err error
+		reactions, err = tx.Chat().ListReactions(ctx, messageID)
+		return err
+	})
+	return reactions, err
+}
+
+// validEmoji accepts up to maxEmojiLength characters, none of them spaces
+// or control characters. Which emoji, or shortcodes, to offer is left to
+// clients.
+func validEmoji(emoji string) bool {
+	n := utf8.RuneCountInString(emoji)
+	if n == 0 || n > maxEmojiLength || !utf8.ValidString(emoji) {
+		return false
+	}
+	return strings.IndexFunc(emoji, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}) < 0
+}
+
+// visibleMessage returns messageID when userID may see it: a message of a
+// room they joined, or a direct message they sent or received. Other
+// direct messages are not found at all.
+func visibleMessage(ctx context.Context, tx repository.IRepositoryManager, orgID, userID, messageID int32) (dbCtx.Message, error) {
+	msg, err := tx.Chat().GetMessage(ctx, dbCtx.GetMessageParams{
+		ID:             messageID,
+		OrganizationID: orgID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbCtx.Message{}, ErrMessageNotFound
+	}
+	if err != nil {
+		return dbCtx.Message{}, err
+	}
+	if msg.RecipientID.Valid {
+		if msg.SenderID != userID && msg.RecipientID.Int32 != userID {
+			return dbCtx.Message{}, ErrMessageNotFound
+		}
+		return msg, nil
+	}
+	if err := requireMember(ctx, tx, orgID, msg.RoomID.Int32, userID); err != nil {
+		return dbCtx.Message{}, err
 	}
+	return msg, nil
 }
 
-func (s *ChatService) SaveMessage(ctx context.Context, senderID int32, content string) error {
-	_, err := s.repo.Chat().CreateMessage(ctx, dbCtx.CreateMessageParams{
-		SenderID: senderID,
-		Content:  content,
+// requireRoom fails with ErrRoomNotFound unless roomID belongs to orgID.
+func requireRoom(ctx context.Context, tx repository.IRepositoryManager, orgID, roomID int32) error {
+	_, err := tx.Chat().GetRoom(ctx, dbCtx.GetChatRoomParams{
+		ID:             roomID,
+		OrganizationID: orgID,
 	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrRoomNotFound
+	}
 	return err
 }
 
-func (s *ChatService) GetMessages(ctx context.Context, limit, offset int32) ([]dbCtx.GetMessagesRow, error) {
-	return s.repo.Chat().GetMessages(ctx, dbCtx.GetMessagesParams{
-		Limit:  limit,
-		Offset: offset,
+// requireMember is requireRoom that also fails with ErrNotRoomMember unless
+// userID has joined the room.
+func requireMember(ctx context.Context, tx repository.IRepositoryManager, orgID, roomID, userID int32) error {
+	if err := requireRoom(ctx, tx, orgID, roomID); err != nil {
+		return err
+	}
+	ok, err := tx.Chat().IsRoomMember(ctx, dbCtx.IsRoomMemberParams{
+		RoomID: roomID,
+		UserID: userID,
 	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotRoomMember
+	}
+	return nil
 }
@@ -3,43 +3,143 @@ package chat
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"example.com/api/config"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/tenant"
 	"github.com/gorilla/websocket"
 )
 
+// Defaults for the ConnLimits fields left zero.
+const (
+	defaultPongWait       = 60 * time.Second
+	defaultWriteWait      = 10 * time.Second
+	defaultMaxMessageSize = 16 << 10
+	defaultPresenceGrace  = 5 * time.Second
+	sendBuffer            = 256
+)
+
+// ConnLimits bounds the WebSocket connections of a hub's clients. The
+// server pings every PingPeriod, which must be shorter than PongWait, and
+// drops a connection that has not answered for PongWait or whose writes
+// take longer than WriteWait. A client frame larger than MaxMessageSize
+// bytes closes the connection. A user stays online for PresenceGrace after
+// their last connection closes; a negative grace takes them offline at
+// once.
+type ConnLimits struct {
+	PingPeriod     time.Duration
+	PongWait       time.Duration
+	WriteWait      time.Duration
+	MaxMessageSize int64
+	PresenceGrace  time.Duration
+}
+
+// NewConnLimits returns the limits cfg sets.
+func NewConnLimits(cfg config.ChatConfig) ConnLimits {
+	return ConnLimits{
+		PingPeriod:     cfg.PingPeriod * time.Second,
+		PongWait:       cfg.PongWait * time.Second,
+		WriteWait:      cfg.WriteWait * time.Second,
+		MaxMessageSize: cfg.MaxMessageSize,
+		PresenceGrace:  cfg.PresenceGrace * time.Second,
+	}
+}
+
+func (l ConnLimits) withDefaults() ConnLimits {
+	if l.PongWait <= 0 {
+		l.PongWait = defaultPongWait
+	}
+	if l.PingPeriod <= 0 || l.PingPeriod >= l.PongWait {
+		l.PingPeriod = l.PongWait * 9 / 10
+	}
+	if l.WriteWait <= 0 {
+		l.WriteWait = defaultWriteWait
+	}
+	if l.MaxMessageSize <= 0 {
+		l.MaxMessageSize = defaultMaxMessageSize
+	}
+	if l.PresenceGrace == 0 {
+		l.PresenceGrace = defaultPresenceGrace
+	}
+	return l
+}
+
 type Client struct {
 	Hub      *Hub
 	conn     *websocket.Conn
 	send     chan []byte
 	userID   int32
 	username string
+	orgID    int32
+	// rooms is owned by the hub, which keeps it in step with its own index.
+	rooms map[int32]bool
+	// closeCode and closeText are set by the hub before it closes send, and
+	// tell SendMessages how to close the connection.
+	closeCode int
+	closeText string
+	// replayed is owned by the goroutine writing to the connection.
+	replayed *replayed
 }
 
-type Message struct {
-	Username string    `json:"username"`
-	Content  string    `json:"content"`
-	Time     time.Time `json:"time"`
-}
-
+// NewClient returns a client of user uID chatting in organization orgID,
+// subscribed to rooms.
 func NewClient(
-	hub *Hub, conn *websocket.Conn, uID int32, uname string) *Client {
+	hub *Hub, conn *websocket.Conn, uID int32, uname string, orgID int32, rooms []int32) *Client {
+	subscribed := make(map[int32]bool, len(rooms))
+	for _, id := range rooms {
+		subscribed[id] = true
+	}
 	return &Client{
 		Hub:      hub,
 		conn:     conn,
-		send:     make(chan []byte, 256),
+		send:     make(chan []byte, sendBuffer),
 		userID:   uID,
 		username: uname,
+		orgID:    orgID,
+		rooms:    subscribed,
 	}
 }
 
+const (
+	defaultHistoryLimit = 50
+	maxHistoryLimit     = 100
+)
+
+// frameError is a failure reported to the client in an error frame.
+type frameError struct {
+	code    string
+	message string
+}
+
+func (e *frameError) Error() string {
+	return e.message
+}
+
+func invalidPayload(message string) error {
+	return &frameError{code: ErrCodeInvalidPayload, message: message}
+}
+
+// HandleMessages reads the client's frames until the connection closes or
+// stops answering pings. Every frame the server cannot carry out is
+// answered with an error frame.
 func (c *Client) HandleMessages(chatService IChatService) {
 	defer func() {
 		c.Hub.unregister <- c
 		c.conn.Close()
 	}()
 
+	limits := c.Hub.limits
+	c.conn.SetReadLimit(limits.MaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(limits.PongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(limits.PongWait))
+	})
+
 	for {
 		_, data, err := c.conn.ReadMessage()
 		if err != nil {
@@ -49,40 +149,392 @@ func (c *Client) HandleMessages(chatService IChatService) {
 			break
 		}
 
-		var rawMsg struct {
-			Content string `json:"content"`
+		var frame Frame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			c.fail("", &frameError{code: ErrCodeInvalidFrame, message: "frame is not valid JSON"})
+			continue
 		}
-		if err := json.Unmarshal(data, &rawMsg); err != nil {
+		if frame.V != 0 && frame.V != ProtocolVersion {
+			c.fail(frame.ID, &frameError{code: ErrCodeUnsupportedVersion, message: fmt.Sprintf("protocol version %d is not supported", frame.V)})
 			continue
 		}
+		if err := c.handle(chatService, frame); err != nil {
+			c.fail(frame.ID, err)
+		}
+	}
+}
 
-		msg := Message{
-			Username: c.username,
-			Content:  rawMsg.Content,
-			Time:     time.Now(),
+func (c *Client) handle(chatService IChatService, frame Frame) error {
+	ctx := tenant.WithID(context.Background(), c.orgID)
+	switch frame.Type {
+	case FrameSend:
+		var p SendPayload
+		if err := decodePayload(frame, &p); err != nil {
+			return err
 		}
+		return c.post(ctx, chatService, frame.ID, p)
 
-		if err := chatService.SaveMessage(context.Background(), c.userID, msg.Content); err != nil {
-			log.Printf("error saving message: %v", err)
-			continue
+	case FrameEdit:
+		var p EditPayload
+		if err := decodePayload(frame, &p); err != nil {
+			return err
+		}
+		if p.Message == 0 || strings.TrimSpace(p.Content) == "" {
+			return invalidPayload("message and content are required")
 		}
+		msg, err := chatService.EditMessage(ctx, c.userID, p.Message, p.Content)
+		if err != nil {
+			return err
+		}
+		c.reply(FrameAck, frame.ID, AckPayload{MessageID: msg.ID, Time: msg.EditedAt.Time})
+		c.Hub.Update(msg)
+		return nil
 
-		messageJSON, err := json.Marshal(msg)
+	case FrameDelete:
+		var p DeletePayload
+		if err := decodePayload(frame, &p); err != nil {
+			return err
+		}
+		if p.Message == 0 {
+			return invalidPayload("message is required")
+		}
+		msg, err := chatService.DeleteMessage(ctx, c.userID, p.Message)
 		if err != nil {
-			log.Printf("error marshaling message: %v", err)
-			continue
+			return err
+		}
+		c.reply(FrameAck, frame.ID, AckPayload{MessageID: msg.ID, Time: msg.DeletedAt.Time})
+		c.Hub.Update(msg)
+		return nil
+
+	case FrameReact, FrameUnreact:
+		var p ReactPayload
+		if err := decodePayload(frame, &p); err != nil {
+			return err
+		}
+		if p.Message == 0 || p.Emoji == "" {
+			return invalidPayload("message and emoji are required")
+		}
+		var (
+			reaction Reaction
+			err      error
+		)
+		if frame.Type == FrameReact {
+			reaction, err = chatService.React(ctx, c.userID, p.Message, p.Emoji)
+		} else {
+			reaction, err = chatService.Unreact(ctx, c.userID, p.Message, p.Emoji)
+		}
+		if err != nil {
+			return err
+		}
+		c.reply(FrameAck, frame.ID, AckPayload{MessageID: reaction.Message.ID, Time: time.Now().UTC()})
+		c.Hub.Reaction(c.userID, reaction, frame.Type == FrameReact)
+		return nil
+
+	case FrameRead:
+		var p ReadPayload
+		if err := decodePayload(frame, &p); err != nil {
+			return err
+		}
+		return c.read(ctx, chatService, frame.ID, p)
+
+	case FrameJoin, FrameLeave:
+		var p RoomPayload
+		if err := decodePayload(frame, &p); err != nil {
+			return err
+		}
+		if p.Room == 0 {
+			return invalidPayload("room is required")
 		}
-		c.Hub.broadcast <- messageJSON
+		if frame.Type == FrameJoin {
+			if err := chatService.JoinRoom(ctx, c.userID, p.Room); err != nil {
+				return err
+			}
+			c.Hub.Join(c.orgID, c.userID, p.Room)
+			return nil
+		}
+		if err := chatService.LeaveRoom(ctx, c.userID, p.Room); err != nil && !errors.Is(err, ErrNotRoomMember) {
+			return err
+		}
+		c.Hub.Leave(c.orgID, c.userID, p.Room)
+		return nil
+
+	case FrameTyping:
+		var p TypingPayload
+		if err := decodePayload(frame, &p); err != nil {
+			return err
+		}
+		return c.typing(p)
+
+	case FrameHistory:
+		var p HistoryRequest
+		if err := decodePayload(frame, &p); err != nil {
+			return err
+		}
+		return c.history(ctx, chatService, frame.ID, p)
+
+	default:
+		return &frameError{code: ErrCodeUnknownType, message: fmt.Sprintf("unknown frame type %q", frame.Type)}
 	}
 }
 
-func (c *Client) SendMessages() {
-	defer c.conn.Close()
+func decodePayload(frame Frame, v any) error {
+	if len(frame.Payload) == 0 {
+		return invalidPayload("payload is required")
+	}
+	if err := json.Unmarshal(frame.Payload, v); err != nil {
+		return invalidPayload("payload does not match the frame type")
+	}
+	return nil
+}
+
+// post stores a message, acks it to the client and hands it to the hub,
+// followed by the new reply count of its thread when it is a reply.
+func (c *Client) post(ctx context.Context, chatService IChatService, id string, p SendPayload) error {
+	if strings.TrimSpace(p.Content) == "" {
+		return invalidPayload("content is required")
+	}
+	targets := 0
+	for _, target := range []int32{p.Room, p.To, p.Parent} {
+		if target != 0 {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return invalidPayload("exactly one of room, to and parent is required")
+	}
+
+	var (
+		saved   dbCtx.Message
+		replies int64
+		err     error
+	)
+	env := envelope{Kind: envelopeMessage, OrgID: c.orgID, SenderID: c.userID}
+	switch {
+	case p.To != 0:
+		saved, err = chatService.SendDirect(ctx, c.userID, p.To, p.Content)
+		env.RecipientID = p.To
+	case p.Parent != 0:
+		saved, replies, err = chatService.Reply(ctx, c.userID, p.Parent, p.Content)
+		env.RoomID = saved.RoomID.Int32
+	default:
+		saved, err = chatService.SaveMessage(ctx, c.userID, p.Room, p.Content)
+		env.RoomID = p.Room
+	}
+	if err != nil {
+		return err
+	}
+
+	c.reply(FrameAck, id, AckPayload{MessageID: saved.ID, Time: saved.CreatedAt.Time})
+	env.Data, err = encodeFrame(FrameMessage, "", newMessage(saved, c.username))
+	if err != nil {
+		log.Printf("error marshaling message: %v", err)
+		return nil
+	}
+	c.Hub.publish(env)
+	if saved.ParentID.Valid {
+		c.Hub.Thread(saved, replies)
+	}
+	return nil
+}
+
+// read moves the client's read marker, acks it and sends a receipt to the
+// other participants.
+func (c *Client) read(ctx context.Context, chatService IChatService, id string, p ReadPayload) error {
+	if (p.Room == 0) == (p.With == 0) {
+		return invalidPayload("exactly one of room and with is required")
+	}
+	if p.Message < 0 {
+		return invalidPayload("message must not be negative")
+	}
+
+	var (
+		lastRead int32
+		err      error
+	)
+	if p.Room != 0 {
+		lastRead, err = chatService.MarkRoomRead(ctx, c.userID, p.Room, p.Message)
+	} else {
+		lastRead, err = chatService.MarkConversationRead(ctx, c.userID, p.With, p.Message)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.reply(FrameAck, id, AckPayload{MessageID: lastRead, Time: time.Now().UTC()})
+	if lastRead != 0 {
+		c.Hub.Receipt(c.orgID, c.userID, p.Room, p.With, lastRead)
+	}
+	return nil
+}
+
+// typing passes a typing notice on to the room or user it names. It is
+// only passed on to rooms the client is subscribed to.
+func (c *Client) typing(p TypingPayload) error {
+	if (p.Room == 0) == (p.To == 0) {
+		return invalidPayload("exactly one of room and to is required")
+	}
+	if p.Room != 0 && !c.Hub.subscribed(c, p.Room) {
+		return ErrNotRoomMember
+	}
+
+	data, err := encodeFrame(FrameTyping, "", TypingPayload{Room: p.Room, To: p.To, From: c.userID, Username: c.username})
+	if err != nil {
+		return err
+	}
+	c.Hub.publish(envelope{
+		Kind:        envelopeMessage,
+		OrgID:       c.orgID,
+		RoomID:      p.Room,
+		RecipientID: p.To,
+		SenderID:    c.userID,
+		SkipSender:  true,
+		Data:        data,
+	})
+	return nil
+}
+
+// history answers with a page of a room's history or of a direct
+// conversation.
+func (c *Client) history(ctx context.Context, chatService IChatService, id string, p HistoryRequest) error {
+	if (p.Room == 0) == (p.With == 0) {
+		return invalidPayload("exactly one of room and with is required")
+	}
+	if p.Limit <= 0 {
+		p.Limit = defaultHistoryLimit
+	}
+	if p.Limit > maxHistoryLimit || p.Offset < 0 {
+		return invalidPayload(fmt.Sprintf("limit must be at most %d and offset not negative", maxHistoryLimit))
+	}
 
-	for message := range c.send {
-		err := c.conn.WriteMessage(websocket.TextMessage, message)
+	messages := make([]Message, 0, p.Limit)
+	if p.Room != 0 {
+		rows, err := chatService.GetMessages(ctx, c.userID, p.Room, p.Limit, p.Offset)
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			messages = append(messages, Message{
+				ID:        r.ID,
+				Room:      r.RoomID.Int32,
+				From:      r.SenderID,
+				Username:  r.SenderName,
+				Content:   r.Content,
+				Time:      r.CreatedAt.Time,
+				EditedAt:  timePtr(r.EditedAt),
+				DeletedAt: timePtr(r.DeletedAt),
+				Replies:   r.ReplyCount,
+				Reactions: reactionCounts(r.Reactions),
+			})
+		}
+	} else {
+		rows, err := chatService.DirectMessages(ctx, c.userID, p.With, p.Limit, p.Offset)
 		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			messages = append(messages, Message{
+				ID:        r.ID,
+				To:        r.RecipientID.Int32,
+				From:      r.SenderID,
+				Username:  r.SenderName,
+				Content:   r.Content,
+				Time:      r.CreatedAt.Time,
+				EditedAt:  timePtr(r.EditedAt),
+				DeletedAt: timePtr(r.DeletedAt),
+				Reactions: reactionCounts(r.Reactions),
+			})
+		}
+	}
+
+	c.reply(FrameHistory, id, HistoryPayload{Messages: messages})
+	return nil
+}
+
+// reply sends the client a frame of its own.
+func (c *Client) reply(kind, id string, payload any) {
+	data, err := encodeFrame(kind, id, payload)
+	if err != nil {
+		log.Printf("error marshaling %s frame: %v", kind, err)
+		return
+	}
+	c.Hub.reply(c, data)
+}
+
+// fail answers the client frame id with an error frame.
+func (c *Client) fail(id string, err error) {
+	var fe *frameError
+	if !errors.As(err, &fe) {
+		code, message := errorCode(err)
+		if code == ErrCodeInternal {
+			log.Printf("error handling frame: %v", err)
+		}
+		fe = &frameError{code: code, message: message}
+	}
+	c.reply(FrameError, id, ErrorPayload{Code: fe.code, Message: fe.message})
+}
+
+// SendMessages writes the frames queued for the client and pings it, until
+// the hub drops the client. Frames queued while a write is under way go out
+// together in one WebSocket message, one per line.
+func (c *Client) SendMessages() {
+	limits := c.Hub.limits
+	ticker := time.NewTicker(limits.PingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			if !ok {
+				c.close(limits.WriteWait)
+				return
+			}
+			if c.replayed.skip(message) {
+				continue
+			}
+			if err := c.write(limits.WriteWait, message); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(limits.WriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// write sends message and whatever else is queued in one WebSocket message.
+func (c *Client) write(wait time.Duration, message []byte) error {
+	c.conn.SetWriteDeadline(time.Now().Add(wait))
+	w, err := c.conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+	w.Write(message)
+	for n := len(c.send); n > 0; n-- {
+		next, ok := <-c.send
+		if !ok {
 			break
 		}
+		if c.replayed.skip(next) {
+			continue
+		}
+		w.Write(frameSeparator)
+		w.Write(next)
+	}
+	return w.Close()
+}
+
+// close tells the client why the hub dropped it.
+func (c *Client) close(wait time.Duration) {
+	code := c.closeCode
+	if code == 0 {
+		code = websocket.CloseNormalClosure
 	}
+	message := websocket.FormatCloseMessage(code, c.closeText)
+	c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wait))
 }
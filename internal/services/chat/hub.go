@@ -1,53 +1,211 @@
 package chat
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/pkg/metrics"
+	"github.com/gorilla/websocket"
+)
+
+// BlockLookup tells the hub who has blocked a user. It is asked once per
+// broadcast, so implementations are expected to answer from a cache.
+type BlockLookup interface {
+	BlockedBy(ctx context.Context, userID int32) ([]int32, error)
+}
+
+const (
+	blockLookupTimeout = time.Second
+	publishTimeout     = time.Second
+	// publishAttempts is how many times an event is handed to the broker.
+	// A retry after a timeout may publish the event twice; the hubs drop
+	// the second copy by its ID.
+	publishAttempts = 2
+	// seenEvents is how many event IDs a hub remembers to drop duplicates.
+	seenEvents = 4096
+	// presenceBuffer is how many presence changes may wait to be published.
+	// Changes beyond it are dropped.
+	presenceBuffer = 256
 )
 
+// Kinds of envelope.
+const (
+	envelopeMessage      = "message"
+	envelopeSubscription = "subscription"
+	envelopePresence     = "presence"
+	envelopePresenceSync = "presenceSync"
+)
+
+// envelope is an event travelling between hubs through the broker: a frame
+// on its way to the clients, a user joining or leaving a room, or a user
+// coming online or going offline on the hub Instance. A frame carries the
+// user who sent it and either the room it was sent to or, for a direct
+// message, its recipient. SkipSender keeps it from the sender's own clients,
+// BlockedBy from the users who blocked the sender.
+//
+// A hub that starts asks the others for their users with a presence sync.
+type envelope struct {
+	ID          string          `json:"id"`
+	Kind        string          `json:"kind"`
+	OrgID       int32           `json:"org"`
+	RoomID      int32           `json:"room,omitempty"`
+	RecipientID int32           `json:"recipient,omitempty"`
+	SenderID    int32           `json:"sender,omitempty"`
+	UserID      int32           `json:"user,omitempty"`
+	Join        bool            `json:"join,omitempty"`
+	SkipSender  bool            `json:"skipSender,omitempty"`
+	BlockedBy   []int32         `json:"blockedBy,omitempty"`
+	Online      bool            `json:"online,omitempty"`
+	Instance    string          `json:"instance,omitempty"`
+	Data        json.RawMessage `json:"data,omitempty"`
+}
+
+// presenceKey is a user of an organization.
+type presenceKey struct {
+	orgID  int32
+	userID int32
+}
+
+// graceExpiry ends the grace period gen of a user who disconnected.
+type graceExpiry struct {
+	key presenceKey
+	gen uint64
+}
+
+// reply is a frame for one client only, such as an ack.
+type reply struct {
+	client *Client
+	data   []byte
+}
+
 type Hub struct {
 	clients    map[*Client]bool
-	broadcast  chan []byte
+	rooms      map[int32]map[*Client]bool
+	users      map[int32]map[*Client]bool
 	register   chan *Client
 	unregister chan *Client
+	replies    chan reply
+	blocks     BlockLookup
+	broker     Broker
+	limits     ConnLimits
 	mu         sync.Mutex
+
+	// connections counts each user's clients on this hub. A user whose
+	// last client left is in leaving until their grace period ends.
+	connections map[presenceKey]int
+	leaving     map[presenceKey]uint64
+	graceSeq    uint64
+	expired     chan graceExpiry
+	// online holds the hubs each user is online on, this one included.
+	online   map[presenceKey]map[string]bool
+	presence chan envelope
+	// done is closed when Run returns.
+	done chan struct{}
+
+	instance string
+	sequence atomic.Uint64
+	seen     map[string]bool
+	seenRing []string
+	seenNext int
 }
 
-func NewHub() *Hub {
+// NewHub returns a hub that delivers a message to the clients subscribed to
+// the room it was sent to, except to the users who have blocked its sender.
+// blocks may be nil, in which case no one is skipped. Direct messages go to
+// every client of the recipient and of the sender.
+//
+// Events go through broker, so hubs sharing a broker serve the same chat
+// whichever of them a client is connected to. A nil broker keeps events in
+// process, for a single instance.
+//
+// Clients' connections are kept within limits; its zero fields take
+// defaults.
+//
+// The hub tells an organization's clients when one of its users comes
+// online or goes offline. A user is online while they have a client on any
+// hub, and stays online for limits.PresenceGrace after their last client
+// leaves, so a reconnect does not show them going away.
+func NewHub(blocks BlockLookup, broker Broker, limits ConnLimits) *Hub {
+	if broker == nil {
+		broker = NewMemoryBroker()
+	}
 	return &Hub{
-		broadcast:  make(chan []byte),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
+		replies:    make(chan reply),
 		clients:    make(map[*Client]bool),
+		rooms:      make(map[int32]map[*Client]bool),
+		users:      make(map[int32]map[*Client]bool),
+		blocks:     blocks,
+		broker:     broker,
+		limits:     limits.withDefaults(),
+
+		connections: make(map[presenceKey]int),
+		leaving:     make(map[presenceKey]uint64),
+		expired:     make(chan graceExpiry),
+		online:      make(map[presenceKey]map[string]bool),
+		presence:    make(chan envelope, presenceBuffer),
+		done:        make(chan struct{}),
+
+		instance: newInstanceID(),
+		seen:     make(map[string]bool, seenEvents),
+		seenRing: make([]string, seenEvents),
 	}
 }
 
+// Run serves the hub until its broker stops delivering events.
 func (h *Hub) Run() {
+	defer close(h.done)
+	go h.announce()
+	h.queuePresence(envelope{Kind: envelopePresenceSync, Instance: h.instance})
+
+	messages := h.broker.Messages()
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client] = true
+			h.addToUser(client)
+			for roomID := range client.rooms {
+				h.addToRoom(client, roomID)
+			}
+			h.connected(client)
 			h.mu.Unlock()
 
-		case client := <-h.unregister:
+		case e := <-h.expired:
 			h.mu.Lock()
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
+			if gen, ok := h.leaving[e.key]; ok && gen == e.gen {
+				delete(h.leaving, e.key)
+				h.announcePresence(e.key, false)
 			}
 			h.mu.Unlock()
 
-		case message := <-h.broadcast:
+		case client := <-h.unregister:
+			h.mu.Lock()
+			h.remove(client, websocket.CloseNormalClosure, "")
+			h.mu.Unlock()
+
+		case r := <-h.replies:
 			h.mu.Lock()
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
-				}
+			if h.clients[r.client] {
+				h.deliver(r.client, r.data)
 			}
 			h.mu.Unlock()
+
+		case data, ok := <-messages:
+			if !ok {
+				return
+			}
+			h.dispatch(data)
 		}
 	}
 }
@@ -55,3 +213,472 @@ func (h *Hub) Run() {
 func (h *Hub) Register(client *Client) {
 	h.register <- client
 }
+
+// Join subscribes every client userID has connected to organization orgID
+// to roomID, on every hub, and tells those clients they joined. It does not
+// touch the stored membership; that is the chat service's job.
+func (h *Hub) Join(orgID, userID, roomID int32) {
+	h.publish(envelope{Kind: envelopeSubscription, OrgID: orgID, UserID: userID, RoomID: roomID, Join: true})
+}
+
+// Update tells the clients who received msg, on every hub, that it was
+// edited or deleted.
+func (h *Hub) Update(msg dbCtx.Message) {
+	data, err := encodeFrame(FrameUpdate, "", newUpdate(msg))
+	if err != nil {
+		log.Printf("error marshaling message update: %v", err)
+		return
+	}
+	h.publish(envelope{
+		Kind:        envelopeMessage,
+		OrgID:       msg.OrganizationID,
+		RoomID:      msg.RoomID.Int32,
+		RecipientID: msg.RecipientID.Int32,
+		SenderID:    msg.SenderID,
+		Data:        data,
+	})
+}
+
+// Receipt tells the other members of roomID, or userID's peer peerID, on
+// every hub that userID has read up to messageID. userID's own clients get
+// it too, so they can clear what they show as unread.
+func (h *Hub) Receipt(orgID, userID, roomID, peerID, messageID int32) {
+	data, err := encodeFrame(FrameReceipt, "", ReceiptPayload{Room: roomID, To: peerID, User: userID, Message: messageID})
+	if err != nil {
+		log.Printf("error marshaling read receipt: %v", err)
+		return
+	}
+	h.publish(envelope{
+		Kind:        envelopeMessage,
+		OrgID:       orgID,
+		RoomID:      roomID,
+		RecipientID: peerID,
+		SenderID:    userID,
+		Data:        data,
+	})
+}
+
+// Reaction tells the clients who received r.Message, on every hub, that
+// userID added or removed their reaction with r.Emoji, unless they blocked
+// userID.
+func (h *Hub) Reaction(userID int32, r Reaction, added bool) {
+	msg := r.Message
+	payload := ReactionPayload{
+		Message: msg.ID,
+		Room:    msg.RoomID.Int32,
+		User:    userID,
+		Emoji:   r.Emoji,
+		Added:   added,
+		Count:   r.Count,
+	}
+	if msg.RecipientID.Valid {
+		payload.To = msg.RecipientID.Int32
+		if payload.To == userID {
+			payload.To = msg.SenderID
+		}
+	}
+	data, err := encodeFrame(FrameReaction, "", payload)
+	if err != nil {
+		log.Printf("error marshaling reaction: %v", err)
+		return
+	}
+	h.publish(envelope{
+		Kind:        envelopeMessage,
+		OrgID:       msg.OrganizationID,
+		RoomID:      payload.Room,
+		RecipientID: payload.To,
+		SenderID:    userID,
+		Data:        data,
+	})
+}
+
+// Thread tells the members of reply's room, on every hub, that its thread
+// has replies replies now. Like the reply itself, it does not reach the
+// members who blocked its sender.
+func (h *Hub) Thread(reply dbCtx.Message, replies int64) {
+	data, err := encodeFrame(FrameThread, "", ThreadPayload{
+		Message: reply.ParentID.Int32,
+		Room:    reply.RoomID.Int32,
+		Replies: replies,
+		Last:    reply.ID,
+	})
+	if err != nil {
+		log.Printf("error marshaling thread: %v", err)
+		return
+	}
+	h.publish(envelope{
+		Kind:     envelopeMessage,
+		OrgID:    reply.OrganizationID,
+		RoomID:   reply.RoomID.Int32,
+		SenderID: reply.SenderID,
+		Data:     data,
+	})
+}
+
+// Leave is the opposite of Join.
+func (h *Hub) Leave(orgID, userID, roomID int32) {
+	h.publish(envelope{Kind: envelopeSubscription, OrgID: orgID, UserID: userID, RoomID: roomID})
+}
+
+// Online returns the users of organization orgID who are online.
+func (h *Hub) Online(orgID int32) []int32 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	users := make([]int32, 0)
+	for key := range h.online {
+		if key.orgID == orgID {
+			users = append(users, key.userID)
+		}
+	}
+	slices.Sort(users)
+	return users
+}
+
+// reply queues data for client alone. It is dropped if the client has
+// gone.
+func (h *Hub) reply(client *Client, data []byte) {
+	h.replies <- reply{client: client, data: data}
+}
+
+// subscribed reports whether client receives roomID's frames.
+func (h *Hub) subscribed(client *Client, roomID int32) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return client.rooms[roomID]
+}
+
+// publish hands env to the broker under a new ID. The users who blocked
+// the sender of a frame are looked up here, on the publishing goroutine, so
+// that Run never waits on the lookup.
+func (h *Hub) publish(env envelope) {
+	env.ID = fmt.Sprintf("%s-%d", h.instance, h.sequence.Add(1))
+	if env.Kind == envelopeMessage {
+		env.BlockedBy = h.blockedBy(env.SenderID)
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		log.Printf("error marshaling hub event: %v", err)
+		return
+	}
+
+	for attempt := 1; attempt <= publishAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+		err = h.broker.Publish(ctx, data)
+		cancel()
+		if err == nil {
+			return
+		}
+	}
+	log.Printf("error publishing hub event: %v", err)
+}
+
+func (h *Hub) dispatch(data []byte) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		log.Printf("error decoding hub event: %v", err)
+		return
+	}
+	if h.duplicate(env.ID) {
+		return
+	}
+
+	switch env.Kind {
+	case envelopeSubscription:
+		h.mu.Lock()
+		h.applySubscription(env)
+		h.mu.Unlock()
+
+	case envelopePresence:
+		h.mu.Lock()
+		h.applyPresence(env)
+		h.mu.Unlock()
+
+	case envelopePresenceSync:
+		if env.Instance == h.instance {
+			return
+		}
+		h.mu.Lock()
+		for key := range h.connections {
+			h.announcePresence(key, true)
+		}
+		for key := range h.leaving {
+			h.announcePresence(key, true)
+		}
+		h.mu.Unlock()
+
+	case envelopeMessage:
+		blockedBy := make(map[int32]bool, len(env.BlockedBy))
+		for _, id := range env.BlockedBy {
+			blockedBy[id] = true
+		}
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if env.RecipientID != 0 {
+			h.deliverDirect(env, blockedBy)
+			return
+		}
+		for client := range h.rooms[env.RoomID] {
+			if client.orgID != env.OrgID || blockedBy[client.userID] {
+				continue
+			}
+			if env.SkipSender && client.userID == env.SenderID {
+				continue
+			}
+			h.deliver(client, env.Data)
+		}
+	}
+}
+
+// duplicate reports whether the event id was seen recently, and remembers
+// it otherwise.
+func (h *Hub) duplicate(id string) bool {
+	if h.seen[id] {
+		return true
+	}
+	if old := h.seenRing[h.seenNext]; old != "" {
+		delete(h.seen, old)
+	}
+	h.seenRing[h.seenNext] = id
+	h.seenNext = (h.seenNext + 1) % len(h.seenRing)
+	h.seen[id] = true
+	return false
+}
+
+func (h *Hub) applySubscription(sub envelope) {
+	kind := FrameLeft
+	if sub.Join {
+		kind = FrameJoined
+	}
+	data, err := encodeFrame(kind, "", RoomPayload{Room: sub.RoomID})
+	if err != nil {
+		log.Printf("error marshaling membership event: %v", err)
+		return
+	}
+
+	for client := range h.users[sub.UserID] {
+		if client.orgID != sub.OrgID {
+			continue
+		}
+		if sub.Join {
+			h.addToRoom(client, sub.RoomID)
+		} else {
+			h.removeFromRoom(client, sub.RoomID)
+		}
+		h.deliver(client, data)
+	}
+}
+
+// applyPresence records a user coming online or going offline on a hub,
+// and tells the organization's clients if that changes whether the user is
+// online at all.
+func (h *Hub) applyPresence(env envelope) {
+	key := presenceKey{orgID: env.OrgID, userID: env.UserID}
+	hubs := h.online[key]
+	wasOnline := len(hubs) > 0
+	if env.Online {
+		if hubs == nil {
+			hubs = make(map[string]bool)
+			h.online[key] = hubs
+		}
+		hubs[env.Instance] = true
+	} else {
+		delete(hubs, env.Instance)
+		if len(hubs) == 0 {
+			delete(h.online, key)
+		}
+	}
+	if wasOnline == env.Online {
+		return
+	}
+
+	data, err := encodeFrame(FramePresence, "", PresencePayload{User: env.UserID, Online: env.Online})
+	if err != nil {
+		log.Printf("error marshaling presence event: %v", err)
+		return
+	}
+	for client := range h.clients {
+		if client.orgID == env.OrgID {
+			h.deliver(client, data)
+		}
+	}
+}
+
+// connected counts a new client of its user, who comes online unless they
+// were only just leaving.
+func (h *Hub) connected(client *Client) {
+	key := presenceKey{orgID: client.orgID, userID: client.userID}
+	h.connections[key]++
+	if h.connections[key] > 1 {
+		return
+	}
+	if _, ok := h.leaving[key]; ok {
+		delete(h.leaving, key)
+		return
+	}
+	h.announcePresence(key, true)
+}
+
+// disconnected is the opposite of connected. A user whose last client left
+// goes offline once their grace period ends.
+func (h *Hub) disconnected(client *Client) {
+	key := presenceKey{orgID: client.orgID, userID: client.userID}
+	h.connections[key]--
+	if h.connections[key] > 0 {
+		return
+	}
+	delete(h.connections, key)
+	if h.limits.PresenceGrace < 0 {
+		h.announcePresence(key, false)
+		return
+	}
+
+	h.graceSeq++
+	expiry := graceExpiry{key: key, gen: h.graceSeq}
+	h.leaving[key] = expiry.gen
+	time.AfterFunc(h.limits.PresenceGrace, func() {
+		select {
+		case h.expired <- expiry:
+		case <-h.done:
+		}
+	})
+}
+
+// announcePresence queues a presence change of this hub for publishing.
+func (h *Hub) announcePresence(key presenceKey, online bool) {
+	h.queuePresence(envelope{
+		Kind:     envelopePresence,
+		OrgID:    key.orgID,
+		UserID:   key.userID,
+		Online:   online,
+		Instance: h.instance,
+	})
+}
+
+// queuePresence hands env to announce, which publishes the changes in order
+// so that the hub is free to keep draining the broker meanwhile. The hub
+// must never wait on announce, which may itself be waiting on the broker the
+// hub drains, so a change that finds the queue full is dropped and counted.
+func (h *Hub) queuePresence(env envelope) {
+	select {
+	case h.presence <- env:
+	default:
+		metrics.ChatPresenceDropped.Inc()
+		log.Printf("dropping presence event of user %d: queue full", env.UserID)
+	}
+}
+
+func (h *Hub) announce() {
+	for {
+		select {
+		case env := <-h.presence:
+			h.publish(env)
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// deliverDirect sends a direct frame to its recipient, unless they blocked
+// the sender, and echoes it to the sender's clients.
+func (h *Hub) deliverDirect(message envelope, blockedBy map[int32]bool) {
+	users := []int32{message.SenderID}
+	if message.SkipSender {
+		users = nil
+	}
+	if !blockedBy[message.RecipientID] {
+		users = append(users, message.RecipientID)
+	}
+	for _, userID := range users {
+		for client := range h.users[userID] {
+			if client.orgID == message.OrgID {
+				h.deliver(client, message.Data)
+			}
+		}
+	}
+}
+
+func (h *Hub) addToUser(client *Client) {
+	clients, ok := h.users[client.userID]
+	if !ok {
+		clients = make(map[*Client]bool)
+		h.users[client.userID] = clients
+	}
+	clients[client] = true
+}
+
+func (h *Hub) addToRoom(client *Client, roomID int32) {
+	members, ok := h.rooms[roomID]
+	if !ok {
+		members = make(map[*Client]bool)
+		h.rooms[roomID] = members
+	}
+	members[client] = true
+	client.rooms[roomID] = true
+}
+
+func (h *Hub) removeFromRoom(client *Client, roomID int32) {
+	delete(client.rooms, roomID)
+	members := h.rooms[roomID]
+	delete(members, client)
+	if len(members) == 0 {
+		delete(h.rooms, roomID)
+	}
+}
+
+// deliver queues data for client. A client whose queue is full is not
+// keeping up; it is dropped and told to try again later.
+func (h *Hub) deliver(client *Client, data []byte) {
+	select {
+	case client.send <- data:
+	default:
+		h.remove(client, websocket.CloseTryAgainLater, "too slow reading messages")
+	}
+}
+
+// remove drops client, whose connection is then closed with code and text.
+// Clients already dropped are left alone, so send is closed only once.
+func (h *Hub) remove(client *Client, code int, text string) {
+	if !h.clients[client] {
+		return
+	}
+	for roomID := range client.rooms {
+		h.removeFromRoom(client, roomID)
+	}
+	clients := h.users[client.userID]
+	delete(clients, client)
+	if len(clients) == 0 {
+		delete(h.users, client.userID)
+	}
+	delete(h.clients, client)
+	h.disconnected(client)
+	client.closeCode, client.closeText = code, text
+	close(client.send)
+}
+
+// blockedBy returns the users who have blocked senderID. When the lookup
+// fails the message is delivered to everyone rather than to no one.
+func (h *Hub) blockedBy(senderID int32) []int32 {
+	if h.blocks == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), blockLookupTimeout)
+	defer cancel()
+
+	ids, err := h.blocks.BlockedBy(ctx, senderID)
+	if err != nil {
+		log.Printf("error looking up blocks: %v", err)
+		return nil
+	}
+	return ids
+}
+
+// newInstanceID names a hub in the IDs of the events it publishes, so IDs
+// from different instances never collide.
+func newInstanceID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,315 @@
+package chat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// ProtocolVersion is the version of the frame format. A frame that names
+// another version is refused with an unsupported_version error; one that
+// names none is taken to be of this version.
+const ProtocolVersion = 1
+
+// Frame is what travels over the WebSocket, in both directions. ID is
+// chosen by the client; the server echoes it on the ack, error or history
+// frame that answers the client's frame. The server may batch several
+// frames into one WebSocket message, one per line.
+type Frame struct {
+	V       int             `json:"v"`
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+var frameSeparator = []byte{'\n'}
+
+// Frame types. Clients send send, edit, delete, read, react, unreact,
+// join, leave, typing and history frames. The server answers a send, edit,
+// delete, read, react or unreact with an ack, a history with a history
+// frame and anything it cannot carry out with an error. It also pushes
+// message, update, receipt, reaction, thread, joined, left, typing and
+// presence frames, and a replayed frame after the messages a reconnecting
+// client missed.
+const (
+	FrameSend     = "send"
+	FrameEdit     = "edit"
+	FrameDelete   = "delete"
+	FrameUpdate   = "update"
+	FrameRead     = "read"
+	FrameReceipt  = "receipt"
+	FrameReact    = "react"
+	FrameUnreact  = "unreact"
+	FrameReaction = "reaction"
+	FrameThread   = "thread"
+	FrameAck      = "ack"
+	FrameError    = "error"
+	FrameMessage  = "message"
+	FrameJoin     = "join"
+	FrameLeave    = "leave"
+	FrameJoined   = "joined"
+	FrameLeft     = "left"
+	FrameTyping   = "typing"
+	FramePresence = "presence"
+	FrameHistory  = "history"
+	FrameReplayed = "replayed"
+)
+
+// Error codes carried by error frames.
+const (
+	ErrCodeInvalidFrame       = "invalid_frame"
+	ErrCodeUnsupportedVersion = "unsupported_version"
+	ErrCodeUnknownType        = "unknown_type"
+	ErrCodeInvalidPayload     = "invalid_payload"
+	ErrCodeRoomNotFound       = "room_not_found"
+	ErrCodeNotRoomMember      = "not_room_member"
+	ErrCodeRecipientNotFound  = "recipient_not_found"
+	ErrCodeMessageNotFound    = "message_not_found"
+	ErrCodeForbidden          = "forbidden"
+	ErrCodeEditWindowClosed   = "edit_window_closed"
+	ErrCodeInternal           = "internal_error"
+)
+
+// SendPayload posts Content to Room, or to the user To as a direct
+// message, or as a reply in the thread of the room message Parent.
+type SendPayload struct {
+	Room    int32  `json:"room,omitempty"`
+	To      int32  `json:"to,omitempty"`
+	Parent  int32  `json:"parent,omitempty"`
+	Content string `json:"content"`
+}
+
+// EditPayload replaces the content of the message Message.
+type EditPayload struct {
+	Message int32  `json:"message"`
+	Content string `json:"content"`
+}
+
+// DeletePayload retracts the message Message.
+type DeletePayload struct {
+	Message int32 `json:"message"`
+}
+
+// ReadPayload marks Room, or the conversation with the user With, read up
+// to the message Message, or up to the newest message when Message is 0.
+type ReadPayload struct {
+	Room    int32 `json:"room,omitempty"`
+	With    int32 `json:"with,omitempty"`
+	Message int32 `json:"message,omitempty"`
+}
+
+// ReceiptPayload says User has read Room, or their conversation with the
+// user To, up to the message Message.
+type ReceiptPayload struct {
+	Room    int32 `json:"room,omitempty"`
+	To      int32 `json:"to,omitempty"`
+	User    int32 `json:"user"`
+	Message int32 `json:"message"`
+}
+
+// ReactPayload adds, or with an unreact frame removes, the client's
+// reaction with Emoji to the message Message.
+type ReactPayload struct {
+	Message int32  `json:"message"`
+	Emoji   string `json:"emoji"`
+}
+
+// ReactionPayload says User added, or removed, their reaction with Emoji
+// to the message Message of Room, or of their conversation with the user
+// To, and that Count users now react to it with Emoji.
+type ReactionPayload struct {
+	Message int32  `json:"message"`
+	Room    int32  `json:"room,omitempty"`
+	To      int32  `json:"to,omitempty"`
+	User    int32  `json:"user"`
+	Emoji   string `json:"emoji"`
+	Added   bool   `json:"added"`
+	Count   int64  `json:"count"`
+}
+
+// ThreadPayload says the thread of the message Message in Room has
+// Replies replies, the newest being Last. It follows the message frame of
+// each reply.
+type ThreadPayload struct {
+	Message int32 `json:"message"`
+	Room    int32 `json:"room"`
+	Replies int64 `json:"replies"`
+	Last    int32 `json:"last"`
+}
+
+// AckPayload confirms a send, edit, delete, read, react or unreact frame
+// was stored. Time is when the message was sent, edited or deleted, or
+// when it was read up to MessageID or reacted to. MessageID of a read is 0
+// when there was nothing to read.
+type AckPayload struct {
+	MessageID int32     `json:"messageId"`
+	Time      time.Time `json:"time"`
+}
+
+type ErrorPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RoomPayload names the room of join, leave, joined and left frames.
+type RoomPayload struct {
+	Room int32 `json:"room"`
+}
+
+// TypingPayload says From is typing in Room, or to the user To. Typing
+// frames are passed on as they come and never stored.
+type TypingPayload struct {
+	Room     int32  `json:"room,omitempty"`
+	To       int32  `json:"to,omitempty"`
+	From     int32  `json:"from"`
+	Username string `json:"username"`
+}
+
+// PresencePayload says whether User is connected.
+type PresencePayload struct {
+	User   int32 `json:"user"`
+	Online bool  `json:"online"`
+}
+
+// HistoryRequest asks for a page of Room's history, or of the direct
+// conversation with the user With, newest first.
+type HistoryRequest struct {
+	Room   int32 `json:"room,omitempty"`
+	With   int32 `json:"with,omitempty"`
+	Limit  int32 `json:"limit,omitempty"`
+	Offset int32 `json:"offset,omitempty"`
+}
+
+type HistoryPayload struct {
+	Messages []Message `json:"messages"`
+}
+
+// ReplayedPayload ends the replay of the messages a client missed. Last is
+// the newest of them, or where the replay started when there were none.
+type ReplayedPayload struct {
+	Messages int   `json:"messages"`
+	Last     int32 `json:"last"`
+}
+
+// Message is the payload of message frames and of the entries of history
+// frames. A deleted message keeps its place with its content cleared. A
+// reply names the message its thread started with as Parent. Replies and
+// Reactions are only filled in history frames, which leave replies out of
+// room history.
+type Message struct {
+	ID        int32           `json:"id"`
+	Room      int32           `json:"room,omitempty"`
+	To        int32           `json:"to,omitempty"`
+	Parent    int32           `json:"parent,omitempty"`
+	From      int32           `json:"from"`
+	Username  string          `json:"username"`
+	Content   string          `json:"content"`
+	Time      time.Time       `json:"time"`
+	EditedAt  *time.Time      `json:"editedAt,omitempty"`
+	DeletedAt *time.Time      `json:"deletedAt,omitempty"`
+	Replies   int64           `json:"replies,omitempty"`
+	Reactions []ReactionCount `json:"reactions,omitempty"`
+}
+
+// ReactionCount is how many users reacted to a message with Emoji, and
+// who, in the order they reacted.
+type ReactionCount struct {
+	Emoji string  `json:"emoji"`
+	Count int64   `json:"count"`
+	Users []int32 `json:"users"`
+}
+
+// UpdatePayload is the new state of an edited or deleted message.
+type UpdatePayload struct {
+	ID        int32      `json:"id"`
+	Room      int32      `json:"room,omitempty"`
+	To        int32      `json:"to,omitempty"`
+	From      int32      `json:"from"`
+	Content   string     `json:"content"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+func newMessage(m dbCtx.Message, username string) Message {
+	return Message{
+		ID:        m.ID,
+		Room:      m.RoomID.Int32,
+		To:        m.RecipientID.Int32,
+		Parent:    m.ParentID.Int32,
+		From:      m.SenderID,
+		Username:  username,
+		Content:   m.Content,
+		Time:      m.CreatedAt.Time,
+		EditedAt:  timePtr(m.EditedAt),
+		DeletedAt: timePtr(m.DeletedAt),
+	}
+}
+
+func newUpdate(m dbCtx.Message) UpdatePayload {
+	return UpdatePayload{
+		ID:        m.ID,
+		Room:      m.RoomID.Int32,
+		To:        m.RecipientID.Int32,
+		From:      m.SenderID,
+		Content:   m.Content,
+		EditedAt:  timePtr(m.EditedAt),
+		DeletedAt: timePtr(m.DeletedAt),
+	}
+}
+
+// reactionCounts decodes the reactions of a history row, which come
+// grouped by emoji as JSON.
+func reactionCounts(raw json.RawMessage) []ReactionCount {
+	if len(raw) == 0 {
+		return nil
+	}
+	var counts []ReactionCount
+	if err := json.Unmarshal(raw, &counts); err != nil {
+		log.Printf("error decoding reactions: %v", err)
+		return nil
+	}
+	return counts
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// encodeFrame builds a frame of type kind answering the client frame id,
+// if any.
+func encodeFrame(kind, id string, payload any) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(Frame{V: ProtocolVersion, Type: kind, ID: id, Payload: data})
+}
+
+// errorCode maps the chat service's errors onto error frame codes.
+func errorCode(err error) (code, message string) {
+	switch {
+	case errors.Is(err, ErrRoomNotFound):
+		return ErrCodeRoomNotFound, "room not found"
+	case errors.Is(err, ErrNotRoomMember):
+		return ErrCodeNotRoomMember, "not a member of this room"
+	case errors.Is(err, ErrRecipientNotFound):
+		return ErrCodeRecipientNotFound, "recipient not found"
+	case errors.Is(err, ErrMessageNotFound):
+		return ErrCodeMessageNotFound, "message not found"
+	case errors.Is(err, ErrMessageForbidden):
+		return ErrCodeForbidden, "not allowed to change this message"
+	case errors.Is(err, ErrEditWindowClosed):
+		return ErrCodeEditWindowClosed, "message can no longer be changed"
+	case errors.Is(err, ErrInvalidEmoji):
+		return ErrCodeInvalidPayload, "emoji must be 1 to 32 characters without spaces"
+	default:
+		return ErrCodeInternal, "something went wrong, try again"
+	}
+}
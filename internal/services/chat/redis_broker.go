@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBroker exchanges hub events over a Redis pub/sub channel. Pub/sub
+// does not keep events for subscribers that are away, so an instance that
+// loses its connection misses what was published meanwhile.
+type RedisBroker struct {
+	client   *redis.Client
+	channel  string
+	pubsub   *redis.PubSub
+	messages chan []byte
+}
+
+// NewRedisBroker subscribes to channel and returns once the subscription is
+// confirmed, so nothing published afterwards is missed.
+func NewRedisBroker(ctx context.Context, client *redis.Client, channel string) (*RedisBroker, error) {
+	pubsub := client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	b := &RedisBroker{
+		client:   client,
+		channel:  channel,
+		pubsub:   pubsub,
+		messages: make(chan []byte),
+	}
+	go b.forward()
+	return b, nil
+}
+
+func (b *RedisBroker) forward() {
+	defer close(b.messages)
+	for msg := range b.pubsub.Channel() {
+		b.messages <- []byte(msg.Payload)
+	}
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, data []byte) error {
+	return b.client.Publish(ctx, b.channel, data).Err()
+}
+
+func (b *RedisBroker) Messages() <-chan []byte {
+	return b.messages
+}
+
+// Close unsubscribes. Messages is closed once the events already received
+// have been read.
+func (b *RedisBroker) Close() error {
+	return b.pubsub.Close()
+}
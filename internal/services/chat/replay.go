@@ -0,0 +1,175 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/tenant"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// replayPage is how many missed messages are read and written at once.
+	replayPage = 100
+	// replayOverlap is how long after a replay the live copies of replayed
+	// messages are still dropped. Messages are published as soon as they
+	// are stored, so their live copies arrive well within it.
+	replayOverlap = 10 * time.Second
+)
+
+// ReplayPoint is where a client left off: after the message AfterID, or
+// after the time AfterTime.
+type ReplayPoint struct {
+	AfterID   int32
+	AfterTime time.Time
+}
+
+// ParseReplayPoint reads a message ID or an RFC 3339 time.
+func ParseReplayPoint(s string) (ReplayPoint, error) {
+	if id, err := strconv.ParseInt(s, 10, 32); err == nil {
+		if id < 0 {
+			return ReplayPoint{}, errors.New("since must not be negative")
+		}
+		return ReplayPoint{AfterID: int32(id)}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return ReplayPoint{}, errors.New("since must be a message ID or an RFC 3339 time")
+	}
+	return ReplayPoint{AfterTime: t}, nil
+}
+
+// replayed holds the messages a replay wrote, whose live copies are
+// dropped until the overlap ends.
+type replayed struct {
+	ids   map[int32]bool
+	until time.Time
+}
+
+// skip reports whether data is the live copy of a replayed message.
+func (r *replayed) skip(data []byte) bool {
+	if r == nil || len(r.ids) == 0 {
+		return false
+	}
+	if time.Now().After(r.until) {
+		r.ids = nil
+		return false
+	}
+	var frame struct {
+		Type    string `json:"type"`
+		Payload struct {
+			ID int32 `json:"id"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &frame); err != nil || frame.Type != FrameMessage {
+		return false
+	}
+	if !r.ids[frame.Payload.ID] {
+		return false
+	}
+	delete(r.ids, frame.Payload.ID)
+	return true
+}
+
+// Replay writes the client the messages it missed since since, oldest
+// first, then a replayed frame, and goes on as SendMessages. The client
+// must be registered with the hub first: its live frames queue up while
+// the replay runs, and the live copies of the replayed messages are
+// dropped, so no message is missed or received twice.
+//
+// A client that misses too much for its queue is dropped like any slow
+// client, and picks up from the last message it got when it reconnects.
+func (c *Client) Replay(chatService IChatService, since ReplayPoint) {
+	ctx := tenant.WithID(context.Background(), c.orgID)
+	wait := c.Hub.limits.WriteWait
+	done := &replayed{ids: make(map[int32]bool)}
+	last := since.AfterID
+
+	for {
+		rows, err := chatService.MessagesSince(ctx, c.userID, since, replayPage)
+		if err != nil {
+			log.Printf("error replaying messages: %v", err)
+			c.abort(websocket.CloseInternalServerErr, "could not replay missed messages")
+			return
+		}
+
+		frames := make([][]byte, 0, len(rows))
+		for _, r := range rows {
+			data, err := encodeFrame(FrameMessage, "", replayMessage(r))
+			if err != nil {
+				log.Printf("error marshaling message: %v", err)
+				continue
+			}
+			frames = append(frames, data)
+			done.ids[r.ID] = true
+			last = r.ID
+		}
+		if err := c.writeFrames(wait, frames); err != nil {
+			c.conn.Close()
+			return
+		}
+		if len(rows) < replayPage {
+			break
+		}
+		since.AfterID = last
+	}
+
+	data, err := encodeFrame(FrameReplayed, "", ReplayedPayload{Messages: len(done.ids), Last: last})
+	if err == nil {
+		err = c.writeFrames(wait, [][]byte{data})
+	}
+	if err != nil {
+		c.conn.Close()
+		return
+	}
+	done.until = time.Now().Add(replayOverlap)
+	c.replayed = done
+	c.SendMessages()
+}
+
+func replayMessage(r dbCtx.ListMessagesSinceRow) Message {
+	return Message{
+		ID:        r.ID,
+		Room:      r.RoomID.Int32,
+		To:        r.RecipientID.Int32,
+		Parent:    r.ParentID.Int32,
+		From:      r.SenderID,
+		Username:  r.SenderName,
+		Content:   r.Content,
+		Time:      r.CreatedAt.Time,
+		EditedAt:  timePtr(r.EditedAt),
+		DeletedAt: timePtr(r.DeletedAt),
+	}
+}
+
+// writeFrames sends frames in one WebSocket message, one per line.
+func (c *Client) writeFrames(wait time.Duration, frames [][]byte) error {
+	if len(frames) == 0 {
+		return nil
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(wait))
+	w, err := c.conn.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+	for i, frame := range frames {
+		if i > 0 {
+			w.Write(frameSeparator)
+		}
+		w.Write(frame)
+	}
+	return w.Close()
+}
+
+// abort closes the connection with code and text, for the client to try
+// again.
+func (c *Client) abort(code int, text string) {
+	message := websocket.FormatCloseMessage(code, text)
+	c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(c.Hub.limits.WriteWait))
+	c.conn.Close()
+}
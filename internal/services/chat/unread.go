@@ -0,0 +1,162 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UnreadCounter keeps users' unread counts where they are cheap to read and
+// to bump as messages arrive. Postgres stays the source of truth: counts
+// the counter does not have are rebuilt from it, and the counter may drop
+// counts at any time, for example when they expire, to have them rebuilt
+// on the next read. Counts are keyed by field, see roomField and
+// conversationField.
+type UnreadCounter interface {
+	// Load returns userID's counts, and false when the counter has none.
+	Load(ctx context.Context, orgID, userID int32) (map[string]int64, bool, error)
+	// Store replaces userID's counts.
+	Store(ctx context.Context, orgID, userID int32, counts map[string]int64) error
+	// Increment adds one to field for each of userIDs whose counts the
+	// counter has. The others are left to be rebuilt.
+	Increment(ctx context.Context, orgID int32, userIDs []int32, field string) error
+}
+
+const (
+	roomFieldPrefix         = "room:"
+	conversationFieldPrefix = "user:"
+)
+
+// roomField is the counter field of room roomID.
+func roomField(roomID int32) string {
+	return fmt.Sprintf("%s%d", roomFieldPrefix, roomID)
+}
+
+// conversationField is the counter field of the conversation with peerID.
+func conversationField(peerID int32) string {
+	return fmt.Sprintf("%s%d", conversationFieldPrefix, peerID)
+}
+
+// fields flattens counts into counter fields.
+func (u UnreadCounts) fields() map[string]int64 {
+	fields := make(map[string]int64, len(u.Rooms)+len(u.Conversations))
+	for roomID, n := range u.Rooms {
+		fields[roomField(roomID)] = n
+	}
+	for peerID, n := range u.Conversations {
+		fields[conversationField(peerID)] = n
+	}
+	return fields
+}
+
+// unreadFromFields is the opposite of UnreadCounts.fields. Fields it does
+// not recognise are skipped.
+func unreadFromFields(fields map[string]int64) UnreadCounts {
+	counts := UnreadCounts{Rooms: map[int32]int64{}, Conversations: map[int32]int64{}}
+	for field, n := range fields {
+		target := counts.Rooms
+		rest, ok := strings.CutPrefix(field, roomFieldPrefix)
+		if !ok {
+			target = counts.Conversations
+			if rest, ok = strings.CutPrefix(field, conversationFieldPrefix); !ok {
+				continue
+			}
+		}
+		id, err := strconv.ParseInt(rest, 10, 32)
+		if err != nil {
+			continue
+		}
+		target[int32(id)] = n
+	}
+	return counts
+}
+
+const (
+	defaultUnreadTTL = time.Hour
+	// unreadStoredField is set in every stored hash, so that a user with
+	// nothing to count still has counts.
+	unreadStoredField = "stored"
+)
+
+// incrementIfStored bumps a field of a hash that exists, and leaves a
+// missing hash missing rather than start it with a single field.
+var incrementIfStored = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+end
+return 0
+`)
+
+// RedisUnreadCounter keeps each user's counts in a Redis hash that expires
+// after ttl, so that counts which drifted from Postgres, say because a
+// user blocked someone, are eventually rebuilt.
+type RedisUnreadCounter struct {
+	client *redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisUnreadCounter keeps counts under keys starting with prefix. A ttl
+// of zero means an hour.
+func NewRedisUnreadCounter(client *redis.Client, prefix string, ttl time.Duration) *RedisUnreadCounter {
+	if ttl <= 0 {
+		ttl = defaultUnreadTTL
+	}
+	return &RedisUnreadCounter{client: client, prefix: prefix, ttl: ttl}
+}
+
+func (c *RedisUnreadCounter) key(orgID, userID int32) string {
+	return fmt.Sprintf("%sunread:org:%d:user:%d", c.prefix, orgID, userID)
+}
+
+func (c *RedisUnreadCounter) Load(ctx context.Context, orgID, userID int32) (map[string]int64, bool, error) {
+	values, err := c.client.HGetAll(ctx, c.key(orgID, userID)).Result()
+	if err != nil || len(values) == 0 {
+		return nil, false, err
+	}
+	counts := make(map[string]int64, len(values))
+	for field, value := range values {
+		if field == unreadStoredField {
+			continue
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("unread count %s: %w", field, err)
+		}
+		counts[field] = n
+	}
+	return counts, true, nil
+}
+
+func (c *RedisUnreadCounter) Store(ctx context.Context, orgID, userID int32, counts map[string]int64) error {
+	key := c.key(orgID, userID)
+	values := make([]any, 0, 2*len(counts)+2)
+	values = append(values, unreadStoredField, 1)
+	for field, n := range counts {
+		values = append(values, field, n)
+	}
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.HSet(ctx, key, values...)
+		pipe.Expire(ctx, key, c.ttl)
+		return nil
+	})
+	return err
+}
+
+func (c *RedisUnreadCounter) Increment(ctx context.Context, orgID int32, userIDs []int32, field string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, userID := range userIDs {
+			incrementIfStored.Eval(ctx, pipe, []string{c.key(orgID, userID)}, field)
+		}
+		return nil
+	})
+	return err
+}
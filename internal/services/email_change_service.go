@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// PendingEmailChange is an email change recorded by RequestTx whose mails
+// are still to be sent with Notify.
+type PendingEmailChange struct {
+	UserID       int32
+	OldEmail     string
+	NewEmail     string
+	confirmToken string
+	cancelToken  string
+}
+
+type IEmailChangeService interface {
+	Request(ctx context.Context, user dbCtx.User, newEmail string) error
+
+	RequestTx(ctx context.Context, tx repository.IRepositoryManager, user dbCtx.User, newEmail string) (*PendingEmailChange, error)
+
+	Notify(ctx context.Context, change *PendingEmailChange) error
+
+	Confirm(ctx context.Context, token string) (*dbCtx.User, error)
+
+	Cancel(ctx context.Context, token string) error
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"example.com/api/config"
+	contracts "example.com/api/internal/contracts/errors"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/mailing"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/internal/storage"
+	"example.com/api/pkg/logging"
+	"example.com/api/pkg/metrics"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidEmailChangeToken = errors.New("invalid email change token")
+	ErrEmailChangeExpired      = errors.New("email change token expired")
+	// ErrEmailChangeNotSent comes with the updated user when an update was
+	// saved but the mails of the email change it requested could not be sent.
+	ErrEmailChangeNotSent = errors.New("email change mail not sent")
+)
+
+type EmailChangeService struct {
+	repo         repository.IRepositoryManager
+	logger       logging.ILogger
+	mailer       mailing.IMailer
+	tokenStorage storage.ITokenStorage
+	cfg          config.EmailChangeConfig
+	audit        IAuditService
+}
+
+func NewEmailChangeService(
+	r repository.IRepositoryManager,
+	l logging.ILogger,
+	m mailing.IMailer,
+	ts storage.ITokenStorage,
+	cfg config.EmailChangeConfig,
+	a IAuditService,
+) *EmailChangeService {
+	return &EmailChangeService{
+		repo:         r,
+		logger:       l,
+		mailer:       m,
+		tokenStorage: ts,
+		cfg:          cfg,
+		audit:        a,
+	}
+}
+
+// Request records newEmail as the user's pending address and mails the
+// links to confirm and to cancel the change.
+func (s *EmailChangeService) Request(ctx context.Context, user dbCtx.User, newEmail string) error {
+	var change *PendingEmailChange
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		var err error
+		change, err = s.RequestTx(ctx, tx, user, newEmail)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return s.Notify(ctx, change)
+}
+
+// RequestTx records newEmail as the user's pending address through tx,
+// cancelling any earlier pending request of the user. Call it inside the
+// transaction of the update asking for the change, and Notify once that
+// transaction has committed.
+func (s *EmailChangeService) RequestTx(ctx context.Context, tx repository.IRepositoryManager, user dbCtx.User, newEmail string) (*PendingEmailChange, error) {
+	confirmToken, confirmHash, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	cancelToken, cancelHash, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	now := time.Now().UTC()
+	metrics.DbCall.WithLabelValues("EmailChange", "Create", "started").Inc()
+	err = tx.EmailChange().CancelPending(ctx, user.ID)
+	if err == nil {
+		_, err = tx.EmailChange().Create(ctx, dbCtx.CreateEmailChangeParams{
+			UserID:           user.ID,
+			OldEmail:         user.Email,
+			NewEmail:         newEmail,
+			ConfirmTokenHash: confirmHash,
+			CancelTokenHash:  cancelHash,
+			ConfirmExpiresAt: now.Add(s.cfg.ConfirmExpireDuration * time.Minute),
+			CancelExpiresAt:  now.Add(s.cfg.CancelExpireDuration * time.Minute),
+		})
+	}
+	if err != nil {
+		metrics.DbCall.WithLabelValues("EmailChange", "Create", "error").Inc()
+		s.logger.Error(logging.Postgres, logging.Insert, "Failed to store email change", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             user.ID,
+		})
+		return nil, fmt.Errorf("failed to store email change: %w", err)
+	}
+	metrics.DbCall.WithLabelValues("EmailChange", "Create", "success").Inc()
+
+	return &PendingEmailChange{
+		UserID:       user.ID,
+		OldEmail:     user.Email,
+		NewEmail:     newEmail,
+		confirmToken: confirmToken,
+		cancelToken:  cancelToken,
+	}, nil
+}
+
+// Notify mails a confirmation link to the new address of change. The
+// current address is told about the change and gets a cancel link that stays
+// valid after confirmation, so the owner can take the account back if
+// somebody else requested the change.
+func (s *EmailChangeService) Notify(ctx context.Context, change *PendingEmailChange) error {
+	notice := mailing.Message{
+		To:      change.OldEmail,
+		Subject: "Your email address is being changed",
+		Body: fmt.Sprintf(
+			"A request was made to change the email address of your account to %s.\n\n"+
+				"If this was not you, cancel the change here:\n%s\n",
+			change.NewEmail, tokenLink(s.cfg.CancelURL, change.cancelToken),
+		),
+	}
+	if err := s.send(ctx, change.UserID, notice); err != nil {
+		return err
+	}
+
+	confirm := mailing.Message{
+		To:      change.NewEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Confirm that this is the new email address of your account:\n%s\n",
+			tokenLink(s.cfg.ConfirmURL, change.confirmToken),
+		),
+	}
+	return s.send(ctx, change.UserID, confirm)
+}
+
+// Confirm swaps the user's email to the pending address. The swap only
+// applies while the user still has the address the change was requested
+// from, so a stale request can never overwrite a newer email.
+func (s *EmailChangeService) Confirm(ctx context.Context, token string) (*dbCtx.User, error) {
+	var user dbCtx.User
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		change, err := tx.EmailChange().GetByConfirmToken(ctx, hashLinkToken(token))
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidEmailChangeToken
+		}
+		if err != nil {
+			return err
+		}
+		if change.ConfirmedAt.Valid || change.CancelledAt.Valid {
+			return ErrInvalidEmailChangeToken
+		}
+		if time.Now().UTC().After(change.ConfirmExpiresAt) {
+			return ErrEmailChangeExpired
+		}
+
+		user, err = tx.User().SwapEmail(ctx, dbCtx.SwapUserEmailParams{
+			ID:       change.UserID,
+			OldEmail: change.OldEmail,
+			NewEmail: change.NewEmail,
+		})
+		if err != nil {
+			return swapEmailError(err, change.NewEmail)
+		}
+
+		if _, err := tx.EmailChange().MarkConfirmed(ctx, change.ID); err != nil {
+			return err
+		}
+		if err := s.audit.RecordTx(ctx, tx, emailSwapAuditEntry(change.UserID, change.OldEmail, change.NewEmail, "confirmed")); err != nil {
+			return err
+		}
+		return outbox.Enqueue(ctx, tx, outbox.UserUpdated, outbox.AggregateUser, user.ID, mapUserToResponse(user))
+	})
+	if err != nil {
+		if !isEmailChangeClientError(err) {
+			s.logger.Error(logging.Postgres, logging.Update, "Failed to confirm email change", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+			})
+			return nil, fmt.Errorf("failed to confirm email change: %w", err)
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Cancel withdraws an email change. A change that was already confirmed is
+// reverted to the old address and the user's refresh tokens are revoked,
+// since whoever made the change may still hold a session.
+func (s *EmailChangeService) Cancel(ctx context.Context, token string) error {
+	var reverted *dbCtx.EmailChange
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		change, err := tx.EmailChange().GetByCancelToken(ctx, hashLinkToken(token))
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidEmailChangeToken
+		}
+		if err != nil {
+			return err
+		}
+		if change.CancelledAt.Valid {
+			return ErrInvalidEmailChangeToken
+		}
+		if time.Now().UTC().After(change.CancelExpiresAt) {
+			return ErrEmailChangeExpired
+		}
+
+		if change.ConfirmedAt.Valid {
+			user, err := tx.User().SwapEmail(ctx, dbCtx.SwapUserEmailParams{
+				ID:       change.UserID,
+				OldEmail: change.NewEmail,
+				NewEmail: change.OldEmail,
+			})
+			if err != nil {
+				return swapEmailError(err, change.OldEmail)
+			}
+			if err := s.audit.RecordTx(ctx, tx, emailSwapAuditEntry(change.UserID, change.NewEmail, change.OldEmail, "reverted")); err != nil {
+				return err
+			}
+			if err := outbox.Enqueue(ctx, tx, outbox.UserUpdated, outbox.AggregateUser, user.ID, mapUserToResponse(user)); err != nil {
+				return err
+			}
+			reverted = &change
+		}
+
+		_, err = tx.EmailChange().MarkCancelled(ctx, change.ID)
+		return err
+	})
+	if err != nil {
+		if !isEmailChangeClientError(err) {
+			s.logger.Error(logging.Postgres, logging.Update, "Failed to cancel email change", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+			})
+			return fmt.Errorf("failed to cancel email change: %w", err)
+		}
+		return err
+	}
+
+	if reverted != nil {
+		if err := s.tokenStorage.Invalidate(ctx, strconv.Itoa(int(reverted.UserID))); err != nil {
+			s.logger.Error(logging.Redis, logging.Delete, "Failed to revoke tokens after email change was reverted", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				"userID":             reverted.UserID,
+			})
+		}
+	}
+	return nil
+}
+
+func (s *EmailChangeService) send(ctx context.Context, userID int32, msg mailing.Message) error {
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.Error(logging.General, logging.ExternalService, "Failed to send email change mail", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// emailSwapAuditEntry describes an email swap. The links are used without
+// signing in, so the event has no actor.
+func emailSwapAuditEntry(userID int32, from, to, reason string) AuditEntry {
+	return AuditEntry{
+		Action:     AuditUserUpdated,
+		TargetType: AuditTargetUser,
+		TargetID:   userID,
+		Before:     map[string]any{"email": from},
+		After:      map[string]any{"email": to},
+		Metadata:   map[string]any{"emailChange": reason},
+	}
+}
+
+// swapEmailError maps SwapEmail failures. No row means the user's email no
+// longer matches the change (or the user is gone), which makes the token
+// useless rather than the request faulty.
+func swapEmailError(err error, email string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidEmailChangeToken
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_email_key" {
+		return &contracts.EmailExistsError{Email: email}
+	}
+	return err
+}
+
+func isEmailChangeClientError(err error) bool {
+	var emailErr *contracts.EmailExistsError
+	return errors.Is(err, ErrInvalidEmailChangeToken) ||
+		errors.Is(err, ErrEmailChangeExpired) ||
+		errors.As(err, &emailErr)
+}
+
+// newLinkToken returns a random token for a mail link together with
+// the hash that is stored in its place.
+func newLinkToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashLinkToken(token), nil
+}
+
+func hashLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func tokenLink(base, token string) string {
+	return base + "?token=" + url.QueryEscape(token)
+}
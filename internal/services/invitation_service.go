@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IInvitationService interface {
+	// Create invites req.Email to the organization and mails them a link
+	// with a single-use token. Only owners may invite further owners.
+	Create(ctx context.Context, orgID, inviterID int32, inviterRole string, req dto.CreateInvitationReq) (dbCtx.Invitation, error)
+	List(ctx context.Context, orgID int32, page dto.ListInvitationsParams) ([]dbCtx.Invitation, error)
+	// Revoke withdraws a pending invitation of the organization.
+	Revoke(ctx context.Context, orgID, id int32) error
+	// Lookup returns the pending invitation a token was issued for.
+	Lookup(ctx context.Context, token string) (dbCtx.Invitation, error)
+	// ConsumeTx marks the invitation used by userID and gives them the role
+	// it was issued with, through tx.
+	ConsumeTx(ctx context.Context, tx repository.IRepositoryManager, inv dbCtx.Invitation, userID int32) error
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/mailing"
+	"example.com/api/pkg/logging"
+)
+
+var (
+	ErrInvalidInvitation  = errors.New("invalid invitation")
+	ErrInvitationExpired  = errors.New("invitation expired")
+	ErrInvitationNotFound = errors.New("invitation not found")
+)
+
+type InvitationService struct {
+	repo   repository.IRepositoryManager
+	logger logging.ILogger
+	mailer mailing.IMailer
+	cfg    config.InvitationConfig
+}
+
+func NewInvitationService(
+	r repository.IRepositoryManager,
+	l logging.ILogger,
+	m mailing.IMailer,
+	cfg config.InvitationConfig,
+) *InvitationService {
+	return &InvitationService{
+		repo:   r,
+		logger: l,
+		mailer: m,
+		cfg:    cfg,
+	}
+}
+
+func (s *InvitationService) Create(ctx context.Context, orgID, inviterID int32, inviterRole string, req dto.CreateInvitationReq) (dbCtx.Invitation, error) {
+	role := req.Role
+	if role == "" {
+		role = OrgRoleMember
+	}
+	if !canManage(inviterRole) || (role == OrgRoleOwner && inviterRole != OrgRoleOwner) {
+		return dbCtx.Invitation{}, ErrOrgRoleForbidden
+	}
+	email, err := normalizeEmail(req.Email)
+	if err != nil {
+		return dbCtx.Invitation{}, err
+	}
+
+	token, hash, err := newLinkToken()
+	if err != nil {
+		return dbCtx.Invitation{}, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	inv, err := s.repo.Invitation().Create(ctx, dbCtx.CreateInvitationParams{
+		OrganizationID: orgID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      hash,
+		InvitedBy:      sql.NullInt32{Int32: inviterID, Valid: inviterID != 0},
+		ExpiresAt:      time.Now().UTC().Add(s.cfg.ExpireDuration * time.Minute),
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Insert, "Failed to create invitation", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"organizationID":     orgID,
+		})
+		return dbCtx.Invitation{}, errors.New("failed to create invitation")
+	}
+
+	msg := mailing.Message{
+		To:      email,
+		Subject: "You have been invited",
+		Body: fmt.Sprintf(
+			"You have been invited to create an account. Accept the invitation here:\n%s\n\n"+
+				"The link can be used once and expires on %s.\n",
+			tokenLink(s.cfg.AcceptURL, token), inv.ExpiresAt.Format(time.RFC1123),
+		),
+	}
+	if err := s.mailer.Send(ctx, msg); err != nil {
+		s.logger.Error(logging.General, logging.ExternalService, "Failed to send invitation mail", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"invitationID":       inv.ID,
+		})
+		return dbCtx.Invitation{}, fmt.Errorf("failed to send email: %w", err)
+	}
+	return inv, nil
+}
+
+func (s *InvitationService) List(ctx context.Context, orgID int32, page dto.ListInvitationsParams) ([]dbCtx.Invitation, error) {
+	invs, err := s.repo.Invitation().List(ctx, dbCtx.ListInvitationsParams{
+		OrganizationID: orgID,
+		Limit:          page.Limit,
+		Offset:         page.Offset,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to list invitations", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"organizationID":     orgID,
+		})
+		return nil, errors.New("failed to list invitations")
+	}
+	return invs, nil
+}
+
+func (s *InvitationService) Revoke(ctx context.Context, orgID, id int32) error {
+	revoked, err := s.repo.Invitation().Revoke(ctx, dbCtx.RevokeInvitationParams{
+		ID:             id,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Update, "Failed to revoke invitation", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"invitationID":       id,
+		})
+		return errors.New("failed to revoke invitation")
+	}
+	if revoked == 0 {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+func (s *InvitationService) Lookup(ctx context.Context, token string) (dbCtx.Invitation, error) {
+	inv, err := s.repo.Invitation().GetByToken(ctx, hashLinkToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbCtx.Invitation{}, ErrInvalidInvitation
+		}
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to look up invitation", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+		})
+		return dbCtx.Invitation{}, errors.New("failed to look up invitation")
+	}
+	if inv.ConsumedAt.Valid || inv.RevokedAt.Valid {
+		return dbCtx.Invitation{}, ErrInvalidInvitation
+	}
+	if time.Now().UTC().After(inv.ExpiresAt) {
+		return dbCtx.Invitation{}, ErrInvitationExpired
+	}
+	return inv, nil
+}
+
+func (s *InvitationService) ConsumeTx(ctx context.Context, tx repository.IRepositoryManager, inv dbCtx.Invitation, userID int32) error {
+	consumed, err := tx.Invitation().Consume(ctx, dbCtx.ConsumeInvitationParams{
+		ID:         inv.ID,
+		ConsumedBy: sql.NullInt32{Int32: userID, Valid: true},
+	})
+	if err == nil && consumed == 0 {
+		return ErrInvalidInvitation
+	}
+	if err == nil {
+		_, err = tx.Organization().UpsertMember(ctx, dbCtx.UpsertOrganizationMemberParams{
+			OrganizationID: inv.OrganizationID,
+			UserID:         userID,
+			Role:           inv.Role,
+		})
+	}
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Update, "Failed to consume invitation", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"invitationID":       inv.ID,
+			"userID":             userID,
+		})
+		return errors.New("failed to consume invitation")
+	}
+	return nil
+}
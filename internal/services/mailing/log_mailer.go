@@ -0,0 +1,27 @@
+package mailing
+
+import (
+	"context"
+
+	"example.com/api/pkg/logging"
+)
+
+// LogMailer writes messages to the application log instead of sending them.
+// It is meant for local development, where the links in the body can be
+// copied from the log.
+type LogMailer struct {
+	logger logging.ILogger
+}
+
+func NewLogMailer(logger logging.ILogger) *LogMailer {
+	return &LogMailer{logger: logger}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.logger.Info(logging.General, logging.ExternalService, "Mail sent to log", map[logging.ExtraKey]any{
+		"to":      msg.To,
+		"subject": msg.Subject,
+		"body":    msg.Body,
+	})
+	return nil
+}
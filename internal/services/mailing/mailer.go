@@ -0,0 +1,25 @@
+package mailing
+
+import (
+	"context"
+
+	"example.com/api/config"
+	"example.com/api/pkg/logging"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type IMailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+func New(cfg config.MailConfig, logger logging.ILogger) IMailer {
+	if cfg.Driver == "smtp" {
+		return NewSMTPMailer(cfg)
+	}
+	return NewLogMailer(logger)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	dto "example.com/api/internal/contracts"
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+const (
+	OrgRoleOwner  = "owner"
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+type IOrganizationService interface {
+	// Create makes a new organization with ownerID as its first owner.
+	Create(ctx context.Context, ownerID int32, req dto.CreateOrganizationReq) (dbCtx.Organization, error)
+	ListForUser(ctx context.Context, userID int32) ([]dbCtx.ListOrganizationsForUserRow, error)
+	// Resolve looks an organization up by slug and returns userID's role in
+	// it. A userID of 0 skips the membership check and returns no role.
+	Resolve(ctx context.Context, slug string, userID int32) (dbCtx.Organization, string, error)
+	ListMembers(ctx context.Context, orgID int32, page dto.ListMembersParams) ([]dbCtx.ListOrganizationMembersRow, error)
+	// SetMemberRole adds userID to the organization or changes their role.
+	// Only owners may grant or take away the owner role, and the last owner
+	// cannot be demoted.
+	SetMemberRole(ctx context.Context, orgID int32, actorRole string, userID int32, role string) (dbCtx.OrganizationMember, error)
+	// RemoveMember removes userID from the organization. Members may leave
+	// on their own; removing anyone else takes an admin or owner.
+	RemoveMember(ctx context.Context, orgID int32, actorRole string, actorID, userID int32) error
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/pkg/logging"
+	"github.com/lib/pq"
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrNotOrganizationMember = errors.New("not a member of this organization")
+	ErrOrganizationSlugTaken = errors.New("organization slug already taken")
+	ErrLastOwner             = errors.New("organization must keep at least one owner")
+	ErrOrgRoleForbidden      = errors.New("insufficient organization role")
+)
+
+type OrganizationService struct {
+	repo   repository.IRepositoryManager
+	logger logging.ILogger
+}
+
+func NewOrganizationService(r repository.IRepositoryManager, l logging.ILogger) *OrganizationService {
+	return &OrganizationService{
+		repo:   r,
+		logger: l,
+	}
+}
+
+func (s *OrganizationService) Create(ctx context.Context, ownerID int32, req dto.CreateOrganizationReq) (dbCtx.Organization, error) {
+	var org dbCtx.Organization
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		var err error
+		org, err = tx.Organization().Create(ctx, dbCtx.CreateOrganizationParams{
+			Slug: req.Slug,
+			Name: req.Name,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.Organization().UpsertMember(ctx, dbCtx.UpsertOrganizationMemberParams{
+			OrganizationID: org.ID,
+			UserID:         ownerID,
+			Role:           OrgRoleOwner,
+		})
+		return err
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return dbCtx.Organization{}, ErrOrganizationSlugTaken
+		}
+		s.logger.Error(logging.Postgres, logging.Insert, "Failed to create organization", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"slug":               req.Slug,
+			"ownerID":            ownerID,
+		})
+		return dbCtx.Organization{}, errors.New("failed to create organization")
+	}
+	return org, nil
+}
+
+func (s *OrganizationService) ListForUser(ctx context.Context, userID int32) ([]dbCtx.ListOrganizationsForUserRow, error) {
+	orgs, err := s.repo.Organization().ListForUser(ctx, userID)
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to list organizations", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		return nil, errors.New("failed to list organizations")
+	}
+	return orgs, nil
+}
+
+func (s *OrganizationService) Resolve(ctx context.Context, slug string, userID int32) (dbCtx.Organization, string, error) {
+	org, err := s.repo.Organization().GetBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbCtx.Organization{}, "", ErrOrganizationNotFound
+		}
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to resolve organization", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"slug":               slug,
+		})
+		return dbCtx.Organization{}, "", errors.New("failed to resolve organization")
+	}
+	if userID == 0 {
+		return org, "", nil
+	}
+
+	member, err := s.member(ctx, org.ID, userID)
+	if err != nil {
+		return dbCtx.Organization{}, "", err
+	}
+	return org, member.Role, nil
+}
+
+func (s *OrganizationService) ListMembers(ctx context.Context, orgID int32, page dto.ListMembersParams) ([]dbCtx.ListOrganizationMembersRow, error) {
+	members, err := s.repo.Organization().ListMembers(ctx, dbCtx.ListOrganizationMembersParams{
+		OrganizationID: orgID,
+		Limit:          page.Limit,
+		Offset:         page.Offset,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to list organization members", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"organizationID":     orgID,
+		})
+		return nil, errors.New("failed to list organization members")
+	}
+	return members, nil
+}
+
+func (s *OrganizationService) SetMemberRole(ctx context.Context, orgID int32, actorRole string, userID int32, role string) (dbCtx.OrganizationMember, error) {
+	if !canManage(actorRole) {
+		return dbCtx.OrganizationMember{}, ErrOrgRoleForbidden
+	}
+	if _, err := s.repo.User().GetByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbCtx.OrganizationMember{}, errors.New("user not found")
+		}
+		return dbCtx.OrganizationMember{}, err
+	}
+
+	var member dbCtx.OrganizationMember
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		current, err := tx.Organization().GetMember(ctx, dbCtx.GetOrganizationMemberParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+		})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		wasOwner := err == nil && current.Role == OrgRoleOwner
+		if (wasOwner || role == OrgRoleOwner) && actorRole != OrgRoleOwner {
+			return ErrOrgRoleForbidden
+		}
+		if wasOwner && role != OrgRoleOwner {
+			if err := ensureAnotherOwner(ctx, tx, orgID); err != nil {
+				return err
+			}
+		}
+
+		member, err = tx.Organization().UpsertMember(ctx, dbCtx.UpsertOrganizationMemberParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+			Role:           role,
+		})
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, ErrOrgRoleForbidden) || errors.Is(err, ErrLastOwner) {
+			return dbCtx.OrganizationMember{}, err
+		}
+		s.logger.Error(logging.Postgres, logging.Update, "Failed to set organization role", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"organizationID":     orgID,
+			"userID":             userID,
+		})
+		return dbCtx.OrganizationMember{}, errors.New("failed to set organization role")
+	}
+	return member, nil
+}
+
+func (s *OrganizationService) RemoveMember(ctx context.Context, orgID int32, actorRole string, actorID, userID int32) error {
+	if actorID != userID && !canManage(actorRole) {
+		return ErrOrgRoleForbidden
+	}
+
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		current, err := tx.Organization().GetMember(ctx, dbCtx.GetOrganizationMemberParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotOrganizationMember
+			}
+			return err
+		}
+		if current.Role == OrgRoleOwner {
+			if actorID != userID && actorRole != OrgRoleOwner {
+				return ErrOrgRoleForbidden
+			}
+			if err := ensureAnotherOwner(ctx, tx, orgID); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Organization().DeleteMember(ctx, dbCtx.DeleteOrganizationMemberParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Chat().DeleteMembershipsInOrganization(ctx, dbCtx.DeleteRoomMembershipsInOrganizationParams{
+			OrganizationID: orgID,
+			UserID:         userID,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, ErrNotOrganizationMember) || errors.Is(err, ErrOrgRoleForbidden) || errors.Is(err, ErrLastOwner) {
+			return err
+		}
+		s.logger.Error(logging.Postgres, logging.Delete, "Failed to remove organization member", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"organizationID":     orgID,
+			"userID":             userID,
+		})
+		return errors.New("failed to remove organization member")
+	}
+	return nil
+}
+
+func (s *OrganizationService) member(ctx context.Context, orgID, userID int32) (dbCtx.OrganizationMember, error) {
+	member, err := s.repo.Organization().GetMember(ctx, dbCtx.GetOrganizationMemberParams{
+		OrganizationID: orgID,
+		UserID:         userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dbCtx.OrganizationMember{}, ErrNotOrganizationMember
+		}
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to get organization member", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"organizationID":     orgID,
+			"userID":             userID,
+		})
+		return dbCtx.OrganizationMember{}, errors.New("failed to get organization member")
+	}
+	return member, nil
+}
+
+func canManage(role string) bool {
+	return role == OrgRoleOwner || role == OrgRoleAdmin
+}
+
+// ensureAnotherOwner is called before an owner loses the role or leaves.
+func ensureAnotherOwner(ctx context.Context, tx repository.IRepositoryManager, orgID int32) error {
+	owners, err := tx.Organization().CountOwners(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
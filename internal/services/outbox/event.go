@@ -0,0 +1,68 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+// Event types written to the outbox.
+const (
+	UserCreated    = "user.created"
+	UserUpdated    = "user.updated"
+	UserDeleted    = "user.deleted"
+	MessageSent    = "message.sent"
+	MessageEdited  = "message.edited"
+	MessageDeleted = "message.deleted"
+)
+
+const (
+	AggregateUser    = "user"
+	AggregateMessage = "message"
+)
+
+// Event is what sinks receive. Delivery is at-least-once, so consumers
+// should use ID to drop events they have already handled.
+type Event struct {
+	ID            int64           `json:"id"`
+	Type          string          `json:"type"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   int32           `json:"aggregateId"`
+	OccurredAt    time.Time       `json:"occurredAt"`
+	Data          json.RawMessage `json:"data"`
+}
+
+func newEvent(row dbCtx.OutboxEvent) Event {
+	return Event{
+		ID:            row.ID,
+		Type:          row.EventType,
+		AggregateType: row.AggregateType,
+		AggregateID:   row.AggregateID,
+		OccurredAt:    row.CreatedAt,
+		Data:          row.Payload,
+	}
+}
+
+// Enqueue writes an event to the outbox through tx. Call it inside the
+// transaction that makes the change, so the event exists if and only if the
+// change was committed.
+func Enqueue(ctx context.Context, tx repository.IRepositoryManager, eventType, aggregateType string, aggregateID int32, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	_, err = tx.Outbox().Create(ctx, dbCtx.CreateOutboxEventParams{
+		EventType:     eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Payload:       payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+	return nil
+}
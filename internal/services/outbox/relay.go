@@ -0,0 +1,164 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"example.com/api/config"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/pkg/logging"
+	"example.com/api/pkg/metrics"
+)
+
+// Relay moves events from the outbox table to the configured sinks.
+// Several relays may run against the same database: claimed events are
+// leased for LeaseDuration, so a relay that dies mid-batch only delays them.
+type Relay struct {
+	repo   repository.IOutboxRepo
+	sinks  []ISink
+	logger logging.ILogger
+	cfg    config.OutboxConfig
+}
+
+func NewRelay(repo repository.IOutboxRepo, sinks []ISink, logger logging.ILogger, cfg config.OutboxConfig) *Relay {
+	return &Relay{
+		repo:   repo,
+		sinks:  sinks,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.PollInterval * time.Millisecond)
+	defer ticker.Stop()
+
+	lastPrune := time.Time{}
+	for {
+		for {
+			n, err := r.RunOnce(ctx)
+			if err != nil {
+				r.logger.Error(logging.Postgres, logging.Select, "Failed to relay outbox events", map[logging.ExtraKey]any{
+					logging.ErrorMessage: err.Error(),
+				})
+			}
+			// A full batch means more may be waiting; keep draining.
+			if err != nil || n < int(r.cfg.BatchSize) || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if r.cfg.Retention > 0 && time.Since(lastPrune) > time.Hour {
+			r.prune(ctx)
+			lastPrune = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce claims one batch of due events and tries to deliver each of them
+// to every sink. It returns how many events were claimed.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	rows, err := r.repo.Claim(ctx, dbCtx.ClaimOutboxEventsParams{
+		LeaseSeconds: int32(r.cfg.LeaseDuration),
+		BatchSize:    r.cfg.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+
+	for _, row := range rows {
+		r.deliver(ctx, row)
+	}
+
+	if pending, err := r.repo.CountPending(ctx); err == nil {
+		metrics.OutboxPending.Set(float64(pending))
+	}
+	return len(rows), nil
+}
+
+func (r *Relay) deliver(ctx context.Context, row dbCtx.OutboxEvent) {
+	event := newEvent(row)
+
+	var failure error
+	for _, sink := range r.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			metrics.OutboxDeliveries.WithLabelValues(sink.Name(), event.Type, "error").Inc()
+			r.logger.Warn(logging.General, logging.ExternalService, "Failed to publish outbox event", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				"sink":               sink.Name(),
+				"eventID":            event.ID,
+				"eventType":          event.Type,
+				"attempt":            row.Attempts,
+			})
+			failure = err
+			continue
+		}
+		metrics.OutboxDeliveries.WithLabelValues(sink.Name(), event.Type, "success").Inc()
+	}
+
+	// Sinks do not remember what they have seen, so a failure in any of them
+	// reschedules the whole event and the others get it again.
+	if failure != nil {
+		err := r.repo.Reschedule(ctx, dbCtx.RescheduleOutboxEventParams{
+			DelaySeconds: Backoff(row.Attempts, r.cfg.MinBackoff*time.Second, r.cfg.MaxBackoff*time.Second).Seconds(),
+			LastError:    failure.Error(),
+			ID:           row.ID,
+		})
+		if err != nil {
+			r.logger.Error(logging.Postgres, logging.Update, "Failed to reschedule outbox event", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				"eventID":            row.ID,
+			})
+		}
+		return
+	}
+
+	if err := r.repo.MarkDelivered(ctx, row.ID); err != nil {
+		r.logger.Error(logging.Postgres, logging.Update, "Failed to mark outbox event delivered", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"eventID":            row.ID,
+		})
+		return
+	}
+	metrics.OutboxDeliveryLag.Observe(time.Since(row.CreatedAt).Seconds())
+}
+
+// Backoff returns the delay before the next attempt after attempts failed
+// ones: min, doubled for every further failure and capped at max.
+func Backoff(attempts int32, min, max time.Duration) time.Duration {
+	delay := min
+	for i := int32(1); i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	return delay
+}
+
+func (r *Relay) prune(ctx context.Context) {
+	before := sql.NullTime{Time: time.Now().Add(-r.cfg.Retention * time.Hour), Valid: true}
+	n, err := r.repo.DeleteDelivered(ctx, before)
+	if err != nil {
+		r.logger.Error(logging.Postgres, logging.Delete, "Failed to prune delivered outbox events", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+		})
+		return
+	}
+	if n > 0 {
+		r.logger.Info(logging.Postgres, logging.Delete, "Pruned delivered outbox events", map[logging.ExtraKey]any{
+			"count": n,
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"example.com/api/config"
+	"example.com/api/internal/storage"
+)
+
+// ISink delivers events to one destination. Publish must return an error
+// unless the destination has accepted the event.
+type ISink interface {
+	Name() string
+	Publish(ctx context.Context, event Event) error
+}
+
+// NewSinks builds the sinks listed in cfg.Sinks.
+func NewSinks(cfg config.OutboxConfig, redisCfg *config.RedisConfig) ([]ISink, error) {
+	sinks := make([]ISink, 0, len(cfg.Sinks))
+	for _, name := range cfg.Sinks {
+		switch name {
+		case "webhook":
+			if cfg.Webhook.URL == "" {
+				return nil, fmt.Errorf("outbox webhook sink needs a url")
+			}
+			sinks = append(sinks, NewWebhookSink(cfg.Webhook.URL, cfg.Webhook.Timeout*time.Second, nil))
+		case "redis":
+			client := storage.NewRedisClient(redisCfg, cfg.RedisStream.DB)
+			sinks = append(sinks, NewRedisStreamSink(client, cfg.RedisStream.Stream, cfg.RedisStream.MaxLen))
+		case "stdout":
+			sinks = append(sinks, NewStdoutSink(os.Stdout))
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q", name)
+		}
+	}
+	return sinks, nil
+}
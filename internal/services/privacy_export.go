@@ -0,0 +1,57 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// WriteArchive writes the export as a zip archive with one JSON document per
+// kind of data, plus the original avatar image when there is one.
+func (e *UserExport) WriteArchive(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	documents := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", e.Profile},
+		{"messages.json", e.Messages},
+		{"sessions.json", e.Sessions},
+	}
+	for _, doc := range documents {
+		data, err := json.MarshalIndent(doc.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := writeArchiveFile(zw, doc.name, data, e.ExportedAt); err != nil {
+			return err
+		}
+	}
+
+	if len(e.Avatar) > 0 {
+		name := "avatar.png"
+		if e.AvatarContentType == "image/jpeg" {
+			name = "avatar.jpg"
+		}
+		if err := writeArchiveFile(zw, name, e.Avatar, e.ExportedAt); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeArchiveFile(zw *zip.Writer, name string, data []byte, modified time.Time) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: modified,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
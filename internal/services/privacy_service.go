@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/storage"
+)
+
+type ExportedMessage struct {
+	ID        int32      `json:"id"`
+	Content   string     `json:"content"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// UserExport is everything stored about a user, as handed out by a data
+// export. Avatar is empty when the user has no uploaded avatar.
+type UserExport struct {
+	UserID            int32
+	ExportedAt        time.Time
+	Profile           dto.UserResponse
+	Messages          []ExportedMessage
+	Sessions          []storage.Session
+	Avatar            []byte
+	AvatarContentType string
+}
+
+type IPrivacyService interface {
+	Export(ctx context.Context, userID int32) (*UserExport, error)
+
+	Erase(ctx context.Context, userID, requestedBy int32) error
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/internal/storage"
+	"example.com/api/internal/storage/blob"
+	"example.com/api/internal/storage/cache"
+	"example.com/api/pkg/logging"
+	"example.com/api/pkg/metrics"
+)
+
+type PrivacyService struct {
+	repo         repository.IRepositoryManager
+	logger       logging.ILogger
+	tokenStorage storage.ITokenStorage
+	cache        cache.ICacheService
+	blobStorage  blob.IBlobStorage
+	audit        IAuditService
+}
+
+func NewPrivacyService(
+	r repository.IRepositoryManager,
+	l logging.ILogger,
+	ts storage.ITokenStorage,
+	c cache.ICacheService,
+	b blob.IBlobStorage,
+	a IAuditService,
+) *PrivacyService {
+	return &PrivacyService{
+		repo:         r,
+		logger:       l,
+		tokenStorage: ts,
+		cache:        c,
+		blobStorage:  b,
+		audit:        a,
+	}
+}
+
+func (s *PrivacyService) Export(ctx context.Context, userID int32) (*UserExport, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, errors.New("user not found")
+	}
+
+	messages, err := s.repo.Chat().GetMessagesBySender(ctx, userID)
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to fetch messages for export", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		return nil, fmt.Errorf("failed to fetch messages: %w", err)
+	}
+
+	sessions, err := s.tokenStorage.Sessions(ctx, strconv.Itoa(int(userID)))
+	if err != nil {
+		s.logger.Error(logging.Redis, logging.Select, "Failed to fetch sessions for export", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+
+	export := &UserExport{
+		UserID:     userID,
+		ExportedAt: time.Now().UTC(),
+		Profile:    dto.NewUserResponse(user),
+		Messages:   make([]ExportedMessage, 0, len(messages)),
+		Sessions:   sessions,
+	}
+	if export.Sessions == nil {
+		export.Sessions = []storage.Session{}
+	}
+	for _, m := range messages {
+		exported := ExportedMessage{ID: m.ID, Content: m.Content}
+		if m.CreatedAt.Valid {
+			exported.CreatedAt = &m.CreatedAt.Time
+		}
+		export.Messages = append(export.Messages, exported)
+	}
+
+	if version := avatarVersion(userID, user.AvatarUrl); version != "" {
+		if err := s.readAvatar(ctx, export, version); err != nil {
+			return nil, err
+		}
+	}
+	return export, nil
+}
+
+func (s *PrivacyService) readAvatar(ctx context.Context, export *UserExport, version string) error {
+	body, info, err := s.blobStorage.Get(ctx, avatarKey(export.UserID, version, avatarVariants[0].name))
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read avatar: %w", err)
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read avatar: %w", err)
+	}
+	export.Avatar = data
+	export.AvatarContentType = info.ContentType
+	return nil
+}
+
+// Erase removes a user's personal data for good, also when the account was
+// soft-deleted already. The users row is kept but anonymized, the messages
+// the user sent or was sent directly are re-attributed to a shared
+// placeholder so they can no longer be linked to each other, and an entry in
+// the append-only user_erasures table records that it happened. The personal
+// data in the user's audit events, failed logins with their email, outbox
+// events and webhook deliveries is redacted as well, and so is their email
+// in invitations; the rows themselves are kept. Blocks, follows and direct
+// message read markers the user made or received are removed, and so are
+// their room read markers and reactions.
+// requestedBy is the user who asked for the erasure, or 0 when it was not a
+// user.
+//
+// Once the transaction has committed, refresh tokens are revoked, the
+// history cached for the rooms the user posted or reacted in is evicted and
+// avatar files are deleted. These steps are not retried, so their failures
+// are logged for an operator to follow up.
+func (s *PrivacyService) Erase(ctx context.Context, userID, requestedBy int32) error {
+	var (
+		avatarUrl  sql.NullString
+		reassigned int64
+		roomIDs    []int32
+	)
+
+	metrics.DbCall.WithLabelValues("User", "Erase", "started").Inc()
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		user, err := tx.User().GetByIDWithDeleted(ctx, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("user not found")
+		}
+		if err != nil {
+			return err
+		}
+		avatarUrl = user.AvatarUrl
+
+		roomIDs, err = tx.Chat().ListRoomIDsWithUserActivity(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		placeholderID, err := tx.User().GetErasedPlaceholderID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get placeholder user: %w", err)
+		}
+		reassigned, err = tx.Chat().ReassignSender(ctx, dbCtx.ReassignMessagesSenderParams{
+			ToSenderID:   placeholderID,
+			FromSenderID: userID,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.Chat().ReassignRecipient(ctx, dbCtx.ReassignMessagesRecipientParams{
+			ToRecipientID:   sql.NullInt32{Int32: placeholderID, Valid: true},
+			FromRecipientID: sql.NullInt32{Int32: userID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if err := tx.Chat().DeleteDirectReadsByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := tx.Chat().DeleteRoomReadsByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := tx.Chat().DeleteReactionsByUser(ctx, userID); err != nil {
+			return err
+		}
+
+		if err := tx.EmailChange().DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := tx.Block().DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		if err := tx.Follow().DeleteByUser(ctx, userID); err != nil {
+			return err
+		}
+		if _, err := tx.User().Anonymize(ctx, userID); err != nil {
+			return err
+		}
+		if err := redactUser(ctx, tx, user); err != nil {
+			return err
+		}
+
+		_, err = tx.Erasure().Create(ctx, dbCtx.CreateUserErasureParams{
+			UserID:             userID,
+			RequestedBy:        sql.NullInt32{Int32: requestedBy, Valid: requestedBy != 0},
+			MessagesAnonymized: int32(reassigned),
+		})
+		if err != nil {
+			return err
+		}
+		err = s.audit.RecordTx(ctx, tx, AuditEntry{
+			Action:     AuditUserErased,
+			TargetType: AuditTargetUser,
+			TargetID:   userID,
+			ActorID:    requestedBy,
+			Metadata:   map[string]any{"messagesAnonymized": reassigned},
+		})
+		if err != nil {
+			return err
+		}
+		return outbox.Enqueue(ctx, tx, outbox.UserDeleted, outbox.AggregateUser, userID, map[string]any{"id": userID, "erased": true})
+	})
+	if err != nil {
+		metrics.DbCall.WithLabelValues("User", "Erase", "error").Inc()
+		if err.Error() == "user not found" {
+			return err
+		}
+		s.logger.Error(logging.Postgres, logging.Delete, "Failed to erase user", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+		return fmt.Errorf("failed to erase user: %w", err)
+	}
+	metrics.DbCall.WithLabelValues("User", "Erase", "success").Inc()
+
+	if err := s.tokenStorage.Invalidate(ctx, strconv.Itoa(int(userID))); err != nil {
+		s.logger.Error(logging.Redis, logging.Delete, "Failed to revoke tokens of erased user", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"userID":             userID,
+		})
+	}
+	for _, roomID := range roomIDs {
+		if _, err := s.cache.Incr(ctx, chat.HistoryGenerationKey(roomID)); err != nil {
+			s.logger.Error(logging.Redis, logging.Delete, "Failed to evict message history of erased user", map[logging.ExtraKey]any{
+				logging.ErrorMessage: err.Error(),
+				"userID":             userID,
+				"roomID":             roomID,
+			})
+		}
+	}
+	if version := avatarVersion(userID, avatarUrl); version != "" {
+		removeAvatarVersion(ctx, s.blobStorage, s.logger, userID, version)
+	}
+	return nil
+}
+
+// redactUser removes the copies of user's email and name kept outside the
+// users row. Failed logins name an email rather than a user, so they are
+// matched on the email the user had.
+func redactUser(ctx context.Context, tx repository.IRepositoryManager, user dbCtx.User) error {
+	if _, err := tx.Audit().RedactForUser(ctx, sql.NullInt32{Int32: user.ID, Valid: true}); err != nil {
+		return err
+	}
+	if _, err := tx.Audit().RedactFailedLogins(ctx, user.Email); err != nil {
+		return err
+	}
+	if _, err := tx.Outbox().RedactForUser(ctx, user.ID); err != nil {
+		return err
+	}
+	if _, err := tx.Webhook().RedactDeliveriesForUser(ctx, user.ID); err != nil {
+		return err
+	}
+	_, err := tx.Invitation().RedactEmail(ctx, dbCtx.RedactInvitationsForEmailParams{
+		UserID: user.ID,
+		Email:  user.Email,
+	})
+	return err
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+
+	dto "example.com/api/internal/contracts"
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IRelationshipService interface {
+	// Follow makes followerID follow followeeID. Following a user twice is
+	// not an error.
+	Follow(ctx context.Context, followerID, followeeID int32) error
+	Unfollow(ctx context.Context, followerID, followeeID int32) error
+	Followers(ctx context.Context, userID int32, page dto.FollowListParams) ([]dbCtx.ListFollowersRow, error)
+	Following(ctx context.Context, userID int32, page dto.FollowListParams) ([]dbCtx.ListFollowingRow, error)
+	// Mutuals lists the users userID follows who follow userID back.
+	Mutuals(ctx context.Context, userID int32, page dto.FollowListParams) ([]dbCtx.ListMutualFollowsRow, error)
+}
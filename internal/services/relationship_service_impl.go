@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/tenant"
+	"example.com/api/pkg/logging"
+)
+
+var (
+	ErrCannotFollowSelf = errors.New("cannot follow yourself")
+	ErrNotFollowing     = errors.New("not following user")
+)
+
+type RelationshipService struct {
+	repo   repository.IRepositoryManager
+	logger logging.ILogger
+}
+
+func NewRelationshipService(r repository.IRepositoryManager, l logging.ILogger) *RelationshipService {
+	return &RelationshipService{
+		repo:   r,
+		logger: l,
+	}
+}
+
+func (s *RelationshipService) Follow(ctx context.Context, followerID, followeeID int32) error {
+	if followerID == followeeID {
+		return ErrCannotFollowSelf
+	}
+	if err := s.ensureUser(ctx, followeeID); err != nil {
+		return err
+	}
+
+	_, err := s.repo.Follow().Create(ctx, dbCtx.CreateFollowParams{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Insert, "Failed to follow user", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"followerID":         followerID,
+			"followeeID":         followeeID,
+		})
+		return errors.New("failed to follow user")
+	}
+	return nil
+}
+
+func (s *RelationshipService) Unfollow(ctx context.Context, followerID, followeeID int32) error {
+	deleted, err := s.repo.Follow().Delete(ctx, dbCtx.DeleteFollowParams{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Delete, "Failed to unfollow user", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"followerID":         followerID,
+			"followeeID":         followeeID,
+		})
+		return errors.New("failed to unfollow user")
+	}
+	if deleted == 0 {
+		return ErrNotFollowing
+	}
+	return nil
+}
+
+func (s *RelationshipService) Followers(ctx context.Context, userID int32, page dto.FollowListParams) ([]dbCtx.ListFollowersRow, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	users, err := s.repo.Follow().ListFollowers(ctx, dbCtx.ListFollowersParams{
+		FolloweeID:     userID,
+		OrganizationID: tenantID(ctx),
+		LimitCount:     page.Limit,
+		OffsetCount:    page.Offset,
+	})
+	if err != nil {
+		s.logListError(err, "Failed to fetch followers", userID)
+		return nil, errors.New("failed to fetch followers")
+	}
+	return users, nil
+}
+
+func (s *RelationshipService) Following(ctx context.Context, userID int32, page dto.FollowListParams) ([]dbCtx.ListFollowingRow, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	users, err := s.repo.Follow().ListFollowing(ctx, dbCtx.ListFollowingParams{
+		FollowerID:     userID,
+		OrganizationID: tenantID(ctx),
+		LimitCount:     page.Limit,
+		OffsetCount:    page.Offset,
+	})
+	if err != nil {
+		s.logListError(err, "Failed to fetch followed users", userID)
+		return nil, errors.New("failed to fetch followed users")
+	}
+	return users, nil
+}
+
+func (s *RelationshipService) Mutuals(ctx context.Context, userID int32, page dto.FollowListParams) ([]dbCtx.ListMutualFollowsRow, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	users, err := s.repo.Follow().ListMutual(ctx, dbCtx.ListMutualFollowsParams{
+		FollowerID:     userID,
+		OrganizationID: tenantID(ctx),
+		LimitCount:     page.Limit,
+		OffsetCount:    page.Offset,
+	})
+	if err != nil {
+		s.logListError(err, "Failed to fetch contacts", userID)
+		return nil, errors.New("failed to fetch contacts")
+	}
+	return users, nil
+}
+
+// ensureUser reports a user who does not exist, or belongs to another
+// organization than the one ctx is scoped to, as not found.
+func (s *RelationshipService) ensureUser(ctx context.Context, id int32) error {
+	_, err := s.repo.User().GetByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("user not found")
+	}
+	if err != nil {
+		return err
+	}
+	return checkTenant(ctx, s.repo, id)
+}
+
+// tenantID returns the organization ctx is scoped to, so that lists of users
+// leave out the members of other organizations.
+func tenantID(ctx context.Context) sql.NullInt32 {
+	orgID, ok := tenant.FromContext(ctx)
+	return sql.NullInt32{Int32: orgID, Valid: ok}
+}
+
+func (s *RelationshipService) logListError(err error, msg string, userID int32) {
+	s.logger.Error(logging.Postgres, logging.Select, msg, map[logging.ExtraKey]any{
+		logging.ErrorMessage: err.Error(),
+		"userID":             userID,
+	})
+}
@@ -0,0 +1,31 @@
+package services
+
+import "context"
+
+// RequestMeta describes who made a request and from where. The API
+// middlewares attach it to the request context so services can record it
+// without every method taking it as an argument.
+type RequestMeta struct {
+	ActorID   int32 // 0 when the request is not authenticated
+	IP        string
+	RequestID string
+}
+
+type requestMetaKey struct{}
+
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// WithActor returns a copy of ctx whose RequestMeta names actorID as the
+// authenticated user.
+func WithActor(ctx context.Context, actorID int32) context.Context {
+	meta := RequestMetaFrom(ctx)
+	meta.ActorID = actorID
+	return WithRequestMeta(ctx, meta)
+}
+
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta
+}
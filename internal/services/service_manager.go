@@ -3,7 +3,9 @@ package services
 import (
 	"example.com/api/internal/services/chat"
 	"example.com/api/internal/services/hashing"
+	"example.com/api/internal/services/mailing"
 	"example.com/api/internal/storage"
+	"example.com/api/internal/storage/blob"
 	"example.com/api/internal/storage/cache"
 )
 
@@ -14,4 +16,15 @@ type IServiceManager interface {
 	Hash() hashing.IHashService
 	TokenStorage() storage.ITokenStorage
 	CacheStorage() cache.ICacheService
+	Avatar() IAvatarService
+	BlobStorage() blob.IBlobStorage
+	Mail() mailing.IMailer
+	EmailChange() IEmailChangeService
+	Privacy() IPrivacyService
+	Audit() IAuditService
+	Webhook() IWebhookService
+	Block() IBlockService
+	Relationship() IRelationshipService
+	Organization() IOrganizationService
+	Invitation() IInvitationService
 }
@@ -1,11 +1,15 @@
 package services
 
 import (
+	"time"
+
 	"example.com/api/config"
 	"example.com/api/internal/repository"
 	"example.com/api/internal/services/chat"
 	"example.com/api/internal/services/hashing"
+	"example.com/api/internal/services/mailing"
 	"example.com/api/internal/storage"
+	"example.com/api/internal/storage/blob"
 	"example.com/api/internal/storage/cache"
 	"example.com/api/pkg/logging"
 )
@@ -20,6 +24,17 @@ type ServiceManager struct {
 	hash         hashing.IHashService
 	tokenStorage storage.ITokenStorage
 	cacheStorage cache.ICacheService
+	avatar       IAvatarService
+	blobStorage  blob.IBlobStorage
+	mail         mailing.IMailer
+	emailChange  IEmailChangeService
+	privacy      IPrivacyService
+	audit        IAuditService
+	webhook      IWebhookService
+	block        IBlockService
+	relationship IRelationshipService
+	organization IOrganizationService
+	invitation   IInvitationService
 }
 
 func NewServiceManager(
@@ -37,14 +52,16 @@ func NewServiceManager(
 
 func (s *ServiceManager) User() IUserService {
 	if s.user == nil {
-		s.user = NewUserService(s.repoManager, s.logger, s.Hash())
+		s.user = NewUserService(s.repoManager, s.logger, s.Hash(), s.EmailChange(), s.Audit())
 	}
 	return s.user
 }
 
 func (s *ServiceManager) Chat() chat.IChatService {
 	if s.chat == nil {
-		s.chat = chat.NewChatService(s.repoManager, s.logger)
+		unreadRedis := storage.NewRedisClient(&s.config.Redis, s.config.Redis.CacheStorage.DB)
+		unread := chat.NewRedisUnreadCounter(unreadRedis, s.config.Redis.CacheStorage.KeyPrefix, s.config.Chat.UnreadTTL*time.Minute)
+		s.chat = chat.NewChatService(s.repoManager, s.logger, s.CacheStorage(), unread, s.config.Chat)
 	}
 	return s.chat
 }
@@ -52,11 +69,15 @@ func (s *ServiceManager) Chat() chat.IChatService {
 func (s *ServiceManager) Auth() IAuthService {
 	if s.auth == nil {
 		s.auth = NewAuthService(
+			s.repoManager,
 			s.config.JWT,
 			s.Hash(),
 			s.User(),
 			s.logger,
 			s.TokenStorage(),
+			s.Audit(),
+			s.Invitation(),
+			s.config.Invitations,
 		)
 	}
 	return s.auth
@@ -84,3 +105,99 @@ func (s *ServiceManager) CacheStorage() cache.ICacheService {
 	}
 	return s.cacheStorage
 }
+
+func (s *ServiceManager) Avatar() IAvatarService {
+	if s.avatar == nil {
+		s.avatar = NewAvatarService(s.repoManager, s.logger, s.BlobStorage(), s.config.Avatar)
+	}
+	return s.avatar
+}
+
+func (s *ServiceManager) BlobStorage() blob.IBlobStorage {
+	if s.blobStorage == nil {
+		switch s.config.Blob.Driver {
+		case "s3":
+			s.blobStorage = blob.NewS3Storage(s.config.Blob.S3, nil)
+		default:
+			s.blobStorage = blob.NewLocalStorage(s.config.Blob.Local.Root)
+		}
+	}
+	return s.blobStorage
+}
+
+func (s *ServiceManager) Mail() mailing.IMailer {
+	if s.mail == nil {
+		s.mail = mailing.New(s.config.Mail, s.logger)
+	}
+	return s.mail
+}
+
+func (s *ServiceManager) EmailChange() IEmailChangeService {
+	if s.emailChange == nil {
+		s.emailChange = NewEmailChangeService(
+			s.repoManager,
+			s.logger,
+			s.Mail(),
+			s.TokenStorage(),
+			s.config.EmailChange,
+			s.Audit(),
+		)
+	}
+	return s.emailChange
+}
+
+func (s *ServiceManager) Privacy() IPrivacyService {
+	if s.privacy == nil {
+		s.privacy = NewPrivacyService(
+			s.repoManager,
+			s.logger,
+			s.TokenStorage(),
+			s.CacheStorage(),
+			s.BlobStorage(),
+			s.Audit(),
+		)
+	}
+	return s.privacy
+}
+
+func (s *ServiceManager) Audit() IAuditService {
+	if s.audit == nil {
+		s.audit = NewAuditService(s.repoManager, s.logger)
+	}
+	return s.audit
+}
+
+func (s *ServiceManager) Webhook() IWebhookService {
+	if s.webhook == nil {
+		s.webhook = NewWebhookService(s.repoManager, s.logger)
+	}
+	return s.webhook
+}
+
+func (s *ServiceManager) Block() IBlockService {
+	if s.block == nil {
+		s.block = NewBlockService(s.repoManager, s.logger, s.CacheStorage())
+	}
+	return s.block
+}
+
+func (s *ServiceManager) Relationship() IRelationshipService {
+	if s.relationship == nil {
+		s.relationship = NewRelationshipService(s.repoManager, s.logger)
+	}
+	return s.relationship
+}
+
+func (s *ServiceManager) Organization() IOrganizationService {
+	if s.organization == nil {
+		s.organization = NewOrganizationService(s.repoManager, s.logger)
+	}
+	return s.organization
+}
+
+func (s *ServiceManager) Invitation() IInvitationService {
+	if s.invitation == nil {
+		s.invitation = NewInvitationService(s.repoManager, s.logger, s.Mail(), s.config.Invitations)
+	}
+	return s.invitation
+}
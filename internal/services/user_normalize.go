@@ -0,0 +1,80 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	contracts "example.com/api/internal/contracts/errors"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	maxEmailLength    = 100
+	minUsernameLength = 3
+	maxUsernameLength = 50
+)
+
+// normalizeEmail returns the one spelling an address is stored and looked up
+// under: trimmed, lowercased, with an internationalized domain converted to
+// its ASCII (punycode) form.
+func normalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", &contracts.InvalidEmailError{Email: email, Reason: "missing local part or domain"}
+	}
+
+	domain, err := idna.Lookup.ToASCII(email[at+1:])
+	if err != nil {
+		return "", &contracts.InvalidEmailError{Email: email, Reason: "invalid domain"}
+	}
+
+	normalized := strings.ToLower(norm.NFC.String(email[:at])) + "@" + strings.ToLower(domain)
+	if len(normalized) > maxEmailLength {
+		return "", &contracts.InvalidEmailError{Email: email, Reason: "too long"}
+	}
+	return normalized, nil
+}
+
+// NormalizeEmail exposes normalizeEmail to handlers that compare an address
+// from a request with a stored one.
+func NormalizeEmail(email string) (string, error) {
+	return normalizeEmail(email)
+}
+
+// canonicalUsername applies NFKC, which folds compatibility forms such as
+// fullwidth letters and ligatures into their plain equivalents.
+func canonicalUsername(username string) string {
+	return strings.TrimSpace(norm.NFKC.String(username))
+}
+
+// normalizeUsername canonicalizes a username and rejects the spellings meant
+// to impersonate another user: invisible formatting characters and Latin
+// letters mixed with lookalike Cyrillic or Greek ones. Whole-script
+// lookalikes are caught by the username_skeleton unique index.
+func normalizeUsername(username string) (string, error) {
+	normalized := canonicalUsername(username)
+
+	length := utf8.RuneCountInString(normalized)
+	if length < minUsernameLength || length > maxUsernameLength {
+		return "", &contracts.InvalidUsernameError{Username: username, Reason: "must be between 3 and 50 characters"}
+	}
+
+	var latin, lookalike bool
+	for _, r := range normalized {
+		switch {
+		case unicode.IsControl(r) || unicode.Is(unicode.Cf, r):
+			return "", &contracts.InvalidUsernameError{Username: username, Reason: "contains invisible or control characters"}
+		case unicode.Is(unicode.Latin, r):
+			latin = true
+		case unicode.In(r, unicode.Cyrillic, unicode.Greek):
+			lookalike = true
+		}
+	}
+	if latin && lookalike {
+		return "", &contracts.InvalidUsernameError{Username: username, Reason: "mixes Latin with Cyrillic or Greek letters"}
+	}
+	return normalized, nil
+}
@@ -4,14 +4,23 @@ import (
 	"context"
 
 	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
 	dbCtx "example.com/api/internal/repository/db"
 )
 
 type IUserService interface {
 	GetByID(ctx context.Context, id int32) (*dbCtx.User, error)
 
+	// GetByIDs returns the users in the order of ids, without duplicates,
+	// and the ids that matched no user.
+	GetByIDs(ctx context.Context, ids []int32) ([]dbCtx.User, []int32, error)
+
 	Create(ctx context.Context, arg dto.CreateUserReq) (*dto.UserResponse, error)
 
+	// CreateTx creates the user through tx, for callers that must commit
+	// the account together with writes of their own.
+	CreateTx(ctx context.Context, tx repository.IRepositoryManager, arg dto.CreateUserReq) (*dto.UserResponse, error)
+
 	GetByUsername(ctx context.Context, username string) (*dbCtx.User, error)
 
 	GetByEmail(ctx context.Context, email string) (*dbCtx.User, error)
@@ -3,15 +3,17 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"time"
 
 	dto "example.com/api/internal/contracts"
 	contracts "example.com/api/internal/contracts/errors"
 	"example.com/api/internal/repository"
 	dbCtx "example.com/api/internal/repository/db"
 	"example.com/api/internal/services/hashing"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/internal/tenant"
 	"example.com/api/pkg/logging"
 	"example.com/api/pkg/metrics"
 	"github.com/lib/pq"
@@ -21,17 +23,48 @@ type UserService struct {
 	hashService hashing.IHashService
 	repo        repository.IRepositoryManager
 	logger      logging.ILogger
+	emailChange IEmailChangeService
+	audit       IAuditService
 }
 
-func NewUserService(r repository.IRepositoryManager, l logging.ILogger, h hashing.IHashService) *UserService {
+var errUserNotFound = errors.New("user not found")
+
+func NewUserService(r repository.IRepositoryManager, l logging.ILogger, h hashing.IHashService, e IEmailChangeService, a IAuditService) *UserService {
 	return &UserService{
 		repo:        r,
 		logger:      l,
 		hashService: h,
+		emailChange: e,
+		audit:       a,
 	}
 }
 
 func (s *UserService) Create(ctx context.Context, arg dto.CreateUserReq) (*dto.UserResponse, error) {
+	var user *dto.UserResponse
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		var err error
+		user, err = s.CreateTx(ctx, tx, arg)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CreateTx creates the user through tx, so that the caller can commit it
+// together with writes of its own.
+func (s *UserService) CreateTx(ctx context.Context, tx repository.IRepositoryManager, arg dto.CreateUserReq) (*dto.UserResponse, error) {
+	username, err := normalizeUsername(arg.Username)
+	if err != nil {
+		return nil, err
+	}
+	email, err := normalizeEmail(arg.Email)
+	if err != nil {
+		return nil, err
+	}
+	arg.Username, arg.Email = username, email
+
 	metrics.DbCall.WithLabelValues("User", "Create", "started").Inc()
 	hashedPassword, err := s.hashService.Hash(arg.Password)
 	if err != nil {
@@ -39,7 +72,7 @@ func (s *UserService) Create(ctx context.Context, arg dto.CreateUserReq) (*dto.U
 	}
 	arg.Password = hashedPassword
 	params := mapCreateUserReqToParams(arg)
-	user, err := s.repo.User().Create(ctx, params)
+	user, err := s.createTx(ctx, tx, params)
 	if err != nil {
 		metrics.DbCall.WithLabelValues("User", "Create", "error").Inc()
 
@@ -74,6 +107,35 @@ func (s *UserService) Create(ctx context.Context, arg dto.CreateUserReq) (*dto.U
 	return &userResponse, nil
 }
 
+func (s *UserService) createTx(ctx context.Context, tx repository.IRepositoryManager, params dbCtx.CreateUserParams) (dbCtx.User, error) {
+	user, err := tx.User().Create(ctx, params)
+	if err != nil {
+		return dbCtx.User{}, err
+	}
+	if orgID, ok := tenant.FromContext(ctx); ok {
+		_, err = tx.Organization().AddMember(ctx, dbCtx.AddOrganizationMemberParams{
+			OrganizationID: orgID,
+			UserID:         user.ID,
+		})
+		if err != nil {
+			return dbCtx.User{}, err
+		}
+	}
+	err = s.audit.RecordTx(ctx, tx, AuditEntry{
+		Action:     AuditUserCreated,
+		TargetType: AuditTargetUser,
+		TargetID:   user.ID,
+		After:      auditSnapshot(user),
+	})
+	if err != nil {
+		return dbCtx.User{}, err
+	}
+	if err := outbox.Enqueue(ctx, tx, outbox.UserCreated, outbox.AggregateUser, user.ID, mapUserToResponse(user)); err != nil {
+		return dbCtx.User{}, err
+	}
+	return user, nil
+}
+
 func (s *UserService) GetByID(ctx context.Context, id int32) (*dbCtx.User, error) {
 	metrics.DbCall.WithLabelValues("User", "GetById", "started").Inc()
 
@@ -88,15 +150,63 @@ func (s *UserService) GetByID(ctx context.Context, id int32) (*dbCtx.User, error
 				"userID":             id,
 			},
 		)
-		return nil, errors.New("user not found")
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := checkTenant(ctx, s.repo, id); err != nil {
+		return nil, err
 	}
 	metrics.DbCall.WithLabelValues("User", "Get", "success").Inc()
 
 	return &user, nil
 }
 
+func (s *UserService) GetByIDs(ctx context.Context, ids []int32) ([]dbCtx.User, []int32, error) {
+	metrics.DbCall.WithLabelValues("User", "GetByIds", "started").Inc()
+
+	unique := make([]int32, 0, len(ids))
+	seen := make(map[int32]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	found, err := s.repo.User().GetByIDs(ctx, unique)
+	if err == nil {
+		found, err = s.filterTenant(ctx, found)
+	}
+	if err != nil {
+		metrics.DbCall.WithLabelValues("User", "GetByIds", "error").Inc()
+		s.logger.Error(
+			logging.Postgres, logging.Select, "Failed to fetch users by IDs",
+			map[logging.ExtraKey]any{logging.ErrorMessage: err.Error()},
+		)
+		return nil, nil, errors.New("failed to fetch users")
+	}
+	metrics.DbCall.WithLabelValues("User", "GetByIds", "success").Inc()
+
+	byID := make(map[int32]dbCtx.User, len(found))
+	for _, user := range found {
+		byID[user.ID] = user
+	}
+	users := make([]dbCtx.User, 0, len(found))
+	missing := []int32{}
+	for _, id := range unique {
+		if user, ok := byID[id]; ok {
+			users = append(users, user)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return users, missing, nil
+}
+
 func (s *UserService) GetByUsername(ctx context.Context, username string) (*dbCtx.User, error) {
-	user, err := s.repo.User().GetByUsername(ctx, username)
+	user, err := s.repo.User().GetByUsername(ctx, canonicalUsername(username))
 	if err != nil {
 		s.logger.Error(
 			logging.Postgres, logging.Select, "Failed to fetch user by username",
@@ -107,10 +217,16 @@ func (s *UserService) GetByUsername(ctx context.Context, username string) (*dbCt
 		)
 		return nil, errors.New("user not found")
 	}
+	if err := checkTenant(ctx, s.repo, user.ID); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
 func (s *UserService) GetByEmail(ctx context.Context, email string) (*dbCtx.User, error) {
+	if normalized, err := normalizeEmail(email); err == nil {
+		email = normalized
+	}
 	user, err := s.repo.User().GetByEmail(ctx, email)
 	if err != nil {
 		s.logger.Error(
@@ -122,13 +238,34 @@ func (s *UserService) GetByEmail(ctx context.Context, email string) (*dbCtx.User
 		)
 		return nil, errors.New("user not found")
 	}
+	if err := checkTenant(ctx, s.repo, user.ID); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
 func (s *UserService) GetAll(ctx context.Context, arg dto.ListUsersParams) ([]dbCtx.User, error) {
 	params := mapListUsersReqToParams(arg)
 
-	users, err := s.repo.User().GetAll(ctx, params)
+	var (
+		users []dbCtx.User
+		err   error
+	)
+	if orgID, ok := tenant.FromContext(ctx); ok {
+		err = s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+			if err := tx.Organization().SetTenant(ctx, orgID); err != nil {
+				return err
+			}
+			users, err = tx.Organization().ListUsers(ctx, dbCtx.ListUsersInOrganizationParams{
+				OrganizationID: orgID,
+				Limit:          params.Limit,
+				Offset:         params.Offset,
+			})
+			return err
+		})
+	} else {
+		users, err = s.repo.User().GetAll(ctx, params)
+	}
 	if err != nil {
 		s.logger.Error(
 			logging.Postgres, logging.Select, "Failed to fetch all users",
@@ -142,7 +279,43 @@ func (s *UserService) GetAll(ctx context.Context, arg dto.ListUsersParams) ([]db
 }
 
 func (s *UserService) SoftDelete(ctx context.Context, id int32) error {
-	rowsAffected, err := s.repo.User().SoftDelete(ctx, id)
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		user, err := tx.User().GetByID(ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errUserNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkTenant(ctx, tx, id); err != nil {
+			return err
+		}
+		rowsAffected, err := tx.User().SoftDelete(ctx, id)
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return errUserNotFound
+		}
+		// Deleted users drop out of follower lists, so their follows go
+		// too; otherwise the counts on other profiles would include them.
+		if err := tx.Follow().DeleteByUser(ctx, id); err != nil {
+			return err
+		}
+		err = s.audit.RecordTx(ctx, tx, AuditEntry{
+			Action:     AuditUserDeleted,
+			TargetType: AuditTargetUser,
+			TargetID:   id,
+			Before:     auditSnapshot(user),
+		})
+		if err != nil {
+			return err
+		}
+		return outbox.Enqueue(ctx, tx, outbox.UserDeleted, outbox.AggregateUser, id, map[string]int32{"id": id})
+	})
+	if errors.Is(err, errUserNotFound) {
+		return err
+	}
 	if err != nil {
 		s.logger.Error(
 			logging.Postgres, logging.Delete, "Failed to soft delete user",
@@ -153,38 +326,58 @@ func (s *UserService) SoftDelete(ctx context.Context, id int32) error {
 		)
 		return errors.New("failed to delete user")
 	}
-	if rowsAffected == 0 {
-		return errors.New("user not found")
-	}
 	return nil
 }
 
 func (s *UserService) UpdateFull(ctx context.Context, arg dto.UpdateUserFullReq) (*dbCtx.User, error) {
+	username, err := normalizeUsername(arg.Username)
+	if err != nil {
+		return nil, err
+	}
+	email, err := normalizeEmail(arg.Email)
+	if err != nil {
+		return nil, err
+	}
+	arg.Username, arg.Email = username, email
+
 	hashedPassword, _ := s.hashService.Hash(arg.Password)
 	arg.Password = hashedPassword
+
+	current, err := s.getForUpdate(ctx, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	pendingEmail := ""
+	if arg.Email != current.Email {
+		taken, err := s.emailTaken(ctx, arg.ID, arg.Email)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			s.logger.Warn(
+				logging.Validation, logging.Update, "Email already exists",
+				map[logging.ExtraKey]any{logging.RequestBody: arg.Email},
+			)
+			return nil, &contracts.EmailExistsError{Email: arg.Email}
+		}
+		pendingEmail = arg.Email
+		arg.Email = current.Email
+	}
 	params := mapUpdateUserFullReqToParams(arg)
 
-	user, err := s.repo.User().UpdateFull(ctx, params)
+	user, change, err := s.updateAudited(ctx, *current, pendingEmail, func(tx repository.IRepositoryManager) (dbCtx.User, error) {
+		return tx.User().UpdateFull(ctx, params)
+	})
 	if err != nil {
 		metrics.DbCall.WithLabelValues("User", "UpdateFull", "error").Inc()
 
 		var pqErr *pq.Error
-		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
-			switch pqErr.Constraint {
-			case "users_username_key":
-				s.logger.Warn(
-					logging.Validation, logging.Update, "Username already exists",
-					map[logging.ExtraKey]any{logging.RequestBody: arg.Username},
-				)
-				return nil, &contracts.UsernameExistsError{Username: arg.Username}
-
-			case "users_email_key":
-				s.logger.Warn(
-					logging.Validation, logging.Update, "Email already exists",
-					map[logging.ExtraKey]any{logging.RequestBody: arg.Email},
-				)
-				return nil, &contracts.EmailExistsError{Email: arg.Email}
-			}
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_username_key" {
+			s.logger.Warn(
+				logging.Validation, logging.Update, "Username already exists",
+				map[logging.ExtraKey]any{logging.RequestBody: arg.Username},
+			)
+			return nil, &contracts.UsernameExistsError{Username: arg.Username}
 		}
 
 		if errors.Is(err, sql.ErrNoRows) {
@@ -209,37 +402,73 @@ func (s *UserService) UpdateFull(ctx context.Context, arg dto.UpdateUserFullReq)
 	}
 
 	metrics.DbCall.WithLabelValues("User", "UpdateFull", "success").Inc()
-	return &user, nil
+
+	return &user, s.notifyEmailChange(ctx, change)
 }
 
 func (s *UserService) UpdatePartial(ctx context.Context, arg dto.UpdateUserPartialReq) (*dbCtx.User, error) {
+	if arg.Username != nil {
+		username, err := normalizeUsername(*arg.Username)
+		if err != nil {
+			return nil, err
+		}
+		arg.Username = &username
+	}
+	if arg.Email != nil {
+		email, err := normalizeEmail(*arg.Email)
+		if err != nil {
+			return nil, err
+		}
+		arg.Email = &email
+	}
 	if arg.Password != nil && *arg.Password != "" {
 		hashedPassword, _ := s.hashService.Hash(*arg.Password)
 		arg.Password = &hashedPassword
 	}
-	params := mapUpdateUserPartialReqToParams(arg)
-
-	user, err := s.repo.User().UpdatePartial(ctx, params)
+	current, err := s.getForUpdate(ctx, arg.ID)
 	if err != nil {
-		metrics.DbCall.WithLabelValues("User", "UpdatePartial", "error").Inc()
-
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
-			switch pqErr.Constraint {
-			case "users_username_key":
-				s.logger.Warn(
-					logging.Validation, logging.Update, "Username already exists",
-					map[logging.ExtraKey]any{logging.RequestBody: arg.Username},
-				)
-				return nil, &contracts.UsernameExistsError{Username: *arg.Username}
-
-			case "users_email_key":
+		return nil, err
+	}
+	pendingEmail := ""
+	if arg.Email != nil {
+		if *arg.Email != current.Email {
+			taken, err := s.emailTaken(ctx, arg.ID, *arg.Email)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
 				s.logger.Warn(
 					logging.Validation, logging.Update, "Email already exists",
 					map[logging.ExtraKey]any{logging.RequestBody: arg.Email},
 				)
 				return nil, &contracts.EmailExistsError{Email: *arg.Email}
 			}
+			pendingEmail = *arg.Email
+		}
+		arg.Email = nil
+	}
+	params := mapUpdateUserPartialReqToParams(arg)
+	if arg.Attributes != nil {
+		attributes, err := json.Marshal(arg.Attributes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode attributes: %w", err)
+		}
+		params.Attributes = attributes
+	}
+
+	user, change, err := s.updateAudited(ctx, *current, pendingEmail, func(tx repository.IRepositoryManager) (dbCtx.User, error) {
+		return tx.User().UpdatePartial(ctx, params)
+	})
+	if err != nil {
+		metrics.DbCall.WithLabelValues("User", "UpdatePartial", "error").Inc()
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_username_key" {
+			s.logger.Warn(
+				logging.Validation, logging.Update, "Username already exists",
+				map[logging.ExtraKey]any{logging.RequestBody: arg.Username},
+			)
+			return nil, &contracts.UsernameExistsError{Username: *arg.Username}
 		}
 
 		if errors.Is(err, sql.ErrNoRows) {
@@ -264,9 +493,161 @@ func (s *UserService) UpdatePartial(ctx context.Context, arg dto.UpdateUserParti
 	}
 
 	metrics.DbCall.WithLabelValues("User", "UpdatePartial", "success").Inc()
+
+	return &user, s.notifyEmailChange(ctx, change)
+}
+
+// updateAudited runs update in a transaction together with the audit event
+// describing it and the user.updated outbox event. An email change is only
+// requested by an update, so it is recorded as pending in the same
+// transaction rather than as part of the diff; its mails are left to the
+// caller, to be sent once the update has committed.
+func (s *UserService) updateAudited(
+	ctx context.Context,
+	current dbCtx.User,
+	pendingEmail string,
+	update func(tx repository.IRepositoryManager) (dbCtx.User, error),
+) (dbCtx.User, *PendingEmailChange, error) {
+	var (
+		user   dbCtx.User
+		change *PendingEmailChange
+	)
+	err := s.repo.WithTx(ctx, func(tx repository.IRepositoryManager) error {
+		var err error
+		user, err = update(tx)
+		if err != nil {
+			return err
+		}
+		if pendingEmail != "" {
+			change, err = s.emailChange.RequestTx(ctx, tx, user, pendingEmail)
+			if err != nil {
+				return err
+			}
+		}
+
+		before, after := diffUsers(current, user)
+		var metadata map[string]any
+		if pendingEmail != "" {
+			metadata = map[string]any{"pendingEmail": pendingEmail}
+		}
+		err = s.audit.RecordTx(ctx, tx, AuditEntry{
+			Action:     AuditUserUpdated,
+			TargetType: AuditTargetUser,
+			TargetID:   user.ID,
+			Before:     before,
+			After:      after,
+			Metadata:   metadata,
+		})
+		if err != nil {
+			return err
+		}
+		return outbox.Enqueue(ctx, tx, outbox.UserUpdated, outbox.AggregateUser, user.ID, mapUserToResponse(user))
+	})
+	return user, change, err
+}
+
+// notifyEmailChange sends the mails of the email change an update recorded,
+// if any. The update itself is already saved by then, so a failure is
+// reported as ErrEmailChangeNotSent for the caller to pass on as a warning;
+// the user can simply ask for the change again.
+func (s *UserService) notifyEmailChange(ctx context.Context, change *PendingEmailChange) error {
+	if change == nil {
+		return nil
+	}
+	if err := s.emailChange.Notify(ctx, change); err != nil {
+		return fmt.Errorf("%w: %v", ErrEmailChangeNotSent, err)
+	}
+	return nil
+}
+
+// getForUpdate loads the user an update applies to, reporting a missing user
+// the same way a failed update does.
+func (s *UserService) getForUpdate(ctx context.Context, id int32) (*dbCtx.User, error) {
+	user, err := s.repo.User().GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logger.Error(
+				logging.Postgres, logging.Update, "User not found",
+				map[logging.ExtraKey]any{
+					logging.ErrorMessage: err.Error(),
+					"userID":             id,
+				},
+			)
+			return nil, errors.New("user not found")
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := checkTenant(ctx, s.repo, id); err != nil {
+		return nil, err
+	}
 	return &user, nil
 }
 
+// checkTenant reports a user outside the organization ctx is scoped to as
+// not found, so that one organization cannot tell another's users exist.
+// Outside of an organization every user is visible.
+func checkTenant(ctx context.Context, repo repository.IRepositoryManager, id int32) error {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	_, err := repo.Organization().GetMember(ctx, dbCtx.GetOrganizationMemberParams{
+		OrganizationID: orgID,
+		UserID:         id,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return errUserNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check membership: %w", err)
+	}
+	return nil
+}
+
+// filterTenant drops the users outside the organization ctx is scoped to.
+func (s *UserService) filterTenant(ctx context.Context, users []dbCtx.User) ([]dbCtx.User, error) {
+	orgID, ok := tenant.FromContext(ctx)
+	if !ok || len(users) == 0 {
+		return users, nil
+	}
+	ids := make([]int32, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	memberIDs, err := s.repo.Organization().FilterMembers(ctx, dbCtx.FilterOrganizationMembersParams{
+		OrganizationID: orgID,
+		UserIds:        ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	members := make(map[int32]bool, len(memberIDs))
+	for _, id := range memberIDs {
+		members[id] = true
+	}
+	kept := users[:0]
+	for _, user := range users {
+		if members[user.ID] {
+			kept = append(kept, user)
+		}
+	}
+	return kept, nil
+}
+
+// emailTaken reports whether email belongs to a user other than id. Email
+// changes are not written by updates, so the unique constraint cannot catch
+// this before the change is confirmed.
+func (s *UserService) emailTaken(ctx context.Context, id int32, email string) (bool, error) {
+	user, err := s.repo.User().GetByEmail(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check email: %w", err)
+	}
+	return user.ID != id, nil
+}
+
 func mapCreateUserReqToParams(dto dto.CreateUserReq) dbCtx.CreateUserParams {
 	return dbCtx.CreateUserParams{
 		Username:     dto.Username,
@@ -302,9 +683,20 @@ func mapUpdateUserPartialReqToParams(dto dto.UpdateUserPartialReq) dbCtx.UpdateU
 	if dto.Password != nil {
 		params.PasswordHash = dto.Password
 	}
+	params.SetBio, params.Bio = mapNullableToParam(dto.Bio)
+	params.SetLocale, params.Locale = mapNullableToParam(dto.Locale)
+	params.SetTimezone, params.Timezone = mapNullableToParam(dto.Timezone)
+	params.SetAvatarUrl, params.AvatarUrl = mapNullableToParam(dto.AvatarURL)
 	return params
 }
 
+func mapNullableToParam(n dto.Nullable[string]) (bool, sql.NullString) {
+	if !n.Set || n.Value == nil {
+		return n.Set, sql.NullString{}
+	}
+	return true, sql.NullString{String: *n.Value, Valid: true}
+}
+
 func mapListUsersReqToParams(dto dto.ListUsersParams) dbCtx.ListUsersParams {
 	return dbCtx.ListUsersParams{
 		Limit:  dto.Limit,
@@ -313,27 +705,7 @@ func mapListUsersReqToParams(dto dto.ListUsersParams) dbCtx.ListUsersParams {
 }
 
 func mapUserToResponse(user dbCtx.User) dto.UserResponse {
-	var createdAt *time.Time
-
-	if user.CreatedAt.Valid {
-		createdAt = &user.CreatedAt.Time
-	}
-	// if user.UpdatedAt.Valid {
-	// 	updatedAt = &user.UpdatedAt.Time
-	// }
-	// if user.DeletedAt.Valid {
-	// 	deletedAt = &user.DeletedAt.Time
-	// }
-
-	return dto.UserResponse{
-		ID:        user.ID,
-		Username:  user.Username,
-		Email:     user.Email,
-		FullName:  user.FullName,
-		CreatedAt: createdAt,
-		// UpdatedAt: updatedAt,
-		// DeletedAt: deletedAt,
-	}
+	return dto.NewUserResponse(user)
 }
 
 // example of a transaction
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	dto "example.com/api/internal/contracts"
+	dbCtx "example.com/api/internal/repository/db"
+)
+
+type IWebhookService interface {
+	Create(ctx context.Context, req dto.CreateWebhookReq) (dbCtx.WebhookSubscription, error)
+	Get(ctx context.Context, id int32) (dbCtx.WebhookSubscription, error)
+	List(ctx context.Context) ([]dbCtx.WebhookSubscription, error)
+	// Update returns the subscription and whether its secret changed.
+	Update(ctx context.Context, id int32, req dto.UpdateWebhookReq) (dbCtx.WebhookSubscription, bool, error)
+	Delete(ctx context.Context, id int32) error
+	ListDeliveries(ctx context.Context, id int32, filter dto.WebhookDeliveryFilter) ([]dbCtx.WebhookDelivery, error)
+	// Redeliver queues a delivered or dead-lettered delivery again.
+	Redeliver(ctx context.Context, id int32, deliveryID int64) (dbCtx.WebhookDelivery, error)
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/pkg/logging"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+type WebhookService struct {
+	repo   repository.IRepositoryManager
+	logger logging.ILogger
+}
+
+func NewWebhookService(r repository.IRepositoryManager, l logging.ILogger) *WebhookService {
+	return &WebhookService{
+		repo:   r,
+		logger: l,
+	}
+}
+
+func (s *WebhookService) Create(ctx context.Context, req dto.CreateWebhookReq) (dbCtx.WebhookSubscription, error) {
+	secret := req.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newWebhookSecret(); err != nil {
+			return dbCtx.WebhookSubscription{}, err
+		}
+	}
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	sub, err := s.repo.Webhook().CreateSubscription(ctx, dbCtx.CreateWebhookSubscriptionParams{
+		Url:        req.URL,
+		EventTypes: req.EventTypes,
+		Secret:     secret,
+		Active:     active,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Insert, "Failed to create webhook", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+		})
+		return dbCtx.WebhookSubscription{}, errors.New("failed to create webhook")
+	}
+	return sub, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, id int32) (dbCtx.WebhookSubscription, error) {
+	sub, err := s.repo.Webhook().GetSubscription(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbCtx.WebhookSubscription{}, ErrWebhookNotFound
+	}
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to fetch webhook", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"webhookID":          id,
+		})
+		return dbCtx.WebhookSubscription{}, errors.New("failed to fetch webhook")
+	}
+	return sub, nil
+}
+
+func (s *WebhookService) List(ctx context.Context) ([]dbCtx.WebhookSubscription, error) {
+	subs, err := s.repo.Webhook().ListSubscriptions(ctx)
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to fetch webhooks", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+		})
+		return nil, errors.New("failed to fetch webhooks")
+	}
+	return subs, nil
+}
+
+func (s *WebhookService) Update(ctx context.Context, id int32, req dto.UpdateWebhookReq) (dbCtx.WebhookSubscription, bool, error) {
+	sub, err := s.Get(ctx, id)
+	if err != nil {
+		return dbCtx.WebhookSubscription{}, false, err
+	}
+
+	params := dbCtx.UpdateWebhookSubscriptionParams{
+		ID:         id,
+		Url:        sub.Url,
+		EventTypes: sub.EventTypes,
+		Secret:     sub.Secret,
+		Active:     sub.Active,
+	}
+	if req.URL != nil {
+		params.Url = *req.URL
+	}
+	if len(req.EventTypes) > 0 {
+		params.EventTypes = req.EventTypes
+	}
+	if req.Active != nil {
+		params.Active = *req.Active
+	}
+	switch {
+	case req.RotateSecret:
+		if params.Secret, err = newWebhookSecret(); err != nil {
+			return dbCtx.WebhookSubscription{}, false, err
+		}
+	case req.Secret != nil:
+		params.Secret = *req.Secret
+	}
+
+	updated, err := s.repo.Webhook().UpdateSubscription(ctx, params)
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbCtx.WebhookSubscription{}, false, ErrWebhookNotFound
+	}
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Update, "Failed to update webhook", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"webhookID":          id,
+		})
+		return dbCtx.WebhookSubscription{}, false, errors.New("failed to update webhook")
+	}
+	return updated, updated.Secret != sub.Secret, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id int32) error {
+	n, err := s.repo.Webhook().DeleteSubscription(ctx, id)
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Delete, "Failed to delete webhook", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"webhookID":          id,
+		})
+		return errors.New("failed to delete webhook")
+	}
+	if n == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, id int32, filter dto.WebhookDeliveryFilter) ([]dbCtx.WebhookDelivery, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.Webhook().ListDeliveries(ctx, dbCtx.ListWebhookDeliveriesParams{
+		SubscriptionID: id,
+		Status:         sql.NullString{String: filter.Status, Valid: filter.Status != ""},
+		LimitCount:     filter.Limit,
+		OffsetCount:    filter.Offset,
+	})
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Select, "Failed to fetch webhook deliveries", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"webhookID":          id,
+		})
+		return nil, errors.New("failed to fetch webhook deliveries")
+	}
+	return deliveries, nil
+}
+
+func (s *WebhookService) Redeliver(ctx context.Context, id int32, deliveryID int64) (dbCtx.WebhookDelivery, error) {
+	delivery, err := s.repo.Webhook().Redeliver(ctx, dbCtx.RedeliverWebhookDeliveryParams{
+		ID:             deliveryID,
+		SubscriptionID: id,
+	})
+	// A delivery that is still pending is already queued, so it is reported
+	// the same as one that does not exist.
+	if errors.Is(err, sql.ErrNoRows) {
+		return dbCtx.WebhookDelivery{}, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		s.logger.Error(logging.Postgres, logging.Update, "Failed to redeliver webhook delivery", map[logging.ExtraKey]any{
+			logging.ErrorMessage: err.Error(),
+			"webhookID":          id,
+			"deliveryID":         deliveryID,
+		})
+		return dbCtx.WebhookDelivery{}, errors.New("failed to redeliver webhook delivery")
+	}
+	return delivery, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
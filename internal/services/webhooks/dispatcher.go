@@ -0,0 +1,173 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"example.com/api/config"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/pkg/logging"
+	"example.com/api/pkg/metrics"
+)
+
+// Delivery states stored in webhook_deliveries.status.
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusDead      = "dead"
+)
+
+// maxErrorBody caps how much of a failed response is kept as last_error.
+const maxErrorBody = 512
+
+// Dispatcher sends pending webhook deliveries. Like the outbox relay it
+// leases what it claims, so several dispatchers can share the table.
+type Dispatcher struct {
+	repo   repository.IWebhookRepo
+	client *http.Client
+	logger logging.ILogger
+	cfg    config.WebhooksConfig
+}
+
+// NewDispatcher returns a dispatcher posting with client. A nil client gets
+// a default one using cfg.Timeout.
+func NewDispatcher(repo repository.IWebhookRepo, client *http.Client, logger logging.ILogger, cfg config.WebhooksConfig) *Dispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout * time.Second}
+	}
+	return &Dispatcher{
+		repo:   repo,
+		client: client,
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+// Run polls for due deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := d.RunOnce(ctx)
+			if err != nil {
+				d.logger.Error(logging.Postgres, logging.Select, "Failed to claim webhook deliveries", map[logging.ExtraKey]any{
+					logging.ErrorMessage: err.Error(),
+				})
+			}
+			if err != nil || n < int(d.cfg.BatchSize) || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce claims one batch of due deliveries and attempts each of them. It
+// returns how many deliveries were claimed.
+func (d *Dispatcher) RunOnce(ctx context.Context) (int, error) {
+	rows, err := d.repo.ClaimDeliveries(ctx, dbCtx.ClaimWebhookDeliveriesParams{
+		LeaseSeconds: int32(d.cfg.LeaseDuration),
+		BatchSize:    d.cfg.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+
+	for _, row := range rows {
+		d.attempt(ctx, row)
+	}
+	return len(rows), nil
+}
+
+func (d *Dispatcher) attempt(ctx context.Context, row dbCtx.ClaimWebhookDeliveriesRow) {
+	statusCode, err := d.send(ctx, row)
+	if err == nil {
+		metrics.WebhookDeliveries.WithLabelValues(row.EventType, "success").Inc()
+		err = d.repo.MarkDelivered(ctx, dbCtx.MarkWebhookDeliveryDeliveredParams{
+			ID:             row.ID,
+			LastStatusCode: statusCode,
+		})
+		if err != nil {
+			d.logUpdateError(err, row.ID)
+		}
+		return
+	}
+
+	d.logger.Warn(logging.General, logging.ExternalService, "Webhook delivery failed", map[logging.ExtraKey]any{
+		logging.ErrorMessage: err.Error(),
+		"deliveryID":         row.ID,
+		"subscriptionID":     row.SubscriptionID,
+		"attempt":            row.Attempts,
+	})
+
+	if row.Attempts >= d.cfg.MaxAttempts {
+		metrics.WebhookDeliveries.WithLabelValues(row.EventType, "dead").Inc()
+		err = d.repo.DeadLetter(ctx, dbCtx.DeadLetterWebhookDeliveryParams{
+			ID:             row.ID,
+			LastStatusCode: statusCode,
+			LastError:      err.Error(),
+		})
+	} else {
+		metrics.WebhookDeliveries.WithLabelValues(row.EventType, "error").Inc()
+		err = d.repo.RetryDelivery(ctx, dbCtx.RetryWebhookDeliveryParams{
+			DelaySeconds:   outbox.Backoff(row.Attempts, d.cfg.MinBackoff*time.Second, d.cfg.MaxBackoff*time.Second).Seconds(),
+			LastStatusCode: statusCode,
+			LastError:      err.Error(),
+			ID:             row.ID,
+		})
+	}
+	if err != nil {
+		d.logUpdateError(err, row.ID)
+	}
+}
+
+// send posts the delivery and returns the response status, 0 when no
+// response was received. Any status other than 2xx is an error.
+func (d *Dispatcher) send(ctx context.Context, row dbCtx.ClaimWebhookDeliveriesRow) (int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, row.Url, bytes.NewReader(row.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(row.Secret, time.Now(), row.Payload))
+	req.Header.Set("X-Webhook-ID", strconv.Itoa(int(row.SubscriptionID)))
+	req.Header.Set("X-Delivery-ID", strconv.FormatInt(row.ID, 10))
+	req.Header.Set("X-Event-ID", strconv.FormatInt(row.EventID, 10))
+	req.Header.Set("X-Event-Type", row.EventType)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return int32(resp.StatusCode), fmt.Errorf("endpoint responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return int32(resp.StatusCode), nil
+}
+
+func (d *Dispatcher) logUpdateError(err error, deliveryID int64) {
+	d.logger.Error(logging.Postgres, logging.Update, "Failed to record webhook delivery attempt", map[logging.ExtraKey]any{
+		logging.ErrorMessage: err.Error(),
+		"deliveryID":         deliveryID,
+	})
+}
@@ -0,0 +1,38 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/outbox"
+)
+
+// SubscriptionSink is the outbox sink feeding webhooks. It only records a
+// pending delivery for every subscription listening for the event; the
+// Dispatcher sends them, so one slow endpoint never holds up the outbox.
+type SubscriptionSink struct {
+	repo repository.IWebhookRepo
+}
+
+func NewSubscriptionSink(repo repository.IWebhookRepo) *SubscriptionSink {
+	return &SubscriptionSink{repo: repo}
+}
+
+func (s *SubscriptionSink) Name() string {
+	return "webhooks"
+}
+
+func (s *SubscriptionSink) Publish(ctx context.Context, event outbox.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.repo.EnqueueDeliveries(ctx, dbCtx.EnqueueWebhookDeliveriesParams{
+		EventID:   event.ID,
+		EventType: event.Type,
+		Payload:   payload,
+	})
+	return err
+}
@@ -8,5 +8,13 @@ import (
 type ICacheService interface {
 	Get(ctx context.Context, key string, dest any) (bool, error)
 	Set(ctx context.Context, key string, value any, exp time.Duration) error
+	// SetNX stores value only if key is not set yet and reports whether it
+	// did, which makes it usable as a lock.
+	SetNX(ctx context.Context, key string, value any, exp time.Duration) (bool, error)
 	Delete(ctx context.Context, key string) error
+	DeletePrefix(ctx context.Context, prefix string) error
+	// Incr adds one to the counter at key, which starts from 0 when the key
+	// is not set, and returns the new value. Counters do not expire, and Get
+	// reads them into an integer.
+	Incr(ctx context.Context, key string) (int64, error)
 }
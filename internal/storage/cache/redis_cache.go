@@ -44,6 +44,41 @@ func (c *RedisCache) Set(ctx context.Context, key string, value any, exp time.Du
 	return c.client.Set(ctx, c.key(key), serialized, exp).Err()
 }
 
+func (c *RedisCache) SetNX(ctx context.Context, key string, value any, exp time.Duration) (bool, error) {
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	return c.client.SetNX(ctx, c.key(key), serialized, exp).Result()
+}
+
 func (c *RedisCache) Delete(ctx context.Context, key string) error {
 	return c.client.Del(ctx, c.key(key)).Err()
 }
+
+// DeletePrefix removes every entry whose key starts with prefix. It walks the
+// keyspace with SCAN so large caches do not block Redis.
+func (c *RedisCache) DeletePrefix(ctx context.Context, prefix string) error {
+	iter := c.client.Scan(ctx, 0, c.key(prefix)+"*", 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 100 {
+			if err := c.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return c.client.Del(ctx, keys...).Err()
+	}
+	return nil
+}
+
+func (c *RedisCache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, c.key(key)).Result()
+}
@@ -45,3 +45,24 @@ func (s *RedisTokenStorage) Validate(ctx context.Context, userID, tokenID string
 func (s *RedisTokenStorage) Invalidate(ctx context.Context, userID string) error {
 	return s.client.Del(ctx, s.key(userID)).Err()
 }
+
+func (s *RedisTokenStorage) Sessions(ctx context.Context, userID string) ([]Session, error) {
+	storedID, err := s.client.Get(ctx, s.key(userID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage error: %w", err)
+	}
+
+	ttl, err := s.client.TTL(ctx, s.key(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("storage error: %w", err)
+	}
+
+	session := Session{TokenID: storedID}
+	if ttl > 0 {
+		session.ExpiresAt = time.Now().Add(ttl).UTC()
+	}
+	return []Session{session}, nil
+}
@@ -5,8 +5,15 @@ import (
 	"time"
 )
 
+// Session describes a stored refresh token without exposing the token.
+type Session struct {
+	TokenID   string    `json:"tokenId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
 type ITokenStorage interface {
 	Store(ctx context.Context, userID string, tokenID string, exp time.Duration) error
 	Validate(ctx context.Context, userID string, tokenID string) error
 	Invalidate(ctx context.Context, userID string) error
+	Sessions(ctx context.Context, userID string) ([]Session, error)
 }
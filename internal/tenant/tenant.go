@@ -0,0 +1,35 @@
+// Package tenant carries the organization a request is made in through its
+// context. Services read it to scope their queries; it lives in its own
+// package so that lower-level packages such as chat can use it as well.
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoTenant is returned by operations that need an organization when the
+// context does not name one.
+var ErrNoTenant = errors.New("no organization in context")
+
+type key struct{}
+
+// WithID returns a copy of ctx that is scoped to organization id.
+func WithID(ctx context.Context, id int32) context.Context {
+	return context.WithValue(ctx, key{}, id)
+}
+
+// FromContext returns the organization ctx is scoped to, if any.
+func FromContext(ctx context.Context) (int32, bool) {
+	id, ok := ctx.Value(key{}).(int32)
+	return id, ok && id != 0
+}
+
+// Require is FromContext for operations that must not run unscoped.
+func Require(ctx context.Context) (int32, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return 0, ErrNoTenant
+	}
+	return id, nil
+}
@@ -41,6 +41,7 @@ const (
 
 	// IO
 	RemoveFile SubCategory = "RemoveFile"
+	WriteFile  SubCategory = "WriteFile"
 )
 
 const (
@@ -56,4 +57,5 @@ const (
 	RequestBody  ExtraKey = "RequestBody"
 	ResponseBody ExtraKey = "ResponseBody"
 	ErrorMessage ExtraKey = "ErrorMessage"
+	RequestId    ExtraKey = "RequestId"
 )
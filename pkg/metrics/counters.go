@@ -14,3 +14,20 @@ var TotalReq = prometheus.NewCounter(prometheus.CounterOpts{
 	Name: "requests_total",
 	Help: "Counting the total number of requests handled",
 })
+
+var OutboxDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "outbox_deliveries_total",
+	Help: "Number of outbox event deliveries per sink",
+},
+	[]string{"sink", "event_type", "status"})
+
+var ChatPresenceDropped = prometheus.NewCounter(prometheus.CounterOpts{
+	Name: "chat_presence_dropped_total",
+	Help: "Number of presence changes dropped because the publish queue was full",
+})
+
+var WebhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "webhook_delivery_attempts_total",
+	Help: "Number of webhook delivery attempts by outcome",
+},
+	[]string{"event_type", "status"})
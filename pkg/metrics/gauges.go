@@ -6,3 +6,8 @@ var NodeUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Name: "node_usage",
 	Help: "Monitoring node usage",
 }, []string{"node", "namespace"})
+
+var OutboxPending = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "outbox_pending_events",
+	Help: "Number of outbox events not delivered yet",
+})
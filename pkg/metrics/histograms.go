@@ -10,3 +10,11 @@ var HttpDuration = prometheus.NewHistogramVec(
 		"path", "method", "status_code",
 	},
 )
+
+var OutboxDeliveryLag = prometheus.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    "outbox_delivery_lag_seconds",
+		Help:    "Time from an outbox event being written to its delivery",
+		Buckets: []float64{0.1, 0.5, 1, 5, 15, 60, 300, 1800},
+	},
+)
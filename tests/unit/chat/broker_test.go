@@ -0,0 +1,190 @@
+package chat_test
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/api/internal/services/chat"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bus connects the hubs of one process the way Redis pub/sub connects app
+// instances. Every event is handed to every hub copies times.
+type bus struct {
+	mu      sync.Mutex
+	members []chan []byte
+	copies  int
+}
+
+type busBroker struct {
+	bus      *bus
+	messages chan []byte
+}
+
+func (b *bus) broker() chat.Broker {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	messages := make(chan []byte, 256)
+	b.members = append(b.members, messages)
+	return busBroker{bus: b, messages: messages}
+}
+
+func (b busBroker) Publish(_ context.Context, data []byte) error {
+	b.bus.mu.Lock()
+	defer b.bus.mu.Unlock()
+	for _, m := range b.bus.members {
+		for i := 0; i < b.bus.copies; i++ {
+			m <- data
+		}
+	}
+	return nil
+}
+
+func (b busBroker) Messages() <-chan []byte {
+	return b.messages
+}
+
+// serveBroker serves a hub using broker.
+func serveBroker(t *testing.T, broker chat.Broker) dialFunc {
+	return serveHub(t, chat.NewHub(nil, broker, chat.ConnLimits{}))
+}
+
+// testHubsShareChat checks that clients of two hubs sharing a broker chat as
+// if they were connected to the same one.
+func testHubsShareChat(t *testing.T, east, west dialFunc) {
+	alice, bob := east(1, 1, 1), west(2, 1, 1)
+	bobPhone := east(2, 1, 1)
+
+	send(t, alice, "hello from east")
+	for _, conn := range []*websocket.Conn{alice, bob, bobPhone} {
+		assert.Equal(t, "hello from east", receive(t, conn, time.Second))
+	}
+
+	// Joining from one instance subscribes the user's clients on all of
+	// them.
+	write(t, bob, chat.FrameJoin, "", chat.RoomPayload{Room: 2})
+	for _, conn := range []*websocket.Conn{bob, bobPhone} {
+		assert.Equal(t, chat.FrameJoined, receiveFrame(t, conn, time.Second).Type)
+	}
+
+	write(t, alice, chat.FrameSend, "", chat.SendPayload{To: 2, Content: "psst"})
+	for _, conn := range []*websocket.Conn{alice, bob, bobPhone} {
+		assert.Equal(t, "psst", receive(t, conn, time.Second))
+	}
+
+	// Each event arrives once: the next frame is the next message.
+	send(t, bob, "second")
+	for _, conn := range []*websocket.Conn{alice, bob, bobPhone} {
+		assert.Equal(t, "second", receive(t, conn, time.Second))
+	}
+}
+
+func TestHub_SharesChatThroughBroker(t *testing.T) {
+	b := &bus{copies: 1}
+	testHubsShareChat(t, serveBroker(t, b.broker()), serveBroker(t, b.broker()))
+}
+
+func TestHub_DropsDuplicateEvents(t *testing.T) {
+	b := &bus{copies: 2}
+	testHubsShareChat(t, serveBroker(t, b.broker()), serveBroker(t, b.broker()))
+}
+
+// testRedis connects to the Redis at TEST_REDIS_ADDR (localhost:6379 by
+// default) and skips the test when there is none.
+func testRedis(t *testing.T) *redis.Client {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr, Password: os.Getenv("TEST_REDIS_PASSWORD")})
+	t.Cleanup(func() { client.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("redis not available at %s: %v", addr, err)
+	}
+	return client
+}
+
+// TestHub_SharesChatThroughRedis runs two hubs against a Redis test server.
+func TestHub_SharesChatThroughRedis(t *testing.T) {
+	client := testRedis(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	channel := "chat:test:" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	newBroker := func() chat.Broker {
+		b, err := chat.NewRedisBroker(ctx, client, channel)
+		require.NoError(t, err)
+		t.Cleanup(func() { b.Close() })
+		return b
+	}
+	testHubsShareChat(t, serveBroker(t, newBroker()), serveBroker(t, newBroker()))
+}
+
+func TestHub_SharesPresenceThroughBroker(t *testing.T) {
+	b := &bus{copies: 1}
+	east := serveHub(t, chat.NewHub(nil, b.broker(), chat.ConnLimits{PresenceGrace: -1}))
+	alice := east(1, 1, 1)
+	assert.Equal(t, chat.PresencePayload{User: 1, Online: true}, receivePresence(t, alice))
+
+	// A hub started later learns who is online from the others.
+	westHub := chat.NewHub(nil, b.broker(), chat.ConnLimits{PresenceGrace: -1})
+	west := serveHub(t, westHub)
+	assert.Eventually(t, func() bool {
+		return slices.Equal(westHub.Online(1), []int32{1})
+	}, time.Second, 10*time.Millisecond)
+
+	bob := west(2, 1, 1)
+	for _, conn := range []*websocket.Conn{alice, bob} {
+		assert.Equal(t, chat.PresencePayload{User: 2, Online: true}, receivePresence(t, conn))
+	}
+	assert.Equal(t, []int32{1, 2}, westHub.Online(1))
+
+	require.NoError(t, alice.Close())
+	assert.Equal(t, chat.PresencePayload{User: 1, Online: false}, receivePresence(t, bob))
+}
+
+// stuckBroker never gets an event through, as a broker that is down.
+type stuckBroker struct {
+	messages chan []byte
+}
+
+func (b stuckBroker) Publish(ctx context.Context, _ []byte) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (b stuckBroker) Messages() <-chan []byte {
+	return b.messages
+}
+
+func TestHub_DropsPresenceRatherThanStall(t *testing.T) {
+	broker := stuckBroker{messages: make(chan []byte)}
+	t.Cleanup(func() { close(broker.messages) })
+	dial := serveHub(t, chat.NewHub(nil, broker, chat.ConnLimits{PresenceGrace: -1}))
+
+	// More users come online than presence changes fit in the queue while
+	// the broker holds up the first one.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for userID := int32(1); userID <= 300; userID++ {
+			dial(userID, 1, 1)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("hub stopped registering clients")
+	}
+}
@@ -0,0 +1,780 @@
+package chat_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/chat"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// missingRoom and missingMessage are the room and message stubChatService
+// does not know. newestMessage is the newest message of every room and
+// conversation it knows. directMessage is the direct message user 1 sent
+// user 2; every other message is user 1's in room 1. threadReplies is how
+// many replies every thread has.
+const (
+	missingRoom    = 404
+	missingMessage = 404
+	newestMessage  = 9
+	directMessage  = 8
+	threadReplies  = 3
+)
+
+// stubChatService lets everyone into every room but missingRoom, and change
+// every message but missingMessage.
+type stubChatService struct{}
+
+func (stubChatService) SaveMessage(_ context.Context, senderID, roomID int32, content string) (dbCtx.Message, error) {
+	if roomID == missingRoom {
+		return dbCtx.Message{}, chat.ErrRoomNotFound
+	}
+	return dbCtx.Message{
+		ID:        int32(len(content)),
+		SenderID:  senderID,
+		RoomID:    sql.NullInt32{Int32: roomID, Valid: true},
+		Content:   content,
+		CreatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}, nil
+}
+func (stubChatService) Reply(_ context.Context, senderID, parentID int32, content string) (dbCtx.Message, int64, error) {
+	if parentID == missingMessage || parentID == directMessage {
+		return dbCtx.Message{}, 0, chat.ErrMessageNotFound
+	}
+	return dbCtx.Message{
+		ID:             int32(len(content)),
+		SenderID:       senderID,
+		RoomID:         sql.NullInt32{Int32: 1, Valid: true},
+		ParentID:       sql.NullInt32{Int32: parentID, Valid: true},
+		OrganizationID: 1,
+		Content:        content,
+		CreatedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+	}, threadReplies, nil
+}
+func (stubChatService) GetMessages(_ context.Context, _, roomID, _, _ int32) ([]dbCtx.GetMessagesRow, error) {
+	return []dbCtx.GetMessagesRow{
+		{
+			ID: 1, SenderID: 1, SenderName: "user1", Content: "earlier", RoomID: sql.NullInt32{Int32: roomID, Valid: true},
+			ReplyCount: 2, Reactions: json.RawMessage(`[{"emoji":"👍","count":2,"users":[2,3]}]`),
+		},
+	}, nil
+}
+func (stubChatService) Thread(context.Context, int32, int32, int32, int32) ([]dbCtx.GetThreadRow, error) {
+	return nil, nil
+}
+func (stubChatService) MessagesSince(context.Context, int32, chat.ReplayPoint, int32) ([]dbCtx.ListMessagesSinceRow, error) {
+	return nil, nil
+}
+func (stubChatService) CreateRoom(context.Context, int32, string) (dbCtx.ChatRoom, error) {
+	return dbCtx.ChatRoom{}, nil
+}
+func (stubChatService) ListRooms(context.Context, int32) ([]dbCtx.ListChatRoomsRow, error) {
+	return nil, nil
+}
+func (stubChatService) JoinRoom(context.Context, int32, int32) error  { return nil }
+func (stubChatService) LeaveRoom(context.Context, int32, int32) error { return nil }
+func (stubChatService) RoomMembers(context.Context, int32) ([]dbCtx.ListRoomMembersRow, error) {
+	return nil, nil
+}
+func (stubChatService) RoomIDs(context.Context, int32) ([]int32, error) { return nil, nil }
+func (stubChatService) SendDirect(_ context.Context, senderID, recipientID int32, content string) (dbCtx.Message, error) {
+	return dbCtx.Message{SenderID: senderID, RecipientID: sql.NullInt32{Int32: recipientID, Valid: true}, Content: content}, nil
+}
+func (stubChatService) Conversations(context.Context, int32, int32, int32) ([]dbCtx.ListConversationsRow, error) {
+	return nil, nil
+}
+func (stubChatService) DirectMessages(context.Context, int32, int32, int32, int32) ([]dbCtx.GetDirectMessagesRow, error) {
+	return nil, nil
+}
+func (stubChatService) MarkRoomRead(_ context.Context, _, roomID, messageID int32) (int32, error) {
+	if roomID == missingRoom {
+		return 0, chat.ErrRoomNotFound
+	}
+	return readUpTo(messageID), nil
+}
+func (stubChatService) MarkConversationRead(_ context.Context, _, _, messageID int32) (int32, error) {
+	return readUpTo(messageID), nil
+}
+func (stubChatService) UnreadCounts(context.Context, int32) (chat.UnreadCounts, error) {
+	return chat.UnreadCounts{}, nil
+}
+
+func readUpTo(messageID int32) int32 {
+	if messageID == 0 || messageID > newestMessage {
+		return newestMessage
+	}
+	return messageID
+}
+
+func (stubChatService) EditMessage(_ context.Context, actorID, messageID int32, content string) (dbCtx.Message, error) {
+	if messageID == missingMessage {
+		return dbCtx.Message{}, chat.ErrMessageNotFound
+	}
+	return dbCtx.Message{
+		ID:             messageID,
+		SenderID:       actorID,
+		RoomID:         sql.NullInt32{Int32: 1, Valid: true},
+		Content:        content,
+		OrganizationID: 1,
+		EditedAt:       sql.NullTime{Time: time.Now(), Valid: true},
+	}, nil
+}
+func (stubChatService) DeleteMessage(_ context.Context, actorID, messageID int32) (dbCtx.Message, error) {
+	if messageID == missingMessage {
+		return dbCtx.Message{}, chat.ErrMessageNotFound
+	}
+	return dbCtx.Message{
+		ID:             messageID,
+		SenderID:       actorID,
+		RoomID:         sql.NullInt32{Int32: 1, Valid: true},
+		OrganizationID: 1,
+		DeletedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+	}, nil
+}
+
+// React counts two reactions with the emoji, Unreact one.
+func (stubChatService) React(_ context.Context, _, messageID int32, emoji string) (chat.Reaction, error) {
+	return stubReaction(messageID, emoji, 2)
+}
+func (stubChatService) Unreact(_ context.Context, _, messageID int32, emoji string) (chat.Reaction, error) {
+	return stubReaction(messageID, emoji, 1)
+}
+func (stubChatService) Reactions(context.Context, int32, int32) ([]dbCtx.ListMessageReactionsRow, error) {
+	return nil, nil
+}
+
+func stubReaction(messageID int32, emoji string, count int64) (chat.Reaction, error) {
+	if messageID == missingMessage {
+		return chat.Reaction{}, chat.ErrMessageNotFound
+	}
+	msg := dbCtx.Message{ID: messageID, SenderID: 1, OrganizationID: 1}
+	if messageID == directMessage {
+		msg.RecipientID = sql.NullInt32{Int32: 2, Valid: true}
+	} else {
+		msg.RoomID = sql.NullInt32{Int32: 1, Valid: true}
+	}
+	return chat.Reaction{Message: msg, Emoji: emoji, Count: count}, nil
+}
+
+type stubBlocks struct {
+	blockedBy map[int32][]int32
+	err       error
+}
+
+func (s stubBlocks) BlockedBy(_ context.Context, userID int32) ([]int32, error) {
+	return s.blockedBy[userID], s.err
+}
+
+// dialFunc connects a user to a hub and waits until the client is
+// registered.
+type dialFunc func(userID, orgID int32, rooms ...int32) *websocket.Conn
+
+// startHub serves an in-process hub over websockets.
+func startHub(t *testing.T, blocks chat.BlockLookup) dialFunc {
+	return serveHub(t, chat.NewHub(blocks, nil, chat.ConnLimits{}))
+}
+
+// serveHub runs hub and serves it over websockets. Users connect with
+// ?user=<id>&org=<id>&rooms=<id>,<id>.
+func serveHub(t *testing.T, hub *chat.Hub) dialFunc {
+	go hub.Run()
+	return serveClients(t, hub, stubChatService{}, nil)
+}
+
+// serveClients serves a running hub over websockets, carrying out the
+// clients' frames with svc. With since set, clients first get the messages
+// they missed since then.
+func serveClients(t *testing.T, hub *chat.Hub, svc chat.IChatService, since *chat.ReplayPoint) dialFunc {
+	upgrader := websocket.Upgrader{}
+	registered := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := strconv.Atoi(r.URL.Query().Get("user"))
+		orgID, _ := strconv.Atoi(r.URL.Query().Get("org"))
+		var rooms []int32
+		for _, id := range strings.Split(r.URL.Query().Get("rooms"), ",") {
+			if roomID, err := strconv.Atoi(id); err == nil {
+				rooms = append(rooms, int32(roomID))
+			}
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := chat.NewClient(hub, conn, int32(userID), "user"+strconv.Itoa(userID), int32(orgID), rooms)
+		hub.Register(client)
+		registered <- struct{}{}
+		if since != nil {
+			go client.Replay(svc, *since)
+		} else {
+			go client.SendMessages()
+		}
+		go client.HandleMessages(svc)
+	}))
+	t.Cleanup(srv.Close)
+
+	return func(userID, orgID int32, rooms ...int32) *websocket.Conn {
+		ids := make([]string, 0, len(rooms))
+		for _, id := range rooms {
+			ids = append(ids, strconv.Itoa(int(id)))
+		}
+		url := "ws" + strings.TrimPrefix(srv.URL, "http") +
+			"?user=" + strconv.Itoa(int(userID)) + "&org=" + strconv.Itoa(int(orgID)) +
+			"&rooms=" + strings.Join(ids, ",")
+		conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		<-registered
+		return conn
+	}
+}
+
+// write sends the hub a frame of type kind carrying payload.
+func write(t *testing.T, conn *websocket.Conn, kind, id string, payload any) {
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteJSON(chat.Frame{V: chat.ProtocolVersion, Type: kind, ID: id, Payload: data}))
+}
+
+// send posts content to room 1, unless another room is given.
+func send(t *testing.T, conn *websocket.Conn, content string, room ...int32) {
+	roomID := int32(1)
+	if len(room) > 0 {
+		roomID = room[0]
+	}
+	write(t, conn, chat.FrameSend, "", chat.SendPayload{Room: roomID, Content: content})
+}
+
+// receive returns the content of the next message, or "" if none arrives.
+func receive(t *testing.T, conn *websocket.Conn, wait time.Duration) string {
+	return receiveMessage(t, conn, wait).Content
+}
+
+// receiveMessage returns the next message, skipping the acks of the
+// client's own sends, or the zero Message if none arrives.
+func receiveMessage(t *testing.T, conn *websocket.Conn, wait time.Duration) chat.Message {
+	for {
+		frame := receiveFrame(t, conn, wait)
+		if frame.Type == chat.FrameAck {
+			continue
+		}
+		var msg chat.Message
+		if frame.Type == "" {
+			return msg
+		}
+		require.Equal(t, chat.FrameMessage, frame.Type)
+		require.NoError(t, json.Unmarshal(frame.Payload, &msg))
+		return msg
+	}
+}
+
+// batched holds, per connection, the frames of the last WebSocket message
+// that receiveFrame has not returned yet.
+var batched sync.Map
+
+// receiveFrame returns the next frame, or the zero Frame if none arrives.
+// Presence frames, which arrive whenever someone connects, are skipped.
+func receiveFrame(t *testing.T, conn *websocket.Conn, wait time.Duration) chat.Frame {
+	for {
+		frame := readFrame(t, conn, wait)
+		if frame.Type != chat.FramePresence {
+			return frame
+		}
+	}
+}
+
+// receivePresence returns the next presence frame's payload, skipping any
+// other frames.
+func receivePresence(t *testing.T, conn *websocket.Conn) chat.PresencePayload {
+	for {
+		frame := readFrame(t, conn, time.Second)
+		require.NotEmpty(t, frame.Type, "no presence frame arrived")
+		if frame.Type == chat.FramePresence {
+			var presence chat.PresencePayload
+			require.NoError(t, json.Unmarshal(frame.Payload, &presence))
+			return presence
+		}
+	}
+}
+
+// readFrame returns the next frame of any type, or the zero Frame if none
+// arrives.
+func readFrame(t *testing.T, conn *websocket.Conn, wait time.Duration) chat.Frame {
+	lines, _ := batched.Load(conn)
+	pending, _ := lines.([][]byte)
+	if len(pending) == 0 {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(wait)))
+		_, data, err := conn.ReadMessage()
+		var netErr interface{ Timeout() bool }
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return chat.Frame{}
+		}
+		require.NoError(t, err)
+		pending = bytes.Split(data, []byte("\n"))
+	}
+	batched.Store(conn, pending[1:])
+
+	var frame chat.Frame
+	require.NoError(t, json.Unmarshal(pending[0], &frame))
+	assert.Equal(t, chat.ProtocolVersion, frame.V)
+	return frame
+}
+
+// receiveError returns the payload of the next frame, which must be an
+// error answering the client frame id.
+func receiveError(t *testing.T, conn *websocket.Conn, id string) chat.ErrorPayload {
+	frame := receiveFrame(t, conn, time.Second)
+	require.Equal(t, chat.FrameError, frame.Type)
+	assert.Equal(t, id, frame.ID)
+	var payload chat.ErrorPayload
+	require.NoError(t, json.Unmarshal(frame.Payload, &payload))
+	return payload
+}
+
+func TestHub_SkipsRecipientsWhoBlockedSender(t *testing.T) {
+	dial := startHub(t, stubBlocks{blockedBy: map[int32][]int32{2: {1}}})
+	blocker, sender, other := dial(1, 1, 1), dial(2, 1, 1), dial(3, 1, 1)
+
+	send(t, sender, "from 2")
+	assert.Equal(t, "from 2", receive(t, other, time.Second))
+	assert.Equal(t, "from 2", receive(t, sender, time.Second))
+
+	// The blocker still gets other users' messages, in order, and nothing
+	// from the user they blocked.
+	send(t, other, "from 3")
+	assert.Equal(t, "from 3", receive(t, blocker, time.Second))
+	assert.Equal(t, "", receive(t, blocker, 100*time.Millisecond))
+}
+
+func TestHub_DeliversToEveryoneWhenLookupFails(t *testing.T) {
+	dial := startHub(t, stubBlocks{
+		blockedBy: map[int32][]int32{2: {1}},
+		err:       errors.New("redis down"),
+	})
+	blocker, sender := dial(1, 1, 1), dial(2, 1, 1)
+
+	send(t, sender, "from 2")
+	assert.Equal(t, "from 2", receive(t, blocker, time.Second))
+}
+
+// slowBlocks takes until the lookup times out to answer for slow senders,
+// and closes started when it begins such a lookup.
+type slowBlocks struct {
+	slow    map[int32]bool
+	started chan struct{}
+}
+
+func (s slowBlocks) BlockedBy(ctx context.Context, userID int32) ([]int32, error) {
+	if s.slow[userID] {
+		close(s.started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return nil, nil
+}
+
+func TestHub_KeepsDeliveringWhileLookupIsSlow(t *testing.T) {
+	blocks := slowBlocks{slow: map[int32]bool{2: true}, started: make(chan struct{})}
+	dial := startHub(t, blocks)
+	reader, slow, other := dial(1, 1, 1), dial(2, 1, 1), dial(3, 1, 1)
+
+	// The slow lookup holds up its sender alone: other users' messages and
+	// new connections go through meanwhile.
+	send(t, slow, "from 2")
+	<-blocks.started
+	send(t, other, "from 3")
+	assert.Equal(t, "from 3", receive(t, reader, 500*time.Millisecond))
+	dial(4, 1, 1)
+	assert.Equal(t, "from 2", receive(t, reader, 2*time.Second))
+}
+
+func TestHub_KeepsMessagesInsideOrganization(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	sender, colleague, outsider := dial(1, 1, 1), dial(2, 1, 1), dial(3, 2, 1)
+
+	send(t, sender, "org 1 only")
+	assert.Equal(t, "org 1 only", receive(t, colleague, time.Second))
+	assert.Equal(t, "", receive(t, outsider, 100*time.Millisecond))
+}
+
+func TestHub_RoutesMessagesToRoomSubscribers(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	general, random := dial(1, 1, 1), dial(2, 1, 2)
+	phone, laptop := dial(3, 1, 1), dial(3, 1, 1)
+
+	send(t, random, "random only", 2)
+	assert.Equal(t, "random only", receive(t, random, time.Second))
+
+	// Joining from one device subscribes all of the user's devices.
+	write(t, phone, chat.FrameJoin, "", chat.RoomPayload{Room: 2})
+	for _, conn := range []*websocket.Conn{phone, laptop} {
+		frame := receiveFrame(t, conn, time.Second)
+		assert.Equal(t, chat.FrameJoined, frame.Type)
+		assert.JSONEq(t, `{"room":2}`, string(frame.Payload))
+	}
+
+	send(t, random, "welcome", 2)
+	for _, conn := range []*websocket.Conn{random, phone, laptop} {
+		assert.Equal(t, "welcome", receive(t, conn, time.Second))
+	}
+
+	write(t, laptop, chat.FrameLeave, "", chat.RoomPayload{Room: 2})
+	for _, conn := range []*websocket.Conn{phone, laptop} {
+		assert.Equal(t, chat.FrameLeft, receiveFrame(t, conn, time.Second).Type)
+	}
+
+	send(t, random, "bye", 2)
+	assert.Equal(t, "bye", receive(t, random, time.Second))
+
+	// Messages are delivered in order, so the next message general and
+	// user 3 see being the one from room 1 shows that nothing sent to room 2
+	// reached them.
+	send(t, general, "general", 1)
+	for _, conn := range []*websocket.Conn{general, phone, laptop} {
+		assert.Equal(t, "general", receive(t, conn, time.Second))
+	}
+}
+
+func TestHub_DeliversDirectMessagesToEveryDevice(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	phone, laptop := dial(1, 1, 1), dial(1, 1, 1)
+	sender, bystander := dial(2, 1, 1), dial(3, 1, 1)
+
+	write(t, sender, chat.FrameSend, "", chat.SendPayload{To: 1, Content: "psst"})
+	for _, conn := range []*websocket.Conn{phone, laptop, sender} {
+		msg := receiveMessage(t, conn, time.Second)
+		assert.Equal(t, "psst", msg.Content)
+		assert.Equal(t, int32(1), msg.To)
+		assert.Equal(t, int32(2), msg.From)
+	}
+
+	// The bystander's next message is the room message sent afterwards, so
+	// the direct message never reached them.
+	send(t, sender, "hello all")
+	assert.Equal(t, "hello all", receive(t, bystander, time.Second))
+}
+
+func TestClient_AcksSendsWithTheStoredMessage(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	sender := dial(1, 1, 1)
+
+	write(t, sender, chat.FrameSend, "c-1", chat.SendPayload{Room: 1, Content: "hello"})
+
+	// The ack and the echo of the message may arrive in either order.
+	var ack chat.AckPayload
+	var msg chat.Message
+	for i := 0; i < 2; i++ {
+		frame := receiveFrame(t, sender, time.Second)
+		switch frame.Type {
+		case chat.FrameAck:
+			assert.Equal(t, "c-1", frame.ID)
+			require.NoError(t, json.Unmarshal(frame.Payload, &ack))
+		case chat.FrameMessage:
+			assert.Empty(t, frame.ID)
+			require.NoError(t, json.Unmarshal(frame.Payload, &msg))
+		default:
+			t.Fatalf("unexpected %s frame", frame.Type)
+		}
+	}
+	assert.Equal(t, int32(len("hello")), ack.MessageID)
+	assert.False(t, ack.Time.IsZero())
+	assert.Equal(t, ack.MessageID, msg.ID)
+	assert.True(t, ack.Time.Equal(msg.Time))
+}
+
+func TestClient_AnswersRejectedFramesWithErrors(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	conn := dial(1, 1, 1)
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("{not json")))
+	assert.Equal(t, chat.ErrCodeInvalidFrame, receiveError(t, conn, "").Code)
+
+	require.NoError(t, conn.WriteJSON(chat.Frame{V: chat.ProtocolVersion + 1, Type: chat.FrameSend, ID: "c-1"}))
+	assert.Equal(t, chat.ErrCodeUnsupportedVersion, receiveError(t, conn, "c-1").Code)
+
+	write(t, conn, "shout", "c-2", chat.SendPayload{Room: 1, Content: "hi"})
+	assert.Equal(t, chat.ErrCodeUnknownType, receiveError(t, conn, "c-2").Code)
+
+	write(t, conn, chat.FrameSend, "c-3", chat.SendPayload{Room: 1, Content: "  "})
+	assert.Equal(t, chat.ErrCodeInvalidPayload, receiveError(t, conn, "c-3").Code)
+
+	write(t, conn, chat.FrameSend, "c-4", chat.SendPayload{Room: missingRoom, Content: "hi"})
+	assert.Equal(t, chat.ErrCodeRoomNotFound, receiveError(t, conn, "c-4").Code)
+
+	write(t, conn, chat.FrameTyping, "c-5", chat.TypingPayload{Room: 2})
+	assert.Equal(t, chat.ErrCodeNotRoomMember, receiveError(t, conn, "c-5").Code)
+}
+
+func TestClient_RelaysTypingToOthersOnly(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	typist, reader := dial(1, 1, 1), dial(2, 1, 1)
+
+	write(t, typist, chat.FrameTyping, "", chat.TypingPayload{Room: 1})
+	frame := receiveFrame(t, reader, time.Second)
+	require.Equal(t, chat.FrameTyping, frame.Type)
+	var typing chat.TypingPayload
+	require.NoError(t, json.Unmarshal(frame.Payload, &typing))
+	assert.Equal(t, chat.TypingPayload{Room: 1, From: 1, Username: "user1"}, typing)
+
+	// The typist's next frame is its own message, not the typing notice.
+	send(t, typist, "done")
+	assert.Equal(t, "done", receive(t, typist, time.Second))
+}
+
+func TestClient_AnswersHistoryRequests(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	conn := dial(1, 1, 1)
+
+	write(t, conn, chat.FrameHistory, "h-1", chat.HistoryRequest{Room: 1})
+	frame := receiveFrame(t, conn, time.Second)
+	require.Equal(t, chat.FrameHistory, frame.Type)
+	assert.Equal(t, "h-1", frame.ID)
+
+	var history chat.HistoryPayload
+	require.NoError(t, json.Unmarshal(frame.Payload, &history))
+	require.Len(t, history.Messages, 1)
+	assert.Equal(t, chat.Message{
+		ID: 1, Room: 1, From: 1, Username: "user1", Content: "earlier", Replies: 2,
+		Reactions: []chat.ReactionCount{{Emoji: "👍", Count: 2, Users: []int32{2, 3}}},
+	}, history.Messages[0])
+}
+
+func TestClient_KeepsConnectionsThatAnswerPings(t *testing.T) {
+	dial := serveHub(t, chat.NewHub(nil, nil, chat.ConnLimits{PingPeriod: 20 * time.Millisecond, PongWait: 100 * time.Millisecond}))
+	conn := dial(1, 1, 1)
+
+	// Reading answers the server's pings.
+	frames := make(chan []byte, 16)
+	go func() {
+		defer close(frames)
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			frames <- data
+		}
+	}()
+
+	time.Sleep(300 * time.Millisecond)
+	send(t, conn, "still here")
+	for data := range frames {
+		if bytes.Contains(data, []byte("still here")) {
+			return
+		}
+	}
+	t.Fatal("connection was closed")
+}
+
+func TestClient_DropsConnectionsThatStopAnsweringPings(t *testing.T) {
+	dial := serveHub(t, chat.NewHub(nil, nil, chat.ConnLimits{PingPeriod: 20 * time.Millisecond, PongWait: 100 * time.Millisecond}))
+	conn := dial(1, 1, 1)
+
+	// Not reading leaves the pings unanswered.
+	time.Sleep(300 * time.Millisecond)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			var netErr interface{ Timeout() bool }
+			assert.False(t, errors.As(err, &netErr) && netErr.Timeout(), "connection was kept open")
+			return
+		}
+	}
+}
+
+func TestClient_ClosesConnectionsSendingOversizedFrames(t *testing.T) {
+	dial := serveHub(t, chat.NewHub(nil, nil, chat.ConnLimits{MaxMessageSize: 128}))
+	conn := dial(1, 1, 1)
+
+	send(t, conn, strings.Repeat("x", 256))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	var err error
+	for err == nil {
+		_, _, err = conn.ReadMessage()
+	}
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig), "got %v", err)
+}
+
+func TestHub_ReportsPresenceOncePerUser(t *testing.T) {
+	dial := serveHub(t, chat.NewHub(nil, nil, chat.ConnLimits{PresenceGrace: -1}))
+	observer := dial(1, 1, 1)
+	outsider := dial(9, 2, 1)
+	assert.Equal(t, chat.PresencePayload{User: 1, Online: true}, receivePresence(t, observer))
+
+	phone := dial(2, 1, 1)
+	assert.Equal(t, chat.PresencePayload{User: 2, Online: true}, receivePresence(t, observer))
+
+	// A second connection, or closing one of two, changes nothing: the next
+	// presence frame is user 2 going offline with the last one.
+	laptop := dial(2, 1, 1)
+	require.NoError(t, phone.Close())
+	require.NoError(t, laptop.Close())
+	assert.Equal(t, chat.PresencePayload{User: 2, Online: false}, receivePresence(t, observer))
+
+	// Presence stays inside the organization.
+	assert.Equal(t, chat.PresencePayload{User: 9, Online: true}, receivePresence(t, outsider))
+	frame := readFrame(t, outsider, 100*time.Millisecond)
+	assert.Empty(t, frame.Type)
+}
+
+func TestHub_KeepsUsersOnlineThroughGracePeriod(t *testing.T) {
+	hub := chat.NewHub(nil, nil, chat.ConnLimits{PresenceGrace: 200 * time.Millisecond})
+	dial := serveHub(t, hub)
+	observer := dial(1, 1, 1)
+	receivePresence(t, observer)
+
+	conn := dial(2, 1, 1)
+	assert.Equal(t, chat.PresencePayload{User: 2, Online: true}, receivePresence(t, observer))
+
+	// Reconnecting within the grace period goes unnoticed.
+	require.NoError(t, conn.Close())
+	conn = dial(2, 1, 1)
+	dial(3, 1, 1)
+	assert.Equal(t, chat.PresencePayload{User: 3, Online: true}, receivePresence(t, observer))
+	assert.Equal(t, []int32{1, 2, 3}, hub.Online(1))
+
+	require.NoError(t, conn.Close())
+	assert.Equal(t, chat.PresencePayload{User: 2, Online: false}, receivePresence(t, observer))
+	assert.Equal(t, []int32{1, 3}, hub.Online(1))
+}
+
+func TestClient_EditsAndDeletesMessages(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	author, reader := dial(1, 1, 1), dial(2, 1, 1)
+
+	write(t, author, chat.FrameEdit, "e-1", chat.EditPayload{Message: 7, Content: "fixed"})
+	var update chat.UpdatePayload
+	for _, conn := range []*websocket.Conn{author, reader} {
+		frame := receiveFrame(t, conn, time.Second)
+		if frame.Type == chat.FrameAck {
+			assert.Equal(t, "e-1", frame.ID)
+			frame = receiveFrame(t, conn, time.Second)
+		}
+		require.Equal(t, chat.FrameUpdate, frame.Type)
+		require.NoError(t, json.Unmarshal(frame.Payload, &update))
+		assert.Equal(t, int32(7), update.ID)
+		assert.Equal(t, "fixed", update.Content)
+		assert.NotNil(t, update.EditedAt)
+		assert.Nil(t, update.DeletedAt)
+	}
+
+	write(t, reader, chat.FrameDelete, "d-1", chat.DeletePayload{Message: missingMessage})
+	assert.Equal(t, chat.ErrCodeMessageNotFound, receiveError(t, reader, "d-1").Code)
+
+	write(t, author, chat.FrameDelete, "d-2", chat.DeletePayload{Message: 7})
+	frame := receiveFrame(t, reader, time.Second)
+	require.Equal(t, chat.FrameUpdate, frame.Type)
+	require.NoError(t, json.Unmarshal(frame.Payload, &update))
+	assert.Empty(t, update.Content)
+	assert.NotNil(t, update.DeletedAt)
+}
+
+func TestClient_ReadSendsReceipts(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	author, reader := dial(1, 1, 1), dial(2, 1, 1)
+
+	write(t, reader, chat.FrameRead, "r-1", chat.ReadPayload{Room: 1, Message: 5})
+	var receipt chat.ReceiptPayload
+	for _, conn := range []*websocket.Conn{author, reader} {
+		frame := receiveFrame(t, conn, time.Second)
+		if frame.Type == chat.FrameAck {
+			var ack chat.AckPayload
+			require.NoError(t, json.Unmarshal(frame.Payload, &ack))
+			assert.Equal(t, "r-1", frame.ID)
+			assert.Equal(t, int32(5), ack.MessageID)
+			frame = receiveFrame(t, conn, time.Second)
+		}
+		require.Equal(t, chat.FrameReceipt, frame.Type)
+		require.NoError(t, json.Unmarshal(frame.Payload, &receipt))
+		assert.Equal(t, chat.ReceiptPayload{Room: 1, User: 2, Message: 5}, receipt)
+	}
+
+	write(t, reader, chat.FrameRead, "r-2", chat.ReadPayload{With: 1})
+	frame := receiveFrame(t, author, time.Second)
+	require.Equal(t, chat.FrameReceipt, frame.Type)
+	receipt = chat.ReceiptPayload{}
+	require.NoError(t, json.Unmarshal(frame.Payload, &receipt))
+	assert.Equal(t, chat.ReceiptPayload{To: 1, User: 2, Message: newestMessage}, receipt)
+
+	write(t, author, chat.FrameRead, "r-3", chat.ReadPayload{Room: missingRoom})
+	assert.Equal(t, chat.ErrCodeRoomNotFound, receiveError(t, author, "r-3").Code)
+
+	write(t, author, chat.FrameRead, "r-4", chat.ReadPayload{Room: 1, With: 2})
+	assert.Equal(t, chat.ErrCodeInvalidPayload, receiveError(t, author, "r-4").Code)
+}
+
+func TestClient_RepliesUpdateThreads(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	author, reader := dial(1, 1, 1), dial(2, 1, 1)
+
+	write(t, author, chat.FrameSend, "s-1", chat.SendPayload{Parent: 5, Content: "agreed"})
+	msg := receiveMessage(t, reader, time.Second)
+	assert.Equal(t, int32(len("agreed")), msg.ID)
+	assert.Equal(t, int32(1), msg.Room)
+	assert.Equal(t, int32(5), msg.Parent)
+
+	frame := receiveFrame(t, reader, time.Second)
+	require.Equal(t, chat.FrameThread, frame.Type)
+	var thread chat.ThreadPayload
+	require.NoError(t, json.Unmarshal(frame.Payload, &thread))
+	assert.Equal(t, chat.ThreadPayload{Message: 5, Room: 1, Replies: threadReplies, Last: msg.ID}, thread)
+
+	write(t, reader, chat.FrameSend, "s-2", chat.SendPayload{Room: 1, Parent: 5, Content: "me too"})
+	assert.Equal(t, chat.ErrCodeInvalidPayload, receiveError(t, reader, "s-2").Code)
+
+	write(t, reader, chat.FrameSend, "s-3", chat.SendPayload{Parent: directMessage, Content: "me too"})
+	assert.Equal(t, chat.ErrCodeMessageNotFound, receiveError(t, reader, "s-3").Code)
+}
+
+func TestClient_ReactionsReachWhoReceivedTheMessage(t *testing.T) {
+	dial := startHub(t, stubBlocks{})
+	author, reader, other := dial(1, 1, 1), dial(2, 1, 1), dial(3, 1, 1)
+
+	// receiveReaction returns the next reaction, skipping the ack of the
+	// client's own reaction.
+	receiveReaction := func(conn *websocket.Conn) chat.ReactionPayload {
+		frame := receiveFrame(t, conn, time.Second)
+		if frame.Type == chat.FrameAck {
+			frame = receiveFrame(t, conn, time.Second)
+		}
+		require.Equal(t, chat.FrameReaction, frame.Type)
+		var reaction chat.ReactionPayload
+		require.NoError(t, json.Unmarshal(frame.Payload, &reaction))
+		return reaction
+	}
+
+	write(t, reader, chat.FrameReact, "r-1", chat.ReactPayload{Message: 7, Emoji: "🎉"})
+	for _, conn := range []*websocket.Conn{author, reader, other} {
+		assert.Equal(t, chat.ReactionPayload{Message: 7, Room: 1, User: 2, Emoji: "🎉", Added: true, Count: 2}, receiveReaction(conn))
+	}
+
+	write(t, reader, chat.FrameUnreact, "r-2", chat.ReactPayload{Message: 7, Emoji: "🎉"})
+	for _, conn := range []*websocket.Conn{author, reader, other} {
+		assert.Equal(t, chat.ReactionPayload{Message: 7, Room: 1, User: 2, Emoji: "🎉", Count: 1}, receiveReaction(conn))
+	}
+
+	// A reaction to a direct message reaches its two users only.
+	write(t, reader, chat.FrameReact, "r-3", chat.ReactPayload{Message: directMessage, Emoji: "👀"})
+	for _, conn := range []*websocket.Conn{author, reader} {
+		assert.Equal(t, chat.ReactionPayload{Message: directMessage, To: 1, User: 2, Emoji: "👀", Added: true, Count: 2}, receiveReaction(conn))
+	}
+	assert.Empty(t, receiveFrame(t, other, 200*time.Millisecond).Type)
+
+	write(t, reader, chat.FrameReact, "r-4", chat.ReactPayload{Message: missingMessage, Emoji: "👀"})
+	assert.Equal(t, chat.ErrCodeMessageNotFound, receiveError(t, reader, "r-4").Code)
+
+	write(t, reader, chat.FrameReact, "r-5", chat.ReactPayload{Message: 7})
+	assert.Equal(t, chat.ErrCodeInvalidPayload, receiveError(t, reader, "r-5").Code)
+}
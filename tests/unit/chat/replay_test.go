@@ -0,0 +1,89 @@
+package chat_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/chat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replayService replays stored, once gate is closed. The content of each
+// stored message is as long as its ID, as stubChatService has it.
+type replayService struct {
+	stubChatService
+	gate   chan struct{}
+	stored []dbCtx.ListMessagesSinceRow
+}
+
+func (s *replayService) MessagesSince(_ context.Context, _ int32, since chat.ReplayPoint, limit int32) ([]dbCtx.ListMessagesSinceRow, error) {
+	<-s.gate
+	var rows []dbCtx.ListMessagesSinceRow
+	for _, r := range s.stored {
+		if r.ID > since.AfterID && len(rows) < int(limit) {
+			rows = append(rows, r)
+		}
+	}
+	return rows, nil
+}
+
+func TestClient_ReplaysMissedMessagesBeforeLiveOnes(t *testing.T) {
+	hub := chat.NewHub(nil, nil, chat.ConnLimits{})
+	dial := serveHub(t, hub)
+	svc := &replayService{gate: make(chan struct{})}
+	for id := int32(1); id <= 150; id++ {
+		svc.stored = append(svc.stored, dbCtx.ListMessagesSinceRow{
+			ID:         id,
+			SenderID:   1,
+			SenderName: "user1",
+			RoomID:     sql.NullInt32{Int32: 1, Valid: true},
+			Content:    strings.Repeat("x", int(id)),
+		})
+	}
+	dialReplay := serveClients(t, hub, svc, &chat.ReplayPoint{})
+
+	sender := dial(1, 1, 1)
+	reader := dialReplay(2, 1, 1)
+
+	// Message 3 was stored before the replay read it, and is published
+	// while the replay runs. The reader gets it once, in the replay.
+	send(t, sender, strings.Repeat("x", 3))
+	require.Len(t, receive(t, sender, time.Second), 3)
+	close(svc.gate)
+
+	for want := int32(1); want <= 150; want++ {
+		msg := receiveMessage(t, reader, time.Second)
+		require.Equal(t, want, msg.ID, "replayed in order, across pages")
+	}
+	frame := receiveFrame(t, reader, time.Second)
+	require.Equal(t, chat.FrameReplayed, frame.Type)
+	var done chat.ReplayedPayload
+	require.NoError(t, json.Unmarshal(frame.Payload, &done))
+	assert.Equal(t, chat.ReplayedPayload{Messages: 150, Last: 150}, done)
+
+	send(t, sender, strings.Repeat("x", 151))
+	assert.Equal(t, int32(151), receiveMessage(t, reader, time.Second).ID, "live messages follow")
+	assert.Equal(t, chat.Message{}, receiveMessage(t, reader, 100*time.Millisecond))
+}
+
+func TestParseReplayPoint(t *testing.T) {
+	point, err := chat.ParseReplayPoint("42")
+	require.NoError(t, err)
+	assert.Equal(t, chat.ReplayPoint{AfterID: 42}, point)
+
+	point, err = chat.ParseReplayPoint("2026-01-02T03:04:05Z")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), point.AfterTime)
+	assert.Zero(t, point.AfterID)
+
+	for _, bad := range []string{"-1", "yesterday", "2026-01-02"} {
+		_, err := chat.ParseReplayPoint(bad)
+		assert.Error(t, err, bad)
+	}
+}
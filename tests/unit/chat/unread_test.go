@@ -0,0 +1,54 @@
+package chat_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"example.com/api/internal/services/chat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisUnreadCounter(t *testing.T) {
+	client := testRedis(t)
+	ctx := context.Background()
+	prefix := "test:" + strconv.FormatInt(time.Now().UnixNano(), 10) + ":"
+	counter := chat.NewRedisUnreadCounter(client, prefix, time.Minute)
+	t.Cleanup(func() {
+		keys, _ := client.Keys(ctx, prefix+"*").Result()
+		if len(keys) > 0 {
+			client.Del(ctx, keys...)
+		}
+	})
+
+	_, ok, err := counter.Load(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.False(t, ok, "no counts before they are stored")
+
+	// Users whose counts are not stored are left alone, rather than start
+	// with counts that miss everything before.
+	require.NoError(t, counter.Increment(ctx, 1, []int32{2, 3}, "room:1"))
+	_, ok, err = counter.Load(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, counter.Store(ctx, 1, 2, map[string]int64{}))
+	counts, ok, err := counter.Load(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, ok, "stored counts exist even when empty")
+	assert.Empty(t, counts)
+
+	require.NoError(t, counter.Store(ctx, 1, 2, map[string]int64{"room:1": 3, "user:4": 1}))
+	require.NoError(t, counter.Increment(ctx, 1, []int32{2, 3}, "room:1"))
+	require.NoError(t, counter.Increment(ctx, 1, []int32{2}, "room:5"))
+	counts, ok, err = counter.Load(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]int64{"room:1": 4, "room:5": 1, "user:4": 1}, counts)
+
+	ttl, err := client.TTL(ctx, prefix+"unread:org:1:user:2").Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+}
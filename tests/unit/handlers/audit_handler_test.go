@@ -0,0 +1,87 @@
+package handlers_tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"example.com/api/internal/api/handlers"
+	dto "example.com/api/internal/contracts"
+	dbCtx "example.com/api/internal/repository/db"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuditHandlerTestSuite struct {
+	suite.Suite
+	serviceManager *mocks.MockServiceManager
+	auditService   *mocks.MockAuditService
+	handler        *handlers.AuditHandler
+	ctx            *gin.Context
+	recorder       *httptest.ResponseRecorder
+}
+
+func (suite *AuditHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	suite.serviceManager = mocks.NewMockServiceManager(suite.T())
+	suite.auditService = mocks.NewMockAuditService(suite.T())
+	suite.handler = handlers.NewAuditHandler(suite.serviceManager, mocks.NewMockLogger(suite.T()))
+	suite.recorder = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.recorder)
+}
+
+func (suite *AuditHandlerTestSuite) newListRequest(query string) {
+	req, _ := http.NewRequest(http.MethodGet, "/api/admin/audit-events?"+query, nil)
+	suite.ctx.Request = req
+}
+
+func (suite *AuditHandlerTestSuite) TestList_Filters() {
+	suite.newListRequest("actorId=7&action=user.updated&since=2025-07-01T00:00:00Z")
+
+	suite.serviceManager.EXPECT().Audit().Return(suite.auditService)
+	suite.auditService.EXPECT().List(mock.Anything, mock.MatchedBy(func(f dto.AuditEventFilter) bool {
+		return f.ActorID != nil && *f.ActorID == 7 &&
+			f.Action == "user.updated" &&
+			f.Since != nil && f.Since.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)) &&
+			f.TargetID == nil &&
+			f.Limit == 50
+	})).Return([]dbCtx.AuditEvent{{
+		ID:         1,
+		ActorID:    sql.NullInt32{Int32: 7, Valid: true},
+		Action:     "user.updated",
+		TargetType: "user",
+		TargetID:   sql.NullInt32{Int32: 3, Valid: true},
+		Before:     json.RawMessage(`{"fullName":"Old"}`),
+		After:      json.RawMessage(`{"fullName":"New"}`),
+		Metadata:   json.RawMessage(`{}`),
+	}}, nil).Once()
+
+	suite.handler.List(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"before":{"fullName":"Old"}`)
+	suite.Contains(suite.recorder.Body.String(), `"targetId":3`)
+}
+
+func (suite *AuditHandlerTestSuite) TestList_InvalidFilter() {
+	for _, query := range []string{"limit=1000", "actorId=abc", "since=yesterday"} {
+		suite.Run(query, func() {
+			suite.SetupTest()
+			suite.newListRequest(query)
+
+			suite.handler.List(suite.ctx)
+
+			suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+		})
+	}
+}
+
+func TestAuditHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditHandlerTestSuite))
+}
@@ -0,0 +1,264 @@
+package handlers_tests
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"example.com/api/internal/api/handlers"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChatHandlerTestSuite struct {
+	suite.Suite
+	serviceManager *mocks.MockServiceManager
+	chatService    *mocks.MockChatService
+	handler        *handlers.ChatHandler
+	ctx            *gin.Context
+	recorder       *httptest.ResponseRecorder
+}
+
+func (suite *ChatHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	hub := chat.NewHub(nil, nil, chat.ConnLimits{})
+	go hub.Run()
+
+	suite.serviceManager = mocks.NewMockServiceManager(suite.T())
+	suite.chatService = mocks.NewMockChatService(suite.T())
+	suite.handler = handlers.NewChatHandler(hub, suite.serviceManager, mocks.NewMockLogger(suite.T()))
+	suite.recorder = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.recorder)
+}
+
+// newRequest builds a request from user 1 acting in organization 7.
+func (suite *ChatHandlerTestSuite) newRequest(method, url, body string, params gin.Params) {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	suite.ctx.Request = req.WithContext(tenant.WithID(req.Context(), 7))
+	suite.ctx.Params = params
+	suite.ctx.Set("user_id", "1")
+}
+
+func (suite *ChatHandlerTestSuite) TestHandleWebSocket_InvalidSince() {
+	suite.newRequest(http.MethodGet, "/api/chat/ws?since=yesterday", "", nil)
+
+	suite.handler.HandleWebSocket(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestCreateRoom() {
+	suite.newRequest(http.MethodPost, "/api/chat/rooms", `{"name":"random"}`, nil)
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().CreateRoom(mock.Anything, int32(1), "random").
+		Return(dbCtx.ChatRoom{ID: 3, OrganizationID: 7, Name: "random"}, nil).Once()
+
+	suite.handler.CreateRoom(suite.ctx)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"joined":true`)
+}
+
+func (suite *ChatHandlerTestSuite) TestCreateRoom_NameTaken() {
+	suite.newRequest(http.MethodPost, "/api/chat/rooms", `{"name":"random"}`, nil)
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().CreateRoom(mock.Anything, int32(1), "random").
+		Return(dbCtx.ChatRoom{}, chat.ErrRoomNameTaken).Once()
+
+	suite.handler.CreateRoom(suite.ctx)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestJoinRoom_NotFound() {
+	suite.newRequest(http.MethodPost, "/api/chat/rooms/3/join", "", gin.Params{{Key: "id", Value: "3"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().JoinRoom(mock.Anything, int32(1), int32(3)).Return(chat.ErrRoomNotFound).Once()
+
+	suite.handler.JoinRoom(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestLeaveRoom_NotMember() {
+	suite.newRequest(http.MethodPost, "/api/chat/rooms/3/leave", "", gin.Params{{Key: "id", Value: "3"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().LeaveRoom(mock.Anything, int32(1), int32(3)).Return(chat.ErrNotRoomMember).Once()
+
+	suite.handler.LeaveRoom(suite.ctx)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestGetMessageHistory_RequiresRoom() {
+	suite.newRequest(http.MethodGet, "/api/chat/messages", "", nil)
+
+	suite.handler.GetMessageHistory(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestListConversations() {
+	suite.newRequest(http.MethodGet, "/api/chat/conversations", "", nil)
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().Conversations(mock.Anything, int32(1), int32(50), int32(0)).
+		Return([]dbCtx.ListConversationsRow{{PeerID: 2, PeerUsername: "bob", LastContent: "hi", UnreadCount: 3}}, nil).Once()
+
+	suite.handler.ListConversations(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"unreadCount":3`)
+	suite.Contains(suite.recorder.Body.String(), `"username":"bob"`)
+}
+
+func (suite *ChatHandlerTestSuite) TestEditMessage() {
+	suite.newRequest(http.MethodPatch, "/api/chat/messages/5", `{"content":"fixed"}`, gin.Params{{Key: "id", Value: "5"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().EditMessage(mock.Anything, int32(1), int32(5), "fixed").
+		Return(dbCtx.Message{ID: 5, SenderID: 1, OrganizationID: 7, Content: "fixed"}, nil).Once()
+
+	suite.handler.EditMessage(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"content":"fixed"`)
+}
+
+func (suite *ChatHandlerTestSuite) TestDeleteMessage_EditWindowClosed() {
+	suite.newRequest(http.MethodDelete, "/api/chat/messages/5", "", gin.Params{{Key: "id", Value: "5"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().DeleteMessage(mock.Anything, int32(1), int32(5)).
+		Return(dbCtx.Message{}, chat.ErrEditWindowClosed).Once()
+
+	suite.handler.DeleteMessage(suite.ctx)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestPresence_NoOneOnline() {
+	suite.newRequest(http.MethodGet, "/api/chat/presence", "", nil)
+
+	suite.handler.Presence(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"online":[]`)
+}
+
+func (suite *ChatHandlerTestSuite) TestMarkRead() {
+	suite.newRequest(http.MethodPost, "/api/chat/read", `{"roomId":3,"messageId":12}`, nil)
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().MarkRoomRead(mock.Anything, int32(1), int32(3), int32(12)).Return(int32(12), nil).Once()
+
+	suite.handler.MarkRead(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"lastReadId":12`)
+}
+
+func (suite *ChatHandlerTestSuite) TestMarkRead_RequiresOneTarget() {
+	suite.newRequest(http.MethodPost, "/api/chat/read", `{"roomId":3,"peerId":2}`, nil)
+
+	suite.handler.MarkRead(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestUnread() {
+	suite.newRequest(http.MethodGet, "/api/chat/unread", "", nil)
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().UnreadCounts(mock.Anything, int32(1)).Return(chat.UnreadCounts{
+		Rooms:         map[int32]int64{4: 0, 3: 2},
+		Conversations: map[int32]int64{2: 1},
+	}, nil).Once()
+
+	suite.handler.Unread(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	body := suite.recorder.Body.String()
+	suite.Contains(body, `"rooms":[{"roomId":3,"unreadCount":2},{"roomId":4,"unreadCount":0}]`)
+	suite.Contains(body, `"conversations":[{"peerId":2,"unreadCount":1}]`)
+	suite.Contains(body, `"total":3`)
+}
+
+func (suite *ChatHandlerTestSuite) TestGetThread_NotMember() {
+	suite.newRequest(http.MethodGet, "/api/chat/messages/5/thread", "", gin.Params{{Key: "id", Value: "5"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().Thread(mock.Anything, int32(1), int32(5), int32(50), int32(0)).
+		Return(nil, chat.ErrNotRoomMember).Once()
+
+	suite.handler.GetThread(suite.ctx)
+
+	suite.Equal(http.StatusForbidden, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestReact() {
+	suite.newRequest(http.MethodPost, "/api/chat/messages/5/reactions", `{"emoji":"👍"}`, gin.Params{{Key: "id", Value: "5"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().React(mock.Anything, int32(1), int32(5), "👍").Return(chat.Reaction{
+		Message: dbCtx.Message{ID: 5, SenderID: 2, OrganizationID: 7, RoomID: sql.NullInt32{Int32: 3, Valid: true}},
+		Emoji:   "👍",
+		Count:   2,
+	}, nil).Once()
+
+	suite.handler.React(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `{"messageId":5,"emoji":"👍","count":2}`)
+}
+
+func (suite *ChatHandlerTestSuite) TestReact_InvalidEmoji() {
+	suite.newRequest(http.MethodPost, "/api/chat/messages/5/reactions", `{"emoji":"thumbs up"}`, gin.Params{{Key: "id", Value: "5"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().React(mock.Anything, int32(1), int32(5), "thumbs up").
+		Return(chat.Reaction{}, chat.ErrInvalidEmoji).Once()
+
+	suite.handler.React(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestUnreact_NotFound() {
+	suite.newRequest(http.MethodDelete, "/api/chat/messages/5/reactions/%F0%9F%91%8D", "", gin.Params{
+		{Key: "id", Value: "5"},
+		{Key: "emoji", Value: "👍"},
+	})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().Unreact(mock.Anything, int32(1), int32(5), "👍").
+		Return(chat.Reaction{}, chat.ErrMessageNotFound).Once()
+
+	suite.handler.Unreact(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *ChatHandlerTestSuite) TestMessageReactions() {
+	suite.newRequest(http.MethodGet, "/api/chat/messages/5/reactions", "", gin.Params{{Key: "id", Value: "5"}})
+	suite.serviceManager.EXPECT().Chat().Return(suite.chatService)
+	suite.chatService.EXPECT().Reactions(mock.Anything, int32(1), int32(5)).Return([]dbCtx.ListMessageReactionsRow{
+		{Emoji: "🎉", UserID: 2, Username: "bob"},
+		{Emoji: "👍", UserID: 3, Username: "carol"},
+		{Emoji: "🎉", UserID: 1, Username: "alice"},
+	}, nil).Once()
+
+	suite.handler.MessageReactions(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	body := suite.recorder.Body.String()
+	suite.Contains(body, `{"emoji":"🎉","count":2,"users":[{"id":2,"username":"bob"`)
+	suite.Contains(body, `{"id":1,"username":"alice"`)
+	suite.Contains(body, `{"emoji":"👍","count":1,"users":[{"id":3,"username":"carol"`)
+	suite.Less(strings.Index(body, "🎉"), strings.Index(body, "👍"))
+}
+
+func TestChatHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ChatHandlerTestSuite))
+}
@@ -0,0 +1,165 @@
+package handlers_tests
+
+import (
+	"bytes"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"example.com/api/internal/api/handlers"
+	dto "example.com/api/internal/contracts"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type OrganizationHandlerTestSuite struct {
+	suite.Suite
+	serviceManager *mocks.MockServiceManager
+	orgService     *mocks.MockOrganizationService
+	invService     *mocks.MockInvitationService
+	handler        *handlers.OrganizationHandler
+	ctx            *gin.Context
+	recorder       *httptest.ResponseRecorder
+}
+
+func (suite *OrganizationHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	suite.serviceManager = mocks.NewMockServiceManager(suite.T())
+	suite.orgService = mocks.NewMockOrganizationService(suite.T())
+	suite.invService = mocks.NewMockInvitationService(suite.T())
+	suite.handler = handlers.NewOrganizationHandler(suite.serviceManager, mocks.NewMockLogger(suite.T()))
+	suite.recorder = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.recorder)
+}
+
+// newRequest builds a request from user 1 acting in organization 7 with
+// role.
+func (suite *OrganizationHandlerTestSuite) newRequest(method, url, body, role string, params gin.Params) {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	suite.ctx.Request = req.WithContext(tenant.WithID(req.Context(), 7))
+	suite.ctx.Params = params
+	suite.ctx.Set("user_id", "1")
+	suite.ctx.Set("org_role", role)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestCreate() {
+	suite.newRequest(http.MethodPost, "/api/organizations", `{"slug":"acme","name":"Acme"}`, "", nil)
+	suite.serviceManager.EXPECT().Organization().Return(suite.orgService)
+	suite.orgService.EXPECT().Create(mock.Anything, int32(1), dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"}).
+		Return(dbCtx.Organization{ID: 7, Slug: "acme", Name: "Acme"}, nil).Once()
+
+	suite.handler.Create(suite.ctx)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"role":"owner"`)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestCreate_SlugTaken() {
+	suite.newRequest(http.MethodPost, "/api/organizations", `{"slug":"acme","name":"Acme"}`, "", nil)
+	suite.serviceManager.EXPECT().Organization().Return(suite.orgService)
+	suite.orgService.EXPECT().Create(mock.Anything, int32(1), mock.Anything).
+		Return(dbCtx.Organization{}, services.ErrOrganizationSlugTaken).Once()
+
+	suite.handler.Create(suite.ctx)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestCreate_InvalidSlug() {
+	for _, slug := range []string{"Acme", "a.b", "-acme", "a"} {
+		suite.SetupTest()
+		suite.newRequest(http.MethodPost, "/api/organizations", `{"slug":"`+slug+`","name":"Acme"}`, "", nil)
+
+		suite.handler.Create(suite.ctx)
+
+		suite.Equal(http.StatusBadRequest, suite.recorder.Code, slug)
+	}
+}
+
+func (suite *OrganizationHandlerTestSuite) TestSetMemberRole_UsesActiveOrganization() {
+	suite.newRequest(http.MethodPut, "/api/organization/members/2", `{"role":"admin"}`, services.OrgRoleOwner,
+		gin.Params{{Key: "id", Value: "2"}})
+	suite.serviceManager.EXPECT().Organization().Return(suite.orgService)
+	suite.orgService.EXPECT().SetMemberRole(mock.Anything, int32(7), services.OrgRoleOwner, int32(2), services.OrgRoleAdmin).
+		Return(dbCtx.OrganizationMember{OrganizationID: 7, UserID: 2, Role: services.OrgRoleAdmin}, nil).Once()
+
+	suite.handler.SetMemberRole(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestRemoveMember_Errors() {
+	cases := map[error]int{
+		services.ErrOrgRoleForbidden:      http.StatusForbidden,
+		services.ErrLastOwner:             http.StatusConflict,
+		services.ErrNotOrganizationMember: http.StatusNotFound,
+	}
+	for err, status := range cases {
+		suite.SetupTest()
+		suite.newRequest(http.MethodDelete, "/api/organization/members/2", "", services.OrgRoleMember,
+			gin.Params{{Key: "id", Value: "2"}})
+		suite.serviceManager.EXPECT().Organization().Return(suite.orgService)
+		suite.orgService.EXPECT().RemoveMember(mock.Anything, int32(7), services.OrgRoleMember, int32(1), int32(2)).
+			Return(err).Once()
+
+		suite.handler.RemoveMember(suite.ctx)
+
+		suite.Equal(status, suite.recorder.Code, err.Error())
+	}
+}
+
+func (suite *OrganizationHandlerTestSuite) TestCreateInvitation_HidesToken() {
+	suite.newRequest(http.MethodPost, "/api/invitations", `{"email":"new@example.com","role":"admin"}`, services.OrgRoleAdmin, nil)
+	suite.serviceManager.EXPECT().Invitation().Return(suite.invService)
+	suite.invService.EXPECT().Create(mock.Anything, int32(7), int32(1), services.OrgRoleAdmin,
+		dto.CreateInvitationReq{Email: "new@example.com", Role: "admin"}).
+		Return(dbCtx.Invitation{ID: 3, Email: "new@example.com", Role: "admin", TokenHash: "secret-hash", ExpiresAt: time.Now().Add(time.Hour)}, nil).Once()
+
+	suite.handler.CreateInvitation(suite.ctx)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"status":"pending"`)
+	suite.NotContains(suite.recorder.Body.String(), "secret-hash")
+}
+
+func (suite *OrganizationHandlerTestSuite) TestListInvitations_Status() {
+	suite.newRequest(http.MethodGet, "/api/invitations", "", services.OrgRoleOwner, nil)
+	past := time.Now().Add(-time.Hour)
+	suite.serviceManager.EXPECT().Invitation().Return(suite.invService)
+	suite.invService.EXPECT().List(mock.Anything, int32(7), dto.ListInvitationsParams{Limit: 50}).Return([]dbCtx.Invitation{
+		{ID: 1, ExpiresAt: past},
+		{ID: 2, ExpiresAt: past, RevokedAt: sql.NullTime{Time: past, Valid: true}},
+		{ID: 3, ExpiresAt: past, ConsumedAt: sql.NullTime{Time: past, Valid: true}},
+	}, nil).Once()
+
+	suite.handler.ListInvitations(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	body := suite.recorder.Body.String()
+	suite.Contains(body, `"status":"expired"`)
+	suite.Contains(body, `"status":"revoked"`)
+	suite.Contains(body, `"status":"accepted"`)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestRevokeInvitation_NotPending() {
+	suite.newRequest(http.MethodDelete, "/api/invitations/3", "", services.OrgRoleOwner, gin.Params{{Key: "id", Value: "3"}})
+	suite.serviceManager.EXPECT().Invitation().Return(suite.invService)
+	suite.invService.EXPECT().Revoke(mock.Anything, int32(7), int32(3)).Return(services.ErrInvitationNotFound).Once()
+
+	suite.handler.RevokeInvitation(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func TestOrganizationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationHandlerTestSuite))
+}
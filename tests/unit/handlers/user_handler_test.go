@@ -1,18 +1,27 @@
 package handlers_tests
 
 import (
+	"archive/zip"
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"example.com/api/config"
 	"example.com/api/internal/api/handlers"
 	"example.com/api/internal/api/responses"
+	"example.com/api/internal/api/validation"
 	dto "example.com/api/internal/contracts"
 	contracts "example.com/api/internal/contracts/errors"
 	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
 	"example.com/api/pkg/logging"
 	mocks "example.com/api/tests/unit/mocks/services"
 	"github.com/gin-gonic/gin"
@@ -24,6 +33,12 @@ type UserHandlerTestSuite struct {
 	suite.Suite
 	serviceManager *mocks.MockServiceManager
 	userService    *mocks.MockUserService
+	avatarService  *mocks.MockAvatarService
+	emailChange    *mocks.MockEmailChangeService
+	privacy        *mocks.MockPrivacyService
+	block          *mocks.MockBlockService
+	relationship   *mocks.MockRelationshipService
+	hash           *mocks.MockHashService
 	logger         *mocks.MockLogger
 	handler        *handlers.UserHandler
 	ctx            *gin.Context
@@ -35,6 +50,12 @@ func (suite *UserHandlerTestSuite) SetupTest() {
 
 	suite.serviceManager = mocks.NewMockServiceManager(suite.T())
 	suite.userService = mocks.NewMockUserService(suite.T())
+	suite.avatarService = mocks.NewMockAvatarService(suite.T())
+	suite.emailChange = mocks.NewMockEmailChangeService(suite.T())
+	suite.privacy = mocks.NewMockPrivacyService(suite.T())
+	suite.block = mocks.NewMockBlockService(suite.T())
+	suite.relationship = mocks.NewMockRelationshipService(suite.T())
+	suite.hash = mocks.NewMockHashService(suite.T())
 	suite.logger = mocks.NewMockLogger(suite.T())
 	suite.handler = handlers.NewUserHandler(suite.serviceManager, suite.logger)
 	suite.recorder = httptest.NewRecorder()
@@ -126,6 +147,27 @@ func (suite *UserHandlerTestSuite) TestCreate_EmailConflict() {
 	suite.Equal(http.StatusConflict, suite.recorder.Code)
 }
 
+func (suite *UserHandlerTestSuite) TestCreate_InvalidUsername() {
+	reqBody := []byte(`{
+		"username": "pаypal",
+		"email": "new@example.com",
+		"password": "password",
+		"fullName": "Test User"
+	}`)
+	req, _ := http.NewRequest(http.MethodPost, "/users", bytes.NewBuffer(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	suite.ctx.Request = req
+
+	expectedErr := &contracts.InvalidUsernameError{Username: "pаypal", Reason: "mixes Latin with Cyrillic or Greek letters"}
+	suite.serviceManager.EXPECT().User().Return(suite.userService).Once()
+	suite.userService.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, expectedErr).Once()
+
+	suite.handler.Create(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), "Invalid username")
+}
+
 func (suite *UserHandlerTestSuite) TestCreate_InternalError() {
 	reqBody := []byte(`{
 		"username": "user",
@@ -277,6 +319,60 @@ func (suite *UserHandlerTestSuite) TestGetByID_Success() {
 	suite.Equal(expectedUser.Username, actualUser.Username)
 	suite.Equal(expectedUser.Email, actualUser.Email)
 }
+func (suite *UserHandlerTestSuite) newBatchGetRequest(body string) {
+	req, _ := http.NewRequest(http.MethodPost, "/users/batch-get", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	suite.ctx.Request = req
+}
+
+func (suite *UserHandlerTestSuite) TestBatchGet_Success() {
+	suite.newBatchGetRequest(`{"ids":[3,1,9]}`)
+
+	suite.serviceManager.EXPECT().User().Return(suite.userService).Once()
+	suite.userService.EXPECT().GetByIDs(mock.Anything, []int32{3, 1, 9}).Return(
+		[]dbCtx.User{{ID: 3, Username: "third"}, {ID: 1, Username: "first"}},
+		[]int32{9},
+		nil,
+	).Once()
+
+	suite.handler.BatchGet(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+
+	var response struct {
+		Data dto.BatchGetUsersResponse `json:"data"`
+	}
+	suite.NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	suite.Require().Len(response.Data.Users, 2)
+	suite.Equal(int32(3), response.Data.Users[0].ID)
+	suite.Equal(int32(1), response.Data.Users[1].ID)
+	suite.Equal([]int32{9}, response.Data.Missing)
+}
+
+func (suite *UserHandlerTestSuite) TestBatchGet_InvalidRequest() {
+	tooMany := make([]int32, dto.MaxBatchGetUsers+1)
+	for i := range tooMany {
+		tooMany[i] = int32(i + 1)
+	}
+	tooManyBody, _ := json.Marshal(map[string]any{"ids": tooMany})
+
+	for name, body := range map[string]string{
+		"Empty":    `{"ids":[]}`,
+		"Missing":  `{}`,
+		"Zero ID":  `{"ids":[1,0]}`,
+		"Too Many": string(tooManyBody),
+	} {
+		suite.Run(name, func() {
+			suite.SetupTest()
+			suite.newBatchGetRequest(body)
+
+			suite.handler.BatchGet(suite.ctx)
+
+			suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+		})
+	}
+}
+
 func (suite *UserHandlerTestSuite) TestDelete_InvalidID() {
 	// Setup invalid ID (non-integer)
 	suite.ctx.Params = []gin.Param{{Key: "id", Value: "abc"}}
@@ -354,6 +450,702 @@ func (suite *UserHandlerTestSuite) TestDelete_Success() {
 	suite.Empty(suite.recorder.Body.Bytes())
 }
 
+func (suite *UserHandlerTestSuite) newPatchRequest(contentType, body string) {
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+	req, _ := http.NewRequest(http.MethodPatch, "/users/1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	suite.ctx.Request = req
+}
+
+func (suite *UserHandlerTestSuite) expectCurrentUser() {
+	suite.serviceManager.EXPECT().User().Return(suite.userService)
+	suite.userService.EXPECT().GetByID(mock.Anything, int32(1)).Return(&dbCtx.User{
+		ID:       1,
+		Username: "olduser",
+		Email:    "old@example.com",
+		FullName: "Old Name",
+	}, nil).Once()
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_MergePatchNullClearsField() {
+	suite.newPatchRequest("application/merge-patch+json", `{"fullName": null, "username": "newuser"}`)
+	suite.expectCurrentUser()
+
+	suite.userService.EXPECT().UpdatePartial(
+		mock.Anything,
+		mock.MatchedBy(func(req dto.UpdateUserPartialReq) bool {
+			return req.ID == 1 &&
+				req.Username != nil && *req.Username == "newuser" &&
+				req.FullName != nil && *req.FullName == "" &&
+				req.Email == nil &&
+				req.Password == nil
+		}),
+	).Return(&dbCtx.User{ID: 1, Username: "newuser", Email: "old@example.com"}, nil).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_JSONPatch() {
+	suite.newPatchRequest("application/json-patch+json", `[
+		{"op": "test", "path": "/email", "value": "old@example.com"},
+		{"op": "replace", "path": "/email", "value": "new@example.com"}
+	]`)
+	suite.expectCurrentUser()
+
+	suite.userService.EXPECT().UpdatePartial(
+		mock.Anything,
+		mock.MatchedBy(func(req dto.UpdateUserPartialReq) bool {
+			return req.Email != nil && *req.Email == "new@example.com" &&
+				req.Username == nil &&
+				req.FullName == nil
+		}),
+	).Return(&dbCtx.User{ID: 1, Email: "new@example.com"}, nil).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_JSONPatchTestFailed() {
+	suite.newPatchRequest("application/json-patch+json", `[
+		{"op": "test", "path": "/email", "value": "other@example.com"},
+		{"op": "replace", "path": "/email", "value": "new@example.com"}
+	]`)
+	suite.expectCurrentUser()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusConflict, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_ReadOnlyField() {
+	suite.newPatchRequest("application/json-patch+json", `[{"op": "replace", "path": "/id", "value": 2}]`)
+	suite.expectCurrentUser()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusUnprocessableEntity, suite.recorder.Code)
+
+	var response responses.BaseResponse
+	err := json.Unmarshal(suite.recorder.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("Patch modifies a read-only field", response.Message)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_ValidationAfterPatch() {
+	suite.newPatchRequest("application/merge-patch+json", `{"email": null}`)
+	suite.expectCurrentUser()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+
+	var response responses.BaseResponse
+	err := json.Unmarshal(suite.recorder.Body.Bytes(), &response)
+	suite.NoError(err)
+	suite.Equal("Invalid patched document", response.Message)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_UnsupportedMediaType() {
+	suite.newPatchRequest("text/plain", `fullName=x`)
+	suite.expectCurrentUser()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusUnsupportedMediaType, suite.recorder.Code)
+	suite.NotEmpty(suite.recorder.Header().Get("Accept-Patch"))
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_ProfileFields() {
+	validation.SetAttributeSchema(config.ProfileConfig{
+		Attributes: []config.AttributeConfig{{Name: "company", Type: "string", MaxLength: 10}},
+	})
+	defer validation.SetAttributeSchema(config.ProfileConfig{})
+
+	suite.newPatchRequest("application/merge-patch+json", `{"bio": "Hello", "timezone": "Europe/Berlin", "attributes": {"company": "Acme"}}`)
+	suite.expectCurrentUser()
+
+	suite.userService.EXPECT().UpdatePartial(
+		mock.Anything,
+		mock.MatchedBy(func(req dto.UpdateUserPartialReq) bool {
+			return req.Bio.Set && *req.Bio.Value == "Hello" &&
+				req.Timezone.Set && *req.Timezone.Value == "Europe/Berlin" &&
+				!req.Locale.Set &&
+				req.Attributes["company"] == "Acme"
+		}),
+	).Return(&dbCtx.User{ID: 1}, nil).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_InvalidAttribute() {
+	validation.SetAttributeSchema(config.ProfileConfig{
+		Attributes: []config.AttributeConfig{{Name: "company", Type: "string", MaxLength: 10}},
+	})
+	defer validation.SetAttributeSchema(config.ProfileConfig{})
+
+	suite.newPatchRequest("application/merge-patch+json", `{"attributes": {"company": "A very long company name"}}`)
+	suite.expectCurrentUser()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), "attribute 'company' must be at most 10 characters")
+}
+
+func (suite *UserHandlerTestSuite) newAvatarRequest(field, filename string, content []byte) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile(field, filename)
+	part.Write(content)
+	writer.Close()
+
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+	req, _ := http.NewRequest(http.MethodPut, "/users/1/avatar", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	suite.ctx.Request = req
+}
+
+func (suite *UserHandlerTestSuite) TestUploadAvatar_Success() {
+	suite.newAvatarRequest("avatar", "me.png", []byte("image-bytes"))
+
+	suite.serviceManager.EXPECT().Avatar().Return(suite.avatarService)
+	suite.avatarService.EXPECT().Upload(
+		mock.Anything,
+		int32(1),
+		mock.MatchedBy(func(r io.Reader) bool {
+			data, _ := io.ReadAll(r)
+			return string(data) == "image-bytes"
+		}),
+	).Return(&dbCtx.User{
+		ID:        1,
+		AvatarUrl: sql.NullString{String: "/api/users/1/avatar?v=abc", Valid: true},
+	}, nil).Once()
+
+	suite.handler.UploadAvatar(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"avatarUrl":"/api/users/1/avatar?v=abc"`)
+}
+
+func (suite *UserHandlerTestSuite) TestUploadAvatar_MissingFile() {
+	suite.newAvatarRequest("picture", "me.png", []byte("image-bytes"))
+
+	suite.handler.UploadAvatar(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUploadAvatar_Errors() {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"TooLarge", &contracts.ImageTooLargeError{Limit: 10}, http.StatusRequestEntityTooLarge},
+		{"UnsupportedType", &contracts.UnsupportedImageError{ContentType: "text/plain"}, http.StatusUnsupportedMediaType},
+		{"Dimensions", &contracts.ImageDimensionsError{Width: 9000, Height: 10, Limit: 4096}, http.StatusUnprocessableEntity},
+		{"UserNotFound", errors.New("user not found"), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.SetupTest()
+			suite.newAvatarRequest("avatar", "me.png", []byte("image-bytes"))
+
+			suite.serviceManager.EXPECT().Avatar().Return(suite.avatarService)
+			suite.avatarService.EXPECT().Upload(mock.Anything, int32(1), mock.Anything).Return(nil, tt.err).Once()
+
+			suite.handler.UploadAvatar(suite.ctx)
+
+			suite.Equal(tt.status, suite.recorder.Code)
+		})
+	}
+}
+
+func (suite *UserHandlerTestSuite) TestGetAvatar_CachingHeaders() {
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+	req, _ := http.NewRequest(http.MethodGet, "/users/1/avatar?size=small", nil)
+	suite.ctx.Request = req
+
+	modified := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	suite.serviceManager.EXPECT().Avatar().Return(suite.avatarService)
+	suite.avatarService.EXPECT().Open(mock.Anything, int32(1), "small").Return(&services.Avatar{
+		Body:         io.NopCloser(bytes.NewBufferString("png-bytes")),
+		Size:         9,
+		ContentType:  "image/png",
+		LastModified: modified,
+		ETag:         `"abc-small"`,
+		MaxAge:       3600,
+	}, nil).Once()
+
+	suite.handler.GetAvatar(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("png-bytes", suite.recorder.Body.String())
+	suite.Equal("image/png", suite.recorder.Header().Get("Content-Type"))
+	suite.Equal(`"abc-small"`, suite.recorder.Header().Get("ETag"))
+	suite.Equal("private, max-age=3600", suite.recorder.Header().Get("Cache-Control"))
+	suite.Equal(modified.Format(http.TimeFormat), suite.recorder.Header().Get("Last-Modified"))
+}
+
+func (suite *UserHandlerTestSuite) TestGetAvatar_NotModified() {
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+	req, _ := http.NewRequest(http.MethodGet, "/users/1/avatar", nil)
+	req.Header.Set("If-None-Match", `"abc-original"`)
+	suite.ctx.Request = req
+
+	suite.serviceManager.EXPECT().Avatar().Return(suite.avatarService)
+	suite.avatarService.EXPECT().Open(mock.Anything, int32(1), "").Return(&services.Avatar{
+		Body:        io.NopCloser(bytes.NewBufferString("png-bytes")),
+		Size:        9,
+		ContentType: "image/png",
+		ETag:        `"abc-original"`,
+	}, nil).Once()
+
+	suite.handler.GetAvatar(suite.ctx)
+
+	suite.Equal(http.StatusNotModified, suite.ctx.Writer.Status())
+	suite.Empty(suite.recorder.Body.String())
+}
+
+func (suite *UserHandlerTestSuite) TestGetAvatar_NotFound() {
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+	req, _ := http.NewRequest(http.MethodGet, "/users/1/avatar", nil)
+	suite.ctx.Request = req
+
+	suite.serviceManager.EXPECT().Avatar().Return(suite.avatarService)
+	suite.avatarService.EXPECT().Open(mock.Anything, int32(1), "").Return(nil, services.ErrAvatarNotFound).Once()
+
+	suite.handler.GetAvatar(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) newTokenRequest(path, body string) {
+	req, _ := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	suite.ctx.Request = req
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_EmailPendingConfirmation() {
+	suite.newPatchRequest("application/merge-patch+json", `{"email": "new@example.com"}`)
+	suite.expectCurrentUser()
+
+	suite.userService.EXPECT().UpdatePartial(mock.Anything, mock.Anything).
+		Return(&dbCtx.User{ID: 1, Email: "old@example.com"}, nil).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	var response responses.BaseResponse
+	suite.NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	suite.Contains(response.Message, "confirm the new email address")
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_EmailSameAfterNormalization() {
+	suite.newPatchRequest("application/merge-patch+json", `{"email": "Old@Bücher.example"}`)
+	suite.expectCurrentUser()
+
+	suite.userService.EXPECT().UpdatePartial(mock.Anything, mock.Anything).
+		Return(&dbCtx.User{ID: 1, Email: "old@xn--bcher-kva.example"}, nil).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	var response responses.BaseResponse
+	suite.NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	suite.Equal("User updated successfully", response.Message)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_EmailChangeNotSent() {
+	suite.newPatchRequest("application/merge-patch+json", `{"email": "new@example.com"}`)
+	suite.expectCurrentUser()
+
+	suite.userService.EXPECT().UpdatePartial(mock.Anything, mock.Anything).
+		Return(&dbCtx.User{ID: 1, Email: "old@example.com"}, fmt.Errorf("%w: smtp down", services.ErrEmailChangeNotSent)).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	var response responses.BaseResponse
+	suite.NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	suite.Contains(response.Message, "could not be sent")
+}
+
+func (suite *UserHandlerTestSuite) TestConfirmEmailChange_Success() {
+	suite.newTokenRequest("/auth/email/confirm", `{"token": "abc"}`)
+
+	suite.serviceManager.EXPECT().EmailChange().Return(suite.emailChange)
+	suite.emailChange.EXPECT().Confirm(mock.Anything, "abc").
+		Return(&dbCtx.User{ID: 1, Email: "new@example.com"}, nil).Once()
+
+	suite.handler.ConfirmEmailChange(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"email":"new@example.com"`)
+}
+
+func (suite *UserHandlerTestSuite) TestConfirmEmailChange_MissingToken() {
+	suite.newTokenRequest("/auth/email/confirm", `{}`)
+
+	suite.handler.ConfirmEmailChange(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestConfirmEmailChange_Errors() {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"InvalidToken", services.ErrInvalidEmailChangeToken, http.StatusBadRequest},
+		{"Expired", services.ErrEmailChangeExpired, http.StatusBadRequest},
+		{"EmailTaken", &contracts.EmailExistsError{Email: "new@example.com"}, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.SetupTest()
+			suite.newTokenRequest("/auth/email/confirm", `{"token": "abc"}`)
+
+			suite.serviceManager.EXPECT().EmailChange().Return(suite.emailChange)
+			suite.emailChange.EXPECT().Confirm(mock.Anything, "abc").Return(nil, tt.err).Once()
+
+			suite.handler.ConfirmEmailChange(suite.ctx)
+
+			suite.Equal(tt.status, suite.recorder.Code)
+		})
+	}
+}
+
+func (suite *UserHandlerTestSuite) TestCancelEmailChange_Success() {
+	suite.newTokenRequest("/auth/email/cancel", `{"token": "abc"}`)
+
+	suite.serviceManager.EXPECT().EmailChange().Return(suite.emailChange)
+	suite.emailChange.EXPECT().Cancel(mock.Anything, "abc").Return(nil).Once()
+
+	suite.handler.CancelEmailChange(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestCancelEmailChange_InternalError() {
+	suite.newTokenRequest("/auth/email/cancel", `{"token": "abc"}`)
+
+	suite.serviceManager.EXPECT().EmailChange().Return(suite.emailChange)
+	suite.emailChange.EXPECT().Cancel(mock.Anything, "abc").Return(errors.New("boom")).Once()
+	suite.logger.EXPECT().Error(logging.Internal, logging.Update, "Failed to process email change", mock.Anything).Once()
+
+	suite.handler.CancelEmailChange(suite.ctx)
+
+	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) newMeRequest(method, path, body string) {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	suite.ctx.Request = req
+	suite.ctx.Set("user_id", "1")
+}
+
+func (suite *UserHandlerTestSuite) TestExportMe_Success() {
+	suite.newMeRequest(http.MethodGet, "/api/users/me/export", "")
+
+	suite.serviceManager.EXPECT().Privacy().Return(suite.privacy)
+	suite.privacy.EXPECT().Export(mock.Anything, int32(1)).Return(&services.UserExport{
+		UserID:   1,
+		Profile:  dto.UserResponse{ID: 1, Email: "me@example.com"},
+		Messages: []services.ExportedMessage{},
+	}, nil).Once()
+
+	suite.handler.ExportMe(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Equal("application/zip", suite.recorder.Header().Get("Content-Type"))
+	suite.Contains(suite.recorder.Header().Get("Content-Disposition"), `filename="user-1-export.zip"`)
+	body := suite.recorder.Body.Bytes()
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	suite.Require().NoError(err)
+	suite.NotEmpty(zr.File)
+}
+
+func (suite *UserHandlerTestSuite) TestExportMe_UserNotFound() {
+	suite.newMeRequest(http.MethodGet, "/api/users/me/export", "")
+
+	suite.serviceManager.EXPECT().Privacy().Return(suite.privacy)
+	suite.privacy.EXPECT().Export(mock.Anything, int32(1)).Return(nil, errors.New("user not found")).Once()
+
+	suite.handler.ExportMe(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestEraseMe_Success() {
+	suite.newMeRequest(http.MethodPost, "/api/users/me/erase", `{"password": "secret"}`)
+
+	suite.serviceManager.EXPECT().User().Return(suite.userService)
+	suite.serviceManager.EXPECT().Hash().Return(suite.hash)
+	suite.serviceManager.EXPECT().Privacy().Return(suite.privacy)
+	suite.userService.EXPECT().GetByID(mock.Anything, int32(1)).
+		Return(&dbCtx.User{ID: 1, PasswordHash: "hashed"}, nil).Once()
+	suite.hash.EXPECT().Compare("hashed", "secret").Return(nil).Once()
+	suite.privacy.EXPECT().Erase(mock.Anything, int32(1), int32(1)).Return(nil).Once()
+
+	suite.handler.EraseMe(suite.ctx)
+
+	suite.Equal(http.StatusNoContent, suite.ctx.Writer.Status())
+}
+
+func (suite *UserHandlerTestSuite) TestEraseMe_WrongPassword() {
+	suite.newMeRequest(http.MethodPost, "/api/users/me/erase", `{"password": "wrong"}`)
+
+	suite.serviceManager.EXPECT().User().Return(suite.userService)
+	suite.serviceManager.EXPECT().Hash().Return(suite.hash)
+	suite.userService.EXPECT().GetByID(mock.Anything, int32(1)).
+		Return(&dbCtx.User{ID: 1, PasswordHash: "hashed"}, nil).Once()
+	suite.hash.EXPECT().Compare("hashed", "wrong").Return(errors.New("mismatch")).Once()
+
+	suite.handler.EraseMe(suite.ctx)
+
+	suite.Equal(http.StatusUnauthorized, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestEraseMe_MissingPassword() {
+	suite.newMeRequest(http.MethodPost, "/api/users/me/erase", `{}`)
+
+	suite.handler.EraseMe(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestEraseUser_Success() {
+	suite.newMeRequest(http.MethodPost, "/api/admin/users/2/erase", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.serviceManager.EXPECT().Privacy().Return(suite.privacy)
+	suite.privacy.EXPECT().Erase(mock.Anything, int32(2), int32(1)).Return(nil).Once()
+
+	suite.handler.EraseUser(suite.ctx)
+
+	suite.Equal(http.StatusNoContent, suite.ctx.Writer.Status())
+}
+
+func (suite *UserHandlerTestSuite) TestEraseUser_NotFound() {
+	suite.newMeRequest(http.MethodPost, "/api/admin/users/2/erase", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.serviceManager.EXPECT().Privacy().Return(suite.privacy)
+	suite.privacy.EXPECT().Erase(mock.Anything, int32(2), int32(1)).Return(errors.New("user not found")).Once()
+
+	suite.handler.EraseUser(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestBlockUser_Success() {
+	suite.newMeRequest(http.MethodPut, "/api/users/2/block", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.serviceManager.EXPECT().Block().Return(suite.block).Once()
+	suite.block.EXPECT().Block(mock.Anything, int32(1), int32(2)).Return(nil).Once()
+
+	suite.handler.BlockUser(suite.ctx)
+
+	suite.Equal(http.StatusNoContent, suite.ctx.Writer.Status())
+}
+
+func (suite *UserHandlerTestSuite) TestBlockUser_Errors() {
+	for name, tc := range map[string]struct {
+		err  error
+		code int
+	}{
+		"Self":      {services.ErrCannotBlockSelf, http.StatusBadRequest},
+		"Not Found": {errors.New("user not found"), http.StatusNotFound},
+		"Failure":   {errors.New("failed to block user"), http.StatusInternalServerError},
+	} {
+		suite.Run(name, func() {
+			suite.SetupTest()
+			suite.newMeRequest(http.MethodPut, "/api/users/2/block", "")
+			suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+			suite.serviceManager.EXPECT().Block().Return(suite.block).Once()
+			suite.block.EXPECT().Block(mock.Anything, int32(1), int32(2)).Return(tc.err).Once()
+
+			suite.handler.BlockUser(suite.ctx)
+
+			suite.Equal(tc.code, suite.recorder.Code)
+		})
+	}
+}
+
+func (suite *UserHandlerTestSuite) TestBlockUser_InvalidID() {
+	suite.newMeRequest(http.MethodPut, "/api/users/abc/block", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "abc"}}
+
+	suite.handler.BlockUser(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUnblockUser_NotBlocked() {
+	suite.newMeRequest(http.MethodDelete, "/api/users/2/block", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.serviceManager.EXPECT().Block().Return(suite.block).Once()
+	suite.block.EXPECT().Unblock(mock.Anything, int32(1), int32(2)).Return(services.ErrBlockNotFound).Once()
+
+	suite.handler.UnblockUser(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestListBlocks_Success() {
+	suite.newMeRequest(http.MethodGet, "/api/users/me/blocks", "")
+	blockedAt := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	suite.serviceManager.EXPECT().Block().Return(suite.block).Once()
+	suite.block.EXPECT().List(mock.Anything, int32(1)).Return([]dbCtx.ListUserBlocksRow{
+		{BlockedID: 2, Username: "spammer", CreatedAt: blockedAt},
+	}, nil).Once()
+
+	suite.handler.ListBlocks(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+
+	var response struct {
+		Data []dto.BlockedUserResponse `json:"data"`
+	}
+	suite.NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	suite.Equal([]dto.BlockedUserResponse{{UserID: 2, Username: "spammer", BlockedAt: blockedAt}}, response.Data)
+}
+
+func (suite *UserHandlerTestSuite) TestFollow_Success() {
+	suite.newMeRequest(http.MethodPut, "/api/users/2/follow", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.serviceManager.EXPECT().Relationship().Return(suite.relationship).Once()
+	suite.relationship.EXPECT().Follow(mock.Anything, int32(1), int32(2)).Return(nil).Once()
+
+	suite.handler.Follow(suite.ctx)
+
+	suite.Equal(http.StatusNoContent, suite.ctx.Writer.Status())
+}
+
+func (suite *UserHandlerTestSuite) TestFollow_Self() {
+	suite.newMeRequest(http.MethodPut, "/api/users/1/follow", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "1"}}
+
+	suite.serviceManager.EXPECT().Relationship().Return(suite.relationship).Once()
+	suite.relationship.EXPECT().Follow(mock.Anything, int32(1), int32(1)).Return(services.ErrCannotFollowSelf).Once()
+
+	suite.handler.Follow(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUnfollow_NotFollowing() {
+	suite.newMeRequest(http.MethodDelete, "/api/users/2/follow", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.serviceManager.EXPECT().Relationship().Return(suite.relationship).Once()
+	suite.relationship.EXPECT().Unfollow(mock.Anything, int32(1), int32(2)).Return(services.ErrNotFollowing).Once()
+
+	suite.handler.Unfollow(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestListFollowers_Success() {
+	suite.newMeRequest(http.MethodGet, "/api/users/2/followers?limit=5&offset=10", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+	followedAt := time.Date(2025, 9, 15, 8, 0, 0, 0, time.UTC)
+
+	suite.serviceManager.EXPECT().Relationship().Return(suite.relationship).Once()
+	suite.relationship.EXPECT().Followers(mock.Anything, int32(2), dto.FollowListParams{Limit: 5, Offset: 10}).Return(
+		[]dbCtx.ListFollowersRow{{ID: 3, Username: "fan", FullName: "A Fan", FollowedAt: followedAt}},
+		nil,
+	).Once()
+
+	suite.handler.ListFollowers(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+
+	var response struct {
+		Data []dto.FollowUserResponse `json:"data"`
+	}
+	suite.NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	suite.Equal([]dto.FollowUserResponse{{ID: 3, Username: "fan", FullName: "A Fan", FollowedAt: followedAt}}, response.Data)
+}
+
+func (suite *UserHandlerTestSuite) TestListFollowing_DefaultPage() {
+	suite.newMeRequest(http.MethodGet, "/api/users/2/following", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.serviceManager.EXPECT().Relationship().Return(suite.relationship).Once()
+	suite.relationship.EXPECT().Following(mock.Anything, int32(2), dto.FollowListParams{Limit: 20}).Return(nil, nil).Once()
+
+	suite.handler.ListFollowing(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.JSONEq(`[]`, suite.dataJSON())
+}
+
+func (suite *UserHandlerTestSuite) TestListContacts_InvalidPage() {
+	suite.newMeRequest(http.MethodGet, "/api/users/2/contacts?limit=500", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "2"}}
+
+	suite.handler.ListContacts(suite.ctx)
+
+	suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestListContacts_UserNotFound() {
+	suite.newMeRequest(http.MethodGet, "/api/users/9/contacts", "")
+	suite.ctx.Params = []gin.Param{{Key: "id", Value: "9"}}
+
+	suite.serviceManager.EXPECT().Relationship().Return(suite.relationship).Once()
+	suite.relationship.EXPECT().Mutuals(mock.Anything, int32(9), dto.FollowListParams{Limit: 20}).Return(nil, errors.New("user not found")).Once()
+
+	suite.handler.ListContacts(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) dataJSON() string {
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(suite.recorder.Body.Bytes(), &response))
+	return string(response.Data)
+}
+
 func TestUserHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(UserHandlerTestSuite))
 }
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_UserNotFound() {
+	suite.newPatchRequest("application/merge-patch+json", `{"fullName": "New Name"}`)
+	suite.serviceManager.EXPECT().User().Return(suite.userService)
+	suite.userService.EXPECT().GetByID(mock.Anything, int32(1)).Return(nil, errors.New("user not found")).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *UserHandlerTestSuite) TestUpdatePartial_LookupFailure() {
+	suite.newPatchRequest("application/merge-patch+json", `{"fullName": "New Name"}`)
+	suite.serviceManager.EXPECT().User().Return(suite.userService)
+	suite.userService.EXPECT().GetByID(mock.Anything, int32(1)).Return(nil, errors.New("failed to get user: connection refused")).Once()
+	suite.logger.EXPECT().Error(logging.Internal, logging.Update, "Failed to update user", mock.Anything).Once()
+
+	suite.handler.UpdatePartial(suite.ctx)
+
+	suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+}
@@ -0,0 +1,165 @@
+package handlers_tests
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"example.com/api/internal/api/handlers"
+	dto "example.com/api/internal/contracts"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebhookHandlerTestSuite struct {
+	suite.Suite
+	serviceManager *mocks.MockServiceManager
+	webhookService *mocks.MockWebhookService
+	handler        *handlers.WebhookHandler
+	ctx            *gin.Context
+	recorder       *httptest.ResponseRecorder
+}
+
+func (suite *WebhookHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+
+	suite.serviceManager = mocks.NewMockServiceManager(suite.T())
+	suite.webhookService = mocks.NewMockWebhookService(suite.T())
+	suite.handler = handlers.NewWebhookHandler(suite.serviceManager, mocks.NewMockLogger(suite.T()))
+	suite.recorder = httptest.NewRecorder()
+	suite.ctx, _ = gin.CreateTestContext(suite.recorder)
+}
+
+func (suite *WebhookHandlerTestSuite) newRequest(method, url, body string, params gin.Params) {
+	req, _ := http.NewRequest(method, url, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	suite.ctx.Request = req
+	suite.ctx.Params = params
+}
+
+func (suite *WebhookHandlerTestSuite) TestCreate_ReturnsSecretOnce() {
+	suite.newRequest(http.MethodPost, "/api/admin/webhooks",
+		`{"url":"https://example.com/hook","eventTypes":["user.created","message.sent"]}`, nil)
+
+	sub := dbCtx.WebhookSubscription{
+		ID:         1,
+		Url:        "https://example.com/hook",
+		EventTypes: []string{"user.created", "message.sent"},
+		Secret:     "whsec_generated",
+		Active:     true,
+	}
+	suite.serviceManager.EXPECT().Webhook().Return(suite.webhookService)
+	suite.webhookService.EXPECT().Create(mock.Anything, mock.MatchedBy(func(req dto.CreateWebhookReq) bool {
+		return req.URL == sub.Url && len(req.EventTypes) == 2 && req.Secret == ""
+	})).Return(sub, nil).Once()
+
+	suite.handler.Create(suite.ctx)
+
+	suite.Equal(http.StatusCreated, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"secret":"whsec_generated"`)
+
+	// Reading the webhook back never shows the secret.
+	suite.SetupTest()
+	suite.newRequest(http.MethodGet, "/api/admin/webhooks/1", "", gin.Params{{Key: "id", Value: "1"}})
+	suite.serviceManager.EXPECT().Webhook().Return(suite.webhookService)
+	suite.webhookService.EXPECT().Get(mock.Anything, int32(1)).Return(sub, nil).Once()
+
+	suite.handler.Get(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.NotContains(suite.recorder.Body.String(), "whsec_generated")
+}
+
+func (suite *WebhookHandlerTestSuite) TestCreate_InvalidBody() {
+	for _, body := range []string{
+		`{"url":"not a url","eventTypes":["user.created"]}`,
+		`{"url":"https://example.com/hook","eventTypes":[]}`,
+		`{"url":"https://example.com/hook","eventTypes":["user.exploded"]}`,
+		`{"url":"https://example.com/hook","eventTypes":["user.created"],"secret":"short"}`,
+	} {
+		suite.Run(body, func() {
+			suite.SetupTest()
+			suite.newRequest(http.MethodPost, "/api/admin/webhooks", body, nil)
+
+			suite.handler.Create(suite.ctx)
+
+			suite.Equal(http.StatusBadRequest, suite.recorder.Code)
+		})
+	}
+}
+
+func (suite *WebhookHandlerTestSuite) TestGet_NotFound() {
+	suite.newRequest(http.MethodGet, "/api/admin/webhooks/9", "", gin.Params{{Key: "id", Value: "9"}})
+
+	suite.serviceManager.EXPECT().Webhook().Return(suite.webhookService)
+	suite.webhookService.EXPECT().Get(mock.Anything, int32(9)).Return(dbCtx.WebhookSubscription{}, services.ErrWebhookNotFound).Once()
+
+	suite.handler.Get(suite.ctx)
+
+	suite.Equal(http.StatusNotFound, suite.recorder.Code)
+}
+
+func (suite *WebhookHandlerTestSuite) TestListDeliveries() {
+	suite.newRequest(http.MethodGet, "/api/admin/webhooks/1/deliveries?status=dead", "", gin.Params{{Key: "id", Value: "1"}})
+
+	suite.serviceManager.EXPECT().Webhook().Return(suite.webhookService)
+	suite.webhookService.EXPECT().ListDeliveries(mock.Anything, int32(1), dto.WebhookDeliveryFilter{Status: "dead", Limit: 50}).
+		Return([]dbCtx.WebhookDelivery{{ID: 4, EventID: 12, EventType: "user.updated", Status: "dead", Attempts: 8, LastStatusCode: 500}}, nil).Once()
+
+	suite.handler.ListDeliveries(suite.ctx)
+
+	suite.Equal(http.StatusOK, suite.recorder.Code)
+	suite.Contains(suite.recorder.Body.String(), `"status":"dead"`)
+	suite.Contains(suite.recorder.Body.String(), `"lastStatusCode":500`)
+}
+
+func (suite *WebhookHandlerTestSuite) TestRedeliver() {
+	params := gin.Params{{Key: "id", Value: "1"}, {Key: "deliveryId", Value: "4"}}
+
+	suite.Run("Queued", func() {
+		suite.SetupTest()
+		suite.newRequest(http.MethodPost, "/api/admin/webhooks/1/deliveries/4/redeliver", "", params)
+		suite.serviceManager.EXPECT().Webhook().Return(suite.webhookService)
+		suite.webhookService.EXPECT().Redeliver(mock.Anything, int32(1), int64(4)).
+			Return(dbCtx.WebhookDelivery{ID: 4, Status: "pending"}, nil).Once()
+
+		suite.handler.Redeliver(suite.ctx)
+
+		suite.Equal(http.StatusOK, suite.recorder.Code)
+		suite.Contains(suite.recorder.Body.String(), `"status":"pending"`)
+	})
+
+	suite.Run("Not Found", func() {
+		suite.SetupTest()
+		suite.newRequest(http.MethodPost, "/api/admin/webhooks/1/deliveries/4/redeliver", "", params)
+		suite.serviceManager.EXPECT().Webhook().Return(suite.webhookService)
+		suite.webhookService.EXPECT().Redeliver(mock.Anything, int32(1), int64(4)).
+			Return(dbCtx.WebhookDelivery{}, services.ErrWebhookDeliveryNotFound).Once()
+
+		suite.handler.Redeliver(suite.ctx)
+
+		suite.Equal(http.StatusNotFound, suite.recorder.Code)
+	})
+
+	suite.Run("Failure", func() {
+		suite.SetupTest()
+		suite.newRequest(http.MethodPost, "/api/admin/webhooks/1/deliveries/4/redeliver", "", params)
+		suite.serviceManager.EXPECT().Webhook().Return(suite.webhookService)
+		suite.webhookService.EXPECT().Redeliver(mock.Anything, int32(1), int64(4)).
+			Return(dbCtx.WebhookDelivery{}, errors.New("failed to redeliver webhook delivery")).Once()
+
+		suite.handler.Redeliver(suite.ctx)
+
+		suite.Equal(http.StatusInternalServerError, suite.recorder.Code)
+	})
+}
+
+func TestWebhookHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookHandlerTestSuite))
+}
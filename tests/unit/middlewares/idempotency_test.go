@@ -0,0 +1,266 @@
+package middlewares_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"example.com/api/config"
+	"example.com/api/internal/api/middlewares"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache stores values as JSON, like the Redis cache does.
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	err     error
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{entries: map[string][]byte{}}
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, dest any) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	data, ok := m.entries[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, dest)
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	data, err := json.Marshal(value)
+	m.entries[key] = data
+	return err
+}
+
+func (m *memoryCache) SetNX(_ context.Context, key string, value any, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	if _, ok := m.entries[key]; ok {
+		return false, nil
+	}
+	data, err := json.Marshal(value)
+	m.entries[key] = data
+	return true, err
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return m.err
+}
+
+func (m *memoryCache) DeletePrefix(context.Context, string) error  { return nil }
+func (m *memoryCache) Incr(context.Context, string) (int64, error) { return 0, nil }
+
+type idempotencyTest struct {
+	router *gin.Engine
+	cache  *memoryCache
+	calls  int
+	status int
+	// release, when set, blocks the handler until it is closed.
+	release chan struct{}
+	entered chan struct{}
+}
+
+func newIdempotencyTest(t *testing.T) *idempotencyTest {
+	gin.SetMode(gin.TestMode)
+	logger := mocks.NewMockLogger(t)
+	logger.EXPECT().Warn(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	it := &idempotencyTest{cache: newMemoryCache(), status: http.StatusCreated}
+	it.router = gin.New()
+	it.router.Use(func(c *gin.Context) {
+		c.Set("user_id", c.GetHeader("X-Test-User"))
+		if org, err := strconv.Atoi(c.GetHeader("X-Test-Org")); err == nil {
+			c.Request = c.Request.WithContext(tenant.WithID(c.Request.Context(), int32(org)))
+		}
+	})
+	it.router.POST("/api/users", middlewares.Idempotency(it.cache, config.IdempotencyConfig{TTL: 24, LockTimeout: 30}, logger), func(c *gin.Context) {
+		it.calls++
+		if it.entered != nil {
+			close(it.entered)
+		}
+		if it.release != nil {
+			<-it.release
+		}
+		var body map[string]any
+		_ = c.ShouldBindJSON(&body)
+		c.JSON(it.status, gin.H{"call": it.calls, "username": body["username"]})
+	})
+	return it
+}
+
+func (it *idempotencyTest) post(key, user, body string) *httptest.ResponseRecorder {
+	return it.postFrom(key, user, "", "192.0.2.1:1234", body)
+}
+
+// postFrom posts as user in organization org, from the client address addr.
+func (it *idempotencyTest) postFrom(key, user, org, addr, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/users", bytes.NewBufferString(body))
+	req.RemoteAddr = addr
+	req.Header.Set("X-Test-Org", org)
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(middlewares.IdempotencyKeyHeader, key)
+	}
+	req.Header.Set("X-Test-User", user)
+	rec := httptest.NewRecorder()
+	it.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency(t *testing.T) {
+	const body = `{"username":"jdoe"}`
+
+	t.Run("Without Key", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+
+		it.post("", "1", body)
+		it.post("", "1", body)
+
+		assert.Equal(t, 2, it.calls)
+	})
+
+	t.Run("Replays Stored Response", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+
+		first := it.post("key-1", "1", body)
+		second := it.post("key-1", "1", body)
+
+		assert.Equal(t, 1, it.calls)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, first.Header().Get("Content-Type"), second.Header().Get("Content-Type"))
+		assert.Empty(t, first.Header().Get(middlewares.IdempotentReplayedHeader))
+		assert.Equal(t, "true", second.Header().Get(middlewares.IdempotentReplayedHeader))
+	})
+
+	t.Run("Different Body Is Rejected", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+
+		it.post("key-1", "1", body)
+		rec := it.post("key-1", "1", `{"username":"other"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Equal(t, 1, it.calls)
+	})
+
+	t.Run("Keys Are Scoped To The User", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+
+		it.post("key-1", "1", body)
+		rec := it.post("key-1", "2", body)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 2, it.calls)
+	})
+
+	t.Run("Keys Are Scoped To The Organization", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+
+		it.postFrom("key-1", "1", "1", "192.0.2.1:1234", body)
+		rec := it.postFrom("key-1", "1", "2", "192.0.2.1:1234", body)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Empty(t, rec.Header().Get(middlewares.IdempotentReplayedHeader))
+		assert.Equal(t, 2, it.calls)
+	})
+
+	t.Run("Anonymous Keys Are Scoped To The Client", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+
+		it.postFrom("key-1", "", "1", "192.0.2.1:1234", body)
+		other := it.postFrom("key-1", "", "1", "198.51.100.7:1234", `{"username":"other"}`)
+		retry := it.postFrom("key-1", "", "1", "192.0.2.1:5678", body)
+
+		assert.Equal(t, http.StatusCreated, other.Code)
+		assert.Equal(t, "true", retry.Header().Get(middlewares.IdempotentReplayedHeader))
+		assert.Equal(t, 2, it.calls)
+	})
+
+	t.Run("Server Errors Are Not Stored", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+		it.status = http.StatusInternalServerError
+
+		it.post("key-1", "1", body)
+		it.status = http.StatusCreated
+		rec := it.post("key-1", "1", body)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, 2, it.calls)
+	})
+
+	t.Run("In Flight Key Is Locked", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+		it.entered = make(chan struct{})
+		it.release = make(chan struct{})
+
+		done := make(chan *httptest.ResponseRecorder)
+		go func() { done <- it.post("key-1", "1", body) }()
+		<-it.entered
+
+		concurrent := it.post("key-1", "1", body)
+		reused := it.post("key-1", "1", `{"username":"other"}`)
+		close(it.release)
+		first := <-done
+
+		assert.Equal(t, http.StatusConflict, concurrent.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, reused.Code)
+		assert.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, 1, it.calls)
+
+		// Once the first request is done the key replays.
+		it.entered, it.release = nil, nil
+		replayed := it.post("key-1", "1", body)
+		assert.Equal(t, first.Body.String(), replayed.Body.String())
+	})
+
+	t.Run("Cache Unavailable", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+		it.cache.err = errors.New("connection refused")
+
+		rec := it.post("key-1", "1", body)
+		require.Equal(t, http.StatusCreated, rec.Code)
+		it.post("key-1", "1", body)
+
+		assert.Equal(t, 2, it.calls)
+	})
+
+	t.Run("Key Too Long", func(t *testing.T) {
+		it := newIdempotencyTest(t)
+
+		rec := it.post(string(bytes.Repeat([]byte("k"), 256)), "1", body)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Zero(t, it.calls)
+	})
+}
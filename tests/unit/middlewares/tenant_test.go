@@ -0,0 +1,117 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"example.com/api/config"
+	"example.com/api/internal/api/middlewares"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var tenancyConfig = config.TenancyConfig{
+	Header:              "X-Tenant",
+	BaseDomain:          "chat.example.com",
+	DefaultOrganization: "default",
+}
+
+// serveTenant runs one request through Tenant. userID 0 sends it
+// unauthenticated.
+func serveTenant(t *testing.T, orgs services.IOrganizationService, req *http.Request, userID int32) (*httptest.ResponseRecorder, int32) {
+	gin.SetMode(gin.TestMode)
+	var resolved int32
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set("user_id", strconv.Itoa(int(userID)))
+		}
+	})
+	router.Use(middlewares.Tenant(orgs, tenancyConfig))
+	router.Any("/*path", func(c *gin.Context) {
+		resolved, _ = tenant.FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w, resolved
+}
+
+func TestTenant_ResolutionOrder(t *testing.T) {
+	cases := []struct {
+		name   string
+		host   string
+		header string
+		path   string
+		want   string
+	}{
+		{name: "header wins", host: "globex.chat.example.com", header: "acme", want: "acme"},
+		{name: "socket query", host: "globex.chat.example.com", path: "/api/chat/ws?tenant=acme", want: "acme"},
+		{name: "subdomain", host: "globex.chat.example.com:5000", want: "globex"},
+		{name: "default", host: "chat.example.com", want: "default"},
+		{name: "nested subdomain ignored", host: "a.b.chat.example.com", want: "default"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			orgs := mocks.NewMockOrganizationService(t)
+			orgs.EXPECT().Resolve(mock.Anything, tc.want, int32(1)).
+				Return(dbCtx.Organization{ID: 7, Slug: tc.want}, services.OrgRoleMember, nil).Once()
+
+			path := tc.path
+			if path == "" {
+				path = "/api/users"
+			}
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Host = tc.host
+			if tc.header != "" {
+				req.Header.Set("X-Tenant", tc.header)
+			}
+
+			w, resolved := serveTenant(t, orgs, req, 1)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, int32(7), resolved)
+		})
+	}
+}
+
+func TestTenant_AnonymousRequestsUseDefault(t *testing.T) {
+	orgs := mocks.NewMockOrganizationService(t)
+	orgs.EXPECT().Resolve(mock.Anything, "default", int32(0)).
+		Return(dbCtx.Organization{ID: 1, Slug: "default"}, "", nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", nil)
+	req.Header.Set("X-Tenant", "acme")
+
+	w, resolved := serveTenant(t, orgs, req, 0)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int32(1), resolved)
+}
+
+func TestTenant_Errors(t *testing.T) {
+	cases := map[error]int{
+		services.ErrOrganizationNotFound:  http.StatusNotFound,
+		services.ErrNotOrganizationMember: http.StatusForbidden,
+	}
+	for err, status := range cases {
+		orgs := mocks.NewMockOrganizationService(t)
+		orgs.EXPECT().Resolve(mock.Anything, "acme", int32(1)).Return(dbCtx.Organization{}, "", err).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		req.Header.Set("X-Tenant", "acme")
+
+		w, resolved := serveTenant(t, orgs, req, 1)
+
+		assert.Equal(t, status, w.Code, err.Error())
+		assert.Zero(t, resolved)
+	}
+}
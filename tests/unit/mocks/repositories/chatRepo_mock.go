@@ -38,25 +38,1766 @@ func (_m *MockChatRepo) EXPECT() *MockChatRepo_Expecter {
 	return &MockChatRepo_Expecter{mock: &_m.Mock}
 }
 
+// AddReaction provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) AddReaction(ctx context.Context, params dbCtx.AddMessageReactionParams) (int64, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.AddMessageReactionParams) (int64, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.AddMessageReactionParams) int64); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.AddMessageReactionParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_AddReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReaction'
+type MockChatRepo_AddReaction_Call struct {
+	*mock.Call
+}
+
+// AddReaction is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) AddReaction(ctx interface{}, params interface{}) *MockChatRepo_AddReaction_Call {
+	return &MockChatRepo_AddReaction_Call{Call: _e.mock.On("AddReaction", ctx, params)}
+}
+
+func (_c *MockChatRepo_AddReaction_Call) Run(run func(ctx context.Context, params dbCtx.AddMessageReactionParams)) *MockChatRepo_AddReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.AddMessageReactionParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_AddReaction_Call) Return(n int64, err error) *MockChatRepo_AddReaction_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_AddReaction_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.AddMessageReactionParams) (int64, error)) *MockChatRepo_AddReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddRoomMember provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) AddRoomMember(ctx context.Context, params dbCtx.AddRoomMemberParams) (int64, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRoomMember")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.AddRoomMemberParams) (int64, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.AddRoomMemberParams) int64); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.AddRoomMemberParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_AddRoomMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRoomMember'
+type MockChatRepo_AddRoomMember_Call struct {
+	*mock.Call
+}
+
+// AddRoomMember is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) AddRoomMember(ctx interface{}, params interface{}) *MockChatRepo_AddRoomMember_Call {
+	return &MockChatRepo_AddRoomMember_Call{Call: _e.mock.On("AddRoomMember", ctx, params)}
+}
+
+func (_c *MockChatRepo_AddRoomMember_Call) Run(run func(ctx context.Context, params dbCtx.AddRoomMemberParams)) *MockChatRepo_AddRoomMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.AddRoomMemberParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_AddRoomMember_Call) Return(n int64, err error) *MockChatRepo_AddRoomMember_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_AddRoomMember_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.AddRoomMemberParams) (int64, error)) *MockChatRepo_AddRoomMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountReactions provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) CountReactions(ctx context.Context, params dbCtx.CountMessageReactionsParams) (int64, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReactions")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CountMessageReactionsParams) (int64, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CountMessageReactionsParams) int64); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.CountMessageReactionsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_CountReactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountReactions'
+type MockChatRepo_CountReactions_Call struct {
+	*mock.Call
+}
+
+// CountReactions is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) CountReactions(ctx interface{}, params interface{}) *MockChatRepo_CountReactions_Call {
+	return &MockChatRepo_CountReactions_Call{Call: _e.mock.On("CountReactions", ctx, params)}
+}
+
+func (_c *MockChatRepo_CountReactions_Call) Run(run func(ctx context.Context, params dbCtx.CountMessageReactionsParams)) *MockChatRepo_CountReactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.CountMessageReactionsParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CountReactions_Call) Return(n int64, err error) *MockChatRepo_CountReactions_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_CountReactions_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CountMessageReactionsParams) (int64, error)) *MockChatRepo_CountReactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountReplies provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) CountReplies(ctx context.Context, parentID int32) (int64, error) {
+	ret := _mock.Called(ctx, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReplies")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (int64, error)); ok {
+		return returnFunc(ctx, parentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) int64); ok {
+		r0 = returnFunc(ctx, parentID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_CountReplies_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountReplies'
+type MockChatRepo_CountReplies_Call struct {
+	*mock.Call
+}
+
+// CountReplies is a helper method to define mock.On call
+//   - ctx
+//   - parentID
+func (_e *MockChatRepo_Expecter) CountReplies(ctx interface{}, parentID interface{}) *MockChatRepo_CountReplies_Call {
+	return &MockChatRepo_CountReplies_Call{Call: _e.mock.On("CountReplies", ctx, parentID)}
+}
+
+func (_c *MockChatRepo_CountReplies_Call) Run(run func(ctx context.Context, parentID int32)) *MockChatRepo_CountReplies_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CountReplies_Call) Return(n int64, err error) *MockChatRepo_CountReplies_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_CountReplies_Call) RunAndReturn(run func(ctx context.Context, parentID int32) (int64, error)) *MockChatRepo_CountReplies_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUnreadConversations provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) CountUnreadConversations(ctx context.Context, params dbCtx.CountUnreadConversationsParams) ([]dbCtx.CountUnreadConversationsRow, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnreadConversations")
+	}
+
+	var r0 []dbCtx.CountUnreadConversationsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CountUnreadConversationsParams) ([]dbCtx.CountUnreadConversationsRow, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CountUnreadConversationsParams) []dbCtx.CountUnreadConversationsRow); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.CountUnreadConversationsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.CountUnreadConversationsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_CountUnreadConversations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUnreadConversations'
+type MockChatRepo_CountUnreadConversations_Call struct {
+	*mock.Call
+}
+
+// CountUnreadConversations is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) CountUnreadConversations(ctx interface{}, params interface{}) *MockChatRepo_CountUnreadConversations_Call {
+	return &MockChatRepo_CountUnreadConversations_Call{Call: _e.mock.On("CountUnreadConversations", ctx, params)}
+}
+
+func (_c *MockChatRepo_CountUnreadConversations_Call) Run(run func(ctx context.Context, params dbCtx.CountUnreadConversationsParams)) *MockChatRepo_CountUnreadConversations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.CountUnreadConversationsParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CountUnreadConversations_Call) Return(countUnreadConversationsRows []dbCtx.CountUnreadConversationsRow, err error) *MockChatRepo_CountUnreadConversations_Call {
+	_c.Call.Return(countUnreadConversationsRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_CountUnreadConversations_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CountUnreadConversationsParams) ([]dbCtx.CountUnreadConversationsRow, error)) *MockChatRepo_CountUnreadConversations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUnreadRooms provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) CountUnreadRooms(ctx context.Context, params dbCtx.CountUnreadRoomsParams) ([]dbCtx.CountUnreadRoomsRow, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnreadRooms")
+	}
+
+	var r0 []dbCtx.CountUnreadRoomsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CountUnreadRoomsParams) ([]dbCtx.CountUnreadRoomsRow, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CountUnreadRoomsParams) []dbCtx.CountUnreadRoomsRow); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.CountUnreadRoomsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.CountUnreadRoomsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_CountUnreadRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUnreadRooms'
+type MockChatRepo_CountUnreadRooms_Call struct {
+	*mock.Call
+}
+
+// CountUnreadRooms is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) CountUnreadRooms(ctx interface{}, params interface{}) *MockChatRepo_CountUnreadRooms_Call {
+	return &MockChatRepo_CountUnreadRooms_Call{Call: _e.mock.On("CountUnreadRooms", ctx, params)}
+}
+
+func (_c *MockChatRepo_CountUnreadRooms_Call) Run(run func(ctx context.Context, params dbCtx.CountUnreadRoomsParams)) *MockChatRepo_CountUnreadRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.CountUnreadRoomsParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CountUnreadRooms_Call) Return(countUnreadRoomsRows []dbCtx.CountUnreadRoomsRow, err error) *MockChatRepo_CountUnreadRooms_Call {
+	_c.Call.Return(countUnreadRoomsRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_CountUnreadRooms_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CountUnreadRoomsParams) ([]dbCtx.CountUnreadRoomsRow, error)) *MockChatRepo_CountUnreadRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateDirectMessage provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) CreateDirectMessage(ctx context.Context, params dbCtx.CreateDirectMessageParams) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDirectMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateDirectMessageParams) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateDirectMessageParams) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.CreateDirectMessageParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_CreateDirectMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDirectMessage'
+type MockChatRepo_CreateDirectMessage_Call struct {
+	*mock.Call
+}
+
+// CreateDirectMessage is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) CreateDirectMessage(ctx interface{}, params interface{}) *MockChatRepo_CreateDirectMessage_Call {
+	return &MockChatRepo_CreateDirectMessage_Call{Call: _e.mock.On("CreateDirectMessage", ctx, params)}
+}
+
+func (_c *MockChatRepo_CreateDirectMessage_Call) Run(run func(ctx context.Context, params dbCtx.CreateDirectMessageParams)) *MockChatRepo_CreateDirectMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.CreateDirectMessageParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CreateDirectMessage_Call) Return(message dbCtx.Message, err error) *MockChatRepo_CreateDirectMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatRepo_CreateDirectMessage_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CreateDirectMessageParams) (dbCtx.Message, error)) *MockChatRepo_CreateDirectMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateMessage provides a mock function for the type MockChatRepo
 func (_mock *MockChatRepo) CreateMessage(ctx context.Context, params dbCtx.CreateMessageParams) (dbCtx.Message, error) {
 	ret := _mock.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateMessage")
+		panic("no return value specified for CreateMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateMessageParams) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateMessageParams) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.CreateMessageParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_CreateMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMessage'
+type MockChatRepo_CreateMessage_Call struct {
+	*mock.Call
+}
+
+// CreateMessage is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) CreateMessage(ctx interface{}, params interface{}) *MockChatRepo_CreateMessage_Call {
+	return &MockChatRepo_CreateMessage_Call{Call: _e.mock.On("CreateMessage", ctx, params)}
+}
+
+func (_c *MockChatRepo_CreateMessage_Call) Run(run func(ctx context.Context, params dbCtx.CreateMessageParams)) *MockChatRepo_CreateMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.CreateMessageParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CreateMessage_Call) Return(message dbCtx.Message, err error) *MockChatRepo_CreateMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatRepo_CreateMessage_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CreateMessageParams) (dbCtx.Message, error)) *MockChatRepo_CreateMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRevision provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) CreateRevision(ctx context.Context, params dbCtx.CreateMessageRevisionParams) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRevision")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateMessageRevisionParams) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChatRepo_CreateRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRevision'
+type MockChatRepo_CreateRevision_Call struct {
+	*mock.Call
+}
+
+// CreateRevision is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) CreateRevision(ctx interface{}, params interface{}) *MockChatRepo_CreateRevision_Call {
+	return &MockChatRepo_CreateRevision_Call{Call: _e.mock.On("CreateRevision", ctx, params)}
+}
+
+func (_c *MockChatRepo_CreateRevision_Call) Run(run func(ctx context.Context, params dbCtx.CreateMessageRevisionParams)) *MockChatRepo_CreateRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.CreateMessageRevisionParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CreateRevision_Call) Return(err error) *MockChatRepo_CreateRevision_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChatRepo_CreateRevision_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CreateMessageRevisionParams) error) *MockChatRepo_CreateRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRoom provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) CreateRoom(ctx context.Context, params dbCtx.CreateChatRoomParams) (dbCtx.ChatRoom, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoom")
+	}
+
+	var r0 dbCtx.ChatRoom
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateChatRoomParams) (dbCtx.ChatRoom, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateChatRoomParams) dbCtx.ChatRoom); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dbCtx.ChatRoom)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.CreateChatRoomParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_CreateRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRoom'
+type MockChatRepo_CreateRoom_Call struct {
+	*mock.Call
+}
+
+// CreateRoom is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) CreateRoom(ctx interface{}, params interface{}) *MockChatRepo_CreateRoom_Call {
+	return &MockChatRepo_CreateRoom_Call{Call: _e.mock.On("CreateRoom", ctx, params)}
+}
+
+func (_c *MockChatRepo_CreateRoom_Call) Run(run func(ctx context.Context, params dbCtx.CreateChatRoomParams)) *MockChatRepo_CreateRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.CreateChatRoomParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_CreateRoom_Call) Return(chatRoom dbCtx.ChatRoom, err error) *MockChatRepo_CreateRoom_Call {
+	_c.Call.Return(chatRoom, err)
+	return _c
+}
+
+func (_c *MockChatRepo_CreateRoom_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CreateChatRoomParams) (dbCtx.ChatRoom, error)) *MockChatRepo_CreateRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDirectReadsByUser provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) DeleteDirectReadsByUser(ctx context.Context, userID int32) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDirectReadsByUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChatRepo_DeleteDirectReadsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDirectReadsByUser'
+type MockChatRepo_DeleteDirectReadsByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteDirectReadsByUser is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockChatRepo_Expecter) DeleteDirectReadsByUser(ctx interface{}, userID interface{}) *MockChatRepo_DeleteDirectReadsByUser_Call {
+	return &MockChatRepo_DeleteDirectReadsByUser_Call{Call: _e.mock.On("DeleteDirectReadsByUser", ctx, userID)}
+}
+
+func (_c *MockChatRepo_DeleteDirectReadsByUser_Call) Run(run func(ctx context.Context, userID int32)) *MockChatRepo_DeleteDirectReadsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteDirectReadsByUser_Call) Return(err error) *MockChatRepo_DeleteDirectReadsByUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteDirectReadsByUser_Call) RunAndReturn(run func(ctx context.Context, userID int32) error) *MockChatRepo_DeleteDirectReadsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMembershipsInOrganization provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) DeleteMembershipsInOrganization(ctx context.Context, params dbCtx.DeleteRoomMembershipsInOrganizationParams) error {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMembershipsInOrganization")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.DeleteRoomMembershipsInOrganizationParams) error); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChatRepo_DeleteMembershipsInOrganization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMembershipsInOrganization'
+type MockChatRepo_DeleteMembershipsInOrganization_Call struct {
+	*mock.Call
+}
+
+// DeleteMembershipsInOrganization is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) DeleteMembershipsInOrganization(ctx interface{}, params interface{}) *MockChatRepo_DeleteMembershipsInOrganization_Call {
+	return &MockChatRepo_DeleteMembershipsInOrganization_Call{Call: _e.mock.On("DeleteMembershipsInOrganization", ctx, params)}
+}
+
+func (_c *MockChatRepo_DeleteMembershipsInOrganization_Call) Run(run func(ctx context.Context, params dbCtx.DeleteRoomMembershipsInOrganizationParams)) *MockChatRepo_DeleteMembershipsInOrganization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.DeleteRoomMembershipsInOrganizationParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteMembershipsInOrganization_Call) Return(err error) *MockChatRepo_DeleteMembershipsInOrganization_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteMembershipsInOrganization_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.DeleteRoomMembershipsInOrganizationParams) error) *MockChatRepo_DeleteMembershipsInOrganization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMessage provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) DeleteMessage(ctx context.Context, id int32) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_DeleteMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessage'
+type MockChatRepo_DeleteMessage_Call struct {
+	*mock.Call
+}
+
+// DeleteMessage is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockChatRepo_Expecter) DeleteMessage(ctx interface{}, id interface{}) *MockChatRepo_DeleteMessage_Call {
+	return &MockChatRepo_DeleteMessage_Call{Call: _e.mock.On("DeleteMessage", ctx, id)}
+}
+
+func (_c *MockChatRepo_DeleteMessage_Call) Run(run func(ctx context.Context, id int32)) *MockChatRepo_DeleteMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteMessage_Call) Return(message dbCtx.Message, err error) *MockChatRepo_DeleteMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteMessage_Call) RunAndReturn(run func(ctx context.Context, id int32) (dbCtx.Message, error)) *MockChatRepo_DeleteMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReaction provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) DeleteReaction(ctx context.Context, params dbCtx.DeleteMessageReactionParams) (int64, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReaction")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.DeleteMessageReactionParams) (int64, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.DeleteMessageReactionParams) int64); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.DeleteMessageReactionParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_DeleteReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReaction'
+type MockChatRepo_DeleteReaction_Call struct {
+	*mock.Call
+}
+
+// DeleteReaction is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) DeleteReaction(ctx interface{}, params interface{}) *MockChatRepo_DeleteReaction_Call {
+	return &MockChatRepo_DeleteReaction_Call{Call: _e.mock.On("DeleteReaction", ctx, params)}
+}
+
+func (_c *MockChatRepo_DeleteReaction_Call) Run(run func(ctx context.Context, params dbCtx.DeleteMessageReactionParams)) *MockChatRepo_DeleteReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.DeleteMessageReactionParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteReaction_Call) Return(n int64, err error) *MockChatRepo_DeleteReaction_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteReaction_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.DeleteMessageReactionParams) (int64, error)) *MockChatRepo_DeleteReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReactionsByUser provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) DeleteReactionsByUser(ctx context.Context, userID int32) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReactionsByUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChatRepo_DeleteReactionsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReactionsByUser'
+type MockChatRepo_DeleteReactionsByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteReactionsByUser is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockChatRepo_Expecter) DeleteReactionsByUser(ctx interface{}, userID interface{}) *MockChatRepo_DeleteReactionsByUser_Call {
+	return &MockChatRepo_DeleteReactionsByUser_Call{Call: _e.mock.On("DeleteReactionsByUser", ctx, userID)}
+}
+
+func (_c *MockChatRepo_DeleteReactionsByUser_Call) Run(run func(ctx context.Context, userID int32)) *MockChatRepo_DeleteReactionsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteReactionsByUser_Call) Return(err error) *MockChatRepo_DeleteReactionsByUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteReactionsByUser_Call) RunAndReturn(run func(ctx context.Context, userID int32) error) *MockChatRepo_DeleteReactionsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRoomMember provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) DeleteRoomMember(ctx context.Context, params dbCtx.DeleteRoomMemberParams) (int64, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomMember")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.DeleteRoomMemberParams) (int64, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.DeleteRoomMemberParams) int64); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.DeleteRoomMemberParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_DeleteRoomMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoomMember'
+type MockChatRepo_DeleteRoomMember_Call struct {
+	*mock.Call
+}
+
+// DeleteRoomMember is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) DeleteRoomMember(ctx interface{}, params interface{}) *MockChatRepo_DeleteRoomMember_Call {
+	return &MockChatRepo_DeleteRoomMember_Call{Call: _e.mock.On("DeleteRoomMember", ctx, params)}
+}
+
+func (_c *MockChatRepo_DeleteRoomMember_Call) Run(run func(ctx context.Context, params dbCtx.DeleteRoomMemberParams)) *MockChatRepo_DeleteRoomMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.DeleteRoomMemberParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteRoomMember_Call) Return(n int64, err error) *MockChatRepo_DeleteRoomMember_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteRoomMember_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.DeleteRoomMemberParams) (int64, error)) *MockChatRepo_DeleteRoomMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRoomReadsByUser provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) DeleteRoomReadsByUser(ctx context.Context, userID int32) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRoomReadsByUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChatRepo_DeleteRoomReadsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRoomReadsByUser'
+type MockChatRepo_DeleteRoomReadsByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteRoomReadsByUser is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockChatRepo_Expecter) DeleteRoomReadsByUser(ctx interface{}, userID interface{}) *MockChatRepo_DeleteRoomReadsByUser_Call {
+	return &MockChatRepo_DeleteRoomReadsByUser_Call{Call: _e.mock.On("DeleteRoomReadsByUser", ctx, userID)}
+}
+
+func (_c *MockChatRepo_DeleteRoomReadsByUser_Call) Run(run func(ctx context.Context, userID int32)) *MockChatRepo_DeleteRoomReadsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteRoomReadsByUser_Call) Return(err error) *MockChatRepo_DeleteRoomReadsByUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChatRepo_DeleteRoomReadsByUser_Call) RunAndReturn(run func(ctx context.Context, userID int32) error) *MockChatRepo_DeleteRoomReadsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditMessage provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) EditMessage(ctx context.Context, params dbCtx.EditMessageParams) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.EditMessageParams) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.EditMessageParams) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.EditMessageParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_EditMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditMessage'
+type MockChatRepo_EditMessage_Call struct {
+	*mock.Call
+}
+
+// EditMessage is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) EditMessage(ctx interface{}, params interface{}) *MockChatRepo_EditMessage_Call {
+	return &MockChatRepo_EditMessage_Call{Call: _e.mock.On("EditMessage", ctx, params)}
+}
+
+func (_c *MockChatRepo_EditMessage_Call) Run(run func(ctx context.Context, params dbCtx.EditMessageParams)) *MockChatRepo_EditMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.EditMessageParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_EditMessage_Call) Return(message dbCtx.Message, err error) *MockChatRepo_EditMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatRepo_EditMessage_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.EditMessageParams) (dbCtx.Message, error)) *MockChatRepo_EditMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDirectMessages provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) GetDirectMessages(ctx context.Context, params dbCtx.GetDirectMessagesParams) ([]dbCtx.GetDirectMessagesRow, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDirectMessages")
+	}
+
+	var r0 []dbCtx.GetDirectMessagesRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetDirectMessagesParams) ([]dbCtx.GetDirectMessagesRow, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetDirectMessagesParams) []dbCtx.GetDirectMessagesRow); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.GetDirectMessagesRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.GetDirectMessagesParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_GetDirectMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDirectMessages'
+type MockChatRepo_GetDirectMessages_Call struct {
+	*mock.Call
+}
+
+// GetDirectMessages is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) GetDirectMessages(ctx interface{}, params interface{}) *MockChatRepo_GetDirectMessages_Call {
+	return &MockChatRepo_GetDirectMessages_Call{Call: _e.mock.On("GetDirectMessages", ctx, params)}
+}
+
+func (_c *MockChatRepo_GetDirectMessages_Call) Run(run func(ctx context.Context, params dbCtx.GetDirectMessagesParams)) *MockChatRepo_GetDirectMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.GetDirectMessagesParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_GetDirectMessages_Call) Return(getDirectMessagesRows []dbCtx.GetDirectMessagesRow, err error) *MockChatRepo_GetDirectMessages_Call {
+	_c.Call.Return(getDirectMessagesRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_GetDirectMessages_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.GetDirectMessagesParams) ([]dbCtx.GetDirectMessagesRow, error)) *MockChatRepo_GetDirectMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMessage provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) GetMessage(ctx context.Context, params dbCtx.GetMessageParams) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessageParams) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessageParams) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.GetMessageParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_GetMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessage'
+type MockChatRepo_GetMessage_Call struct {
+	*mock.Call
+}
+
+// GetMessage is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) GetMessage(ctx interface{}, params interface{}) *MockChatRepo_GetMessage_Call {
+	return &MockChatRepo_GetMessage_Call{Call: _e.mock.On("GetMessage", ctx, params)}
+}
+
+func (_c *MockChatRepo_GetMessage_Call) Run(run func(ctx context.Context, params dbCtx.GetMessageParams)) *MockChatRepo_GetMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.GetMessageParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessage_Call) Return(message dbCtx.Message, err error) *MockChatRepo_GetMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessage_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.GetMessageParams) (dbCtx.Message, error)) *MockChatRepo_GetMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMessageForUpdate provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) GetMessageForUpdate(ctx context.Context, params dbCtx.GetMessageForUpdateParams) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageForUpdate")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessageForUpdateParams) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessageForUpdateParams) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.GetMessageForUpdateParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_GetMessageForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessageForUpdate'
+type MockChatRepo_GetMessageForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetMessageForUpdate is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) GetMessageForUpdate(ctx interface{}, params interface{}) *MockChatRepo_GetMessageForUpdate_Call {
+	return &MockChatRepo_GetMessageForUpdate_Call{Call: _e.mock.On("GetMessageForUpdate", ctx, params)}
+}
+
+func (_c *MockChatRepo_GetMessageForUpdate_Call) Run(run func(ctx context.Context, params dbCtx.GetMessageForUpdateParams)) *MockChatRepo_GetMessageForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.GetMessageForUpdateParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessageForUpdate_Call) Return(message dbCtx.Message, err error) *MockChatRepo_GetMessageForUpdate_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessageForUpdate_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.GetMessageForUpdateParams) (dbCtx.Message, error)) *MockChatRepo_GetMessageForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMessages provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) GetMessages(ctx context.Context, params dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessages")
+	}
+
+	var r0 []dbCtx.GetMessagesRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessagesParams) []dbCtx.GetMessagesRow); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.GetMessagesRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.GetMessagesParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_GetMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessages'
+type MockChatRepo_GetMessages_Call struct {
+	*mock.Call
+}
+
+// GetMessages is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) GetMessages(ctx interface{}, params interface{}) *MockChatRepo_GetMessages_Call {
+	return &MockChatRepo_GetMessages_Call{Call: _e.mock.On("GetMessages", ctx, params)}
+}
+
+func (_c *MockChatRepo_GetMessages_Call) Run(run func(ctx context.Context, params dbCtx.GetMessagesParams)) *MockChatRepo_GetMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.GetMessagesParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessages_Call) Return(getMessagesRows []dbCtx.GetMessagesRow, err error) *MockChatRepo_GetMessages_Call {
+	_c.Call.Return(getMessagesRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessages_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error)) *MockChatRepo_GetMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMessagesBySender provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) GetMessagesBySender(ctx context.Context, senderID int32) ([]dbCtx.Message, error) {
+	ret := _mock.Called(ctx, senderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesBySender")
+	}
+
+	var r0 []dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]dbCtx.Message, error)); ok {
+		return returnFunc(ctx, senderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []dbCtx.Message); ok {
+		r0 = returnFunc(ctx, senderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.Message)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, senderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_GetMessagesBySender_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessagesBySender'
+type MockChatRepo_GetMessagesBySender_Call struct {
+	*mock.Call
+}
+
+// GetMessagesBySender is a helper method to define mock.On call
+//   - ctx
+//   - senderID
+func (_e *MockChatRepo_Expecter) GetMessagesBySender(ctx interface{}, senderID interface{}) *MockChatRepo_GetMessagesBySender_Call {
+	return &MockChatRepo_GetMessagesBySender_Call{Call: _e.mock.On("GetMessagesBySender", ctx, senderID)}
+}
+
+func (_c *MockChatRepo_GetMessagesBySender_Call) Run(run func(ctx context.Context, senderID int32)) *MockChatRepo_GetMessagesBySender_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessagesBySender_Call) Return(messages []dbCtx.Message, err error) *MockChatRepo_GetMessagesBySender_Call {
+	_c.Call.Return(messages, err)
+	return _c
+}
+
+func (_c *MockChatRepo_GetMessagesBySender_Call) RunAndReturn(run func(ctx context.Context, senderID int32) ([]dbCtx.Message, error)) *MockChatRepo_GetMessagesBySender_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRoom provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) GetRoom(ctx context.Context, params dbCtx.GetChatRoomParams) (dbCtx.ChatRoom, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoom")
+	}
+
+	var r0 dbCtx.ChatRoom
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetChatRoomParams) (dbCtx.ChatRoom, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetChatRoomParams) dbCtx.ChatRoom); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(dbCtx.ChatRoom)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.GetChatRoomParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_GetRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRoom'
+type MockChatRepo_GetRoom_Call struct {
+	*mock.Call
+}
+
+// GetRoom is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) GetRoom(ctx interface{}, params interface{}) *MockChatRepo_GetRoom_Call {
+	return &MockChatRepo_GetRoom_Call{Call: _e.mock.On("GetRoom", ctx, params)}
+}
+
+func (_c *MockChatRepo_GetRoom_Call) Run(run func(ctx context.Context, params dbCtx.GetChatRoomParams)) *MockChatRepo_GetRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.GetChatRoomParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_GetRoom_Call) Return(chatRoom dbCtx.ChatRoom, err error) *MockChatRepo_GetRoom_Call {
+	_c.Call.Return(chatRoom, err)
+	return _c
+}
+
+func (_c *MockChatRepo_GetRoom_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.GetChatRoomParams) (dbCtx.ChatRoom, error)) *MockChatRepo_GetRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThread provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) GetThread(ctx context.Context, params dbCtx.GetThreadParams) ([]dbCtx.GetThreadRow, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThread")
+	}
+
+	var r0 []dbCtx.GetThreadRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetThreadParams) ([]dbCtx.GetThreadRow, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetThreadParams) []dbCtx.GetThreadRow); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.GetThreadRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.GetThreadParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_GetThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThread'
+type MockChatRepo_GetThread_Call struct {
+	*mock.Call
+}
+
+// GetThread is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) GetThread(ctx interface{}, params interface{}) *MockChatRepo_GetThread_Call {
+	return &MockChatRepo_GetThread_Call{Call: _e.mock.On("GetThread", ctx, params)}
+}
+
+func (_c *MockChatRepo_GetThread_Call) Run(run func(ctx context.Context, params dbCtx.GetThreadParams)) *MockChatRepo_GetThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.GetThreadParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_GetThread_Call) Return(getThreadRows []dbCtx.GetThreadRow, err error) *MockChatRepo_GetThread_Call {
+	_c.Call.Return(getThreadRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_GetThread_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.GetThreadParams) ([]dbCtx.GetThreadRow, error)) *MockChatRepo_GetThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsRoomMember provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) IsRoomMember(ctx context.Context, params dbCtx.IsRoomMemberParams) (bool, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsRoomMember")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.IsRoomMemberParams) (bool, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.IsRoomMemberParams) bool); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.IsRoomMemberParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_IsRoomMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsRoomMember'
+type MockChatRepo_IsRoomMember_Call struct {
+	*mock.Call
+}
+
+// IsRoomMember is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) IsRoomMember(ctx interface{}, params interface{}) *MockChatRepo_IsRoomMember_Call {
+	return &MockChatRepo_IsRoomMember_Call{Call: _e.mock.On("IsRoomMember", ctx, params)}
+}
+
+func (_c *MockChatRepo_IsRoomMember_Call) Run(run func(ctx context.Context, params dbCtx.IsRoomMemberParams)) *MockChatRepo_IsRoomMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.IsRoomMemberParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_IsRoomMember_Call) Return(b bool, err error) *MockChatRepo_IsRoomMember_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockChatRepo_IsRoomMember_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.IsRoomMemberParams) (bool, error)) *MockChatRepo_IsRoomMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListConversations provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListConversations(ctx context.Context, params dbCtx.ListConversationsParams) ([]dbCtx.ListConversationsRow, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListConversations")
+	}
+
+	var r0 []dbCtx.ListConversationsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListConversationsParams) ([]dbCtx.ListConversationsRow, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListConversationsParams) []dbCtx.ListConversationsRow); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListConversationsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.ListConversationsParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ListConversations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListConversations'
+type MockChatRepo_ListConversations_Call struct {
+	*mock.Call
+}
+
+// ListConversations is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) ListConversations(ctx interface{}, params interface{}) *MockChatRepo_ListConversations_Call {
+	return &MockChatRepo_ListConversations_Call{Call: _e.mock.On("ListConversations", ctx, params)}
+}
+
+func (_c *MockChatRepo_ListConversations_Call) Run(run func(ctx context.Context, params dbCtx.ListConversationsParams)) *MockChatRepo_ListConversations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.ListConversationsParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ListConversations_Call) Return(listConversationsRows []dbCtx.ListConversationsRow, err error) *MockChatRepo_ListConversations_Call {
+	_c.Call.Return(listConversationsRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ListConversations_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.ListConversationsParams) ([]dbCtx.ListConversationsRow, error)) *MockChatRepo_ListConversations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMessagesSince provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListMessagesSince(ctx context.Context, params dbCtx.ListMessagesSinceParams) ([]dbCtx.ListMessagesSinceRow, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMessagesSince")
+	}
+
+	var r0 []dbCtx.ListMessagesSinceRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListMessagesSinceParams) ([]dbCtx.ListMessagesSinceRow, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListMessagesSinceParams) []dbCtx.ListMessagesSinceRow); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListMessagesSinceRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.ListMessagesSinceParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ListMessagesSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMessagesSince'
+type MockChatRepo_ListMessagesSince_Call struct {
+	*mock.Call
+}
+
+// ListMessagesSince is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) ListMessagesSince(ctx interface{}, params interface{}) *MockChatRepo_ListMessagesSince_Call {
+	return &MockChatRepo_ListMessagesSince_Call{Call: _e.mock.On("ListMessagesSince", ctx, params)}
+}
+
+func (_c *MockChatRepo_ListMessagesSince_Call) Run(run func(ctx context.Context, params dbCtx.ListMessagesSinceParams)) *MockChatRepo_ListMessagesSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.ListMessagesSinceParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ListMessagesSince_Call) Return(listMessagesSinceRows []dbCtx.ListMessagesSinceRow, err error) *MockChatRepo_ListMessagesSince_Call {
+	_c.Call.Return(listMessagesSinceRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ListMessagesSince_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.ListMessagesSinceParams) ([]dbCtx.ListMessagesSinceRow, error)) *MockChatRepo_ListMessagesSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReactions provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListReactions(ctx context.Context, messageID int32) ([]dbCtx.ListMessageReactionsRow, error) {
+	ret := _mock.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReactions")
+	}
+
+	var r0 []dbCtx.ListMessageReactionsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]dbCtx.ListMessageReactionsRow, error)); ok {
+		return returnFunc(ctx, messageID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []dbCtx.ListMessageReactionsRow); ok {
+		r0 = returnFunc(ctx, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListMessageReactionsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ListReactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReactions'
+type MockChatRepo_ListReactions_Call struct {
+	*mock.Call
+}
+
+// ListReactions is a helper method to define mock.On call
+//   - ctx
+//   - messageID
+func (_e *MockChatRepo_Expecter) ListReactions(ctx interface{}, messageID interface{}) *MockChatRepo_ListReactions_Call {
+	return &MockChatRepo_ListReactions_Call{Call: _e.mock.On("ListReactions", ctx, messageID)}
+}
+
+func (_c *MockChatRepo_ListReactions_Call) Run(run func(ctx context.Context, messageID int32)) *MockChatRepo_ListReactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ListReactions_Call) Return(listMessageReactionsRows []dbCtx.ListMessageReactionsRow, err error) *MockChatRepo_ListReactions_Call {
+	_c.Call.Return(listMessageReactionsRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ListReactions_Call) RunAndReturn(run func(ctx context.Context, messageID int32) ([]dbCtx.ListMessageReactionsRow, error)) *MockChatRepo_ListReactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoomIDsForUser provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListRoomIDsForUser(ctx context.Context, params dbCtx.ListRoomIDsForUserParams) ([]int32, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomIDsForUser")
+	}
+
+	var r0 []int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListRoomIDsForUserParams) ([]int32, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListRoomIDsForUserParams) []int32); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.ListRoomIDsForUserParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ListRoomIDsForUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoomIDsForUser'
+type MockChatRepo_ListRoomIDsForUser_Call struct {
+	*mock.Call
+}
+
+// ListRoomIDsForUser is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) ListRoomIDsForUser(ctx interface{}, params interface{}) *MockChatRepo_ListRoomIDsForUser_Call {
+	return &MockChatRepo_ListRoomIDsForUser_Call{Call: _e.mock.On("ListRoomIDsForUser", ctx, params)}
+}
+
+func (_c *MockChatRepo_ListRoomIDsForUser_Call) Run(run func(ctx context.Context, params dbCtx.ListRoomIDsForUserParams)) *MockChatRepo_ListRoomIDsForUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.ListRoomIDsForUserParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ListRoomIDsForUser_Call) Return(ns []int32, err error) *MockChatRepo_ListRoomIDsForUser_Call {
+	_c.Call.Return(ns, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ListRoomIDsForUser_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.ListRoomIDsForUserParams) ([]int32, error)) *MockChatRepo_ListRoomIDsForUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoomIDsWithUserActivity provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListRoomIDsWithUserActivity(ctx context.Context, userID int32) ([]int32, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomIDsWithUserActivity")
+	}
+
+	var r0 []int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]int32, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []int32); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ListRoomIDsWithUserActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoomIDsWithUserActivity'
+type MockChatRepo_ListRoomIDsWithUserActivity_Call struct {
+	*mock.Call
+}
+
+// ListRoomIDsWithUserActivity is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockChatRepo_Expecter) ListRoomIDsWithUserActivity(ctx interface{}, userID interface{}) *MockChatRepo_ListRoomIDsWithUserActivity_Call {
+	return &MockChatRepo_ListRoomIDsWithUserActivity_Call{Call: _e.mock.On("ListRoomIDsWithUserActivity", ctx, userID)}
+}
+
+func (_c *MockChatRepo_ListRoomIDsWithUserActivity_Call) Run(run func(ctx context.Context, userID int32)) *MockChatRepo_ListRoomIDsWithUserActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ListRoomIDsWithUserActivity_Call) Return(ns []int32, err error) *MockChatRepo_ListRoomIDsWithUserActivity_Call {
+	_c.Call.Return(ns, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ListRoomIDsWithUserActivity_Call) RunAndReturn(run func(ctx context.Context, userID int32) ([]int32, error)) *MockChatRepo_ListRoomIDsWithUserActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoomMembers provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListRoomMembers(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error) {
+	ret := _mock.Called(ctx, roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomMembers")
+	}
+
+	var r0 []dbCtx.ListRoomMembersRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]dbCtx.ListRoomMembersRow, error)); ok {
+		return returnFunc(ctx, roomID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []dbCtx.ListRoomMembersRow); ok {
+		r0 = returnFunc(ctx, roomID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListRoomMembersRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, roomID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ListRoomMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoomMembers'
+type MockChatRepo_ListRoomMembers_Call struct {
+	*mock.Call
+}
+
+// ListRoomMembers is a helper method to define mock.On call
+//   - ctx
+//   - roomID
+func (_e *MockChatRepo_Expecter) ListRoomMembers(ctx interface{}, roomID interface{}) *MockChatRepo_ListRoomMembers_Call {
+	return &MockChatRepo_ListRoomMembers_Call{Call: _e.mock.On("ListRoomMembers", ctx, roomID)}
+}
+
+func (_c *MockChatRepo_ListRoomMembers_Call) Run(run func(ctx context.Context, roomID int32)) *MockChatRepo_ListRoomMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ListRoomMembers_Call) Return(listRoomMembersRows []dbCtx.ListRoomMembersRow, err error) *MockChatRepo_ListRoomMembers_Call {
+	_c.Call.Return(listRoomMembersRows, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ListRoomMembers_Call) RunAndReturn(run func(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error)) *MockChatRepo_ListRoomMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoomRecipients provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListRoomRecipients(ctx context.Context, params dbCtx.ListRoomRecipientsParams) ([]int32, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoomRecipients")
 	}
 
-	var r0 dbCtx.Message
+	var r0 []int32
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateMessageParams) (dbCtx.Message, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListRoomRecipientsParams) ([]int32, error)); ok {
 		return returnFunc(ctx, params)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.CreateMessageParams) dbCtx.Message); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListRoomRecipientsParams) []int32); ok {
 		r0 = returnFunc(ctx, params)
 	} else {
-		r0 = ret.Get(0).(dbCtx.Message)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.CreateMessageParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.ListRoomRecipientsParams) error); ok {
 		r1 = returnFunc(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -64,56 +1805,56 @@ func (_mock *MockChatRepo) CreateMessage(ctx context.Context, params dbCtx.Creat
 	return r0, r1
 }
 
-// MockChatRepo_CreateMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMessage'
-type MockChatRepo_CreateMessage_Call struct {
+// MockChatRepo_ListRoomRecipients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoomRecipients'
+type MockChatRepo_ListRoomRecipients_Call struct {
 	*mock.Call
 }
 
-// CreateMessage is a helper method to define mock.On call
+// ListRoomRecipients is a helper method to define mock.On call
 //   - ctx
 //   - params
-func (_e *MockChatRepo_Expecter) CreateMessage(ctx interface{}, params interface{}) *MockChatRepo_CreateMessage_Call {
-	return &MockChatRepo_CreateMessage_Call{Call: _e.mock.On("CreateMessage", ctx, params)}
+func (_e *MockChatRepo_Expecter) ListRoomRecipients(ctx interface{}, params interface{}) *MockChatRepo_ListRoomRecipients_Call {
+	return &MockChatRepo_ListRoomRecipients_Call{Call: _e.mock.On("ListRoomRecipients", ctx, params)}
 }
 
-func (_c *MockChatRepo_CreateMessage_Call) Run(run func(ctx context.Context, params dbCtx.CreateMessageParams)) *MockChatRepo_CreateMessage_Call {
+func (_c *MockChatRepo_ListRoomRecipients_Call) Run(run func(ctx context.Context, params dbCtx.ListRoomRecipientsParams)) *MockChatRepo_ListRoomRecipients_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(dbCtx.CreateMessageParams))
+		run(args[0].(context.Context), args[1].(dbCtx.ListRoomRecipientsParams))
 	})
 	return _c
 }
 
-func (_c *MockChatRepo_CreateMessage_Call) Return(message dbCtx.Message, err error) *MockChatRepo_CreateMessage_Call {
-	_c.Call.Return(message, err)
+func (_c *MockChatRepo_ListRoomRecipients_Call) Return(ns []int32, err error) *MockChatRepo_ListRoomRecipients_Call {
+	_c.Call.Return(ns, err)
 	return _c
 }
 
-func (_c *MockChatRepo_CreateMessage_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.CreateMessageParams) (dbCtx.Message, error)) *MockChatRepo_CreateMessage_Call {
+func (_c *MockChatRepo_ListRoomRecipients_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.ListRoomRecipientsParams) ([]int32, error)) *MockChatRepo_ListRoomRecipients_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMessages provides a mock function for the type MockChatRepo
-func (_mock *MockChatRepo) GetMessages(ctx context.Context, params dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error) {
+// ListRooms provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ListRooms(ctx context.Context, params dbCtx.ListChatRoomsParams) ([]dbCtx.ListChatRoomsRow, error) {
 	ret := _mock.Called(ctx, params)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMessages")
+		panic("no return value specified for ListRooms")
 	}
 
-	var r0 []dbCtx.GetMessagesRow
+	var r0 []dbCtx.ListChatRoomsRow
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListChatRoomsParams) ([]dbCtx.ListChatRoomsRow, error)); ok {
 		return returnFunc(ctx, params)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.GetMessagesParams) []dbCtx.GetMessagesRow); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ListChatRoomsParams) []dbCtx.ListChatRoomsRow); ok {
 		r0 = returnFunc(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dbCtx.GetMessagesRow)
+			r0 = ret.Get(0).([]dbCtx.ListChatRoomsRow)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.GetMessagesParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.ListChatRoomsParams) error); ok {
 		r1 = returnFunc(ctx, params)
 	} else {
 		r1 = ret.Error(1)
@@ -121,31 +1862,251 @@ func (_mock *MockChatRepo) GetMessages(ctx context.Context, params dbCtx.GetMess
 	return r0, r1
 }
 
-// MockChatRepo_GetMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMessages'
-type MockChatRepo_GetMessages_Call struct {
+// MockChatRepo_ListRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRooms'
+type MockChatRepo_ListRooms_Call struct {
 	*mock.Call
 }
 
-// GetMessages is a helper method to define mock.On call
+// ListRooms is a helper method to define mock.On call
 //   - ctx
 //   - params
-func (_e *MockChatRepo_Expecter) GetMessages(ctx interface{}, params interface{}) *MockChatRepo_GetMessages_Call {
-	return &MockChatRepo_GetMessages_Call{Call: _e.mock.On("GetMessages", ctx, params)}
+func (_e *MockChatRepo_Expecter) ListRooms(ctx interface{}, params interface{}) *MockChatRepo_ListRooms_Call {
+	return &MockChatRepo_ListRooms_Call{Call: _e.mock.On("ListRooms", ctx, params)}
 }
 
-func (_c *MockChatRepo_GetMessages_Call) Run(run func(ctx context.Context, params dbCtx.GetMessagesParams)) *MockChatRepo_GetMessages_Call {
+func (_c *MockChatRepo_ListRooms_Call) Run(run func(ctx context.Context, params dbCtx.ListChatRoomsParams)) *MockChatRepo_ListRooms_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(dbCtx.GetMessagesParams))
+		run(args[0].(context.Context), args[1].(dbCtx.ListChatRoomsParams))
 	})
 	return _c
 }
 
-func (_c *MockChatRepo_GetMessages_Call) Return(getMessagesRows []dbCtx.GetMessagesRow, err error) *MockChatRepo_GetMessages_Call {
-	_c.Call.Return(getMessagesRows, err)
+func (_c *MockChatRepo_ListRooms_Call) Return(listChatRoomsRows []dbCtx.ListChatRoomsRow, err error) *MockChatRepo_ListRooms_Call {
+	_c.Call.Return(listChatRoomsRows, err)
 	return _c
 }
 
-func (_c *MockChatRepo_GetMessages_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.GetMessagesParams) ([]dbCtx.GetMessagesRow, error)) *MockChatRepo_GetMessages_Call {
+func (_c *MockChatRepo_ListRooms_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.ListChatRoomsParams) ([]dbCtx.ListChatRoomsRow, error)) *MockChatRepo_ListRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkConversationRead provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) MarkConversationRead(ctx context.Context, params dbCtx.MarkConversationReadParams) (int32, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkConversationRead")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.MarkConversationReadParams) (int32, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.MarkConversationReadParams) int32); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.MarkConversationReadParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_MarkConversationRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkConversationRead'
+type MockChatRepo_MarkConversationRead_Call struct {
+	*mock.Call
+}
+
+// MarkConversationRead is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) MarkConversationRead(ctx interface{}, params interface{}) *MockChatRepo_MarkConversationRead_Call {
+	return &MockChatRepo_MarkConversationRead_Call{Call: _e.mock.On("MarkConversationRead", ctx, params)}
+}
+
+func (_c *MockChatRepo_MarkConversationRead_Call) Run(run func(ctx context.Context, params dbCtx.MarkConversationReadParams)) *MockChatRepo_MarkConversationRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.MarkConversationReadParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_MarkConversationRead_Call) Return(n int32, err error) *MockChatRepo_MarkConversationRead_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_MarkConversationRead_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.MarkConversationReadParams) (int32, error)) *MockChatRepo_MarkConversationRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRoomRead provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) MarkRoomRead(ctx context.Context, params dbCtx.MarkRoomReadParams) (int32, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRoomRead")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.MarkRoomReadParams) (int32, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.MarkRoomReadParams) int32); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.MarkRoomReadParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_MarkRoomRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRoomRead'
+type MockChatRepo_MarkRoomRead_Call struct {
+	*mock.Call
+}
+
+// MarkRoomRead is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) MarkRoomRead(ctx interface{}, params interface{}) *MockChatRepo_MarkRoomRead_Call {
+	return &MockChatRepo_MarkRoomRead_Call{Call: _e.mock.On("MarkRoomRead", ctx, params)}
+}
+
+func (_c *MockChatRepo_MarkRoomRead_Call) Run(run func(ctx context.Context, params dbCtx.MarkRoomReadParams)) *MockChatRepo_MarkRoomRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.MarkRoomReadParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_MarkRoomRead_Call) Return(n int32, err error) *MockChatRepo_MarkRoomRead_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_MarkRoomRead_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.MarkRoomReadParams) (int32, error)) *MockChatRepo_MarkRoomRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReassignRecipient provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ReassignRecipient(ctx context.Context, params dbCtx.ReassignMessagesRecipientParams) (int64, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignRecipient")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ReassignMessagesRecipientParams) (int64, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ReassignMessagesRecipientParams) int64); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.ReassignMessagesRecipientParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ReassignRecipient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignRecipient'
+type MockChatRepo_ReassignRecipient_Call struct {
+	*mock.Call
+}
+
+// ReassignRecipient is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) ReassignRecipient(ctx interface{}, params interface{}) *MockChatRepo_ReassignRecipient_Call {
+	return &MockChatRepo_ReassignRecipient_Call{Call: _e.mock.On("ReassignRecipient", ctx, params)}
+}
+
+func (_c *MockChatRepo_ReassignRecipient_Call) Run(run func(ctx context.Context, params dbCtx.ReassignMessagesRecipientParams)) *MockChatRepo_ReassignRecipient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.ReassignMessagesRecipientParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ReassignRecipient_Call) Return(n int64, err error) *MockChatRepo_ReassignRecipient_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ReassignRecipient_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.ReassignMessagesRecipientParams) (int64, error)) *MockChatRepo_ReassignRecipient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReassignSender provides a mock function for the type MockChatRepo
+func (_mock *MockChatRepo) ReassignSender(ctx context.Context, params dbCtx.ReassignMessagesSenderParams) (int64, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignSender")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ReassignMessagesSenderParams) (int64, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dbCtx.ReassignMessagesSenderParams) int64); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dbCtx.ReassignMessagesSenderParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatRepo_ReassignSender_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignSender'
+type MockChatRepo_ReassignSender_Call struct {
+	*mock.Call
+}
+
+// ReassignSender is a helper method to define mock.On call
+//   - ctx
+//   - params
+func (_e *MockChatRepo_Expecter) ReassignSender(ctx interface{}, params interface{}) *MockChatRepo_ReassignSender_Call {
+	return &MockChatRepo_ReassignSender_Call{Call: _e.mock.On("ReassignSender", ctx, params)}
+}
+
+func (_c *MockChatRepo_ReassignSender_Call) Run(run func(ctx context.Context, params dbCtx.ReassignMessagesSenderParams)) *MockChatRepo_ReassignSender_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dbCtx.ReassignMessagesSenderParams))
+	})
+	return _c
+}
+
+func (_c *MockChatRepo_ReassignSender_Call) Return(n int64, err error) *MockChatRepo_ReassignSender_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatRepo_ReassignSender_Call) RunAndReturn(run func(ctx context.Context, params dbCtx.ReassignMessagesSenderParams) (int64, error)) *MockChatRepo_ReassignSender_Call {
 	_c.Call.Return(run)
 	return _c
 }
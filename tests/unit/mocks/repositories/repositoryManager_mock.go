@@ -38,6 +38,98 @@ func (_m *MockRepositoryManager) EXPECT() *MockRepositoryManager_Expecter {
 	return &MockRepositoryManager_Expecter{mock: &_m.Mock}
 }
 
+// Audit provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Audit() repository.IAuditRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Audit")
+	}
+
+	var r0 repository.IAuditRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IAuditRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IAuditRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Audit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Audit'
+type MockRepositoryManager_Audit_Call struct {
+	*mock.Call
+}
+
+// Audit is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Audit() *MockRepositoryManager_Audit_Call {
+	return &MockRepositoryManager_Audit_Call{Call: _e.mock.On("Audit")}
+}
+
+func (_c *MockRepositoryManager_Audit_Call) Run(run func()) *MockRepositoryManager_Audit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Audit_Call) Return(iAuditRepo repository.IAuditRepo) *MockRepositoryManager_Audit_Call {
+	_c.Call.Return(iAuditRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Audit_Call) RunAndReturn(run func() repository.IAuditRepo) *MockRepositoryManager_Audit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Block provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Block() repository.IBlockRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 repository.IBlockRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IBlockRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IBlockRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Block'
+type MockRepositoryManager_Block_Call struct {
+	*mock.Call
+}
+
+// Block is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Block() *MockRepositoryManager_Block_Call {
+	return &MockRepositoryManager_Block_Call{Call: _e.mock.On("Block")}
+}
+
+func (_c *MockRepositoryManager_Block_Call) Run(run func()) *MockRepositoryManager_Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Block_Call) Return(iBlockRepo repository.IBlockRepo) *MockRepositoryManager_Block_Call {
+	_c.Call.Return(iBlockRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Block_Call) RunAndReturn(run func() repository.IBlockRepo) *MockRepositoryManager_Block_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Chat provides a mock function for the type MockRepositoryManager
 func (_mock *MockRepositoryManager) Chat() repository.IChatRepo {
 	ret := _mock.Called()
@@ -84,6 +176,282 @@ func (_c *MockRepositoryManager_Chat_Call) RunAndReturn(run func() repository.IC
 	return _c
 }
 
+// EmailChange provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) EmailChange() repository.IEmailChangeRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailChange")
+	}
+
+	var r0 repository.IEmailChangeRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IEmailChangeRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IEmailChangeRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_EmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmailChange'
+type MockRepositoryManager_EmailChange_Call struct {
+	*mock.Call
+}
+
+// EmailChange is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) EmailChange() *MockRepositoryManager_EmailChange_Call {
+	return &MockRepositoryManager_EmailChange_Call{Call: _e.mock.On("EmailChange")}
+}
+
+func (_c *MockRepositoryManager_EmailChange_Call) Run(run func()) *MockRepositoryManager_EmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_EmailChange_Call) Return(iEmailChangeRepo repository.IEmailChangeRepo) *MockRepositoryManager_EmailChange_Call {
+	_c.Call.Return(iEmailChangeRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_EmailChange_Call) RunAndReturn(run func() repository.IEmailChangeRepo) *MockRepositoryManager_EmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Erasure provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Erasure() repository.IUserErasureRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Erasure")
+	}
+
+	var r0 repository.IUserErasureRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IUserErasureRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IUserErasureRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Erasure_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Erasure'
+type MockRepositoryManager_Erasure_Call struct {
+	*mock.Call
+}
+
+// Erasure is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Erasure() *MockRepositoryManager_Erasure_Call {
+	return &MockRepositoryManager_Erasure_Call{Call: _e.mock.On("Erasure")}
+}
+
+func (_c *MockRepositoryManager_Erasure_Call) Run(run func()) *MockRepositoryManager_Erasure_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Erasure_Call) Return(iUserErasureRepo repository.IUserErasureRepo) *MockRepositoryManager_Erasure_Call {
+	_c.Call.Return(iUserErasureRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Erasure_Call) RunAndReturn(run func() repository.IUserErasureRepo) *MockRepositoryManager_Erasure_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Follow provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Follow() repository.IFollowRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Follow")
+	}
+
+	var r0 repository.IFollowRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IFollowRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IFollowRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Follow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Follow'
+type MockRepositoryManager_Follow_Call struct {
+	*mock.Call
+}
+
+// Follow is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Follow() *MockRepositoryManager_Follow_Call {
+	return &MockRepositoryManager_Follow_Call{Call: _e.mock.On("Follow")}
+}
+
+func (_c *MockRepositoryManager_Follow_Call) Run(run func()) *MockRepositoryManager_Follow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Follow_Call) Return(iFollowRepo repository.IFollowRepo) *MockRepositoryManager_Follow_Call {
+	_c.Call.Return(iFollowRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Follow_Call) RunAndReturn(run func() repository.IFollowRepo) *MockRepositoryManager_Follow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invitation provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Invitation() repository.IInvitationRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invitation")
+	}
+
+	var r0 repository.IInvitationRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IInvitationRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IInvitationRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Invitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invitation'
+type MockRepositoryManager_Invitation_Call struct {
+	*mock.Call
+}
+
+// Invitation is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Invitation() *MockRepositoryManager_Invitation_Call {
+	return &MockRepositoryManager_Invitation_Call{Call: _e.mock.On("Invitation")}
+}
+
+func (_c *MockRepositoryManager_Invitation_Call) Run(run func()) *MockRepositoryManager_Invitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Invitation_Call) Return(iInvitationRepo repository.IInvitationRepo) *MockRepositoryManager_Invitation_Call {
+	_c.Call.Return(iInvitationRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Invitation_Call) RunAndReturn(run func() repository.IInvitationRepo) *MockRepositoryManager_Invitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Organization provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Organization() repository.IOrganizationRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Organization")
+	}
+
+	var r0 repository.IOrganizationRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IOrganizationRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IOrganizationRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Organization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Organization'
+type MockRepositoryManager_Organization_Call struct {
+	*mock.Call
+}
+
+// Organization is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Organization() *MockRepositoryManager_Organization_Call {
+	return &MockRepositoryManager_Organization_Call{Call: _e.mock.On("Organization")}
+}
+
+func (_c *MockRepositoryManager_Organization_Call) Run(run func()) *MockRepositoryManager_Organization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Organization_Call) Return(iOrganizationRepo repository.IOrganizationRepo) *MockRepositoryManager_Organization_Call {
+	_c.Call.Return(iOrganizationRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Organization_Call) RunAndReturn(run func() repository.IOrganizationRepo) *MockRepositoryManager_Organization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Outbox provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Outbox() repository.IOutboxRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Outbox")
+	}
+
+	var r0 repository.IOutboxRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IOutboxRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IOutboxRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Outbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Outbox'
+type MockRepositoryManager_Outbox_Call struct {
+	*mock.Call
+}
+
+// Outbox is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Outbox() *MockRepositoryManager_Outbox_Call {
+	return &MockRepositoryManager_Outbox_Call{Call: _e.mock.On("Outbox")}
+}
+
+func (_c *MockRepositoryManager_Outbox_Call) Run(run func()) *MockRepositoryManager_Outbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Outbox_Call) Return(iOutboxRepo repository.IOutboxRepo) *MockRepositoryManager_Outbox_Call {
+	_c.Call.Return(iOutboxRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Outbox_Call) RunAndReturn(run func() repository.IOutboxRepo) *MockRepositoryManager_Outbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // User provides a mock function for the type MockRepositoryManager
 func (_mock *MockRepositoryManager) User() repository.IUserRepo {
 	ret := _mock.Called()
@@ -130,6 +498,52 @@ func (_c *MockRepositoryManager_User_Call) RunAndReturn(run func() repository.IU
 	return _c
 }
 
+// Webhook provides a mock function for the type MockRepositoryManager
+func (_mock *MockRepositoryManager) Webhook() repository.IWebhookRepo {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Webhook")
+	}
+
+	var r0 repository.IWebhookRepo
+	if returnFunc, ok := ret.Get(0).(func() repository.IWebhookRepo); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(repository.IWebhookRepo)
+		}
+	}
+	return r0
+}
+
+// MockRepositoryManager_Webhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhook'
+type MockRepositoryManager_Webhook_Call struct {
+	*mock.Call
+}
+
+// Webhook is a helper method to define mock.On call
+func (_e *MockRepositoryManager_Expecter) Webhook() *MockRepositoryManager_Webhook_Call {
+	return &MockRepositoryManager_Webhook_Call{Call: _e.mock.On("Webhook")}
+}
+
+func (_c *MockRepositoryManager_Webhook_Call) Run(run func()) *MockRepositoryManager_Webhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepositoryManager_Webhook_Call) Return(iWebhookRepo repository.IWebhookRepo) *MockRepositoryManager_Webhook_Call {
+	_c.Call.Return(iWebhookRepo)
+	return _c
+}
+
+func (_c *MockRepositoryManager_Webhook_Call) RunAndReturn(run func() repository.IWebhookRepo) *MockRepositoryManager_Webhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithTx provides a mock function for the type MockRepositoryManager
 func (_mock *MockRepositoryManager) WithTx(context1 context.Context, fn func(repository.IRepositoryManager) error) error {
 	ret := _mock.Called(context1, fn)
@@ -37,6 +37,61 @@ func (_m *MockUserRepo) EXPECT() *MockUserRepo_Expecter {
 	return &MockUserRepo_Expecter{mock: &_m.Mock}
 }
 
+// Anonymize provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) Anonymize(ctx repository.Ctx, id int32) (repository.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Anonymize")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, int32) (repository.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, int32) repository.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(repository.Ctx, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepo_Anonymize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Anonymize'
+type MockUserRepo_Anonymize_Call struct {
+	*mock.Call
+}
+
+// Anonymize is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepo_Expecter) Anonymize(ctx interface{}, id interface{}) *MockUserRepo_Anonymize_Call {
+	return &MockUserRepo_Anonymize_Call{Call: _e.mock.On("Anonymize", ctx, id)}
+}
+
+func (_c *MockUserRepo_Anonymize_Call) Run(run func(ctx repository.Ctx, id int32)) *MockUserRepo_Anonymize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.Ctx), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_Anonymize_Call) Return(v repository.User, err error) *MockUserRepo_Anonymize_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockUserRepo_Anonymize_Call) RunAndReturn(run func(ctx repository.Ctx, id int32) (repository.User, error)) *MockUserRepo_Anonymize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockUserRepo
 func (_mock *MockUserRepo) Create(ctx repository.Ctx, arg dbCtx.CreateUserParams) (repository.User, error) {
 	ret := _mock.Called(ctx, arg)
@@ -259,6 +314,118 @@ func (_c *MockUserRepo_GetByID_Call) RunAndReturn(run func(ctx repository.Ctx, i
 	return _c
 }
 
+// GetByIDWithDeleted provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) GetByIDWithDeleted(ctx repository.Ctx, id int32) (repository.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDWithDeleted")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, int32) (repository.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, int32) repository.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(repository.Ctx, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepo_GetByIDWithDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDWithDeleted'
+type MockUserRepo_GetByIDWithDeleted_Call struct {
+	*mock.Call
+}
+
+// GetByIDWithDeleted is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockUserRepo_Expecter) GetByIDWithDeleted(ctx interface{}, id interface{}) *MockUserRepo_GetByIDWithDeleted_Call {
+	return &MockUserRepo_GetByIDWithDeleted_Call{Call: _e.mock.On("GetByIDWithDeleted", ctx, id)}
+}
+
+func (_c *MockUserRepo_GetByIDWithDeleted_Call) Run(run func(ctx repository.Ctx, id int32)) *MockUserRepo_GetByIDWithDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.Ctx), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_GetByIDWithDeleted_Call) Return(v repository.User, err error) *MockUserRepo_GetByIDWithDeleted_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockUserRepo_GetByIDWithDeleted_Call) RunAndReturn(run func(ctx repository.Ctx, id int32) (repository.User, error)) *MockUserRepo_GetByIDWithDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByIDs provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) GetByIDs(ctx repository.Ctx, ids []int32) ([]repository.User, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, []int32) ([]repository.User, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, []int32) []repository.User); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(repository.Ctx, []int32) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepo_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockUserRepo_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx
+//   - ids
+func (_e *MockUserRepo_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockUserRepo_GetByIDs_Call {
+	return &MockUserRepo_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
+}
+
+func (_c *MockUserRepo_GetByIDs_Call) Run(run func(ctx repository.Ctx, ids []int32)) *MockUserRepo_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.Ctx), args[1].([]int32))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_GetByIDs_Call) Return(vs []repository.User, err error) *MockUserRepo_GetByIDs_Call {
+	_c.Call.Return(vs, err)
+	return _c
+}
+
+func (_c *MockUserRepo_GetByIDs_Call) RunAndReturn(run func(ctx repository.Ctx, ids []int32) ([]repository.User, error)) *MockUserRepo_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByUsername provides a mock function for the type MockUserRepo
 func (_mock *MockUserRepo) GetByUsername(ctx repository.Ctx, username string) (repository.User, error) {
 	ret := _mock.Called(ctx, username)
@@ -314,6 +481,60 @@ func (_c *MockUserRepo_GetByUsername_Call) RunAndReturn(run func(ctx repository.
 	return _c
 }
 
+// GetErasedPlaceholderID provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) GetErasedPlaceholderID(ctx repository.Ctx) (int32, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetErasedPlaceholderID")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx) (int32, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx) int32); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(repository.Ctx) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepo_GetErasedPlaceholderID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetErasedPlaceholderID'
+type MockUserRepo_GetErasedPlaceholderID_Call struct {
+	*mock.Call
+}
+
+// GetErasedPlaceholderID is a helper method to define mock.On call
+//   - ctx
+func (_e *MockUserRepo_Expecter) GetErasedPlaceholderID(ctx interface{}) *MockUserRepo_GetErasedPlaceholderID_Call {
+	return &MockUserRepo_GetErasedPlaceholderID_Call{Call: _e.mock.On("GetErasedPlaceholderID", ctx)}
+}
+
+func (_c *MockUserRepo_GetErasedPlaceholderID_Call) Run(run func(ctx repository.Ctx)) *MockUserRepo_GetErasedPlaceholderID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.Ctx))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_GetErasedPlaceholderID_Call) Return(n int32, err error) *MockUserRepo_GetErasedPlaceholderID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserRepo_GetErasedPlaceholderID_Call) RunAndReturn(run func(ctx repository.Ctx) (int32, error)) *MockUserRepo_GetErasedPlaceholderID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SoftDelete provides a mock function for the type MockUserRepo
 func (_mock *MockUserRepo) SoftDelete(ctx repository.Ctx, id int32) (int64, error) {
 	ret := _mock.Called(ctx, id)
@@ -369,6 +590,61 @@ func (_c *MockUserRepo_SoftDelete_Call) RunAndReturn(run func(ctx repository.Ctx
 	return _c
 }
 
+// SwapEmail provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) SwapEmail(ctx repository.Ctx, arg dbCtx.SwapUserEmailParams) (repository.User, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SwapEmail")
+	}
+
+	var r0 repository.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, dbCtx.SwapUserEmailParams) (repository.User, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(repository.Ctx, dbCtx.SwapUserEmailParams) repository.User); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(repository.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(repository.Ctx, dbCtx.SwapUserEmailParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepo_SwapEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SwapEmail'
+type MockUserRepo_SwapEmail_Call struct {
+	*mock.Call
+}
+
+// SwapEmail is a helper method to define mock.On call
+//   - ctx
+//   - arg
+func (_e *MockUserRepo_Expecter) SwapEmail(ctx interface{}, arg interface{}) *MockUserRepo_SwapEmail_Call {
+	return &MockUserRepo_SwapEmail_Call{Call: _e.mock.On("SwapEmail", ctx, arg)}
+}
+
+func (_c *MockUserRepo_SwapEmail_Call) Run(run func(ctx repository.Ctx, arg dbCtx.SwapUserEmailParams)) *MockUserRepo_SwapEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(repository.Ctx), args[1].(dbCtx.SwapUserEmailParams))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_SwapEmail_Call) Return(v repository.User, err error) *MockUserRepo_SwapEmail_Call {
+	_c.Call.Return(v, err)
+	return _c
+}
+
+func (_c *MockUserRepo_SwapEmail_Call) RunAndReturn(run func(ctx repository.Ctx, arg dbCtx.SwapUserEmailParams) (repository.User, error)) *MockUserRepo_SwapEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateFull provides a mock function for the type MockUserRepo
 func (_mock *MockUserRepo) UpdateFull(ctx repository.Ctx, arg dbCtx.UpdateUserFullParams) (repository.User, error) {
 	ret := _mock.Called(ctx, arg)
@@ -40,6 +40,75 @@ func (_m *MockAuthService) EXPECT() *MockAuthService_Expecter {
 	return &MockAuthService_Expecter{mock: &_m.Mock}
 }
 
+// AcceptInvite provides a mock function for the type MockAuthService
+func (_mock *MockAuthService) AcceptInvite(ctx context.Context, args dto.AcceptInvitation) (*dto.UserResponse, string, string, error) {
+	ret := _mock.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvite")
+	}
+
+	var r0 *dto.UserResponse
+	var r1 string
+	var r2 string
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.AcceptInvitation) (*dto.UserResponse, string, string, error)); ok {
+		return returnFunc(ctx, args)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.AcceptInvitation) *dto.UserResponse); ok {
+		r0 = returnFunc(ctx, args)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.UserResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.AcceptInvitation) string); ok {
+		r1 = returnFunc(ctx, args)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, dto.AcceptInvitation) string); ok {
+		r2 = returnFunc(ctx, args)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context, dto.AcceptInvitation) error); ok {
+		r3 = returnFunc(ctx, args)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockAuthService_AcceptInvite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvite'
+type MockAuthService_AcceptInvite_Call struct {
+	*mock.Call
+}
+
+// AcceptInvite is a helper method to define mock.On call
+//   - ctx
+//   - args
+func (_e *MockAuthService_Expecter) AcceptInvite(ctx interface{}, args interface{}) *MockAuthService_AcceptInvite_Call {
+	return &MockAuthService_AcceptInvite_Call{Call: _e.mock.On("AcceptInvite", ctx, args)}
+}
+
+func (_c *MockAuthService_AcceptInvite_Call) Run(run func(ctx context.Context, args dto.AcceptInvitation)) *MockAuthService_AcceptInvite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dto.AcceptInvitation))
+	})
+	return _c
+}
+
+func (_c *MockAuthService_AcceptInvite_Call) Return(userResponse *dto.UserResponse, s string, s1 string, err error) *MockAuthService_AcceptInvite_Call {
+	_c.Call.Return(userResponse, s, s1, err)
+	return _c
+}
+
+func (_c *MockAuthService_AcceptInvite_Call) RunAndReturn(run func(ctx context.Context, args dto.AcceptInvitation) (*dto.UserResponse, string, string, error)) *MockAuthService_AcceptInvite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Authenticate provides a mock function for the type MockAuthService
 func (_mock *MockAuthService) Authenticate(ctx context.Context, email string, password string) (*dbCtx.User, error) {
 	ret := _mock.Called(ctx, email, password)
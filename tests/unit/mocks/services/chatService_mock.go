@@ -8,6 +8,7 @@ import (
 	"context"
 
 	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/chat"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -38,9 +39,297 @@ func (_m *MockChatService) EXPECT() *MockChatService_Expecter {
 	return &MockChatService_Expecter{mock: &_m.Mock}
 }
 
+// Conversations provides a mock function for the type MockChatService
+func (_mock *MockChatService) Conversations(ctx context.Context, userID int32, limit int32, offset int32) ([]dbCtx.ListConversationsRow, error) {
+	ret := _mock.Called(ctx, userID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Conversations")
+	}
+
+	var r0 []dbCtx.ListConversationsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32) ([]dbCtx.ListConversationsRow, error)); ok {
+		return returnFunc(ctx, userID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32) []dbCtx.ListConversationsRow); ok {
+		r0 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListConversationsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, int32) error); ok {
+		r1 = returnFunc(ctx, userID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_Conversations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Conversations'
+type MockChatService_Conversations_Call struct {
+	*mock.Call
+}
+
+// Conversations is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - limit
+//   - offset
+func (_e *MockChatService_Expecter) Conversations(ctx interface{}, userID interface{}, limit interface{}, offset interface{}) *MockChatService_Conversations_Call {
+	return &MockChatService_Conversations_Call{Call: _e.mock.On("Conversations", ctx, userID, limit, offset)}
+}
+
+func (_c *MockChatService_Conversations_Call) Run(run func(ctx context.Context, userID int32, limit int32, offset int32)) *MockChatService_Conversations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_Conversations_Call) Return(listConversationsRows []dbCtx.ListConversationsRow, err error) *MockChatService_Conversations_Call {
+	_c.Call.Return(listConversationsRows, err)
+	return _c
+}
+
+func (_c *MockChatService_Conversations_Call) RunAndReturn(run func(ctx context.Context, userID int32, limit int32, offset int32) ([]dbCtx.ListConversationsRow, error)) *MockChatService_Conversations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRoom provides a mock function for the type MockChatService
+func (_mock *MockChatService) CreateRoom(ctx context.Context, creatorID int32, name string) (dbCtx.ChatRoom, error) {
+	ret := _mock.Called(ctx, creatorID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRoom")
+	}
+
+	var r0 dbCtx.ChatRoom
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, string) (dbCtx.ChatRoom, error)); ok {
+		return returnFunc(ctx, creatorID, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, string) dbCtx.ChatRoom); ok {
+		r0 = returnFunc(ctx, creatorID, name)
+	} else {
+		r0 = ret.Get(0).(dbCtx.ChatRoom)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, string) error); ok {
+		r1 = returnFunc(ctx, creatorID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_CreateRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRoom'
+type MockChatService_CreateRoom_Call struct {
+	*mock.Call
+}
+
+// CreateRoom is a helper method to define mock.On call
+//   - ctx
+//   - creatorID
+//   - name
+func (_e *MockChatService_Expecter) CreateRoom(ctx interface{}, creatorID interface{}, name interface{}) *MockChatService_CreateRoom_Call {
+	return &MockChatService_CreateRoom_Call{Call: _e.mock.On("CreateRoom", ctx, creatorID, name)}
+}
+
+func (_c *MockChatService_CreateRoom_Call) Run(run func(ctx context.Context, creatorID int32, name string)) *MockChatService_CreateRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockChatService_CreateRoom_Call) Return(chatRoom dbCtx.ChatRoom, err error) *MockChatService_CreateRoom_Call {
+	_c.Call.Return(chatRoom, err)
+	return _c
+}
+
+func (_c *MockChatService_CreateRoom_Call) RunAndReturn(run func(ctx context.Context, creatorID int32, name string) (dbCtx.ChatRoom, error)) *MockChatService_CreateRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMessage provides a mock function for the type MockChatService
+func (_mock *MockChatService) DeleteMessage(ctx context.Context, actorID int32, messageID int32) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, actorID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, actorID, messageID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, actorID, messageID)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = returnFunc(ctx, actorID, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_DeleteMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMessage'
+type MockChatService_DeleteMessage_Call struct {
+	*mock.Call
+}
+
+// DeleteMessage is a helper method to define mock.On call
+//   - ctx
+//   - actorID
+//   - messageID
+func (_e *MockChatService_Expecter) DeleteMessage(ctx interface{}, actorID interface{}, messageID interface{}) *MockChatService_DeleteMessage_Call {
+	return &MockChatService_DeleteMessage_Call{Call: _e.mock.On("DeleteMessage", ctx, actorID, messageID)}
+}
+
+func (_c *MockChatService_DeleteMessage_Call) Run(run func(ctx context.Context, actorID int32, messageID int32)) *MockChatService_DeleteMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_DeleteMessage_Call) Return(message dbCtx.Message, err error) *MockChatService_DeleteMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatService_DeleteMessage_Call) RunAndReturn(run func(ctx context.Context, actorID int32, messageID int32) (dbCtx.Message, error)) *MockChatService_DeleteMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DirectMessages provides a mock function for the type MockChatService
+func (_mock *MockChatService) DirectMessages(ctx context.Context, userID int32, peerID int32, limit int32, offset int32) ([]dbCtx.GetDirectMessagesRow, error) {
+	ret := _mock.Called(ctx, userID, peerID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DirectMessages")
+	}
+
+	var r0 []dbCtx.GetDirectMessagesRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32, int32) ([]dbCtx.GetDirectMessagesRow, error)); ok {
+		return returnFunc(ctx, userID, peerID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32, int32) []dbCtx.GetDirectMessagesRow); ok {
+		r0 = returnFunc(ctx, userID, peerID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.GetDirectMessagesRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, int32, int32) error); ok {
+		r1 = returnFunc(ctx, userID, peerID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_DirectMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DirectMessages'
+type MockChatService_DirectMessages_Call struct {
+	*mock.Call
+}
+
+// DirectMessages is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - peerID
+//   - limit
+//   - offset
+func (_e *MockChatService_Expecter) DirectMessages(ctx interface{}, userID interface{}, peerID interface{}, limit interface{}, offset interface{}) *MockChatService_DirectMessages_Call {
+	return &MockChatService_DirectMessages_Call{Call: _e.mock.On("DirectMessages", ctx, userID, peerID, limit, offset)}
+}
+
+func (_c *MockChatService_DirectMessages_Call) Run(run func(ctx context.Context, userID int32, peerID int32, limit int32, offset int32)) *MockChatService_DirectMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(int32), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_DirectMessages_Call) Return(getDirectMessagesRows []dbCtx.GetDirectMessagesRow, err error) *MockChatService_DirectMessages_Call {
+	_c.Call.Return(getDirectMessagesRows, err)
+	return _c
+}
+
+func (_c *MockChatService_DirectMessages_Call) RunAndReturn(run func(ctx context.Context, userID int32, peerID int32, limit int32, offset int32) ([]dbCtx.GetDirectMessagesRow, error)) *MockChatService_DirectMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditMessage provides a mock function for the type MockChatService
+func (_mock *MockChatService) EditMessage(ctx context.Context, actorID int32, messageID int32, content string) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, actorID, messageID, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, actorID, messageID, content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, actorID, messageID, content)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, string) error); ok {
+		r1 = returnFunc(ctx, actorID, messageID, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_EditMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditMessage'
+type MockChatService_EditMessage_Call struct {
+	*mock.Call
+}
+
+// EditMessage is a helper method to define mock.On call
+//   - ctx
+//   - actorID
+//   - messageID
+//   - content
+func (_e *MockChatService_Expecter) EditMessage(ctx interface{}, actorID interface{}, messageID interface{}, content interface{}) *MockChatService_EditMessage_Call {
+	return &MockChatService_EditMessage_Call{Call: _e.mock.On("EditMessage", ctx, actorID, messageID, content)}
+}
+
+func (_c *MockChatService_EditMessage_Call) Run(run func(ctx context.Context, actorID int32, messageID int32, content string)) *MockChatService_EditMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockChatService_EditMessage_Call) Return(message dbCtx.Message, err error) *MockChatService_EditMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatService_EditMessage_Call) RunAndReturn(run func(ctx context.Context, actorID int32, messageID int32, content string) (dbCtx.Message, error)) *MockChatService_EditMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMessages provides a mock function for the type MockChatService
-func (_mock *MockChatService) GetMessages(ctx context.Context, limit int32, offset int32) ([]dbCtx.GetMessagesRow, error) {
-	ret := _mock.Called(ctx, limit, offset)
+func (_mock *MockChatService) GetMessages(ctx context.Context, viewerID int32, roomID int32, limit int32, offset int32) ([]dbCtx.GetMessagesRow, error) {
+	ret := _mock.Called(ctx, viewerID, roomID, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMessages")
@@ -48,18 +337,18 @@ func (_mock *MockChatService) GetMessages(ctx context.Context, limit int32, offs
 
 	var r0 []dbCtx.GetMessagesRow
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) ([]dbCtx.GetMessagesRow, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32, int32) ([]dbCtx.GetMessagesRow, error)); ok {
+		return returnFunc(ctx, viewerID, roomID, limit, offset)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) []dbCtx.GetMessagesRow); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32, int32) []dbCtx.GetMessagesRow); ok {
+		r0 = returnFunc(ctx, viewerID, roomID, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dbCtx.GetMessagesRow)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, int32, int32) error); ok {
+		r1 = returnFunc(ctx, viewerID, roomID, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,15 +362,17 @@ type MockChatService_GetMessages_Call struct {
 
 // GetMessages is a helper method to define mock.On call
 //   - ctx
+//   - viewerID
+//   - roomID
 //   - limit
 //   - offset
-func (_e *MockChatService_Expecter) GetMessages(ctx interface{}, limit interface{}, offset interface{}) *MockChatService_GetMessages_Call {
-	return &MockChatService_GetMessages_Call{Call: _e.mock.On("GetMessages", ctx, limit, offset)}
+func (_e *MockChatService_Expecter) GetMessages(ctx interface{}, viewerID interface{}, roomID interface{}, limit interface{}, offset interface{}) *MockChatService_GetMessages_Call {
+	return &MockChatService_GetMessages_Call{Call: _e.mock.On("GetMessages", ctx, viewerID, roomID, limit, offset)}
 }
 
-func (_c *MockChatService_GetMessages_Call) Run(run func(ctx context.Context, limit int32, offset int32)) *MockChatService_GetMessages_Call {
+func (_c *MockChatService_GetMessages_Call) Run(run func(ctx context.Context, viewerID int32, roomID int32, limit int32, offset int32)) *MockChatService_GetMessages_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int32), args[2].(int32))
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(int32), args[4].(int32))
 	})
 	return _c
 }
@@ -91,54 +382,909 @@ func (_c *MockChatService_GetMessages_Call) Return(getMessagesRows []dbCtx.GetMe
 	return _c
 }
 
-func (_c *MockChatService_GetMessages_Call) RunAndReturn(run func(ctx context.Context, limit int32, offset int32) ([]dbCtx.GetMessagesRow, error)) *MockChatService_GetMessages_Call {
+func (_c *MockChatService_GetMessages_Call) RunAndReturn(run func(ctx context.Context, viewerID int32, roomID int32, limit int32, offset int32) ([]dbCtx.GetMessagesRow, error)) *MockChatService_GetMessages_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SaveMessage provides a mock function for the type MockChatService
-func (_mock *MockChatService) SaveMessage(ctx context.Context, senderID int32, content string) error {
-	ret := _mock.Called(ctx, senderID, content)
+// JoinRoom provides a mock function for the type MockChatService
+func (_mock *MockChatService) JoinRoom(ctx context.Context, userID int32, roomID int32) error {
+	ret := _mock.Called(ctx, userID, roomID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SaveMessage")
+		panic("no return value specified for JoinRoom")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, string) error); ok {
-		r0 = returnFunc(ctx, senderID, content)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = returnFunc(ctx, userID, roomID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockChatService_SaveMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMessage'
-type MockChatService_SaveMessage_Call struct {
+// MockChatService_JoinRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'JoinRoom'
+type MockChatService_JoinRoom_Call struct {
 	*mock.Call
 }
 
-// SaveMessage is a helper method to define mock.On call
+// JoinRoom is a helper method to define mock.On call
 //   - ctx
-//   - senderID
-//   - content
-func (_e *MockChatService_Expecter) SaveMessage(ctx interface{}, senderID interface{}, content interface{}) *MockChatService_SaveMessage_Call {
-	return &MockChatService_SaveMessage_Call{Call: _e.mock.On("SaveMessage", ctx, senderID, content)}
+//   - userID
+//   - roomID
+func (_e *MockChatService_Expecter) JoinRoom(ctx interface{}, userID interface{}, roomID interface{}) *MockChatService_JoinRoom_Call {
+	return &MockChatService_JoinRoom_Call{Call: _e.mock.On("JoinRoom", ctx, userID, roomID)}
 }
 
-func (_c *MockChatService_SaveMessage_Call) Run(run func(ctx context.Context, senderID int32, content string)) *MockChatService_SaveMessage_Call {
+func (_c *MockChatService_JoinRoom_Call) Run(run func(ctx context.Context, userID int32, roomID int32)) *MockChatService_JoinRoom_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(int32), args[2].(string))
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_JoinRoom_Call) Return(err error) *MockChatService_JoinRoom_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChatService_JoinRoom_Call) RunAndReturn(run func(ctx context.Context, userID int32, roomID int32) error) *MockChatService_JoinRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LeaveRoom provides a mock function for the type MockChatService
+func (_mock *MockChatService) LeaveRoom(ctx context.Context, userID int32, roomID int32) error {
+	ret := _mock.Called(ctx, userID, roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaveRoom")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) error); ok {
+		r0 = returnFunc(ctx, userID, roomID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChatService_LeaveRoom_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LeaveRoom'
+type MockChatService_LeaveRoom_Call struct {
+	*mock.Call
+}
+
+// LeaveRoom is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - roomID
+func (_e *MockChatService_Expecter) LeaveRoom(ctx interface{}, userID interface{}, roomID interface{}) *MockChatService_LeaveRoom_Call {
+	return &MockChatService_LeaveRoom_Call{Call: _e.mock.On("LeaveRoom", ctx, userID, roomID)}
+}
+
+func (_c *MockChatService_LeaveRoom_Call) Run(run func(ctx context.Context, userID int32, roomID int32)) *MockChatService_LeaveRoom_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32))
 	})
 	return _c
 }
 
-func (_c *MockChatService_SaveMessage_Call) Return(err error) *MockChatService_SaveMessage_Call {
+func (_c *MockChatService_LeaveRoom_Call) Return(err error) *MockChatService_LeaveRoom_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChatService_SaveMessage_Call) RunAndReturn(run func(ctx context.Context, senderID int32, content string) error) *MockChatService_SaveMessage_Call {
+func (_c *MockChatService_LeaveRoom_Call) RunAndReturn(run func(ctx context.Context, userID int32, roomID int32) error) *MockChatService_LeaveRoom_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRooms provides a mock function for the type MockChatService
+func (_mock *MockChatService) ListRooms(ctx context.Context, userID int32) ([]dbCtx.ListChatRoomsRow, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRooms")
+	}
+
+	var r0 []dbCtx.ListChatRoomsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]dbCtx.ListChatRoomsRow, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []dbCtx.ListChatRoomsRow); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListChatRoomsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_ListRooms_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRooms'
+type MockChatService_ListRooms_Call struct {
+	*mock.Call
+}
+
+// ListRooms is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockChatService_Expecter) ListRooms(ctx interface{}, userID interface{}) *MockChatService_ListRooms_Call {
+	return &MockChatService_ListRooms_Call{Call: _e.mock.On("ListRooms", ctx, userID)}
+}
+
+func (_c *MockChatService_ListRooms_Call) Run(run func(ctx context.Context, userID int32)) *MockChatService_ListRooms_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_ListRooms_Call) Return(listChatRoomsRows []dbCtx.ListChatRoomsRow, err error) *MockChatService_ListRooms_Call {
+	_c.Call.Return(listChatRoomsRows, err)
+	return _c
+}
+
+func (_c *MockChatService_ListRooms_Call) RunAndReturn(run func(ctx context.Context, userID int32) ([]dbCtx.ListChatRoomsRow, error)) *MockChatService_ListRooms_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkConversationRead provides a mock function for the type MockChatService
+func (_mock *MockChatService) MarkConversationRead(ctx context.Context, userID int32, peerID int32, messageID int32) (int32, error) {
+	ret := _mock.Called(ctx, userID, peerID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkConversationRead")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32) (int32, error)); ok {
+		return returnFunc(ctx, userID, peerID, messageID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32) int32); ok {
+		r0 = returnFunc(ctx, userID, peerID, messageID)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, int32) error); ok {
+		r1 = returnFunc(ctx, userID, peerID, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_MarkConversationRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkConversationRead'
+type MockChatService_MarkConversationRead_Call struct {
+	*mock.Call
+}
+
+// MarkConversationRead is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - peerID
+//   - messageID
+func (_e *MockChatService_Expecter) MarkConversationRead(ctx interface{}, userID interface{}, peerID interface{}, messageID interface{}) *MockChatService_MarkConversationRead_Call {
+	return &MockChatService_MarkConversationRead_Call{Call: _e.mock.On("MarkConversationRead", ctx, userID, peerID, messageID)}
+}
+
+func (_c *MockChatService_MarkConversationRead_Call) Run(run func(ctx context.Context, userID int32, peerID int32, messageID int32)) *MockChatService_MarkConversationRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_MarkConversationRead_Call) Return(n int32, err error) *MockChatService_MarkConversationRead_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatService_MarkConversationRead_Call) RunAndReturn(run func(ctx context.Context, userID int32, peerID int32, messageID int32) (int32, error)) *MockChatService_MarkConversationRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRoomRead provides a mock function for the type MockChatService
+func (_mock *MockChatService) MarkRoomRead(ctx context.Context, userID int32, roomID int32, messageID int32) (int32, error) {
+	ret := _mock.Called(ctx, userID, roomID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRoomRead")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32) (int32, error)); ok {
+		return returnFunc(ctx, userID, roomID, messageID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32) int32); ok {
+		r0 = returnFunc(ctx, userID, roomID, messageID)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, int32) error); ok {
+		r1 = returnFunc(ctx, userID, roomID, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_MarkRoomRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRoomRead'
+type MockChatService_MarkRoomRead_Call struct {
+	*mock.Call
+}
+
+// MarkRoomRead is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - roomID
+//   - messageID
+func (_e *MockChatService_Expecter) MarkRoomRead(ctx interface{}, userID interface{}, roomID interface{}, messageID interface{}) *MockChatService_MarkRoomRead_Call {
+	return &MockChatService_MarkRoomRead_Call{Call: _e.mock.On("MarkRoomRead", ctx, userID, roomID, messageID)}
+}
+
+func (_c *MockChatService_MarkRoomRead_Call) Run(run func(ctx context.Context, userID int32, roomID int32, messageID int32)) *MockChatService_MarkRoomRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_MarkRoomRead_Call) Return(n int32, err error) *MockChatService_MarkRoomRead_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChatService_MarkRoomRead_Call) RunAndReturn(run func(ctx context.Context, userID int32, roomID int32, messageID int32) (int32, error)) *MockChatService_MarkRoomRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MessagesSince provides a mock function for the type MockChatService
+func (_mock *MockChatService) MessagesSince(ctx context.Context, userID int32, since chat.ReplayPoint, limit int32) ([]dbCtx.ListMessagesSinceRow, error) {
+	ret := _mock.Called(ctx, userID, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessagesSince")
+	}
+
+	var r0 []dbCtx.ListMessagesSinceRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, chat.ReplayPoint, int32) ([]dbCtx.ListMessagesSinceRow, error)); ok {
+		return returnFunc(ctx, userID, since, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, chat.ReplayPoint, int32) []dbCtx.ListMessagesSinceRow); ok {
+		r0 = returnFunc(ctx, userID, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListMessagesSinceRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, chat.ReplayPoint, int32) error); ok {
+		r1 = returnFunc(ctx, userID, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_MessagesSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MessagesSince'
+type MockChatService_MessagesSince_Call struct {
+	*mock.Call
+}
+
+// MessagesSince is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - since
+//   - limit
+func (_e *MockChatService_Expecter) MessagesSince(ctx interface{}, userID interface{}, since interface{}, limit interface{}) *MockChatService_MessagesSince_Call {
+	return &MockChatService_MessagesSince_Call{Call: _e.mock.On("MessagesSince", ctx, userID, since, limit)}
+}
+
+func (_c *MockChatService_MessagesSince_Call) Run(run func(ctx context.Context, userID int32, since chat.ReplayPoint, limit int32)) *MockChatService_MessagesSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(chat.ReplayPoint), args[3].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_MessagesSince_Call) Return(listMessagesSinceRows []dbCtx.ListMessagesSinceRow, err error) *MockChatService_MessagesSince_Call {
+	_c.Call.Return(listMessagesSinceRows, err)
+	return _c
+}
+
+func (_c *MockChatService_MessagesSince_Call) RunAndReturn(run func(ctx context.Context, userID int32, since chat.ReplayPoint, limit int32) ([]dbCtx.ListMessagesSinceRow, error)) *MockChatService_MessagesSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// React provides a mock function for the type MockChatService
+func (_mock *MockChatService) React(ctx context.Context, userID int32, messageID int32, emoji string) (chat.Reaction, error) {
+	ret := _mock.Called(ctx, userID, messageID, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for React")
+	}
+
+	var r0 chat.Reaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) (chat.Reaction, error)); ok {
+		return returnFunc(ctx, userID, messageID, emoji)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) chat.Reaction); ok {
+		r0 = returnFunc(ctx, userID, messageID, emoji)
+	} else {
+		r0 = ret.Get(0).(chat.Reaction)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, string) error); ok {
+		r1 = returnFunc(ctx, userID, messageID, emoji)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_React_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'React'
+type MockChatService_React_Call struct {
+	*mock.Call
+}
+
+// React is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - messageID
+//   - emoji
+func (_e *MockChatService_Expecter) React(ctx interface{}, userID interface{}, messageID interface{}, emoji interface{}) *MockChatService_React_Call {
+	return &MockChatService_React_Call{Call: _e.mock.On("React", ctx, userID, messageID, emoji)}
+}
+
+func (_c *MockChatService_React_Call) Run(run func(ctx context.Context, userID int32, messageID int32, emoji string)) *MockChatService_React_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockChatService_React_Call) Return(reaction chat.Reaction, err error) *MockChatService_React_Call {
+	_c.Call.Return(reaction, err)
+	return _c
+}
+
+func (_c *MockChatService_React_Call) RunAndReturn(run func(ctx context.Context, userID int32, messageID int32, emoji string) (chat.Reaction, error)) *MockChatService_React_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reactions provides a mock function for the type MockChatService
+func (_mock *MockChatService) Reactions(ctx context.Context, userID int32, messageID int32) ([]dbCtx.ListMessageReactionsRow, error) {
+	ret := _mock.Called(ctx, userID, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reactions")
+	}
+
+	var r0 []dbCtx.ListMessageReactionsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) ([]dbCtx.ListMessageReactionsRow, error)); ok {
+		return returnFunc(ctx, userID, messageID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) []dbCtx.ListMessageReactionsRow); ok {
+		r0 = returnFunc(ctx, userID, messageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListMessageReactionsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = returnFunc(ctx, userID, messageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_Reactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reactions'
+type MockChatService_Reactions_Call struct {
+	*mock.Call
+}
+
+// Reactions is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - messageID
+func (_e *MockChatService_Expecter) Reactions(ctx interface{}, userID interface{}, messageID interface{}) *MockChatService_Reactions_Call {
+	return &MockChatService_Reactions_Call{Call: _e.mock.On("Reactions", ctx, userID, messageID)}
+}
+
+func (_c *MockChatService_Reactions_Call) Run(run func(ctx context.Context, userID int32, messageID int32)) *MockChatService_Reactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_Reactions_Call) Return(listMessageReactionsRows []dbCtx.ListMessageReactionsRow, err error) *MockChatService_Reactions_Call {
+	_c.Call.Return(listMessageReactionsRows, err)
+	return _c
+}
+
+func (_c *MockChatService_Reactions_Call) RunAndReturn(run func(ctx context.Context, userID int32, messageID int32) ([]dbCtx.ListMessageReactionsRow, error)) *MockChatService_Reactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reply provides a mock function for the type MockChatService
+func (_mock *MockChatService) Reply(ctx context.Context, senderID int32, parentID int32, content string) (dbCtx.Message, int64, error) {
+	ret := _mock.Called(ctx, senderID, parentID, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reply")
+	}
+
+	var r0 dbCtx.Message
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) (dbCtx.Message, int64, error)); ok {
+		return returnFunc(ctx, senderID, parentID, content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, senderID, parentID, content)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, string) int64); ok {
+		r1 = returnFunc(ctx, senderID, parentID, content)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int32, int32, string) error); ok {
+		r2 = returnFunc(ctx, senderID, parentID, content)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockChatService_Reply_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reply'
+type MockChatService_Reply_Call struct {
+	*mock.Call
+}
+
+// Reply is a helper method to define mock.On call
+//   - ctx
+//   - senderID
+//   - parentID
+//   - content
+func (_e *MockChatService_Expecter) Reply(ctx interface{}, senderID interface{}, parentID interface{}, content interface{}) *MockChatService_Reply_Call {
+	return &MockChatService_Reply_Call{Call: _e.mock.On("Reply", ctx, senderID, parentID, content)}
+}
+
+func (_c *MockChatService_Reply_Call) Run(run func(ctx context.Context, senderID int32, parentID int32, content string)) *MockChatService_Reply_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockChatService_Reply_Call) Return(message dbCtx.Message, n int64, err error) *MockChatService_Reply_Call {
+	_c.Call.Return(message, n, err)
+	return _c
+}
+
+func (_c *MockChatService_Reply_Call) RunAndReturn(run func(ctx context.Context, senderID int32, parentID int32, content string) (dbCtx.Message, int64, error)) *MockChatService_Reply_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RoomIDs provides a mock function for the type MockChatService
+func (_mock *MockChatService) RoomIDs(ctx context.Context, userID int32) ([]int32, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RoomIDs")
+	}
+
+	var r0 []int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]int32, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []int32); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_RoomIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RoomIDs'
+type MockChatService_RoomIDs_Call struct {
+	*mock.Call
+}
+
+// RoomIDs is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockChatService_Expecter) RoomIDs(ctx interface{}, userID interface{}) *MockChatService_RoomIDs_Call {
+	return &MockChatService_RoomIDs_Call{Call: _e.mock.On("RoomIDs", ctx, userID)}
+}
+
+func (_c *MockChatService_RoomIDs_Call) Run(run func(ctx context.Context, userID int32)) *MockChatService_RoomIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_RoomIDs_Call) Return(ns []int32, err error) *MockChatService_RoomIDs_Call {
+	_c.Call.Return(ns, err)
+	return _c
+}
+
+func (_c *MockChatService_RoomIDs_Call) RunAndReturn(run func(ctx context.Context, userID int32) ([]int32, error)) *MockChatService_RoomIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RoomMembers provides a mock function for the type MockChatService
+func (_mock *MockChatService) RoomMembers(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error) {
+	ret := _mock.Called(ctx, roomID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RoomMembers")
+	}
+
+	var r0 []dbCtx.ListRoomMembersRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]dbCtx.ListRoomMembersRow, error)); ok {
+		return returnFunc(ctx, roomID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []dbCtx.ListRoomMembersRow); ok {
+		r0 = returnFunc(ctx, roomID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.ListRoomMembersRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, roomID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_RoomMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RoomMembers'
+type MockChatService_RoomMembers_Call struct {
+	*mock.Call
+}
+
+// RoomMembers is a helper method to define mock.On call
+//   - ctx
+//   - roomID
+func (_e *MockChatService_Expecter) RoomMembers(ctx interface{}, roomID interface{}) *MockChatService_RoomMembers_Call {
+	return &MockChatService_RoomMembers_Call{Call: _e.mock.On("RoomMembers", ctx, roomID)}
+}
+
+func (_c *MockChatService_RoomMembers_Call) Run(run func(ctx context.Context, roomID int32)) *MockChatService_RoomMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_RoomMembers_Call) Return(listRoomMembersRows []dbCtx.ListRoomMembersRow, err error) *MockChatService_RoomMembers_Call {
+	_c.Call.Return(listRoomMembersRows, err)
+	return _c
+}
+
+func (_c *MockChatService_RoomMembers_Call) RunAndReturn(run func(ctx context.Context, roomID int32) ([]dbCtx.ListRoomMembersRow, error)) *MockChatService_RoomMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveMessage provides a mock function for the type MockChatService
+func (_mock *MockChatService) SaveMessage(ctx context.Context, senderID int32, roomID int32, content string) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, senderID, roomID, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMessage")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, senderID, roomID, content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, senderID, roomID, content)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, string) error); ok {
+		r1 = returnFunc(ctx, senderID, roomID, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_SaveMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveMessage'
+type MockChatService_SaveMessage_Call struct {
+	*mock.Call
+}
+
+// SaveMessage is a helper method to define mock.On call
+//   - ctx
+//   - senderID
+//   - roomID
+//   - content
+func (_e *MockChatService_Expecter) SaveMessage(ctx interface{}, senderID interface{}, roomID interface{}, content interface{}) *MockChatService_SaveMessage_Call {
+	return &MockChatService_SaveMessage_Call{Call: _e.mock.On("SaveMessage", ctx, senderID, roomID, content)}
+}
+
+func (_c *MockChatService_SaveMessage_Call) Run(run func(ctx context.Context, senderID int32, roomID int32, content string)) *MockChatService_SaveMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockChatService_SaveMessage_Call) Return(message dbCtx.Message, err error) *MockChatService_SaveMessage_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatService_SaveMessage_Call) RunAndReturn(run func(ctx context.Context, senderID int32, roomID int32, content string) (dbCtx.Message, error)) *MockChatService_SaveMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendDirect provides a mock function for the type MockChatService
+func (_mock *MockChatService) SendDirect(ctx context.Context, senderID int32, recipientID int32, content string) (dbCtx.Message, error) {
+	ret := _mock.Called(ctx, senderID, recipientID, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDirect")
+	}
+
+	var r0 dbCtx.Message
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) (dbCtx.Message, error)); ok {
+		return returnFunc(ctx, senderID, recipientID, content)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) dbCtx.Message); ok {
+		r0 = returnFunc(ctx, senderID, recipientID, content)
+	} else {
+		r0 = ret.Get(0).(dbCtx.Message)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, string) error); ok {
+		r1 = returnFunc(ctx, senderID, recipientID, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_SendDirect_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDirect'
+type MockChatService_SendDirect_Call struct {
+	*mock.Call
+}
+
+// SendDirect is a helper method to define mock.On call
+//   - ctx
+//   - senderID
+//   - recipientID
+//   - content
+func (_e *MockChatService_Expecter) SendDirect(ctx interface{}, senderID interface{}, recipientID interface{}, content interface{}) *MockChatService_SendDirect_Call {
+	return &MockChatService_SendDirect_Call{Call: _e.mock.On("SendDirect", ctx, senderID, recipientID, content)}
+}
+
+func (_c *MockChatService_SendDirect_Call) Run(run func(ctx context.Context, senderID int32, recipientID int32, content string)) *MockChatService_SendDirect_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockChatService_SendDirect_Call) Return(message dbCtx.Message, err error) *MockChatService_SendDirect_Call {
+	_c.Call.Return(message, err)
+	return _c
+}
+
+func (_c *MockChatService_SendDirect_Call) RunAndReturn(run func(ctx context.Context, senderID int32, recipientID int32, content string) (dbCtx.Message, error)) *MockChatService_SendDirect_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Thread provides a mock function for the type MockChatService
+func (_mock *MockChatService) Thread(ctx context.Context, viewerID int32, messageID int32, limit int32, offset int32) ([]dbCtx.GetThreadRow, error) {
+	ret := _mock.Called(ctx, viewerID, messageID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Thread")
+	}
+
+	var r0 []dbCtx.GetThreadRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32, int32) ([]dbCtx.GetThreadRow, error)); ok {
+		return returnFunc(ctx, viewerID, messageID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, int32, int32) []dbCtx.GetThreadRow); ok {
+		r0 = returnFunc(ctx, viewerID, messageID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.GetThreadRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, int32, int32) error); ok {
+		r1 = returnFunc(ctx, viewerID, messageID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_Thread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Thread'
+type MockChatService_Thread_Call struct {
+	*mock.Call
+}
+
+// Thread is a helper method to define mock.On call
+//   - ctx
+//   - viewerID
+//   - messageID
+//   - limit
+//   - offset
+func (_e *MockChatService_Expecter) Thread(ctx interface{}, viewerID interface{}, messageID interface{}, limit interface{}, offset interface{}) *MockChatService_Thread_Call {
+	return &MockChatService_Thread_Call{Call: _e.mock.On("Thread", ctx, viewerID, messageID, limit, offset)}
+}
+
+func (_c *MockChatService_Thread_Call) Run(run func(ctx context.Context, viewerID int32, messageID int32, limit int32, offset int32)) *MockChatService_Thread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(int32), args[4].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_Thread_Call) Return(getThreadRows []dbCtx.GetThreadRow, err error) *MockChatService_Thread_Call {
+	_c.Call.Return(getThreadRows, err)
+	return _c
+}
+
+func (_c *MockChatService_Thread_Call) RunAndReturn(run func(ctx context.Context, viewerID int32, messageID int32, limit int32, offset int32) ([]dbCtx.GetThreadRow, error)) *MockChatService_Thread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unreact provides a mock function for the type MockChatService
+func (_mock *MockChatService) Unreact(ctx context.Context, userID int32, messageID int32, emoji string) (chat.Reaction, error) {
+	ret := _mock.Called(ctx, userID, messageID, emoji)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unreact")
+	}
+
+	var r0 chat.Reaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) (chat.Reaction, error)); ok {
+		return returnFunc(ctx, userID, messageID, emoji)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32, string) chat.Reaction); ok {
+		r0 = returnFunc(ctx, userID, messageID, emoji)
+	} else {
+		r0 = ret.Get(0).(chat.Reaction)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32, string) error); ok {
+		r1 = returnFunc(ctx, userID, messageID, emoji)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_Unreact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unreact'
+type MockChatService_Unreact_Call struct {
+	*mock.Call
+}
+
+// Unreact is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - messageID
+//   - emoji
+func (_e *MockChatService_Expecter) Unreact(ctx interface{}, userID interface{}, messageID interface{}, emoji interface{}) *MockChatService_Unreact_Call {
+	return &MockChatService_Unreact_Call{Call: _e.mock.On("Unreact", ctx, userID, messageID, emoji)}
+}
+
+func (_c *MockChatService_Unreact_Call) Run(run func(ctx context.Context, userID int32, messageID int32, emoji string)) *MockChatService_Unreact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(int32), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockChatService_Unreact_Call) Return(reaction chat.Reaction, err error) *MockChatService_Unreact_Call {
+	_c.Call.Return(reaction, err)
+	return _c
+}
+
+func (_c *MockChatService_Unreact_Call) RunAndReturn(run func(ctx context.Context, userID int32, messageID int32, emoji string) (chat.Reaction, error)) *MockChatService_Unreact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnreadCounts provides a mock function for the type MockChatService
+func (_mock *MockChatService) UnreadCounts(ctx context.Context, userID int32) (chat.UnreadCounts, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnreadCounts")
+	}
+
+	var r0 chat.UnreadCounts
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (chat.UnreadCounts, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) chat.UnreadCounts); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(chat.UnreadCounts)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_UnreadCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnreadCounts'
+type MockChatService_UnreadCounts_Call struct {
+	*mock.Call
+}
+
+// UnreadCounts is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockChatService_Expecter) UnreadCounts(ctx interface{}, userID interface{}) *MockChatService_UnreadCounts_Call {
+	return &MockChatService_UnreadCounts_Call{Call: _e.mock.On("UnreadCounts", ctx, userID)}
+}
+
+func (_c *MockChatService_UnreadCounts_Call) Run(run func(ctx context.Context, userID int32)) *MockChatService_UnreadCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockChatService_UnreadCounts_Call) Return(unreadCounts chat.UnreadCounts, err error) *MockChatService_UnreadCounts_Call {
+	_c.Call.Return(unreadCounts, err)
+	return _c
+}
+
+func (_c *MockChatService_UnreadCounts_Call) RunAndReturn(run func(ctx context.Context, userID int32) (chat.UnreadCounts, error)) *MockChatService_UnreadCounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -8,7 +8,9 @@ import (
 	"example.com/api/internal/services"
 	"example.com/api/internal/services/chat"
 	"example.com/api/internal/services/hashing"
+	"example.com/api/internal/services/mailing"
 	"example.com/api/internal/storage"
+	"example.com/api/internal/storage/blob"
 	"example.com/api/internal/storage/cache"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -40,6 +42,52 @@ func (_m *MockServiceManager) EXPECT() *MockServiceManager_Expecter {
 	return &MockServiceManager_Expecter{mock: &_m.Mock}
 }
 
+// Audit provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Audit() services.IAuditService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Audit")
+	}
+
+	var r0 services.IAuditService
+	if returnFunc, ok := ret.Get(0).(func() services.IAuditService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IAuditService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Audit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Audit'
+type MockServiceManager_Audit_Call struct {
+	*mock.Call
+}
+
+// Audit is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Audit() *MockServiceManager_Audit_Call {
+	return &MockServiceManager_Audit_Call{Call: _e.mock.On("Audit")}
+}
+
+func (_c *MockServiceManager_Audit_Call) Run(run func()) *MockServiceManager_Audit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Audit_Call) Return(iAuditService services.IAuditService) *MockServiceManager_Audit_Call {
+	_c.Call.Return(iAuditService)
+	return _c
+}
+
+func (_c *MockServiceManager_Audit_Call) RunAndReturn(run func() services.IAuditService) *MockServiceManager_Audit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Auth provides a mock function for the type MockServiceManager
 func (_mock *MockServiceManager) Auth() services.IAuthService {
 	ret := _mock.Called()
@@ -86,6 +134,144 @@ func (_c *MockServiceManager_Auth_Call) RunAndReturn(run func() services.IAuthSe
 	return _c
 }
 
+// Avatar provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Avatar() services.IAvatarService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Avatar")
+	}
+
+	var r0 services.IAvatarService
+	if returnFunc, ok := ret.Get(0).(func() services.IAvatarService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IAvatarService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Avatar_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Avatar'
+type MockServiceManager_Avatar_Call struct {
+	*mock.Call
+}
+
+// Avatar is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Avatar() *MockServiceManager_Avatar_Call {
+	return &MockServiceManager_Avatar_Call{Call: _e.mock.On("Avatar")}
+}
+
+func (_c *MockServiceManager_Avatar_Call) Run(run func()) *MockServiceManager_Avatar_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Avatar_Call) Return(iAvatarService services.IAvatarService) *MockServiceManager_Avatar_Call {
+	_c.Call.Return(iAvatarService)
+	return _c
+}
+
+func (_c *MockServiceManager_Avatar_Call) RunAndReturn(run func() services.IAvatarService) *MockServiceManager_Avatar_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BlobStorage provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) BlobStorage() blob.IBlobStorage {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlobStorage")
+	}
+
+	var r0 blob.IBlobStorage
+	if returnFunc, ok := ret.Get(0).(func() blob.IBlobStorage); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(blob.IBlobStorage)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_BlobStorage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlobStorage'
+type MockServiceManager_BlobStorage_Call struct {
+	*mock.Call
+}
+
+// BlobStorage is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) BlobStorage() *MockServiceManager_BlobStorage_Call {
+	return &MockServiceManager_BlobStorage_Call{Call: _e.mock.On("BlobStorage")}
+}
+
+func (_c *MockServiceManager_BlobStorage_Call) Run(run func()) *MockServiceManager_BlobStorage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_BlobStorage_Call) Return(iBlobStorage blob.IBlobStorage) *MockServiceManager_BlobStorage_Call {
+	_c.Call.Return(iBlobStorage)
+	return _c
+}
+
+func (_c *MockServiceManager_BlobStorage_Call) RunAndReturn(run func() blob.IBlobStorage) *MockServiceManager_BlobStorage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Block provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Block() services.IBlockService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 services.IBlockService
+	if returnFunc, ok := ret.Get(0).(func() services.IBlockService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IBlockService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Block'
+type MockServiceManager_Block_Call struct {
+	*mock.Call
+}
+
+// Block is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Block() *MockServiceManager_Block_Call {
+	return &MockServiceManager_Block_Call{Call: _e.mock.On("Block")}
+}
+
+func (_c *MockServiceManager_Block_Call) Run(run func()) *MockServiceManager_Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Block_Call) Return(iBlockService services.IBlockService) *MockServiceManager_Block_Call {
+	_c.Call.Return(iBlockService)
+	return _c
+}
+
+func (_c *MockServiceManager_Block_Call) RunAndReturn(run func() services.IBlockService) *MockServiceManager_Block_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CacheStorage provides a mock function for the type MockServiceManager
 func (_mock *MockServiceManager) CacheStorage() cache.ICacheService {
 	ret := _mock.Called()
@@ -178,6 +364,52 @@ func (_c *MockServiceManager_Chat_Call) RunAndReturn(run func() chat.IChatServic
 	return _c
 }
 
+// EmailChange provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) EmailChange() services.IEmailChangeService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailChange")
+	}
+
+	var r0 services.IEmailChangeService
+	if returnFunc, ok := ret.Get(0).(func() services.IEmailChangeService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IEmailChangeService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_EmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EmailChange'
+type MockServiceManager_EmailChange_Call struct {
+	*mock.Call
+}
+
+// EmailChange is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) EmailChange() *MockServiceManager_EmailChange_Call {
+	return &MockServiceManager_EmailChange_Call{Call: _e.mock.On("EmailChange")}
+}
+
+func (_c *MockServiceManager_EmailChange_Call) Run(run func()) *MockServiceManager_EmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_EmailChange_Call) Return(iEmailChangeService services.IEmailChangeService) *MockServiceManager_EmailChange_Call {
+	_c.Call.Return(iEmailChangeService)
+	return _c
+}
+
+func (_c *MockServiceManager_EmailChange_Call) RunAndReturn(run func() services.IEmailChangeService) *MockServiceManager_EmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Hash provides a mock function for the type MockServiceManager
 func (_mock *MockServiceManager) Hash() hashing.IHashService {
 	ret := _mock.Called()
@@ -224,6 +456,236 @@ func (_c *MockServiceManager_Hash_Call) RunAndReturn(run func() hashing.IHashSer
 	return _c
 }
 
+// Invitation provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Invitation() services.IInvitationService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invitation")
+	}
+
+	var r0 services.IInvitationService
+	if returnFunc, ok := ret.Get(0).(func() services.IInvitationService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IInvitationService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Invitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invitation'
+type MockServiceManager_Invitation_Call struct {
+	*mock.Call
+}
+
+// Invitation is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Invitation() *MockServiceManager_Invitation_Call {
+	return &MockServiceManager_Invitation_Call{Call: _e.mock.On("Invitation")}
+}
+
+func (_c *MockServiceManager_Invitation_Call) Run(run func()) *MockServiceManager_Invitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Invitation_Call) Return(iInvitationService services.IInvitationService) *MockServiceManager_Invitation_Call {
+	_c.Call.Return(iInvitationService)
+	return _c
+}
+
+func (_c *MockServiceManager_Invitation_Call) RunAndReturn(run func() services.IInvitationService) *MockServiceManager_Invitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Mail provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Mail() mailing.IMailer {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Mail")
+	}
+
+	var r0 mailing.IMailer
+	if returnFunc, ok := ret.Get(0).(func() mailing.IMailer); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(mailing.IMailer)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Mail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Mail'
+type MockServiceManager_Mail_Call struct {
+	*mock.Call
+}
+
+// Mail is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Mail() *MockServiceManager_Mail_Call {
+	return &MockServiceManager_Mail_Call{Call: _e.mock.On("Mail")}
+}
+
+func (_c *MockServiceManager_Mail_Call) Run(run func()) *MockServiceManager_Mail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Mail_Call) Return(iMailer mailing.IMailer) *MockServiceManager_Mail_Call {
+	_c.Call.Return(iMailer)
+	return _c
+}
+
+func (_c *MockServiceManager_Mail_Call) RunAndReturn(run func() mailing.IMailer) *MockServiceManager_Mail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Organization provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Organization() services.IOrganizationService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Organization")
+	}
+
+	var r0 services.IOrganizationService
+	if returnFunc, ok := ret.Get(0).(func() services.IOrganizationService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IOrganizationService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Organization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Organization'
+type MockServiceManager_Organization_Call struct {
+	*mock.Call
+}
+
+// Organization is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Organization() *MockServiceManager_Organization_Call {
+	return &MockServiceManager_Organization_Call{Call: _e.mock.On("Organization")}
+}
+
+func (_c *MockServiceManager_Organization_Call) Run(run func()) *MockServiceManager_Organization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Organization_Call) Return(iOrganizationService services.IOrganizationService) *MockServiceManager_Organization_Call {
+	_c.Call.Return(iOrganizationService)
+	return _c
+}
+
+func (_c *MockServiceManager_Organization_Call) RunAndReturn(run func() services.IOrganizationService) *MockServiceManager_Organization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Privacy provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Privacy() services.IPrivacyService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Privacy")
+	}
+
+	var r0 services.IPrivacyService
+	if returnFunc, ok := ret.Get(0).(func() services.IPrivacyService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IPrivacyService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Privacy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Privacy'
+type MockServiceManager_Privacy_Call struct {
+	*mock.Call
+}
+
+// Privacy is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Privacy() *MockServiceManager_Privacy_Call {
+	return &MockServiceManager_Privacy_Call{Call: _e.mock.On("Privacy")}
+}
+
+func (_c *MockServiceManager_Privacy_Call) Run(run func()) *MockServiceManager_Privacy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Privacy_Call) Return(iPrivacyService services.IPrivacyService) *MockServiceManager_Privacy_Call {
+	_c.Call.Return(iPrivacyService)
+	return _c
+}
+
+func (_c *MockServiceManager_Privacy_Call) RunAndReturn(run func() services.IPrivacyService) *MockServiceManager_Privacy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Relationship provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Relationship() services.IRelationshipService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Relationship")
+	}
+
+	var r0 services.IRelationshipService
+	if returnFunc, ok := ret.Get(0).(func() services.IRelationshipService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IRelationshipService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Relationship_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Relationship'
+type MockServiceManager_Relationship_Call struct {
+	*mock.Call
+}
+
+// Relationship is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Relationship() *MockServiceManager_Relationship_Call {
+	return &MockServiceManager_Relationship_Call{Call: _e.mock.On("Relationship")}
+}
+
+func (_c *MockServiceManager_Relationship_Call) Run(run func()) *MockServiceManager_Relationship_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Relationship_Call) Return(iRelationshipService services.IRelationshipService) *MockServiceManager_Relationship_Call {
+	_c.Call.Return(iRelationshipService)
+	return _c
+}
+
+func (_c *MockServiceManager_Relationship_Call) RunAndReturn(run func() services.IRelationshipService) *MockServiceManager_Relationship_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TokenStorage provides a mock function for the type MockServiceManager
 func (_mock *MockServiceManager) TokenStorage() storage.ITokenStorage {
 	ret := _mock.Called()
@@ -315,3 +777,49 @@ func (_c *MockServiceManager_User_Call) RunAndReturn(run func() services.IUserSe
 	_c.Call.Return(run)
 	return _c
 }
+
+// Webhook provides a mock function for the type MockServiceManager
+func (_mock *MockServiceManager) Webhook() services.IWebhookService {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Webhook")
+	}
+
+	var r0 services.IWebhookService
+	if returnFunc, ok := ret.Get(0).(func() services.IWebhookService); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(services.IWebhookService)
+		}
+	}
+	return r0
+}
+
+// MockServiceManager_Webhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Webhook'
+type MockServiceManager_Webhook_Call struct {
+	*mock.Call
+}
+
+// Webhook is a helper method to define mock.On call
+func (_e *MockServiceManager_Expecter) Webhook() *MockServiceManager_Webhook_Call {
+	return &MockServiceManager_Webhook_Call{Call: _e.mock.On("Webhook")}
+}
+
+func (_c *MockServiceManager_Webhook_Call) Run(run func()) *MockServiceManager_Webhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockServiceManager_Webhook_Call) Return(iWebhookService services.IWebhookService) *MockServiceManager_Webhook_Call {
+	_c.Call.Return(iWebhookService)
+	return _c
+}
+
+func (_c *MockServiceManager_Webhook_Call) RunAndReturn(run func() services.IWebhookService) *MockServiceManager_Webhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
 	dbCtx "example.com/api/internal/repository/db"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -96,6 +97,64 @@ func (_c *MockUserService_Create_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// CreateTx provides a mock function for the type MockUserService
+func (_mock *MockUserService) CreateTx(ctx context.Context, tx repository.IRepositoryManager, arg dto.CreateUserReq) (*dto.UserResponse, error) {
+	ret := _mock.Called(ctx, tx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTx")
+	}
+
+	var r0 *dto.UserResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.IRepositoryManager, dto.CreateUserReq) (*dto.UserResponse, error)); ok {
+		return returnFunc(ctx, tx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, repository.IRepositoryManager, dto.CreateUserReq) *dto.UserResponse); ok {
+		r0 = returnFunc(ctx, tx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.UserResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, repository.IRepositoryManager, dto.CreateUserReq) error); ok {
+		r1 = returnFunc(ctx, tx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_CreateTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTx'
+type MockUserService_CreateTx_Call struct {
+	*mock.Call
+}
+
+// CreateTx is a helper method to define mock.On call
+//   - ctx
+//   - tx
+//   - arg
+func (_e *MockUserService_Expecter) CreateTx(ctx interface{}, tx interface{}, arg interface{}) *MockUserService_CreateTx_Call {
+	return &MockUserService_CreateTx_Call{Call: _e.mock.On("CreateTx", ctx, tx, arg)}
+}
+
+func (_c *MockUserService_CreateTx_Call) Run(run func(ctx context.Context, tx repository.IRepositoryManager, arg dto.CreateUserReq)) *MockUserService_CreateTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.IRepositoryManager), args[2].(dto.CreateUserReq))
+	})
+	return _c
+}
+
+func (_c *MockUserService_CreateTx_Call) Return(userResponse *dto.UserResponse, err error) *MockUserService_CreateTx_Call {
+	_c.Call.Return(userResponse, err)
+	return _c
+}
+
+func (_c *MockUserService_CreateTx_Call) RunAndReturn(run func(ctx context.Context, tx repository.IRepositoryManager, arg dto.CreateUserReq) (*dto.UserResponse, error)) *MockUserService_CreateTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAll provides a mock function for the type MockUserService
 func (_mock *MockUserService) GetAll(ctx context.Context, arg dto.ListUsersParams) ([]dbCtx.User, error) {
 	ret := _mock.Called(ctx, arg)
@@ -267,6 +326,71 @@ func (_c *MockUserService_GetByID_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// GetByIDs provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetByIDs(ctx context.Context, ids []int32) ([]dbCtx.User, []int32, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDs")
+	}
+
+	var r0 []dbCtx.User
+	var r1 []int32
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int32) ([]dbCtx.User, []int32, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int32) []dbCtx.User); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dbCtx.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int32) []int32); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]int32)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, []int32) error); ok {
+		r2 = returnFunc(ctx, ids)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockUserService_GetByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDs'
+type MockUserService_GetByIDs_Call struct {
+	*mock.Call
+}
+
+// GetByIDs is a helper method to define mock.On call
+//   - ctx
+//   - ids
+func (_e *MockUserService_Expecter) GetByIDs(ctx interface{}, ids interface{}) *MockUserService_GetByIDs_Call {
+	return &MockUserService_GetByIDs_Call{Call: _e.mock.On("GetByIDs", ctx, ids)}
+}
+
+func (_c *MockUserService_GetByIDs_Call) Run(run func(ctx context.Context, ids []int32)) *MockUserService_GetByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int32))
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetByIDs_Call) Return(users []dbCtx.User, ns []int32, err error) *MockUserService_GetByIDs_Call {
+	_c.Call.Return(users, ns, err)
+	return _c
+}
+
+func (_c *MockUserService_GetByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int32) ([]dbCtx.User, []int32, error)) *MockUserService_GetByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByUsername provides a mock function for the type MockUserService
 func (_mock *MockUserService) GetByUsername(ctx context.Context, username string) (*dbCtx.User, error) {
 	ret := _mock.Called(ctx, username)
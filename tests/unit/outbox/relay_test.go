@@ -0,0 +1,104 @@
+package outbox_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"example.com/api/config"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/outbox"
+	repoMocks "example.com/api/tests/unit/mocks/repositories"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	err    error
+	events []outbox.Event
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Publish(ctx context.Context, event outbox.Event) error {
+	s.events = append(s.events, event)
+	return s.err
+}
+
+func relayConfig() config.OutboxConfig {
+	return config.OutboxConfig{
+		BatchSize:     10,
+		LeaseDuration: 60,
+		MinBackoff:    2,
+		MaxBackoff:    10,
+	}
+}
+
+func outboxRow(id int64, attempts int32) dbCtx.OutboxEvent {
+	return dbCtx.OutboxEvent{
+		ID:            id,
+		EventType:     outbox.UserUpdated,
+		AggregateType: outbox.AggregateUser,
+		AggregateID:   1,
+		Payload:       json.RawMessage(`{}`),
+		Attempts:      attempts,
+		CreatedAt:     time.Now(),
+	}
+}
+
+func TestRelay_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	claim := dbCtx.ClaimOutboxEventsParams{LeaseSeconds: 60, BatchSize: 10}
+
+	t.Run("Delivers In Order", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepo(t)
+		logger := mocks.NewMockLogger(t)
+		sink := &recordingSink{}
+
+		repo.EXPECT().Claim(ctx, claim).Return([]dbCtx.OutboxEvent{outboxRow(3, 1), outboxRow(1, 1)}, nil).Once()
+		repo.EXPECT().MarkDelivered(ctx, int64(1)).Return(nil).Once()
+		repo.EXPECT().MarkDelivered(ctx, int64(3)).Return(nil).Once()
+		repo.EXPECT().CountPending(ctx).Return(0, nil).Once()
+
+		n, err := outbox.NewRelay(repo, []outbox.ISink{sink}, logger, relayConfig()).RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		require.Len(t, sink.events, 2)
+		assert.Equal(t, int64(1), sink.events[0].ID)
+		assert.Equal(t, int64(3), sink.events[1].ID)
+	})
+
+	t.Run("Failed Sink Reschedules With Backoff", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepo(t)
+		logger := mocks.NewMockLogger(t)
+		ok := &recordingSink{}
+		failing := &recordingSink{err: errors.New("connection refused")}
+
+		repo.EXPECT().Claim(ctx, claim).Return([]dbCtx.OutboxEvent{outboxRow(1, 1), outboxRow(2, 3), outboxRow(3, 9)}, nil).Once()
+		repo.EXPECT().Reschedule(ctx, dbCtx.RescheduleOutboxEventParams{DelaySeconds: 2, LastError: "connection refused", ID: 1}).Return(nil).Once()
+		repo.EXPECT().Reschedule(ctx, dbCtx.RescheduleOutboxEventParams{DelaySeconds: 8, LastError: "connection refused", ID: 2}).Return(nil).Once()
+		repo.EXPECT().Reschedule(ctx, dbCtx.RescheduleOutboxEventParams{DelaySeconds: 10, LastError: "connection refused", ID: 3}).Return(nil).Once()
+		repo.EXPECT().CountPending(ctx).Return(3, nil).Once()
+		logger.EXPECT().Warn(mock.Anything, mock.Anything, "Failed to publish outbox event", mock.Anything).Times(3)
+
+		n, err := outbox.NewRelay(repo, []outbox.ISink{ok, failing}, logger, relayConfig()).RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, n)
+		assert.Len(t, ok.events, 3)
+	})
+
+	t.Run("Claim Error", func(t *testing.T) {
+		repo := repoMocks.NewMockOutboxRepo(t)
+		logger := mocks.NewMockLogger(t)
+
+		repo.EXPECT().Claim(ctx, claim).Return(nil, errors.New("db down")).Once()
+
+		n, err := outbox.NewRelay(repo, nil, logger, relayConfig()).RunOnce(ctx)
+		require.Error(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -0,0 +1,83 @@
+package outbox_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"example.com/api/internal/services/outbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvent() outbox.Event {
+	return outbox.Event{
+		ID:            42,
+		Type:          outbox.UserCreated,
+		AggregateType: outbox.AggregateUser,
+		AggregateID:   7,
+		OccurredAt:    time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC),
+		Data:          json.RawMessage(`{"id":7,"username":"jdoe"}`),
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Posts Event", func(t *testing.T) {
+		var (
+			header http.Header
+			body   []byte
+		)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Clone()
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		sink := outbox.NewWebhookSink(server.URL, time.Second, server.Client())
+		require.NoError(t, sink.Publish(ctx, testEvent()))
+
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+		assert.Equal(t, "42", header.Get("X-Event-ID"))
+		assert.Equal(t, outbox.UserCreated, header.Get("X-Event-Type"))
+
+		var got outbox.Event
+		require.NoError(t, json.Unmarshal(body, &got))
+		assert.Equal(t, testEvent().ID, got.ID)
+		assert.Equal(t, testEvent().AggregateID, got.AggregateID)
+		assert.JSONEq(t, string(testEvent().Data), string(got.Data))
+	})
+
+	t.Run("Non 2xx Fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		sink := outbox.NewWebhookSink(server.URL, time.Second, server.Client())
+		err := sink.Publish(ctx, testEvent())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+	})
+}
+
+func TestStdoutSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := outbox.NewStdoutSink(&buf)
+
+	require.NoError(t, sink.Publish(context.Background(), testEvent()))
+	require.NoError(t, sink.Publish(context.Background(), testEvent()))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var got outbox.Event
+	require.NoError(t, json.Unmarshal(lines[0], &got))
+	assert.Equal(t, outbox.UserCreated, got.Type)
+}
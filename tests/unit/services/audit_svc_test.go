@@ -0,0 +1,144 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAuditService(t *testing.T) *services.AuditService {
+	return services.NewAuditService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t))
+}
+
+func auditFields(t *testing.T, raw json.RawMessage) map[string]any {
+	fields := map[string]any{}
+	require.NoError(t, json.Unmarshal(raw, &fields))
+	return fields
+}
+
+func TestAuditService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := services.WithActor(services.WithRequestMeta(context.Background(), services.RequestMeta{
+		IP:        "203.0.113.7",
+		RequestID: "req-1",
+	}), 42)
+
+	audit := newTestAuditService(t)
+	newUserService := func(t *testing.T) *services.UserService {
+		mockHash := mocks.NewMockHashService(t)
+		mockHash.EXPECT().Hash(mock.Anything).Return("hashed", nil).Maybe()
+		return services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), mockHash, nil, audit)
+	}
+
+	events := func(t *testing.T, filter dto.AuditEventFilter) []dbCtx.AuditEvent {
+		if filter.Limit == 0 {
+			filter.Limit = 50
+		}
+		events, err := audit.List(ctx, filter)
+		require.NoError(t, err)
+		return events
+	}
+
+	t.Run("Create Records Actor And Request", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		user, err := newUserService(t).Create(ctx, dto.CreateUserReq{
+			Username: "audited",
+			Email:    "audited@example.com",
+			FullName: "Audited",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+
+		got := events(t, dto.AuditEventFilter{Action: services.AuditUserCreated})
+		require.Len(t, got, 1)
+		assert.Equal(t, user.ID, got[0].TargetID.Int32)
+		assert.EqualValues(t, 42, got[0].ActorID.Int32)
+		assert.Equal(t, "203.0.113.7", got[0].Ip)
+		assert.Equal(t, "req-1", got[0].RequestID)
+		assert.Equal(t, "audited@example.com", auditFields(t, got[0].After)["email"])
+		assert.NotContains(t, string(got[0].After), "hashed")
+	})
+
+	t.Run("Update Records Only Changed Fields", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "diff@example.com", "diff_user", "Old Name")
+
+		_, err := newUserService(t).UpdatePartial(ctx, dto.UpdateUserPartialReq{
+			ID:       userID,
+			FullName: ptr("New Name"),
+			Username: ptr("diff_user"),
+		})
+		require.NoError(t, err)
+
+		got := events(t, dto.AuditEventFilter{Action: services.AuditUserUpdated, TargetID: &userID})
+		require.Len(t, got, 1)
+		assert.Equal(t, map[string]any{"fullName": "Old Name"}, auditFields(t, got[0].Before))
+		assert.Equal(t, map[string]any{"fullName": "New Name"}, auditFields(t, got[0].After))
+	})
+
+	t.Run("Failed Update Records Nothing", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		seedUser(t, "taken@example.com", "taken")
+		userID := seedUser(t, "other@example.com", "other")
+		logger := mocks.NewMockLogger(t)
+		logger.EXPECT().Warn(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), logger, nil, nil, audit)
+
+		_, err := userService.UpdatePartial(ctx, dto.UpdateUserPartialReq{ID: userID, Username: ptr("taken")})
+		require.Error(t, err)
+
+		assert.Empty(t, events(t, dto.AuditEventFilter{TargetID: &userID}))
+	})
+
+	t.Run("Delete Records Previous State", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "gone@example.com")
+
+		require.NoError(t, newUserService(t).SoftDelete(ctx, userID))
+
+		got := events(t, dto.AuditEventFilter{Action: services.AuditUserDeleted})
+		require.Len(t, got, 1)
+		assert.Equal(t, "gone@example.com", auditFields(t, got[0].Before)["email"])
+	})
+
+	t.Run("Login Attempts", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "login@example.com", "", "", "stored_hash")
+		mockHash := mocks.NewMockHashService(t)
+		mockHash.EXPECT().Compare("stored_hash", "right").Return(nil).Once()
+		mockHash.EXPECT().Compare("stored_hash", "wrong").Return(assert.AnError).Once()
+		logger := mocks.NewMockLogger(t)
+		logger.EXPECT().Error(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), logger, mockHash, nil, audit)
+		authService := services.NewAuthService(repository.NewRepositoryManager(testDB), config.JWTConfig{}, mockHash, userService, logger, &fakeTokenStorage{}, audit, nil, config.InvitationConfig{})
+		anonymous := services.WithRequestMeta(context.Background(), services.RequestMeta{IP: "198.51.100.1"})
+
+		_, err := authService.Authenticate(anonymous, "login@example.com", "wrong")
+		require.Error(t, err)
+		_, err = authService.Authenticate(anonymous, "nobody@example.com", "wrong")
+		require.Error(t, err)
+		_, err = authService.Authenticate(anonymous, "login@example.com", "right")
+		require.NoError(t, err)
+
+		failed := events(t, dto.AuditEventFilter{Action: services.AuditLoginFailed})
+		require.Len(t, failed, 2)
+		assert.Equal(t, "nobody@example.com", auditFields(t, failed[0].Metadata)["email"])
+		assert.False(t, failed[0].TargetID.Valid)
+		assert.Equal(t, userID, failed[1].TargetID.Int32)
+		assert.Equal(t, "198.51.100.1", failed[1].Ip)
+
+		login := events(t, dto.AuditEventFilter{Action: services.AuditLogin, ActorID: &userID})
+		require.Len(t, login, 1)
+		assert.Equal(t, userID, login[0].TargetID.Int32)
+	})
+}
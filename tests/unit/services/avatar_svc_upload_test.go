@@ -0,0 +1,198 @@
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"example.com/api/config"
+	contracts "example.com/api/internal/contracts/errors"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/storage/blob"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testAvatarConfig = config.AvatarConfig{
+	MaxUploadSize: 1 << 20,
+	MaxDimension:  1024,
+	CacheMaxAge:   60,
+}
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// encodeTestJPEG returns a JPEG carrying an EXIF APP1 segment with the given
+// orientation tag.
+func encodeTestJPEG(t *testing.T, width, height int, orientation uint16) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil))
+	data := buf.Bytes()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry[0:], 0x0112)
+	binary.BigEndian.PutUint16(entry[2:], 3)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(tiff, entry...)
+	tiff = append(tiff, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func readAvatar(t *testing.T, svc *services.AvatarService, userID int32, size string) (image.Config, *services.Avatar) {
+	avatar, err := svc.Open(context.Background(), userID, size)
+	require.NoError(t, err)
+	defer avatar.Body.Close()
+
+	data, err := io.ReadAll(avatar.Body)
+	require.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	require.NoError(t, err)
+	return cfg, avatar
+}
+
+func TestAvatarService_Upload(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+
+	t.Run("Success Generates Variants", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "avatar_success@example.com")
+
+		root := t.TempDir()
+		svc := services.NewAvatarService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), blob.NewLocalStorage(root), testAvatarConfig)
+
+		user, err := svc.Upload(ctx, userID, bytes.NewReader(encodeTestPNG(t, 600, 400)))
+		require.NoError(t, err)
+		require.True(t, user.AvatarUrl.Valid)
+		assert.True(t, strings.HasPrefix(user.AvatarUrl.String, "/api/users/"))
+
+		original, avatar := readAvatar(t, svc, userID, "original")
+		assert.Equal(t, 600, original.Width)
+		assert.Equal(t, 400, original.Height)
+		assert.Equal(t, "image/png", avatar.ContentType)
+		assert.Equal(t, testAvatarConfig.CacheMaxAge, avatar.MaxAge)
+
+		medium, _ := readAvatar(t, svc, userID, "medium")
+		assert.Equal(t, 256, medium.Width)
+		assert.Equal(t, 256, medium.Height)
+
+		small, _ := readAvatar(t, svc, userID, "small")
+		assert.Equal(t, 64, small.Width)
+		assert.Equal(t, 64, small.Height)
+	})
+
+	t.Run("Replacing Removes Previous Version", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "avatar_replace@example.com")
+
+		root := t.TempDir()
+		svc := services.NewAvatarService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), blob.NewLocalStorage(root), testAvatarConfig)
+
+		first, err := svc.Upload(ctx, userID, bytes.NewReader(encodeTestPNG(t, 100, 100)))
+		require.NoError(t, err)
+		second, err := svc.Upload(ctx, userID, bytes.NewReader(encodeTestPNG(t, 120, 120)))
+		require.NoError(t, err)
+		assert.NotEqual(t, first.AvatarUrl.String, second.AvatarUrl.String)
+
+		versions, err := os.ReadDir(filepath.Join(root, "avatars", strconv.Itoa(int(userID))))
+		require.NoError(t, err)
+		assert.Len(t, versions, 1, "only the current version should remain")
+	})
+
+	t.Run("Applies JPEG Orientation", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "avatar_orientation@example.com")
+
+		svc := services.NewAvatarService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), blob.NewLocalStorage(t.TempDir()), testAvatarConfig)
+
+		_, err := svc.Upload(ctx, userID, bytes.NewReader(encodeTestJPEG(t, 40, 20, 6)))
+		require.NoError(t, err)
+
+		original, avatar := readAvatar(t, svc, userID, "original")
+		assert.Equal(t, 20, original.Width)
+		assert.Equal(t, 40, original.Height)
+		assert.Equal(t, "image/jpeg", avatar.ContentType)
+	})
+
+	t.Run("Rejections", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "avatar_reject@example.com")
+
+		cfg := testAvatarConfig
+		cfg.MaxDimension = 200
+		svc := services.NewAvatarService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), blob.NewLocalStorage(t.TempDir()), cfg)
+
+		_, err := svc.Upload(ctx, userID, strings.NewReader("plain text is not an image"))
+		var unsupportedErr *contracts.UnsupportedImageError
+		assert.ErrorAs(t, err, &unsupportedErr)
+
+		_, err = svc.Upload(ctx, userID, bytes.NewReader(encodeTestPNG(t, 300, 100)))
+		var dimensionsErr *contracts.ImageDimensionsError
+		assert.ErrorAs(t, err, &dimensionsErr)
+
+		_, err = svc.Upload(ctx, userID, bytes.NewReader(make([]byte, cfg.MaxUploadSize+1)))
+		var tooLargeErr *contracts.ImageTooLargeError
+		assert.ErrorAs(t, err, &tooLargeErr)
+
+		_, err = svc.Open(ctx, userID, "original")
+		assert.ErrorIs(t, err, services.ErrAvatarNotFound)
+
+		_, err = svc.Open(ctx, userID, "huge")
+		assert.ErrorIs(t, err, services.ErrInvalidAvatarSize)
+	})
+
+	t.Run("User Not Found", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+
+		svc := services.NewAvatarService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), blob.NewLocalStorage(t.TempDir()), testAvatarConfig)
+
+		_, err := svc.Upload(ctx, 99999, bytes.NewReader(encodeTestPNG(t, 10, 10)))
+		require.Error(t, err)
+		assert.Equal(t, "user not found", err.Error())
+	})
+
+	t.Run("Users Of Other Organizations Are Not Found", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "avatar_outsider@example.com")
+		seedMembers(t, seedOrganization(t, "globex"), userID)
+		scoped := tenant.WithID(ctx, seedOrganization(t, "acme"))
+		svc := services.NewAvatarService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), blob.NewLocalStorage(t.TempDir()), testAvatarConfig)
+		_, err := svc.Upload(ctx, userID, bytes.NewReader(encodeTestPNG(t, 10, 10)))
+		require.NoError(t, err)
+
+		_, err = svc.Upload(scoped, userID, bytes.NewReader(encodeTestPNG(t, 10, 10)))
+		assert.EqualError(t, err, "user not found")
+		_, err = svc.Open(scoped, userID, "")
+		assert.EqualError(t, err, "user not found")
+	})
+}
@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"example.com/api/config"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+	repo := repository.NewRepositoryManager(testDB)
+
+	t.Run("Block And Unblock", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		cache := &fakeCache{}
+		svc := services.NewBlockService(repo, mocks.NewMockLogger(t), cache)
+		blocker := seedUser(t, "blocker@example.com", "blocker")
+		blocked := seedUser(t, "blocked@example.com", "blocked")
+
+		assert.ErrorIs(t, svc.Block(ctx, blocker, blocker), services.ErrCannotBlockSelf)
+		assert.EqualError(t, svc.Block(ctx, blocker, 9999), "user not found")
+
+		require.NoError(t, svc.Block(ctx, blocker, blocked))
+		require.NoError(t, svc.Block(ctx, blocker, blocked), "blocking twice is not an error")
+		assert.Equal(t, []string{chat.HistoryViewerPrefix(blocker)}, cache.deletedPrefixes)
+
+		blocks, err := svc.List(ctx, blocker)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, blocked, blocks[0].BlockedID)
+		assert.Equal(t, "blocked", blocks[0].Username)
+
+		blockedBy, err := svc.BlockedBy(ctx, blocked)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{blocker}, blockedBy)
+
+		blockedBy, err = svc.BlockedBy(ctx, blocker)
+		require.NoError(t, err)
+		assert.Empty(t, blockedBy)
+
+		require.NoError(t, svc.Unblock(ctx, blocker, blocked))
+		assert.ErrorIs(t, svc.Unblock(ctx, blocker, blocked), services.ErrBlockNotFound)
+
+		blocks, err = svc.List(ctx, blocker)
+		require.NoError(t, err)
+		assert.Empty(t, blocks)
+	})
+
+	t.Run("List Leaves Out Other Organizations", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc := services.NewBlockService(repo, mocks.NewMockLogger(t), &fakeCache{})
+		blocker := seedUser(t, "blocker@example.com", "blocker")
+		colleague := seedUser(t, "colleague@example.com", "colleague")
+		outsider := seedUser(t, "outsider@example.com", "outsider")
+		acme := seedOrganization(t, "acme")
+		seedMembers(t, acme, blocker, colleague)
+		seedMembers(t, seedOrganization(t, "globex"), blocker, outsider)
+
+		require.NoError(t, svc.Block(ctx, blocker, colleague))
+		require.NoError(t, svc.Block(ctx, blocker, outsider))
+
+		blocks, err := svc.List(tenant.WithID(ctx, acme), blocker)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		assert.Equal(t, colleague, blocks[0].BlockedID)
+
+		assert.EqualError(t, svc.Block(tenant.WithID(ctx, acme), colleague, outsider), "user not found")
+	})
+
+	t.Run("Blocked By Is Not Refilled With A Stale List", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		cache := &fakeCache{}
+		svc := services.NewBlockService(repo, mocks.NewMockLogger(t), cache)
+		blocker := seedUser(t, "blocker@example.com", "blocker")
+		blocked := seedUser(t, "blocked@example.com", "blocked")
+
+		blockedBy, err := svc.BlockedBy(ctx, blocked)
+		require.NoError(t, err)
+		assert.Empty(t, blockedBy)
+		stale := cache.entries
+
+		// A read that started before the block fills the cache after it
+		cache.entries = map[string][]byte{}
+		require.NoError(t, svc.Block(ctx, blocker, blocked))
+		assert.Equal(t, []string{fmt.Sprintf("%s%d:version", services.BlockedByCachePrefix, blocked)}, cache.incremented)
+		for key, value := range stale {
+			cache.entries[key] = value
+		}
+
+		blockedBy, err = svc.BlockedBy(ctx, blocked)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{blocker}, blockedBy)
+	})
+
+	t.Run("History Hides Blocked Senders", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		logger := mocks.NewMockLogger(t)
+		svc := services.NewBlockService(repo, logger, &fakeCache{})
+		chatSvc := chat.NewChatService(repo, logger, &fakeCache{}, nil, config.ChatConfig{})
+		viewer := seedUser(t, "viewer@example.com", "viewer")
+		spammer := seedUser(t, "spammer@example.com", "spammer")
+		friend := seedUser(t, "friend@example.com", "friend")
+		orgID := seedOrganization(t, "default")
+		ctx := tenant.WithID(ctx, orgID)
+		roomID := seedRoom(t, orgID, "general", viewer, spammer, friend)
+
+		for _, sender := range []int32{spammer, friend} {
+			_, err := repo.Chat().CreateMessage(ctx, dbCtx.CreateMessageParams{
+				SenderID:       sender,
+				Content:        "hello",
+				RoomID:         roomID,
+				OrganizationID: orgID,
+			})
+			require.NoError(t, err)
+		}
+		require.NoError(t, svc.Block(ctx, viewer, spammer))
+
+		messages, err := chatSvc.GetMessages(ctx, viewer, roomID, 50, 0)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, friend, messages[0].SenderID)
+
+		messages, err = chatSvc.GetMessages(ctx, friend, roomID, 50, 0)
+		require.NoError(t, err)
+		assert.Len(t, messages, 2, "blocks only filter the blocker's history")
+	})
+}
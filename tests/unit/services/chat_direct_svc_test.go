@@ -0,0 +1,125 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectMessages(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	TruncateTables(t, testDB, testTableNames)
+	repo := repository.NewRepositoryManager(testDB)
+	logger := mocks.NewMockLogger(t)
+	svc := chat.NewChatService(repo, logger, &fakeCache{}, nil, config.ChatConfig{})
+	orgs := services.NewOrganizationService(repo, logger)
+
+	alice := seedUser(t, "alice@example.com", "alice")
+	bob := seedUser(t, "bob@example.com", "bob")
+	carol := seedUser(t, "carol@example.com", "carol")
+	outsider := seedUser(t, "outsider@example.com", "outsider")
+	org, err := orgs.Create(context.Background(), alice, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+	require.NoError(t, err)
+	for _, userID := range []int32{bob, carol} {
+		_, err := orgs.SetMemberRole(context.Background(), org.ID, services.OrgRoleOwner, userID, services.OrgRoleMember)
+		require.NoError(t, err)
+	}
+	ctx := tenant.WithID(context.Background(), org.ID)
+
+	t.Run("Only Reaches Members Who Have Not Blocked The Sender", func(t *testing.T) {
+		_, err := svc.SendDirect(ctx, alice, outsider, "hi")
+		assert.ErrorIs(t, err, chat.ErrRecipientNotFound)
+		_, err = svc.SendDirect(ctx, alice, alice, "note to self")
+		assert.ErrorIs(t, err, chat.ErrRecipientNotFound)
+
+		require.NoError(t, services.NewBlockService(repo, logger, &fakeCache{}).Block(ctx, bob, carol))
+		_, err = svc.SendDirect(ctx, carol, bob, "hi")
+		assert.ErrorIs(t, err, chat.ErrRecipientNotFound)
+	})
+
+	t.Run("Conversations Track Unread Messages", func(t *testing.T) {
+		for _, m := range []struct {
+			from, to int32
+			content  string
+		}{{alice, bob, "one"}, {bob, alice, "two"}, {alice, bob, "three"}} {
+			msg, err := svc.SendDirect(ctx, m.from, m.to, m.content)
+			require.NoError(t, err)
+			assert.Equal(t, m.to, msg.RecipientID.Int32)
+			assert.False(t, msg.RoomID.Valid)
+		}
+
+		conversations, err := svc.Conversations(ctx, bob, 50, 0)
+		require.NoError(t, err)
+		require.Len(t, conversations, 1)
+		assert.Equal(t, alice, conversations[0].PeerID)
+		assert.Equal(t, "three", conversations[0].LastContent)
+		assert.Equal(t, int64(2), conversations[0].UnreadCount)
+
+		messages, err := svc.DirectMessages(ctx, bob, alice, 50, 0)
+		require.NoError(t, err)
+		require.Len(t, messages, 3)
+		assert.Equal(t, "three", messages[0].Content)
+
+		conversations, err = svc.Conversations(ctx, bob, 50, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), conversations[0].UnreadCount)
+		conversations, err = svc.Conversations(ctx, alice, 50, 0)
+		require.NoError(t, err)
+		require.Len(t, conversations, 1)
+		assert.Equal(t, int64(1), conversations[0].UnreadCount)
+	})
+	t.Run("Replays What A User Missed", func(t *testing.T) {
+		room, err := svc.CreateRoom(ctx, alice, "replay")
+		require.NoError(t, err)
+		require.NoError(t, svc.JoinRoom(ctx, bob, room.ID))
+		before, err := svc.SaveMessage(ctx, alice, room.ID, "seen")
+		require.NoError(t, err)
+
+		var want []int32
+		for _, send := range []func() (int32, error){
+			func() (int32, error) { m, err := svc.SaveMessage(ctx, alice, room.ID, "missed"); return m.ID, err },
+			func() (int32, error) { m, err := svc.SendDirect(ctx, alice, bob, "missed too"); return m.ID, err },
+			func() (int32, error) { m, err := svc.SendDirect(ctx, bob, alice, "sent elsewhere"); return m.ID, err },
+		} {
+			id, err := send()
+			require.NoError(t, err)
+			want = append(want, id)
+		}
+		_, err = svc.SendDirect(ctx, alice, carol, "not for bob")
+		require.NoError(t, err)
+
+		missed, err := svc.MessagesSince(ctx, bob, chat.ReplayPoint{AfterID: before.ID}, 100)
+		require.NoError(t, err)
+		got := make([]int32, 0, len(missed))
+		for _, m := range missed {
+			got = append(got, m.ID)
+		}
+		assert.Equal(t, want, got)
+
+		missed, err = svc.MessagesSince(ctx, bob, chat.ReplayPoint{AfterID: before.ID}, 1)
+		require.NoError(t, err)
+		require.Len(t, missed, 1)
+		assert.Equal(t, "alice", missed[0].SenderName)
+	})
+
+	t.Run("Replay Leaves Out Direct Messages From Blocked Users", func(t *testing.T) {
+		before, err := svc.SendDirect(ctx, alice, carol, "seen")
+		require.NoError(t, err)
+		_, err = svc.SendDirect(ctx, alice, carol, "sent before the block")
+		require.NoError(t, err)
+		require.NoError(t, services.NewBlockService(repo, logger, &fakeCache{}).Block(ctx, carol, alice))
+
+		missed, err := svc.MessagesSince(ctx, carol, chat.ReplayPoint{AfterID: before.ID}, 100)
+		require.NoError(t, err)
+		assert.Empty(t, missed)
+	})
+}
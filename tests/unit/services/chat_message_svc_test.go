@@ -0,0 +1,107 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditAndDeleteMessages(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	TruncateTables(t, testDB, testTableNames)
+	repo := repository.NewRepositoryManager(testDB)
+	logger := mocks.NewMockLogger(t)
+	cache := &fakeCache{}
+	svc := chat.NewChatService(repo, logger, cache, nil, config.ChatConfig{EditWindow: 15})
+	orgs := services.NewOrganizationService(repo, logger)
+
+	owner := seedUser(t, "owner@example.com", "owner")
+	alice := seedUser(t, "alice@example.com", "alice")
+	bob := seedUser(t, "bob@example.com", "bob")
+	org, err := orgs.Create(context.Background(), owner, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+	require.NoError(t, err)
+	for _, userID := range []int32{alice, bob} {
+		_, err := orgs.SetMemberRole(context.Background(), org.ID, services.OrgRoleOwner, userID, services.OrgRoleMember)
+		require.NoError(t, err)
+	}
+	ctx := tenant.WithID(context.Background(), org.ID)
+	roomID := seedRoom(t, org.ID, "general", owner, alice, bob)
+
+	t.Run("Authors Edit Their Messages", func(t *testing.T) {
+		msg, err := svc.SaveMessage(ctx, alice, roomID, "helo")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{chat.HistoryGenerationKey(roomID)}, cache.incremented)
+		cache.incremented = nil
+
+		_, err = svc.EditMessage(ctx, bob, msg.ID, "hijacked")
+		assert.ErrorIs(t, err, chat.ErrMessageForbidden)
+
+		edited, err := svc.EditMessage(ctx, alice, msg.ID, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", edited.Content)
+		assert.True(t, edited.EditedAt.Valid)
+		assert.Equal(t, []string{chat.HistoryGenerationKey(roomID)}, cache.incremented)
+
+		var revisions []string
+		rows, err := testDB.QueryContext(context.Background(),
+			`SELECT content FROM message_revisions WHERE message_id = $1 ORDER BY id`, msg.ID)
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+			var content string
+			require.NoError(t, rows.Scan(&content))
+			revisions = append(revisions, content)
+		}
+		assert.Equal(t, []string{"helo"}, revisions)
+	})
+
+	t.Run("Admins Delete Room Messages", func(t *testing.T) {
+		msg, err := svc.SaveMessage(ctx, bob, roomID, "spam")
+		require.NoError(t, err)
+
+		deleted, err := svc.DeleteMessage(ctx, owner, msg.ID)
+		require.NoError(t, err)
+		assert.Empty(t, deleted.Content)
+		assert.True(t, deleted.DeletedAt.Valid)
+
+		_, err = svc.EditMessage(ctx, bob, msg.ID, "back")
+		assert.ErrorIs(t, err, chat.ErrMessageNotFound)
+		messages, err := svc.GetMessages(ctx, alice, roomID, 50, 0)
+		require.NoError(t, err)
+		assert.Equal(t, msg.ID, messages[0].ID)
+		assert.True(t, messages[0].DeletedAt.Valid)
+	})
+
+	t.Run("Direct Messages Stay With Their Author", func(t *testing.T) {
+		msg, err := svc.SendDirect(ctx, alice, bob, "psst")
+		require.NoError(t, err)
+
+		_, err = svc.DeleteMessage(ctx, bob, msg.ID)
+		assert.ErrorIs(t, err, chat.ErrMessageForbidden)
+		_, err = svc.DeleteMessage(ctx, owner, msg.ID)
+		assert.ErrorIs(t, err, chat.ErrMessageNotFound)
+		_, err = svc.DeleteMessage(ctx, alice, msg.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("The Edit Window Closes", func(t *testing.T) {
+		msg, err := svc.SaveMessage(ctx, alice, roomID, "old news")
+		require.NoError(t, err)
+		_, err = testDB.ExecContext(context.Background(),
+			`UPDATE messages SET created_at = created_at - interval '1 hour' WHERE id = $1`, msg.ID)
+		require.NoError(t, err)
+
+		_, err = svc.EditMessage(ctx, alice, msg.ID, "new news")
+		assert.ErrorIs(t, err, chat.ErrEditWindowClosed)
+	})
+}
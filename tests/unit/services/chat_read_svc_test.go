@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryUnread is an UnreadCounter that keeps counts in a map.
+type memoryUnread struct {
+	mu     sync.Mutex
+	counts map[string]map[string]int64
+}
+
+func (m *memoryUnread) key(orgID, userID int32) string {
+	return fmt.Sprintf("%d:%d", orgID, userID)
+}
+
+func (m *memoryUnread) Load(_ context.Context, orgID, userID int32) (map[string]int64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	counts, ok := m.counts[m.key(orgID, userID)]
+	return maps.Clone(counts), ok, nil
+}
+
+func (m *memoryUnread) Store(_ context.Context, orgID, userID int32, counts map[string]int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counts == nil {
+		m.counts = make(map[string]map[string]int64)
+	}
+	m.counts[m.key(orgID, userID)] = maps.Clone(counts)
+	return nil
+}
+
+func (m *memoryUnread) Increment(_ context.Context, orgID int32, userIDs []int32, field string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, userID := range userIDs {
+		if counts, ok := m.counts[m.key(orgID, userID)]; ok {
+			counts[field]++
+		}
+	}
+	return nil
+}
+
+// forget drops userID's counts, as when they expire.
+func (m *memoryUnread) forget(orgID, userID int32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.counts, m.key(orgID, userID))
+}
+
+func TestReadState(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	TruncateTables(t, testDB, testTableNames)
+	repo := repository.NewRepositoryManager(testDB)
+	logger := mocks.NewMockLogger(t)
+	unread := &memoryUnread{}
+	svc := chat.NewChatService(repo, logger, &fakeCache{}, unread, config.ChatConfig{})
+	orgs := services.NewOrganizationService(repo, logger)
+
+	alice := seedUser(t, "alice@example.com", "alice")
+	bob := seedUser(t, "bob@example.com", "bob")
+	carol := seedUser(t, "carol@example.com", "carol")
+	org, err := orgs.Create(context.Background(), alice, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+	require.NoError(t, err)
+	for _, userID := range []int32{bob, carol} {
+		_, err := orgs.SetMemberRole(context.Background(), org.ID, services.OrgRoleOwner, userID, services.OrgRoleMember)
+		require.NoError(t, err)
+	}
+	ctx := tenant.WithID(context.Background(), org.ID)
+
+	room, err := svc.CreateRoom(ctx, alice, "random")
+	require.NoError(t, err)
+	_, err = svc.SaveMessage(ctx, alice, room.ID, "before bob joined")
+	require.NoError(t, err)
+	require.NoError(t, svc.JoinRoom(ctx, bob, room.ID))
+	require.NoError(t, svc.JoinRoom(ctx, carol, room.ID))
+	require.NoError(t, services.NewBlockService(repo, logger, &fakeCache{}).Block(ctx, carol, alice))
+
+	t.Run("Counts What Arrived Since Joining", func(t *testing.T) {
+		counts, err := svc.UnreadCounts(ctx, bob)
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]int64{room.ID: 0}, counts.Rooms)
+		assert.Empty(t, counts.Conversations)
+
+		var last int32
+		for _, content := range []string{"one", "two", "three"} {
+			msg, err := svc.SaveMessage(ctx, alice, room.ID, content)
+			require.NoError(t, err)
+			last = msg.ID
+		}
+		_, err = svc.SendDirect(ctx, alice, bob, "psst")
+		require.NoError(t, err)
+
+		counts, err = svc.UnreadCounts(ctx, bob)
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]int64{room.ID: 3}, counts.Rooms, "counted as messages arrive")
+		assert.Equal(t, map[int32]int64{alice: 1}, counts.Conversations)
+
+		unread.forget(org.ID, bob)
+		rebuilt, err := svc.UnreadCounts(ctx, bob)
+		require.NoError(t, err)
+		assert.Equal(t, counts, rebuilt, "rebuilt from Postgres")
+
+		counts, err = svc.UnreadCounts(ctx, carol)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), counts.Rooms[room.ID], "blocked senders are not counted")
+
+		counts, err = svc.UnreadCounts(ctx, alice)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), counts.Rooms[room.ID], "own messages are not counted")
+
+		lastRead, err := svc.MarkRoomRead(ctx, bob, room.ID, last-1)
+		require.NoError(t, err)
+		assert.Equal(t, last-1, lastRead)
+		counts, err = svc.UnreadCounts(ctx, bob)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), counts.Rooms[room.ID])
+
+		lastRead, err = svc.MarkRoomRead(ctx, bob, room.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, last-1, lastRead, "the read marker never moves back")
+
+		lastRead, err = svc.MarkRoomRead(ctx, bob, room.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, last, lastRead)
+		_, err = svc.MarkConversationRead(ctx, bob, alice, 0)
+		require.NoError(t, err)
+		counts, err = svc.UnreadCounts(ctx, bob)
+		require.NoError(t, err)
+		assert.Equal(t, map[int32]int64{room.ID: 0}, counts.Rooms)
+		assert.Empty(t, counts.Conversations)
+	})
+
+	t.Run("Only Members Mark Rooms Read", func(t *testing.T) {
+		other, err := svc.CreateRoom(ctx, alice, "other")
+		require.NoError(t, err)
+		_, err = svc.MarkRoomRead(ctx, bob, other.ID, 0)
+		assert.ErrorIs(t, err, chat.ErrNotRoomMember)
+
+		lastRead, err := svc.MarkRoomRead(ctx, alice, other.ID, 0)
+		require.NoError(t, err)
+		assert.Zero(t, lastRead, "nothing to read in an empty room")
+	})
+}
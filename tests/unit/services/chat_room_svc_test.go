@@ -0,0 +1,90 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatRooms(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	repo := repository.NewRepositoryManager(testDB)
+
+	t.Run("Create, Join And Leave", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		cache := &fakeCache{}
+		svc := chat.NewChatService(repo, mocks.NewMockLogger(t), cache, nil, config.ChatConfig{})
+		alice := seedUser(t, "alice@example.com", "alice")
+		bob := seedUser(t, "bob@example.com", "bob")
+		ctx := tenant.WithID(context.Background(), seedOrganization(t, "default"))
+
+		room, err := svc.CreateRoom(ctx, alice, "random")
+		require.NoError(t, err)
+		_, err = svc.CreateRoom(ctx, bob, "random")
+		assert.ErrorIs(t, err, chat.ErrRoomNameTaken)
+
+		rooms, err := svc.ListRooms(ctx, bob)
+		require.NoError(t, err)
+		require.Len(t, rooms, 1)
+		assert.False(t, rooms[0].Joined)
+
+		_, err = svc.SaveMessage(ctx, bob, room.ID, "let me in")
+		assert.ErrorIs(t, err, chat.ErrNotRoomMember)
+		_, err = svc.GetMessages(ctx, bob, room.ID, 50, 0)
+		assert.ErrorIs(t, err, chat.ErrNotRoomMember)
+		assert.ErrorIs(t, svc.JoinRoom(ctx, bob, 9999), chat.ErrRoomNotFound)
+
+		require.NoError(t, svc.JoinRoom(ctx, bob, room.ID))
+		require.NoError(t, svc.JoinRoom(ctx, bob, room.ID), "joining twice is not an error")
+		msg, err := svc.SaveMessage(ctx, bob, room.ID, "hi")
+		require.NoError(t, err)
+		assert.Equal(t, room.ID, msg.RoomID.Int32)
+
+		members, err := svc.RoomMembers(ctx, room.ID)
+		require.NoError(t, err)
+		assert.Len(t, members, 2)
+		ids, err := svc.RoomIDs(ctx, bob)
+		require.NoError(t, err)
+		assert.Equal(t, []int32{room.ID}, ids)
+
+		require.NoError(t, svc.LeaveRoom(ctx, bob, room.ID))
+		assert.ErrorIs(t, svc.LeaveRoom(ctx, bob, room.ID), chat.ErrNotRoomMember)
+		assert.Equal(t, []string{chat.HistoryRoomPrefix(bob, room.ID)}, cache.deletedPrefixes)
+		_, err = svc.GetMessages(ctx, bob, room.ID, 50, 0)
+		assert.ErrorIs(t, err, chat.ErrNotRoomMember)
+	})
+
+	t.Run("Leaving The Organization Leaves Its Rooms", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		logger := mocks.NewMockLogger(t)
+		orgs := services.NewOrganizationService(repo, logger)
+		svc := chat.NewChatService(repo, logger, &fakeCache{}, nil, config.ChatConfig{})
+		owner := seedUser(t, "owner@example.com", "owner")
+		member := seedUser(t, "member@example.com", "member")
+		org, err := orgs.Create(context.Background(), owner, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+		require.NoError(t, err)
+		_, err = orgs.SetMemberRole(context.Background(), org.ID, services.OrgRoleOwner, member, services.OrgRoleMember)
+		require.NoError(t, err)
+		ctx := tenant.WithID(context.Background(), org.ID)
+		roomID := seedRoom(t, org.ID, "general", owner, member)
+
+		require.NoError(t, orgs.RemoveMember(context.Background(), org.ID, services.OrgRoleMember, member, member))
+
+		ids, err := svc.RoomIDs(ctx, member)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+		members, err := svc.RoomMembers(ctx, roomID)
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+		assert.Equal(t, owner, members[0].ID)
+	})
+}
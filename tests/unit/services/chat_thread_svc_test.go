@@ -0,0 +1,134 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadsAndReactions(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	TruncateTables(t, testDB, testTableNames)
+	repo := repository.NewRepositoryManager(testDB)
+	logger := mocks.NewMockLogger(t)
+	cache := &fakeCache{}
+	svc := chat.NewChatService(repo, logger, cache, nil, config.ChatConfig{})
+	orgs := services.NewOrganizationService(repo, logger)
+
+	owner := seedUser(t, "owner@example.com", "owner")
+	alice := seedUser(t, "alice@example.com", "alice")
+	bob := seedUser(t, "bob@example.com", "bob")
+	org, err := orgs.Create(context.Background(), owner, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+	require.NoError(t, err)
+	for _, userID := range []int32{alice, bob} {
+		_, err := orgs.SetMemberRole(context.Background(), org.ID, services.OrgRoleOwner, userID, services.OrgRoleMember)
+		require.NoError(t, err)
+	}
+	ctx := tenant.WithID(context.Background(), org.ID)
+	roomID := seedRoom(t, org.ID, "general", owner, alice)
+
+	parent, err := svc.SaveMessage(ctx, alice, roomID, "lunch?")
+	require.NoError(t, err)
+
+	t.Run("Replies Join The Thread Of Their Parent", func(t *testing.T) {
+		first, replies, err := svc.Reply(ctx, owner, parent.ID, "yes")
+		require.NoError(t, err)
+		assert.Equal(t, parent.ID, first.ParentID.Int32)
+		assert.Equal(t, roomID, first.RoomID.Int32)
+		assert.Equal(t, int64(1), replies)
+
+		// A reply to a reply goes to the same thread.
+		second, replies, err := svc.Reply(ctx, alice, first.ID, "noon")
+		require.NoError(t, err)
+		assert.Equal(t, parent.ID, second.ParentID.Int32)
+		assert.Equal(t, int64(2), replies)
+
+		_, _, err = svc.Reply(ctx, bob, parent.ID, "me too")
+		assert.ErrorIs(t, err, chat.ErrNotRoomMember)
+
+		thread, err := svc.Thread(ctx, owner, second.ID, 50, 0)
+		require.NoError(t, err)
+		require.Len(t, thread, 3)
+		assert.Equal(t, []int32{parent.ID, first.ID, second.ID}, []int32{thread[0].ID, thread[1].ID, thread[2].ID})
+		assert.Equal(t, int64(2), thread[0].ReplyCount)
+
+		messages, err := svc.GetMessages(ctx, owner, roomID, 50, 0)
+		require.NoError(t, err)
+		require.Len(t, messages, 1, "replies stay out of the room's history")
+		assert.Equal(t, int64(2), messages[0].ReplyCount)
+	})
+
+	t.Run("Direct Messages Have No Threads", func(t *testing.T) {
+		msg, err := svc.SendDirect(ctx, alice, bob, "psst")
+		require.NoError(t, err)
+
+		_, _, err = svc.Reply(ctx, bob, msg.ID, "what")
+		assert.ErrorIs(t, err, chat.ErrMessageNotFound)
+		_, err = svc.Thread(ctx, bob, msg.ID, 50, 0)
+		assert.ErrorIs(t, err, chat.ErrMessageNotFound)
+	})
+
+	t.Run("Reactions Are Counted By Emoji", func(t *testing.T) {
+		cache.incremented = nil
+		reaction, err := svc.React(ctx, owner, parent.ID, "👍")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), reaction.Count)
+		assert.Equal(t, []string{chat.HistoryGenerationKey(roomID)}, cache.incremented)
+
+		reaction, err = svc.React(ctx, alice, parent.ID, "👍")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), reaction.Count)
+		reaction, err = svc.React(ctx, alice, parent.ID, "👍")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), reaction.Count, "reacting twice counts once")
+		_, err = svc.React(ctx, alice, parent.ID, "🍕")
+		require.NoError(t, err)
+
+		messages, err := svc.GetMessages(ctx, owner, roomID, 50, 0)
+		require.NoError(t, err)
+		var counts []chat.ReactionCount
+		require.NoError(t, json.Unmarshal(messages[0].Reactions, &counts))
+		assert.Equal(t, []chat.ReactionCount{
+			{Emoji: "👍", Count: 2, Users: []int32{owner, alice}},
+			{Emoji: "🍕", Count: 1, Users: []int32{alice}},
+		}, counts)
+
+		reaction, err = svc.Unreact(ctx, owner, parent.ID, "👍")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), reaction.Count)
+
+		reactors, err := svc.Reactions(ctx, owner, parent.ID)
+		require.NoError(t, err)
+		require.Len(t, reactors, 2)
+		assert.Equal(t, "alice", reactors[0].Username)
+	})
+
+	t.Run("Only Those Who See A Message React To It", func(t *testing.T) {
+		_, err := svc.React(ctx, bob, parent.ID, "👍")
+		assert.ErrorIs(t, err, chat.ErrNotRoomMember)
+		_, err = svc.React(ctx, owner, parent.ID, "thumbs up")
+		assert.ErrorIs(t, err, chat.ErrInvalidEmoji)
+
+		msg, err := svc.SendDirect(ctx, alice, bob, "psst")
+		require.NoError(t, err)
+		_, err = svc.React(ctx, owner, msg.ID, "👀")
+		assert.ErrorIs(t, err, chat.ErrMessageNotFound)
+		_, err = svc.React(ctx, bob, msg.ID, "👀")
+		require.NoError(t, err)
+
+		conversation, err := svc.DirectMessages(ctx, alice, bob, 50, 0)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"emoji":"👀","count":1,"users":[`+strconv.Itoa(int(bob))+`]}]`, string(conversation[0].Reactions))
+	})
+}
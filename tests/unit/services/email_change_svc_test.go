@@ -0,0 +1,181 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"example.com/api/config"
+	contracts "example.com/api/internal/contracts/errors"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/mailing"
+	"example.com/api/internal/storage"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testEmailChangeConfig = config.EmailChangeConfig{
+	ConfirmExpireDuration: 60,
+	CancelExpireDuration:  120,
+	ConfirmURL:            "http://app.test/email/confirm",
+	CancelURL:             "http://app.test/email/cancel",
+}
+
+// fakeTokenStorage records which users had their refresh tokens revoked.
+type fakeTokenStorage struct {
+	invalidated []string
+}
+
+func (f *fakeTokenStorage) Store(context.Context, string, string, time.Duration) error { return nil }
+func (f *fakeTokenStorage) Validate(context.Context, string, string) error             { return nil }
+func (f *fakeTokenStorage) Sessions(context.Context, string) ([]storage.Session, error) {
+	return nil, nil
+}
+func (f *fakeTokenStorage) Invalidate(_ context.Context, userID string) error {
+	f.invalidated = append(f.invalidated, userID)
+	return nil
+}
+
+var tokenLinkPattern = regexp.MustCompile(`https?://\S+\?token=\S+`)
+
+// captureMail collects every message sent through the mock mailer, keyed by
+// recipient.
+func captureMail(mailer *mocks.MockMailer) map[string]mailing.Message {
+	sent := map[string]mailing.Message{}
+	mailer.EXPECT().Send(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, msg mailing.Message) error {
+		sent[msg.To] = msg
+		return nil
+	})
+	return sent
+}
+
+func tokenFromMail(t *testing.T, msg mailing.Message) string {
+	link := tokenLinkPattern.FindString(msg.Body)
+	require.NotEmpty(t, link, "mail has no token link: %s", msg.Body)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	return u.Query().Get("token")
+}
+
+func TestEmailChangeService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+
+	newService := func(t *testing.T) (*services.EmailChangeService, map[string]mailing.Message, *fakeTokenStorage) {
+		mailer := mocks.NewMockMailer(t)
+		tokens := &fakeTokenStorage{}
+		repo := repository.NewRepositoryManager(testDB)
+		logger := mocks.NewMockLogger(t)
+		svc := services.NewEmailChangeService(repo, logger, mailer, tokens, testEmailChangeConfig, services.NewAuditService(repo, logger))
+		return svc, captureMail(mailer), tokens
+	}
+
+	request := func(t *testing.T, svc *services.EmailChangeService, userID int32, newEmail string) {
+		user, err := repository.NewRepositoryManager(testDB).User().GetByID(ctx, userID)
+		require.NoError(t, err)
+		require.NoError(t, svc.Request(ctx, user, newEmail))
+	}
+
+	currentEmail := func(t *testing.T, userID int32) string {
+		var email string
+		require.NoError(t, testDB.QueryRowContext(ctx, "SELECT email FROM users WHERE id = $1", userID).Scan(&email))
+		return email
+	}
+
+	t.Run("Confirm Applies Pending Email", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "change_old@example.com")
+		svc, sent, _ := newService(t)
+
+		request(t, svc, userID, "change_new@example.com")
+		assert.Equal(t, "change_old@example.com", currentEmail(t, userID))
+		require.Contains(t, sent, "change_old@example.com")
+		require.Contains(t, sent, "change_new@example.com")
+		assert.Contains(t, sent["change_old@example.com"].Body, testEmailChangeConfig.CancelURL)
+
+		user, err := svc.Confirm(ctx, tokenFromMail(t, sent["change_new@example.com"]))
+		require.NoError(t, err)
+		assert.Equal(t, "change_new@example.com", user.Email)
+		assert.Equal(t, "change_new@example.com", currentEmail(t, userID))
+
+		// A token works only once
+		_, err = svc.Confirm(ctx, tokenFromMail(t, sent["change_new@example.com"]))
+		assert.ErrorIs(t, err, services.ErrInvalidEmailChangeToken)
+	})
+
+	t.Run("New Request Supersedes Pending One", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "supersede@example.com")
+		svc, sent, _ := newService(t)
+
+		request(t, svc, userID, "first@example.com")
+		firstToken := tokenFromMail(t, sent["first@example.com"])
+		request(t, svc, userID, "second@example.com")
+
+		_, err := svc.Confirm(ctx, firstToken)
+		assert.ErrorIs(t, err, services.ErrInvalidEmailChangeToken)
+		assert.Equal(t, "supersede@example.com", currentEmail(t, userID))
+	})
+
+	t.Run("Expired Token", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "expired@example.com")
+		svc, sent, _ := newService(t)
+
+		request(t, svc, userID, "expired_new@example.com")
+		_, err := testDB.ExecContext(ctx, "UPDATE email_changes SET confirm_expires_at = confirm_expires_at - INTERVAL '1 day'")
+		require.NoError(t, err)
+
+		_, err = svc.Confirm(ctx, tokenFromMail(t, sent["expired_new@example.com"]))
+		assert.ErrorIs(t, err, services.ErrEmailChangeExpired)
+		assert.Equal(t, "expired@example.com", currentEmail(t, userID))
+	})
+
+	t.Run("Email Taken Before Confirmation", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "racer@example.com")
+		svc, sent, _ := newService(t)
+
+		request(t, svc, userID, "contested@example.com")
+		seedUser(t, "contested@example.com")
+
+		_, err := svc.Confirm(ctx, tokenFromMail(t, sent["contested@example.com"]))
+		var emailErr *contracts.EmailExistsError
+		require.True(t, errors.As(err, &emailErr))
+		assert.Equal(t, "racer@example.com", currentEmail(t, userID))
+	})
+
+	t.Run("Cancel Pending Change", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "cancel_pending@example.com")
+		svc, sent, tokens := newService(t)
+
+		request(t, svc, userID, "never@example.com")
+		require.NoError(t, svc.Cancel(ctx, tokenFromMail(t, sent["cancel_pending@example.com"])))
+
+		_, err := svc.Confirm(ctx, tokenFromMail(t, sent["never@example.com"]))
+		assert.ErrorIs(t, err, services.ErrInvalidEmailChangeToken)
+		assert.Equal(t, "cancel_pending@example.com", currentEmail(t, userID))
+		assert.Empty(t, tokens.invalidated)
+	})
+
+	t.Run("Cancel Reverts Confirmed Change", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "victim@example.com")
+		svc, sent, tokens := newService(t)
+
+		request(t, svc, userID, "attacker@example.com")
+		_, err := svc.Confirm(ctx, tokenFromMail(t, sent["attacker@example.com"]))
+		require.NoError(t, err)
+
+		require.NoError(t, svc.Cancel(ctx, tokenFromMail(t, sent["victim@example.com"])))
+		assert.Equal(t, "victim@example.com", currentEmail(t, userID))
+		assert.Len(t, tokens.invalidated, 1)
+	})
+}
@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testInvitationConfig = config.InvitationConfig{
+	ExpireDuration: 60,
+	AcceptURL:      "http://localhost:3000/invite/accept",
+}
+
+func TestInvitationService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+	repo := repository.NewRepositoryManager(testDB)
+
+	newServices := func(t *testing.T, cfg config.InvitationConfig) (*services.InvitationService, *services.AuthService, *mocks.MockMailer) {
+		logger := mocks.NewMockLogger(t)
+		mailer := mocks.NewMockMailer(t)
+		hash := mocks.NewMockHashService(t)
+		hash.EXPECT().Hash(mock.Anything).Return("hashed_password", nil).Maybe()
+		audit := services.NewAuditService(repo, logger)
+		invitations := services.NewInvitationService(repo, logger, mailer, cfg)
+		users := services.NewUserService(repo, logger, hash, nil, audit)
+		auth := services.NewAuthService(repo, config.JWTConfig{Secret: "test-secret"}, hash, users, logger, &fakeTokenStorage{}, audit, invitations, cfg)
+		return invitations, auth, mailer
+	}
+
+	role := func(t *testing.T, orgID, userID int32) string {
+		member, err := repo.Organization().GetMember(ctx, dbCtx.GetOrganizationMemberParams{OrganizationID: orgID, UserID: userID})
+		require.NoError(t, err)
+		return member.Role
+	}
+
+	t.Run("Accept Registers Into Organization", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		invitations, auth, mailer := newServices(t, testInvitationConfig)
+		sent := captureMail(mailer)
+		owner := seedUser(t, "owner@example.com", "owner")
+		orgID := seedOrganization(t, "acme")
+
+		inv, err := invitations.Create(ctx, orgID, owner, services.OrgRoleOwner,
+			dto.CreateInvitationReq{Email: "New.Hire@Example.com", Role: services.OrgRoleAdmin})
+		require.NoError(t, err)
+		assert.Equal(t, "new.hire@example.com", inv.Email)
+		require.Contains(t, sent, "new.hire@example.com")
+		token := tokenFromMail(t, sent["new.hire@example.com"])
+
+		user, access, refresh, err := auth.AcceptInvite(ctx, dto.AcceptInvitation{Token: token, Name: "newhire", Password: "secret1"})
+		require.NoError(t, err)
+		assert.Equal(t, "new.hire@example.com", user.Email)
+		assert.NotEmpty(t, access)
+		assert.NotEmpty(t, refresh)
+		assert.Equal(t, services.OrgRoleAdmin, role(t, orgID, user.ID))
+
+		// The token is single-use.
+		_, _, _, err = auth.AcceptInvite(ctx, dto.AcceptInvitation{Token: token, Name: "again", Password: "secret1"})
+		assert.ErrorIs(t, err, services.ErrInvalidInvitation)
+	})
+
+	t.Run("Failed Accept Leaves No Account", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		invitations, _, mailer := newServices(t, testInvitationConfig)
+		sent := captureMail(mailer)
+		owner := seedUser(t, "owner@example.com", "owner")
+		orgID := seedOrganization(t, "acme")
+		_, err := invitations.Create(ctx, orgID, owner, services.OrgRoleOwner, dto.CreateInvitationReq{Email: "retry@example.com"})
+		require.NoError(t, err)
+		token := tokenFromMail(t, sent["retry@example.com"])
+		inv, err := invitations.Lookup(ctx, token)
+		require.NoError(t, err)
+
+		logger := mocks.NewMockLogger(t)
+		hash := mocks.NewMockHashService(t)
+		hash.EXPECT().Hash(mock.Anything).Return("hashed_password", nil).Once()
+		audit := services.NewAuditService(repo, logger)
+		failing := mocks.NewMockInvitationService(t)
+		failing.EXPECT().Lookup(mock.Anything, token).Return(inv, nil).Once()
+		failing.EXPECT().ConsumeTx(mock.Anything, mock.Anything, inv, mock.Anything).Return(errors.New("failed to consume invitation")).Once()
+		users := services.NewUserService(repo, logger, hash, nil, audit)
+		auth := services.NewAuthService(repo, config.JWTConfig{Secret: "test-secret"}, hash, users, logger, &fakeTokenStorage{}, audit, failing, testInvitationConfig)
+
+		_, _, _, err = auth.AcceptInvite(ctx, dto.AcceptInvitation{Token: token, Name: "retry", Password: "secret1"})
+		require.Error(t, err)
+		_, err = repo.User().GetByEmail(ctx, "retry@example.com")
+		assert.ErrorIs(t, err, sql.ErrNoRows, "the account is rolled back with the invitation")
+
+		// The invitation can still be accepted.
+		_, auth, _ = newServices(t, testInvitationConfig)
+		user, _, _, err := auth.AcceptInvite(ctx, dto.AcceptInvitation{Token: token, Name: "retry", Password: "secret1"})
+		require.NoError(t, err)
+		assert.Equal(t, services.OrgRoleMember, role(t, orgID, user.ID))
+	})
+
+	t.Run("Revoked And Expired Invitations Are Rejected", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		invitations, auth, mailer := newServices(t, testInvitationConfig)
+		sent := captureMail(mailer)
+		owner := seedUser(t, "owner@example.com", "owner")
+		orgID := seedOrganization(t, "acme")
+
+		revoked, err := invitations.Create(ctx, orgID, owner, services.OrgRoleOwner, dto.CreateInvitationReq{Email: "revoked@example.com"})
+		require.NoError(t, err)
+		require.NoError(t, invitations.Revoke(ctx, orgID, revoked.ID))
+		assert.ErrorIs(t, invitations.Revoke(ctx, orgID, revoked.ID), services.ErrInvitationNotFound)
+		_, _, _, err = auth.AcceptInvite(ctx, dto.AcceptInvitation{
+			Token: tokenFromMail(t, sent["revoked@example.com"]), Name: "revoked", Password: "secret1",
+		})
+		assert.ErrorIs(t, err, services.ErrInvalidInvitation)
+
+		expired, err := invitations.Create(ctx, orgID, owner, services.OrgRoleOwner, dto.CreateInvitationReq{Email: "late@example.com"})
+		require.NoError(t, err)
+		_, err = testDB.ExecContext(ctx, "UPDATE invitations SET expires_at = now() - interval '1 minute' WHERE id = $1", expired.ID)
+		require.NoError(t, err)
+		_, _, _, err = auth.AcceptInvite(ctx, dto.AcceptInvitation{
+			Token: tokenFromMail(t, sent["late@example.com"]), Name: "late", Password: "secret1",
+		})
+		assert.ErrorIs(t, err, services.ErrInvitationExpired)
+
+		list, err := invitations.List(ctx, orgID, dto.ListInvitationsParams{Limit: 50})
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+	})
+
+	t.Run("Admins Cannot Invite Owners", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		invitations, _, _ := newServices(t, testInvitationConfig)
+		admin := seedUser(t, "admin@example.com", "admin")
+		orgID := seedOrganization(t, "acme")
+
+		_, err := invitations.Create(ctx, orgID, admin, services.OrgRoleAdmin,
+			dto.CreateInvitationReq{Email: "boss@example.com", Role: services.OrgRoleOwner})
+		assert.ErrorIs(t, err, services.ErrOrgRoleForbidden)
+	})
+
+	t.Run("Closed Registration", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		_, auth, _ := newServices(t, testInvitationConfig)
+
+		_, _, _, err := auth.RegisterUser(ctx, dto.Register{Name: "walkin", Email: "walkin@example.com", Password: "secret1"})
+		assert.ErrorIs(t, err, services.ErrRegistrationClosed)
+	})
+}
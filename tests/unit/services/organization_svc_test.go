@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+	repo := repository.NewRepositoryManager(testDB)
+
+	t.Run("Create Makes Creator Owner", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc := services.NewOrganizationService(repo, mocks.NewMockLogger(t))
+		alice := seedUser(t, "alice@example.com", "alice")
+		bob := seedUser(t, "bob@example.com", "bob")
+
+		org, err := svc.Create(ctx, alice, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+		require.NoError(t, err)
+
+		_, err = svc.Create(ctx, bob, dto.CreateOrganizationReq{Slug: "acme", Name: "Other"})
+		assert.ErrorIs(t, err, services.ErrOrganizationSlugTaken)
+
+		resolved, role, err := svc.Resolve(ctx, "acme", alice)
+		require.NoError(t, err)
+		assert.Equal(t, org.ID, resolved.ID)
+		assert.Equal(t, services.OrgRoleOwner, role)
+
+		_, _, err = svc.Resolve(ctx, "acme", bob)
+		assert.ErrorIs(t, err, services.ErrNotOrganizationMember)
+		_, _, err = svc.Resolve(ctx, "missing", alice)
+		assert.ErrorIs(t, err, services.ErrOrganizationNotFound)
+	})
+
+	t.Run("Roles Keep An Owner", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc := services.NewOrganizationService(repo, mocks.NewMockLogger(t))
+		owner := seedUser(t, "owner@example.com", "owner")
+		admin := seedUser(t, "admin@example.com", "admin")
+		member := seedUser(t, "member@example.com", "member")
+		org, err := svc.Create(ctx, owner, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+		require.NoError(t, err)
+
+		_, err = svc.SetMemberRole(ctx, org.ID, services.OrgRoleOwner, admin, services.OrgRoleAdmin)
+		require.NoError(t, err)
+		_, err = svc.SetMemberRole(ctx, org.ID, services.OrgRoleAdmin, member, services.OrgRoleMember)
+		require.NoError(t, err)
+
+		_, err = svc.SetMemberRole(ctx, org.ID, services.OrgRoleAdmin, member, services.OrgRoleOwner)
+		assert.ErrorIs(t, err, services.ErrOrgRoleForbidden)
+		_, err = svc.SetMemberRole(ctx, org.ID, services.OrgRoleOwner, owner, services.OrgRoleAdmin)
+		assert.ErrorIs(t, err, services.ErrLastOwner)
+		assert.ErrorIs(t, svc.RemoveMember(ctx, org.ID, services.OrgRoleOwner, owner, owner), services.ErrLastOwner)
+		assert.ErrorIs(t, svc.RemoveMember(ctx, org.ID, services.OrgRoleMember, member, admin), services.ErrOrgRoleForbidden)
+
+		require.NoError(t, svc.RemoveMember(ctx, org.ID, services.OrgRoleMember, member, member))
+		members, err := svc.ListMembers(ctx, org.ID, dto.ListMembersParams{Limit: 50})
+		require.NoError(t, err)
+		assert.Len(t, members, 2)
+	})
+
+	t.Run("Tenants Do Not See Each Other", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		logger := mocks.NewMockLogger(t)
+		orgs := services.NewOrganizationService(repo, logger)
+		alice := seedUser(t, "alice@example.com", "alice")
+		bob := seedUser(t, "bob@example.com", "bob")
+		acme, err := orgs.Create(ctx, alice, dto.CreateOrganizationReq{Slug: "acme", Name: "Acme"})
+		require.NoError(t, err)
+		globex, err := orgs.Create(ctx, bob, dto.CreateOrganizationReq{Slug: "globex", Name: "Globex"})
+		require.NoError(t, err)
+		acmeCtx := tenant.WithID(ctx, acme.ID)
+		globexCtx := tenant.WithID(ctx, globex.ID)
+
+		chatSvc := chat.NewChatService(repo, logger, &fakeCache{}, nil, config.ChatConfig{})
+		acmeRoom := seedRoom(t, acme.ID, "general", alice)
+		globexRoom := seedRoom(t, globex.ID, "general", bob)
+		_, err = chatSvc.SaveMessage(acmeCtx, alice, acmeRoom, "acme only")
+		require.NoError(t, err)
+		_, err = chatSvc.SaveMessage(ctx, alice, acmeRoom, "nowhere")
+		assert.ErrorIs(t, err, tenant.ErrNoTenant)
+		_, err = chatSvc.SaveMessage(globexCtx, alice, acmeRoom, "wrong tenant")
+		assert.ErrorIs(t, err, chat.ErrNotRoomMember)
+
+		messages, err := chatSvc.GetMessages(acmeCtx, alice, acmeRoom, 50, 0)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		messages, err = chatSvc.GetMessages(globexCtx, bob, globexRoom, 50, 0)
+		require.NoError(t, err)
+		assert.Empty(t, messages)
+		_, err = chatSvc.GetMessages(globexCtx, bob, acmeRoom, 50, 0)
+		assert.ErrorIs(t, err, chat.ErrRoomNotFound)
+
+		users := services.NewUserService(repo, logger, nil, nil, nil)
+		_, err = users.GetByID(globexCtx, alice)
+		assert.EqualError(t, err, "user not found")
+		found, _, err := users.GetByIDs(globexCtx, []int32{alice, bob})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, bob, found[0].ID)
+	})
+}
@@ -0,0 +1,231 @@
+package services_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"example.com/api/internal/repository"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/chat"
+	"example.com/api/internal/storage/blob"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCache keeps entries in memory and records which key prefixes were
+// evicted and which counters were bumped.
+type fakeCache struct {
+	entries         map[string][]byte
+	deletedPrefixes []string
+	incremented     []string
+}
+
+func (f *fakeCache) Get(_ context.Context, key string, dest any) (bool, error) {
+	data, ok := f.entries[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, dest)
+}
+func (f *fakeCache) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if f.entries == nil {
+		f.entries = map[string][]byte{}
+	}
+	f.entries[key] = data
+	return err
+}
+func (f *fakeCache) SetNX(context.Context, string, any, time.Duration) (bool, error) {
+	return true, nil
+}
+func (f *fakeCache) Delete(_ context.Context, key string) error {
+	delete(f.entries, key)
+	return nil
+}
+func (f *fakeCache) Incr(ctx context.Context, key string) (int64, error) {
+	f.incremented = append(f.incremented, key)
+	var n int64
+	if _, err := f.Get(ctx, key, &n); err != nil {
+		return 0, err
+	}
+	return n + 1, f.Set(ctx, key, n+1, 0)
+}
+func (f *fakeCache) DeletePrefix(_ context.Context, prefix string) error {
+	f.deletedPrefixes = append(f.deletedPrefixes, prefix)
+	return nil
+}
+
+func TestPrivacyService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+	repo := repository.NewRepositoryManager(testDB)
+
+	newService := func(t *testing.T) (*services.PrivacyService, *fakeTokenStorage, *fakeCache) {
+		tokens := &fakeTokenStorage{}
+		cache := &fakeCache{}
+		storage := blob.NewLocalStorage(t.TempDir())
+		logger := mocks.NewMockLogger(t)
+		return services.NewPrivacyService(repo, logger, tokens, cache, storage, services.NewAuditService(repo, logger)), tokens, cache
+	}
+
+	sendMessage := func(t *testing.T, senderID int32, content string) dbCtx.Message {
+		orgID := seedOrganization(t, "default")
+		msg, err := repo.Chat().CreateMessage(ctx, dbCtx.CreateMessageParams{
+			SenderID:       senderID,
+			Content:        content,
+			RoomID:         seedRoom(t, orgID, "general", senderID),
+			OrganizationID: orgID,
+		})
+		require.NoError(t, err)
+		return msg
+	}
+
+	t.Run("Export Contains Profile And Messages", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "export@example.com")
+		sendMessage(t, userID, "hello")
+		svc, _, _ := newService(t)
+
+		export, err := svc.Export(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "export@example.com", export.Profile.Email)
+		require.Len(t, export.Messages, 1)
+		assert.Equal(t, "hello", export.Messages[0].Content)
+
+		var buf bytes.Buffer
+		require.NoError(t, export.WriteArchive(&buf))
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		var names []string
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		assert.ElementsMatch(t, []string{"profile.json", "messages.json", "sessions.json"}, names)
+	})
+
+	t.Run("Export Unknown User", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc, _, _ := newService(t)
+
+		_, err := svc.Export(ctx, 99999)
+		assert.EqualError(t, err, "user not found")
+	})
+
+	t.Run("Erase Anonymizes User And Messages", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "erase@example.com", "erase_me", "Erase Me")
+		msg := sendMessage(t, userID, "still here")
+		svc, tokens, cache := newService(t)
+
+		require.NoError(t, svc.Erase(ctx, userID, userID))
+
+		var email, username, fullName string
+		require.NoError(t, testDB.QueryRowContext(ctx,
+			"SELECT email, username, full_name FROM users WHERE id = $1", userID).Scan(&email, &username, &fullName))
+		assert.NotContains(t, email, "erase@example.com")
+		assert.NotEqual(t, "erase_me", username)
+		assert.NotEqual(t, "Erase Me", fullName)
+
+		var senderID int32
+		require.NoError(t, testDB.QueryRowContext(ctx,
+			"SELECT sender_id FROM messages WHERE id = $1", msg.ID).Scan(&senderID))
+		assert.NotEqual(t, userID, senderID)
+
+		var anonymized int32
+		require.NoError(t, testDB.QueryRowContext(ctx,
+			"SELECT messages_anonymized FROM user_erasures WHERE user_id = $1 ORDER BY id DESC LIMIT 1", userID).Scan(&anonymized))
+		assert.EqualValues(t, 1, anonymized)
+
+		assert.Len(t, tokens.invalidated, 1)
+		assert.Equal(t, []string{chat.HistoryGenerationKey(msg.RoomID.Int32)}, cache.incremented)
+		assert.Empty(t, cache.deletedPrefixes)
+
+		// The erased email can be registered again
+		seedUser(t, "erase@example.com")
+	})
+
+	t.Run("Erase Soft Deleted User", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "gone@example.com", "gone", "Gone User")
+		_, err := repo.User().SoftDelete(ctx, userID)
+		require.NoError(t, err)
+		svc, _, _ := newService(t)
+
+		require.NoError(t, svc.Erase(ctx, userID, 0))
+
+		var email string
+		require.NoError(t, testDB.QueryRowContext(ctx, "SELECT email FROM users WHERE id = $1", userID).Scan(&email))
+		assert.NotEqual(t, "gone@example.com", email)
+	})
+
+	t.Run("Erase Redacts Copies Of Email And Name", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "copies@example.com", "copies", "Copied Name")
+		orgID := seedOrganization(t, "acme")
+		svc, _, _ := newService(t)
+
+		seed := []struct {
+			query string
+			args  []any
+		}{
+			{`INSERT INTO outbox_events (event_type, aggregate_type, aggregate_id, payload)
+			  VALUES ('user.created', 'user', $1, '{"email": "copies@example.com", "fullName": "Copied Name"}')`, []any{userID}},
+			{`INSERT INTO webhook_subscriptions (url, secret, event_types) VALUES ('https://example.com/hook', 'secret', '{}')`, nil},
+			{`INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+			  SELECT id, 1, 'user.created', jsonb_build_object('aggregateType', 'user', 'aggregateId', $1::integer,
+			      'data', '{"email": "copies@example.com", "fullName": "Copied Name"}'::jsonb)
+			  FROM webhook_subscriptions`, []any{userID}},
+			{`INSERT INTO invitations (organization_id, email, token_hash, expires_at)
+			  VALUES ($1, 'Copies@example.com', repeat('a', 64), CURRENT_TIMESTAMP + interval '1 day')`, []any{orgID}},
+			{`INSERT INTO audit_events (action, target_type, metadata)
+			  VALUES ('auth.login_failed', 'user', '{"email": "copies@example.com", "reason": "unknown email"}')`, nil},
+		}
+		for _, row := range seed {
+			_, err := testDB.ExecContext(ctx, row.query, row.args...)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, svc.Erase(ctx, userID, 0))
+
+		for _, query := range []string{
+			"SELECT payload::text FROM outbox_events WHERE aggregate_type = 'user'",
+			"SELECT payload::text FROM webhook_deliveries",
+			"SELECT email FROM invitations",
+			"SELECT metadata::text FROM audit_events WHERE action = 'auth.login_failed'",
+		} {
+			rows, err := testDB.QueryContext(ctx, query)
+			require.NoError(t, err)
+			for rows.Next() {
+				var value string
+				require.NoError(t, rows.Scan(&value))
+				assert.NotContains(t, value, "copies@example.com", query)
+				assert.NotContains(t, value, "Copied Name", query)
+			}
+			require.NoError(t, rows.Close())
+		}
+	})
+
+	t.Run("Erasure Log Is Append Only", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userID := seedUser(t, "append@example.com")
+		svc, _, _ := newService(t)
+		require.NoError(t, svc.Erase(ctx, userID, 0))
+
+		_, err := testDB.ExecContext(ctx, "DELETE FROM user_erasures WHERE user_id = $1", userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Erase Unknown User", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc, _, _ := newService(t)
+
+		err := svc.Erase(ctx, 99999, 0)
+		assert.EqualError(t, err, "user not found")
+	})
+}
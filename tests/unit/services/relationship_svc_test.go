@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/tenant"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelationshipService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+	repo := repository.NewRepositoryManager(testDB)
+	page := dto.FollowListParams{Limit: 20}
+
+	counts := func(t *testing.T, id int32) (followers, following int32) {
+		user, err := repo.User().GetByID(ctx, id)
+		require.NoError(t, err)
+		return user.FollowersCount, user.FollowingCount
+	}
+
+	t.Run("Follow Updates Lists And Counts", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc := services.NewRelationshipService(repo, mocks.NewMockLogger(t))
+		alice := seedUser(t, "alice@example.com", "alice")
+		bob := seedUser(t, "bob@example.com", "bob")
+		carol := seedUser(t, "carol@example.com", "carol")
+
+		assert.ErrorIs(t, svc.Follow(ctx, alice, alice), services.ErrCannotFollowSelf)
+		assert.EqualError(t, svc.Follow(ctx, alice, 9999), "user not found")
+
+		require.NoError(t, svc.Follow(ctx, alice, bob))
+		require.NoError(t, svc.Follow(ctx, alice, bob), "following twice is not an error")
+		require.NoError(t, svc.Follow(ctx, bob, alice))
+		require.NoError(t, svc.Follow(ctx, alice, carol))
+
+		followers, following := counts(t, alice)
+		assert.Equal(t, int32(1), followers)
+		assert.Equal(t, int32(2), following)
+
+		followed, err := svc.Following(ctx, alice, page)
+		require.NoError(t, err)
+		require.Len(t, followed, 2)
+		assert.Equal(t, carol, followed[0].ID, "newest follow comes first")
+
+		fans, err := svc.Followers(ctx, bob, page)
+		require.NoError(t, err)
+		require.Len(t, fans, 1)
+		assert.Equal(t, "alice", fans[0].Username)
+
+		mutuals, err := svc.Mutuals(ctx, alice, page)
+		require.NoError(t, err)
+		require.Len(t, mutuals, 1)
+		assert.Equal(t, bob, mutuals[0].ID)
+
+		require.NoError(t, svc.Unfollow(ctx, bob, alice))
+		assert.ErrorIs(t, svc.Unfollow(ctx, bob, alice), services.ErrNotFollowing)
+
+		mutuals, err = svc.Mutuals(ctx, alice, page)
+		require.NoError(t, err)
+		assert.Empty(t, mutuals)
+
+		followers, _ = counts(t, alice)
+		assert.Equal(t, int32(0), followers)
+	})
+
+	t.Run("Lists Leave Out Other Organizations", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc := services.NewRelationshipService(repo, mocks.NewMockLogger(t))
+		alice := seedUser(t, "alice@example.com", "alice")
+		bob := seedUser(t, "bob@example.com", "bob")
+		mallory := seedUser(t, "mallory@example.com", "mallory")
+		acme := seedOrganization(t, "acme")
+		seedMembers(t, acme, alice, bob)
+		seedMembers(t, seedOrganization(t, "globex"), alice, mallory)
+		scoped := tenant.WithID(ctx, acme)
+
+		for _, other := range []int32{bob, mallory} {
+			require.NoError(t, svc.Follow(ctx, alice, other))
+			require.NoError(t, svc.Follow(ctx, other, alice))
+		}
+
+		fans, err := svc.Followers(scoped, alice, page)
+		require.NoError(t, err)
+		require.Len(t, fans, 1)
+		assert.Equal(t, bob, fans[0].ID)
+
+		followed, err := svc.Following(scoped, alice, page)
+		require.NoError(t, err)
+		require.Len(t, followed, 1)
+		assert.Equal(t, bob, followed[0].ID)
+
+		mutuals, err := svc.Mutuals(scoped, alice, page)
+		require.NoError(t, err)
+		require.Len(t, mutuals, 1)
+		assert.Equal(t, bob, mutuals[0].ID)
+
+		mutuals, err = svc.Mutuals(ctx, alice, page)
+		require.NoError(t, err)
+		assert.Len(t, mutuals, 2, "an unscoped context sees every organization")
+
+		// Users of other organizations cannot be told apart from missing ones.
+		assert.EqualError(t, svc.Follow(scoped, bob, mallory), "user not found")
+		_, err = svc.Followers(scoped, mallory, page)
+		assert.EqualError(t, err, "user not found")
+	})
+
+	t.Run("Deleting A User Drops Their Follows", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		logger := mocks.NewMockLogger(t)
+		svc := services.NewRelationshipService(repo, logger)
+		users := services.NewUserService(repo, logger, nil, nil, newTestAuditService(t))
+		alice := seedUser(t, "alice@example.com", "alice")
+		bob := seedUser(t, "bob@example.com", "bob")
+
+		require.NoError(t, svc.Follow(ctx, alice, bob))
+		require.NoError(t, svc.Follow(ctx, bob, alice))
+		require.NoError(t, users.SoftDelete(ctx, bob))
+
+		followers, following := counts(t, alice)
+		assert.Equal(t, int32(0), followers)
+		assert.Equal(t, int32(0), following)
+
+		_, err := svc.Followers(ctx, bob, page)
+		assert.EqualError(t, err, "user not found")
+	})
+}
@@ -15,7 +15,7 @@ import (
 )
 
 var testDB *sql.DB
-var testTableNames = []string{"users"}
+var testTableNames = []string{"users", "audit_events", "outbox_events", "webhook_subscriptions", "organizations"}
 
 func TestMain(m *testing.M) {
 	dbURL := os.Getenv("TEST_DB_URL")
@@ -50,7 +50,7 @@ func TruncateTables(t *testing.T, db *sql.DB, tables []string) {
 		return
 	}
 
-	allowedTables := map[string]bool{"users": true}
+	allowedTables := map[string]bool{"users": true, "audit_events": true, "outbox_events": true, "webhook_subscriptions": true, "organizations": true}
 	for _, table := range tables {
 		if !allowedTables[table] {
 			log.Fatalf("Attempted to truncate disallowed table: %s", table)
@@ -94,3 +94,44 @@ func seedUser(t *testing.T, email string, options ...string) int32 {
 	require.NoError(t, err, "Failed to seed user with email: %s", email)
 	return id
 }
+
+// seedOrganization returns the id of the organization with slug, creating it
+// if needed, so several helpers of one test can share an organization.
+func seedOrganization(t *testing.T, slug string) int32 {
+	var id int32
+	err := testDB.QueryRowContext(context.Background(), `
+        INSERT INTO organizations (slug, name) VALUES ($1, $1)
+        ON CONFLICT (slug) DO UPDATE SET slug = EXCLUDED.slug
+        RETURNING id`, slug).Scan(&id)
+	require.NoError(t, err, "Failed to seed organization: %s", slug)
+	return id
+}
+
+// seedMembers adds users to the organization as plain members.
+func seedMembers(t *testing.T, orgID int32, users ...int32) {
+	for _, userID := range users {
+		_, err := testDB.ExecContext(context.Background(), `
+            INSERT INTO organization_members (organization_id, user_id) VALUES ($1, $2)
+            ON CONFLICT DO NOTHING`, orgID, userID)
+		require.NoError(t, err, "Failed to add user %d to organization %d", userID, orgID)
+	}
+}
+
+// seedRoom returns the id of the room called name in orgID, creating it if
+// needed, and adds members to it.
+func seedRoom(t *testing.T, orgID int32, name string, members ...int32) int32 {
+	ctx := context.Background()
+	var id int32
+	err := testDB.QueryRowContext(ctx, `
+        INSERT INTO chat_rooms (organization_id, name) VALUES ($1, $2)
+        ON CONFLICT (organization_id, name) DO UPDATE SET name = EXCLUDED.name
+        RETURNING id`, orgID, name).Scan(&id)
+	require.NoError(t, err, "Failed to seed room: %s", name)
+	for _, userID := range members {
+		_, err := testDB.ExecContext(ctx, `
+            INSERT INTO room_members (room_id, user_id) VALUES ($1, $2)
+            ON CONFLICT DO NOTHING`, id, userID)
+		require.NoError(t, err, "Failed to add user %d to room %s", userID, name)
+	}
+	return id
+}
@@ -42,7 +42,7 @@ func TestUserService_Create(t *testing.T) {
 		mockLogger.EXPECT().Error(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
 		mockLogger.EXPECT().Warn(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		userResponse, err := userService.Create(ctx, req)
@@ -66,6 +66,13 @@ func TestUserService_Create(t *testing.T) {
 		assert.Equal(t, req.Username, createdUser.Username)
 		assert.Equal(t, hashedPassword, createdUser.PasswordHash)
 
+		// Assert (Outbox)
+		var eventType string
+		var aggregateID int32
+		err = testDB.QueryRowContext(ctx, "SELECT event_type, aggregate_id FROM outbox_events").Scan(&eventType, &aggregateID)
+		require.NoError(t, err, "Failed to query outbox event from test DB")
+		assert.Equal(t, "user.created", eventType)
+		assert.Equal(t, userResponse.ID, aggregateID)
 	})
 
 	t.Run("Username Exists", func(t *testing.T) {
@@ -93,7 +100,7 @@ func TestUserService_Create(t *testing.T) {
 		mockLogger.EXPECT().Warn(logging.Validation, logging.FailedToCreateUser, "Username already exists", mock.AnythingOfType("map[logging.ExtraKey]interface {}")).Once()
 		mockLogger.EXPECT().Error(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		userResponse, err := userService.Create(ctx, req)
@@ -128,7 +135,7 @@ func TestUserService_Create(t *testing.T) {
 		mockHashService.EXPECT().Hash(req.Password).Return(hashedPassword, nil).Once()
 		mockLogger.EXPECT().Warn(logging.Validation, logging.FailedToCreateUser, "Email already exists", mock.AnythingOfType("map[logging.ExtraKey]interface {}")).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		userResponse, err := userService.Create(ctx, req)
 
@@ -154,7 +161,7 @@ func TestUserService_Create(t *testing.T) {
 		hashingErr := errors.New("hashing failed")
 		mockHashService.EXPECT().Hash(req.Password).Return("", hashingErr).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		userResponse, err := userService.Create(ctx, req)
 
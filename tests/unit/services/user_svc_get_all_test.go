@@ -39,7 +39,7 @@ func TestUserService_GetAll(t *testing.T) {
 		mockLogger := mocks.NewMockLogger(t)
 		mockHashService := mocks.NewMockHashService(t)
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Seed 3 users
 		emails := seedUsers(t, 3)
@@ -69,7 +69,7 @@ func TestUserService_GetAll(t *testing.T) {
 		mockLogger := mocks.NewMockLogger(t)
 		mockHashService := mocks.NewMockHashService(t)
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		seedUsers(t, 2) // Seed users, but limit 0 should return none
 
@@ -87,7 +87,7 @@ func TestUserService_GetAll(t *testing.T) {
 		mockLogger := mocks.NewMockLogger(t)
 		mockHashService := mocks.NewMockHashService(t)
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		seedUsers(t, 2) // Only 2 users
 
@@ -105,7 +105,7 @@ func TestUserService_GetAll(t *testing.T) {
 		mockLogger := mocks.NewMockLogger(t)
 		mockHashService := mocks.NewMockHashService(t)
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Expect error log with PostgreSQL's "LIMIT must not be negative" message
 		mockLogger.EXPECT().Error(logging.Postgres, logging.Select, "Failed to fetch all users",
@@ -129,7 +129,7 @@ func TestUserService_GetAll(t *testing.T) {
 		mockLogger := mocks.NewMockLogger(t)
 		mockHashService := mocks.NewMockHashService(t)
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		emails := seedUsers(t, 3)
 
@@ -0,0 +1,34 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_GetByIDs(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+
+	TruncateTables(t, testDB, testTableNames)
+	repoManager := repository.NewRepositoryManager(testDB)
+	userService := services.NewUserService(repoManager, mocks.NewMockLogger(t), mocks.NewMockHashService(t), nil, newTestAuditService(t))
+
+	first := seedUser(t, "batch1@example.com", "batch_one")
+	second := seedUser(t, "batch2@example.com", "batch_two")
+	deleted := seedUser(t, "batch3@example.com", "batch_three")
+	require.NoError(t, userService.SoftDelete(ctx, deleted))
+
+	users, missing, err := userService.GetByIDs(ctx, []int32{second, 999, first, second, deleted})
+
+	require.NoError(t, err)
+	require.Len(t, users, 2, "duplicates are returned once")
+	assert.Equal(t, second, users[0].ID, "users keep the requested order")
+	assert.Equal(t, first, users[1].ID)
+	assert.Equal(t, []int32{999, deleted}, missing)
+}
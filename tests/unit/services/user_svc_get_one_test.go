@@ -30,7 +30,7 @@ func TestUserService_GetByID(t *testing.T) {
 		seededUserID := seedUser(t, email, "getbyid_user_notx", "Get By ID NoTX", "hash")
 		require.NotZero(t, seededUserID, "Failed to seed user")
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		user, err := userService.GetByID(ctx, seededUserID)
@@ -57,7 +57,7 @@ func TestUserService_GetByID(t *testing.T) {
 				return ok && id == nonExistentID
 			})).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		user, err := userService.GetByID(ctx, nonExistentID)
@@ -87,7 +87,7 @@ func TestUserService_GetByUsername(t *testing.T) {
 		seededUserID := seedUser(t, email, username, "Get By Username", "hash")
 		require.NotZero(t, seededUserID, "Failed to seed user")
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		user, err := userService.GetByUsername(ctx, username)
@@ -114,7 +114,7 @@ func TestUserService_GetByUsername(t *testing.T) {
 				return ok && username == nonExistentUsername
 			})).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		user, err := userService.GetByUsername(ctx, nonExistentUsername)
@@ -143,7 +143,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 		seededUserID := seedUser(t, email, "getbyemail_user", "Get By Email", "hash")
 		require.NotZero(t, seededUserID, "Failed to seed user")
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		user, err := userService.GetByEmail(ctx, email)
@@ -170,7 +170,7 @@ func TestUserService_GetByEmail(t *testing.T) {
 				return ok && email == nonExistentEmail
 			})).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		user, err := userService.GetByEmail(ctx, nonExistentEmail)
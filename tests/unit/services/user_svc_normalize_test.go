@@ -0,0 +1,141 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	dto "example.com/api/internal/contracts"
+	contracts "example.com/api/internal/contracts/errors"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/pkg/logging"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_Normalization(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+
+	newService := func(t *testing.T) (*services.UserService, *mocks.MockLogger) {
+		mockHash := mocks.NewMockHashService(t)
+		mockHash.EXPECT().Hash(mock.Anything).Return("hashed", nil).Maybe()
+		mockLogger := mocks.NewMockLogger(t)
+		return services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, mockHash, nil, newTestAuditService(t)), mockLogger
+	}
+
+	t.Run("Email Stored Lowercased And Trimmed", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userService, _ := newService(t)
+
+		user, err := userService.Create(ctx, dto.CreateUserReq{
+			Username: "mixedcase",
+			Email:    " Bob@Example.COM ",
+			FullName: "Bob",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "bob@example.com", user.Email)
+
+		found, err := userService.GetByEmail(ctx, "BOB@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("Email Differing Only In Case Conflicts", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		seedUser(t, "bob@example.com")
+		userService, mockLogger := newService(t)
+		mockLogger.EXPECT().Warn(logging.Validation, logging.FailedToCreateUser, "Email already exists", mock.Anything).Once()
+
+		_, err := userService.Create(ctx, dto.CreateUserReq{
+			Username: "another_bob",
+			Email:    "Bob@Example.com",
+			FullName: "Bob",
+			Password: "password123",
+		})
+		var emailErr *contracts.EmailExistsError
+		require.ErrorAs(t, err, &emailErr)
+	})
+
+	t.Run("IDN Domain Stored As Punycode", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userService, _ := newService(t)
+
+		user, err := userService.Create(ctx, dto.CreateUserReq{
+			Username: "reader",
+			Email:    "reader@Bücher.de",
+			FullName: "Reader",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "reader@xn--bcher-kva.de", user.Email)
+	})
+
+	t.Run("Username Differing Only In Case Conflicts", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		seedUser(t, "first@example.com", "Alice")
+		userService, mockLogger := newService(t)
+		mockLogger.EXPECT().Warn(logging.Validation, logging.FailedToCreateUser, "Username already exists", mock.Anything).Once()
+
+		_, err := userService.Create(ctx, dto.CreateUserReq{
+			Username: "alice",
+			Email:    "second@example.com",
+			FullName: "Alice",
+			Password: "password123",
+		})
+		var usernameErr *contracts.UsernameExistsError
+		require.ErrorAs(t, err, &usernameErr)
+	})
+
+	t.Run("Whole Script Confusable Username Conflicts", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		seedUser(t, "first@example.com", "cox")
+		userService, mockLogger := newService(t)
+		mockLogger.EXPECT().Warn(logging.Validation, logging.FailedToCreateUser, "Username already exists", mock.Anything).Once()
+
+		_, err := userService.Create(ctx, dto.CreateUserReq{
+			Username: "сох", // Cyrillic
+			Email:    "second@example.com",
+			FullName: "Cox",
+			Password: "password123",
+		})
+		var usernameErr *contracts.UsernameExistsError
+		require.ErrorAs(t, err, &usernameErr)
+	})
+
+	t.Run("Username Canonicalized With NFKC", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userService, _ := newService(t)
+
+		user, err := userService.Create(ctx, dto.CreateUserReq{
+			Username: "Ｆｕｌｌｗｉｄｔｈ",
+			Email:    "fullwidth@example.com",
+			FullName: "Fullwidth",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Fullwidth", user.Username)
+
+		found, err := userService.GetByUsername(ctx, "FULLWIDTH")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("Invalid Usernames Rejected", func(t *testing.T) {
+		userService, _ := newService(t)
+
+		for _, username := range []string{"pаypal", "zero​width"} { // Cyrillic а, zero-width space
+			_, err := userService.Create(ctx, dto.CreateUserReq{
+				Username: username,
+				Email:    "invalid@example.com",
+				FullName: "Invalid",
+				Password: "password123",
+			})
+			var invalidErr *contracts.InvalidUsernameError
+			assert.ErrorAs(t, err, &invalidErr, username)
+		}
+	})
+}
@@ -34,11 +34,19 @@ func TestUserService_UpdatePartial(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		mockHash := mocks.NewMockHashService(t)
 		mockLogger := mocks.NewMockLogger(t)
-		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, mockHash)
+		mockEmailChange := mocks.NewMockEmailChangeService(t)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, mockHash, mockEmailChange, newTestAuditService(t))
 
 		// Seed user
 		seedUserWithId(t, 1, "olduser", "old@example.com", "Old Name", "oldhash")
 
+		// The new email waits for confirmation instead of being written
+		change := &services.PendingEmailChange{UserID: 1, OldEmail: "old@example.com", NewEmail: "new@example.com"}
+		mockEmailChange.EXPECT().RequestTx(mock.Anything, mock.Anything,
+			mock.MatchedBy(func(u dbCtx.User) bool { return u.ID == 1 && u.Email == "old@example.com" }),
+			"new@example.com").Return(change, nil).Once()
+		mockEmailChange.EXPECT().Notify(mock.Anything, change).Return(nil).Once()
+
 		// Setup mock for password hashing
 		newPass := "newpass123"
 		hashedPass := "hashednewpass123"
@@ -55,13 +63,13 @@ func TestUserService_UpdatePartial(t *testing.T) {
 		user, err := userService.UpdatePartial(ctx, arg)
 		require.NoError(t, err)
 		assert.Equal(t, "newuser", user.Username)
-		assert.Equal(t, "new@example.com", user.Email)
+		assert.Equal(t, "old@example.com", user.Email)
 		assert.Equal(t, "New Name", user.FullName)
 		assert.Equal(t, hashedPass, user.PasswordHash)
 
 		// Verify DB state
 		var dbUser dbCtx.User
-		err = testDB.QueryRowContext(ctx, "SELECT * FROM users WHERE id = $1", 1).Scan(
+		err = testDB.QueryRowContext(ctx, "SELECT id, username, email, full_name, password_hash, created_at, updated_at, deleted_at FROM users WHERE id = $1", 1).Scan(
 			&dbUser.ID,
 			&dbUser.Username,
 			&dbUser.Email,
@@ -73,15 +81,40 @@ func TestUserService_UpdatePartial(t *testing.T) {
 		)
 		require.NoError(t, err)
 		assert.Equal(t, "newuser", dbUser.Username)
-		assert.Equal(t, "new@example.com", dbUser.Email)
+		assert.Equal(t, "old@example.com", dbUser.Email)
 		assert.Equal(t, "New Name", dbUser.FullName)
 		assert.Equal(t, hashedPass, dbUser.PasswordHash)
 	})
 
+	t.Run("Email Change Mail Not Sent", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		mockEmailChange := mocks.NewMockEmailChangeService(t)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), nil, mockEmailChange, newTestAuditService(t))
+		seedUserWithId(t, 1, "olduser", "old@example.com", "Old Name", "oldhash")
+
+		change := &services.PendingEmailChange{UserID: 1, OldEmail: "old@example.com", NewEmail: "new@example.com"}
+		mockEmailChange.EXPECT().RequestTx(mock.Anything, mock.Anything, mock.Anything, "new@example.com").Return(change, nil).Once()
+		mockEmailChange.EXPECT().Notify(mock.Anything, change).Return(errors.New("smtp down")).Once()
+
+		user, err := userService.UpdatePartial(ctx, dto.UpdateUserPartialReq{
+			ID:       1,
+			Email:    ptr("new@example.com"),
+			FullName: ptr("New Name"),
+		})
+
+		// The update is saved all the same; only the mail has to be redone
+		assert.ErrorIs(t, err, services.ErrEmailChangeNotSent)
+		require.NotNil(t, user)
+		assert.Equal(t, "New Name", user.FullName)
+		var fullName string
+		require.NoError(t, testDB.QueryRowContext(ctx, "SELECT full_name FROM users WHERE id = 1").Scan(&fullName))
+		assert.Equal(t, "New Name", fullName)
+	})
+
 	t.Run("Username Conflict", func(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		mockLogger := mocks.NewMockLogger(t)
-		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, nil)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, nil, nil, newTestAuditService(t))
 
 		// Seed conflicting users
 		seedUserWithId(t, 1, "existing", "user1@example.com", "User1", "hash1")
@@ -109,7 +142,7 @@ func TestUserService_UpdatePartial(t *testing.T) {
 	t.Run("Email Conflict", func(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		mockLogger := mocks.NewMockLogger(t)
-		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, nil)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, nil, nil, newTestAuditService(t))
 
 		// Seed conflicting users
 		seedUserWithId(t, 1, "user1", "existing@example.com", "User1", "hash1")
@@ -137,7 +170,7 @@ func TestUserService_UpdatePartial(t *testing.T) {
 	t.Run("User Not Found", func(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		mockLogger := mocks.NewMockLogger(t)
-		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, nil)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mockLogger, nil, nil, newTestAuditService(t))
 
 		arg := dto.UpdateUserPartialReq{ID: 999, Username: ptr("newuser")}
 
@@ -154,7 +187,7 @@ func TestUserService_UpdatePartial(t *testing.T) {
 
 	t.Run("Partial Update (Only FullName)", func(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
-		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), nil)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), nil, nil, newTestAuditService(t))
 
 		seedUserWithId(t, 1, "user", "user@example.com", "Old Name", "hash")
 
@@ -173,7 +206,7 @@ func TestUserService_UpdatePartial(t *testing.T) {
 	t.Run("Empty Password", func(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		mockHash := mocks.NewMockHashService(t)
-		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), mockHash)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), mockHash, nil, newTestAuditService(t))
 
 		seedUserWithId(t, 1, "user", "user@example.com", "User", "oldhash")
 
@@ -197,7 +230,7 @@ func TestUserService_UpdatePartial(t *testing.T) {
 
 	t.Run("No Changes (All Fields Nil)", func(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
-		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), nil)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), nil, nil, newTestAuditService(t))
 
 		seedUserWithId(t, 1, "user", "user@example.com", "User", "hash")
 
@@ -210,6 +243,29 @@ func TestUserService_UpdatePartial(t *testing.T) {
 		assert.Equal(t, "User", user.FullName)
 		assert.Equal(t, "hash", user.PasswordHash)
 	})
+
+	t.Run("Profile Fields Set And Cleared", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		userService := services.NewUserService(repository.NewRepositoryManager(testDB), mocks.NewMockLogger(t), nil, nil, newTestAuditService(t))
+
+		seedUserWithId(t, 1, "user", "user@example.com", "User", "hash")
+		_, err := testDB.ExecContext(ctx, "UPDATE users SET locale = 'en-US' WHERE id = 1")
+		require.NoError(t, err)
+
+		arg := dto.UpdateUserPartialReq{
+			ID:         1,
+			Bio:        dto.Nullable[string]{Set: true, Value: ptr("Hello")},
+			Locale:     dto.Nullable[string]{Set: true},
+			Attributes: map[string]any{"company": "Acme"},
+		}
+
+		user, err := userService.UpdatePartial(ctx, arg)
+		require.NoError(t, err)
+		assert.Equal(t, "Hello", user.Bio.String)
+		assert.False(t, user.Locale.Valid)
+		assert.JSONEq(t, `{"company": "Acme"}`, string(user.Attributes))
+		assert.Equal(t, "User", user.FullName) // Unchanged
+	})
 }
 
 // Helper to create string pointers
@@ -44,7 +44,14 @@ func TestUserService_UpdateFull(t *testing.T) {
 		hashedPassword := "hashed_newpassword"
 		mockHashService.EXPECT().Hash(updateReq.Password).Return(hashedPassword, nil).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		mockEmailChange := mocks.NewMockEmailChangeService(t)
+		change := &services.PendingEmailChange{UserID: userID, OldEmail: "updatefull@example.com", NewEmail: updateReq.Email}
+		mockEmailChange.EXPECT().RequestTx(mock.Anything, mock.Anything,
+			mock.MatchedBy(func(u dbCtx.User) bool { return u.ID == userID && u.Email == "updatefull@example.com" }),
+			updateReq.Email).Return(change, nil).Once()
+		mockEmailChange.EXPECT().Notify(mock.Anything, change).Return(nil).Once()
+
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, mockEmailChange, newTestAuditService(t))
 
 		// Act
 		updatedUser, err := userService.UpdateFull(ctx, updateReq)
@@ -54,7 +61,7 @@ func TestUserService_UpdateFull(t *testing.T) {
 		require.NotNil(t, updatedUser)
 		assert.Equal(t, updateReq.ID, updatedUser.ID)
 		assert.Equal(t, updateReq.Username, updatedUser.Username)
-		assert.Equal(t, updateReq.Email, updatedUser.Email)
+		assert.Equal(t, "updatefull@example.com", updatedUser.Email) // pending confirmation
 		assert.Equal(t, updateReq.FullName, updatedUser.FullName)
 
 		// Verify in database
@@ -65,7 +72,7 @@ func TestUserService_UpdateFull(t *testing.T) {
 		)
 		require.NoError(t, err)
 		assert.Equal(t, updateReq.Username, dbUser.Username)
-		assert.Equal(t, updateReq.Email, dbUser.Email)
+		assert.Equal(t, "updatefull@example.com", dbUser.Email)
 		assert.Equal(t, updateReq.FullName, dbUser.FullName)
 		assert.Equal(t, hashedPassword, dbUser.PasswordHash)
 	})
@@ -103,7 +110,7 @@ func TestUserService_UpdateFull(t *testing.T) {
 				return ok && username == existingUsername
 			})).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		updatedUser, err := userService.UpdateFull(ctx, updateReq)
@@ -149,7 +156,7 @@ func TestUserService_UpdateFull(t *testing.T) {
 				return ok && email == existingEmail
 			})).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		updatedUser, err := userService.UpdateFull(ctx, updateReq)
@@ -186,7 +193,7 @@ func TestUserService_UpdateFull(t *testing.T) {
 				return ok && id == nonExistentID
 			})).Once()
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		updatedUser, err := userService.UpdateFull(ctx, updateReq)
@@ -225,7 +232,7 @@ func TestUserService_UpdateUserTx(t *testing.T) {
 
 	mockHashService.EXPECT().Hash(updateArgs.PasswordHash).Return(hashedPassword, nil).Maybe() // Maybe() because UpdateFull might not be called if tx fails early
 
-	userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+	userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 	updatedUser, err := userService.UpdateUserTx(ctx, updateArgs)
 
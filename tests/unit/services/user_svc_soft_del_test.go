@@ -29,7 +29,7 @@ func TestUserService_SoftDelete(t *testing.T) {
 		userID := seedUser(t, "softdelete@example.com", "softdelete_user", "Soft Delete User", "hash")
 		require.NotZero(t, userID, "Failed to seed user")
 
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		err := userService.SoftDelete(ctx, userID)
@@ -52,7 +52,7 @@ func TestUserService_SoftDelete(t *testing.T) {
 		mockLogger := mocks.NewMockLogger(t)
 
 		nonExistentID := int32(9999)
-		userService := services.NewUserService(repoManager, mockLogger, mockHashService)
+		userService := services.NewUserService(repoManager, mockLogger, mockHashService, nil, newTestAuditService(t))
 
 		// Act
 		err := userService.SoftDelete(ctx, nonExistentID)
@@ -68,7 +68,7 @@ func TestUserService_SoftDelete(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		repoManager := repository.NewRepositoryManager(testDB)
 		mockLogger := mocks.NewMockLogger(t)
-		userService := services.NewUserService(repoManager, mockLogger, nil)
+		userService := services.NewUserService(repoManager, mockLogger, nil, nil, newTestAuditService(t))
 
 		// Act
 		err := userService.SoftDelete(ctx, -1) // Negative ID
@@ -84,7 +84,7 @@ func TestUserService_SoftDelete(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		repoManager := repository.NewRepositoryManager(testDB)
 		mockLogger := mocks.NewMockLogger(t)
-		userService := services.NewUserService(repoManager, mockLogger, nil)
+		userService := services.NewUserService(repoManager, mockLogger, nil, nil, newTestAuditService(t))
 
 		// Act
 		err := userService.SoftDelete(ctx, 0) // Zero ID
@@ -100,7 +100,7 @@ func TestUserService_SoftDelete(t *testing.T) {
 		TruncateTables(t, testDB, testTableNames)
 		repoManager := repository.NewRepositoryManager(testDB)
 		mockLogger := mocks.NewMockLogger(t)
-		userService := services.NewUserService(repoManager, mockLogger, nil)
+		userService := services.NewUserService(repoManager, mockLogger, nil, nil, newTestAuditService(t))
 
 		// Act
 		err := userService.SoftDelete(ctx, math.MaxInt32) // Max int32 value
@@ -0,0 +1,134 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"example.com/api/config"
+	dto "example.com/api/internal/contracts"
+	"example.com/api/internal/repository"
+	"example.com/api/internal/services"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/internal/services/webhooks"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookService(t *testing.T) {
+	require.NotNil(t, testDB, "Test Database connection (testDB) is not initialized")
+	ctx := context.Background()
+	repo := repository.NewRepositoryManager(testDB)
+
+	t.Run("Update Only Changes Given Fields", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc := services.NewWebhookService(repo, mocks.NewMockLogger(t))
+
+		sub, err := svc.Create(ctx, dto.CreateWebhookReq{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{outbox.UserCreated},
+		})
+		require.NoError(t, err)
+		assert.True(t, sub.Active)
+		assert.Regexp(t, `^whsec_[0-9a-f]{64}$`, sub.Secret)
+
+		inactive := false
+		updated, rotated, err := svc.Update(ctx, sub.ID, dto.UpdateWebhookReq{Active: &inactive})
+		require.NoError(t, err)
+		assert.False(t, rotated)
+		assert.False(t, updated.Active)
+		assert.Equal(t, sub.Url, updated.Url)
+		assert.Equal(t, sub.EventTypes, updated.EventTypes)
+
+		updated, rotated, err = svc.Update(ctx, sub.ID, dto.UpdateWebhookReq{RotateSecret: true})
+		require.NoError(t, err)
+		assert.True(t, rotated)
+		assert.NotEqual(t, sub.Secret, updated.Secret)
+
+		require.NoError(t, svc.Delete(ctx, sub.ID))
+		assert.ErrorIs(t, svc.Delete(ctx, sub.ID), services.ErrWebhookNotFound)
+		_, err = svc.Get(ctx, sub.ID)
+		assert.ErrorIs(t, err, services.ErrWebhookNotFound)
+	})
+
+	t.Run("Dead Letter And Redeliver", func(t *testing.T) {
+		TruncateTables(t, testDB, testTableNames)
+		svc := services.NewWebhookService(repo, mocks.NewMockLogger(t))
+		logger := mocks.NewMockLogger(t)
+		logger.EXPECT().Warn(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+		var healthy atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !healthy.Load() {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		sub, err := svc.Create(ctx, dto.CreateWebhookReq{
+			URL:        server.URL,
+			EventTypes: []string{outbox.UserUpdated},
+		})
+		require.NoError(t, err)
+
+		// Only the subscribed type fans out, and relaying twice does not
+		// create a second delivery.
+		sink := webhooks.NewSubscriptionSink(repo.Webhook())
+		for _, event := range []outbox.Event{
+			{ID: 1, Type: outbox.UserUpdated, Data: json.RawMessage(`{}`)},
+			{ID: 1, Type: outbox.UserUpdated, Data: json.RawMessage(`{}`)},
+			{ID: 2, Type: outbox.UserCreated, Data: json.RawMessage(`{}`)},
+		} {
+			require.NoError(t, sink.Publish(ctx, event))
+		}
+
+		dispatcher := webhooks.NewDispatcher(repo.Webhook(), server.Client(), logger, config.WebhooksConfig{
+			BatchSize:     10,
+			LeaseDuration: 60,
+			MaxAttempts:   1,
+			MinBackoff:    1,
+			MaxBackoff:    1,
+		})
+		n, err := dispatcher.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		dead, err := svc.ListDeliveries(ctx, sub.ID, dto.WebhookDeliveryFilter{Status: webhooks.StatusDead, Limit: 50})
+		require.NoError(t, err)
+		require.Len(t, dead, 1)
+		assert.Equal(t, int32(502), dead[0].LastStatusCode)
+		assert.Equal(t, int64(1), dead[0].EventID)
+
+		// Dead deliveries are never picked up again on their own.
+		n, err = dispatcher.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+
+		redelivered, err := svc.Redeliver(ctx, sub.ID, dead[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhooks.StatusPending, redelivered.Status)
+		assert.Zero(t, redelivered.Attempts)
+
+		_, err = svc.Redeliver(ctx, sub.ID, dead[0].ID)
+		assert.ErrorIs(t, err, services.ErrWebhookDeliveryNotFound, "a pending delivery is already queued")
+
+		healthy.Store(true)
+		n, err = dispatcher.RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		history, err := svc.ListDeliveries(ctx, sub.ID, dto.WebhookDeliveryFilter{Limit: 50})
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, webhooks.StatusDelivered, history[0].Status)
+		assert.Equal(t, int32(200), history[0].LastStatusCode)
+		assert.True(t, history[0].DeliveredAt.Valid)
+	})
+}
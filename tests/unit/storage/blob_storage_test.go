@@ -0,0 +1,164 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"example.com/api/config"
+	"example.com/api/internal/storage/blob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func testBlobStorage(t *testing.T, storage blob.IBlobStorage) {
+	ctx := context.Background()
+
+	t.Run("Put And Get", func(t *testing.T) {
+		err := storage.Put(ctx, "avatars/1/v1/small", bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png")
+		require.NoError(t, err)
+
+		body, info, err := storage.Get(ctx, "avatars/1/v1/small")
+		require.NoError(t, err)
+		defer body.Close()
+
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		assert.Equal(t, pngHeader, data)
+		assert.Equal(t, int64(len(pngHeader)), info.Size)
+		assert.Equal(t, "image/png", info.ContentType)
+		assert.False(t, info.LastModified.IsZero())
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		replacement := append(append([]byte{}, pngHeader...), 0x00)
+		err := storage.Put(ctx, "avatars/1/v1/small", bytes.NewReader(replacement), int64(len(replacement)), "image/png")
+		require.NoError(t, err)
+
+		body, _, err := storage.Get(ctx, "avatars/1/v1/small")
+		require.NoError(t, err)
+		defer body.Close()
+
+		data, _ := io.ReadAll(body)
+		assert.Equal(t, replacement, data)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, storage.Delete(ctx, "avatars/1/v1/small"))
+
+		_, _, err := storage.Get(ctx, "avatars/1/v1/small")
+		assert.ErrorIs(t, err, blob.ErrNotFound)
+	})
+
+	t.Run("Delete Missing", func(t *testing.T) {
+		assert.NoError(t, storage.Delete(ctx, "avatars/404/v1/small"))
+	})
+
+	t.Run("Get Missing", func(t *testing.T) {
+		_, _, err := storage.Get(ctx, "avatars/404/v1/small")
+		assert.ErrorIs(t, err, blob.ErrNotFound)
+	})
+}
+
+func TestLocalStorage(t *testing.T) {
+	storage := blob.NewLocalStorage(t.TempDir())
+	testBlobStorage(t, storage)
+
+	t.Run("Rejects Path Traversal", func(t *testing.T) {
+		err := storage.Put(context.Background(), "../escape", bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png")
+		assert.Error(t, err)
+	})
+}
+
+// fakeS3 is a minimal in-memory stand-in for an S3-compatible server. It
+// only accepts path-style requests signed with Signature Version 4.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+	bucket  string
+	access  string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential="+f.access+"/") ||
+		!strings.Contains(auth, "SignedHeaders=host;x-amz-content-sha256;x-amz-date") ||
+		r.Header.Get("X-Amz-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	prefix := "/" + f.bucket + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(r.URL.Path, prefix)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[key] = data
+		f.types[key] = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[key])
+		w.Header().Set("Last-Modified", "Sat, 10 May 2025 12:00:00 GMT")
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := &fakeS3{
+		objects: map[string][]byte{},
+		types:   map[string]string{},
+		bucket:  "avatars",
+		access:  "test-access",
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	storage := blob.NewS3Storage(config.S3Config{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "avatars",
+		AccessKey: "test-access",
+		SecretKey: "test-secret",
+	}, server.Client())
+	testBlobStorage(t, storage)
+
+	t.Run("Surfaces Server Errors", func(t *testing.T) {
+		unauthorized := blob.NewS3Storage(config.S3Config{
+			Endpoint:  server.URL,
+			Region:    "us-east-1",
+			Bucket:    "avatars",
+			AccessKey: "wrong",
+			SecretKey: "test-secret",
+		}, server.Client())
+
+		err := unauthorized.Put(context.Background(), "avatars/1/v1/small", bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png")
+		assert.ErrorContains(t, err, "403")
+	})
+}
@@ -0,0 +1,151 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"example.com/api/config"
+	dbCtx "example.com/api/internal/repository/db"
+	"example.com/api/internal/services/outbox"
+	"example.com/api/internal/services/webhooks"
+	repoMocks "example.com/api/tests/unit/mocks/repositories"
+	mocks "example.com/api/tests/unit/mocks/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "whsec_test"
+
+func dispatcherConfig() config.WebhooksConfig {
+	return config.WebhooksConfig{
+		BatchSize:     10,
+		LeaseDuration: 60,
+		MaxAttempts:   3,
+		MinBackoff:    10,
+		MaxBackoff:    60,
+	}
+}
+
+func claimedDelivery(url string, attempts int32) dbCtx.ClaimWebhookDeliveriesRow {
+	return dbCtx.ClaimWebhookDeliveriesRow{
+		ID:             5,
+		SubscriptionID: 2,
+		EventID:        42,
+		EventType:      outbox.UserCreated,
+		Payload:        json.RawMessage(`{"id":42,"type":"user.created","data":{"id":7}}`),
+		Status:         webhooks.StatusPending,
+		Attempts:       attempts,
+		Url:            url,
+		Secret:         testSecret,
+	}
+}
+
+// receiver is a local endpoint that answers with status and verifies every
+// request it gets like an integrator would.
+func receiver(t *testing.T, status int) (*httptest.Server, *[]http.Header) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.NoError(t, webhooks.Verify(testSecret, r.Header.Get(webhooks.SignatureHeader), body, time.Minute, time.Now()))
+		headers = append(headers, r.Header.Clone())
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("nope"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &headers
+}
+
+func TestDispatcher_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	claim := dbCtx.ClaimWebhookDeliveriesParams{LeaseSeconds: 60, BatchSize: 10}
+
+	t.Run("Delivered", func(t *testing.T) {
+		server, headers := receiver(t, http.StatusNoContent)
+		repo := repoMocks.NewMockWebhookRepo(t)
+
+		repo.EXPECT().ClaimDeliveries(ctx, claim).Return([]dbCtx.ClaimWebhookDeliveriesRow{claimedDelivery(server.URL, 1)}, nil).Once()
+		repo.EXPECT().MarkDelivered(ctx, dbCtx.MarkWebhookDeliveryDeliveredParams{ID: 5, LastStatusCode: 204}).Return(nil).Once()
+
+		n, err := webhooks.NewDispatcher(repo, server.Client(), mocks.NewMockLogger(t), dispatcherConfig()).RunOnce(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		require.Len(t, *headers, 1)
+		h := (*headers)[0]
+		assert.Equal(t, "2", h.Get("X-Webhook-ID"))
+		assert.Equal(t, "5", h.Get("X-Delivery-ID"))
+		assert.Equal(t, "42", h.Get("X-Event-ID"))
+		assert.Equal(t, outbox.UserCreated, h.Get("X-Event-Type"))
+	})
+
+	t.Run("Failure Is Retried With Backoff", func(t *testing.T) {
+		server, _ := receiver(t, http.StatusInternalServerError)
+		repo := repoMocks.NewMockWebhookRepo(t)
+		logger := mocks.NewMockLogger(t)
+
+		repo.EXPECT().ClaimDeliveries(ctx, claim).Return([]dbCtx.ClaimWebhookDeliveriesRow{claimedDelivery(server.URL, 2)}, nil).Once()
+		repo.EXPECT().RetryDelivery(ctx, mock.MatchedBy(func(arg dbCtx.RetryWebhookDeliveryParams) bool {
+			return arg.ID == 5 && arg.DelaySeconds == 20 && arg.LastStatusCode == 500 &&
+				arg.LastError == "endpoint responded with status 500: nope"
+		})).Return(nil).Once()
+		logger.EXPECT().Warn(mock.Anything, mock.Anything, "Webhook delivery failed", mock.Anything).Once()
+
+		_, err := webhooks.NewDispatcher(repo, server.Client(), logger, dispatcherConfig()).RunOnce(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Last Attempt Is Dead Lettered", func(t *testing.T) {
+		server, _ := receiver(t, http.StatusGone)
+		repo := repoMocks.NewMockWebhookRepo(t)
+		logger := mocks.NewMockLogger(t)
+
+		repo.EXPECT().ClaimDeliveries(ctx, claim).Return([]dbCtx.ClaimWebhookDeliveriesRow{claimedDelivery(server.URL, 3)}, nil).Once()
+		repo.EXPECT().DeadLetter(ctx, dbCtx.DeadLetterWebhookDeliveryParams{
+			ID:             5,
+			LastStatusCode: 410,
+			LastError:      "endpoint responded with status 410: nope",
+		}).Return(nil).Once()
+		logger.EXPECT().Warn(mock.Anything, mock.Anything, "Webhook delivery failed", mock.Anything).Once()
+
+		_, err := webhooks.NewDispatcher(repo, server.Client(), logger, dispatcherConfig()).RunOnce(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Unreachable Endpoint", func(t *testing.T) {
+		server, _ := receiver(t, http.StatusOK)
+		url := server.URL
+		server.Close()
+		repo := repoMocks.NewMockWebhookRepo(t)
+		logger := mocks.NewMockLogger(t)
+
+		repo.EXPECT().ClaimDeliveries(ctx, claim).Return([]dbCtx.ClaimWebhookDeliveriesRow{claimedDelivery(url, 1)}, nil).Once()
+		repo.EXPECT().RetryDelivery(ctx, mock.MatchedBy(func(arg dbCtx.RetryWebhookDeliveryParams) bool {
+			return arg.ID == 5 && arg.DelaySeconds == 10 && arg.LastStatusCode == 0 && arg.LastError != ""
+		})).Return(nil).Once()
+		logger.EXPECT().Warn(mock.Anything, mock.Anything, "Webhook delivery failed", mock.Anything).Once()
+
+		_, err := webhooks.NewDispatcher(repo, nil, logger, dispatcherConfig()).RunOnce(ctx)
+		require.NoError(t, err)
+	})
+}
+
+func TestSubscriptionSink(t *testing.T) {
+	ctx := context.Background()
+	repo := repoMocks.NewMockWebhookRepo(t)
+	event := outbox.Event{ID: 42, Type: outbox.UserDeleted, AggregateType: outbox.AggregateUser, AggregateID: 7, Data: json.RawMessage(`{"id":7}`)}
+
+	repo.EXPECT().EnqueueDeliveries(ctx, mock.MatchedBy(func(arg dbCtx.EnqueueWebhookDeliveriesParams) bool {
+		var got outbox.Event
+		return arg.EventID == 42 && arg.EventType == outbox.UserDeleted &&
+			json.Unmarshal(arg.Payload, &got) == nil && got.AggregateID == 7
+	})).Return(2, nil).Once()
+
+	require.NoError(t, webhooks.NewSubscriptionSink(repo).Publish(ctx, event))
+}
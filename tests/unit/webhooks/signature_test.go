@@ -0,0 +1,38 @@
+package webhooks_test
+
+import (
+	"testing"
+	"time"
+
+	"example.com/api/internal/services/webhooks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":1,"type":"user.created"}`)
+	sentAt := time.Unix(1754049600, 0)
+	header := webhooks.Sign("secret", sentAt, body)
+
+	assert.Regexp(t, `^t=1754049600,v1=[0-9a-f]{64}$`, header)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{"Valid", "secret", header, body, sentAt.Add(time.Minute), nil},
+		{"Wrong Secret", "other", header, body, sentAt, webhooks.ErrSignatureMismatch},
+		{"Tampered Body", "secret", header, []byte(`{"id":2}`), sentAt, webhooks.ErrSignatureMismatch},
+		{"Replayed", "secret", header, body, sentAt.Add(time.Hour), webhooks.ErrSignatureExpired},
+		{"Malformed", "secret", "v1=abc", body, sentAt, webhooks.ErrMalformedSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhooks.Verify(tt.secret, tt.header, tt.body, 5*time.Minute, tt.now)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}